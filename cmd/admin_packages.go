@@ -0,0 +1,63 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	packages_vulnerability_service "code.gitea.io/gitea/services/packages/vulnerability"
+
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	subcmdPackages = &cli.Command{
+		Name:  "packages",
+		Usage: "Manage the package registry",
+		Commands: []*cli.Command{
+			microcmdPackagesImportVulnerabilities,
+		},
+	}
+
+	microcmdPackagesImportVulnerabilities = &cli.Command{
+		Name:   "import-vulnerabilities",
+		Usage:  "Import a snapshot of an OSV vulnerability database (zip archive of OSV JSON files)",
+		Action: runImportPackageVulnerabilities,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "file",
+				Aliases:  []string{"f"},
+				Usage:    "Path of the zip archive, for example an \"all.zip\" downloaded from https://osv-vulnerabilities.storage.googleapis.com",
+				Required: true,
+			},
+		},
+	}
+)
+
+func runImportPackageVulnerabilities(ctx context.Context, c *cli.Command) error {
+	if err := initDB(ctx); err != nil {
+		return err
+	}
+
+	f, err := os.Open(c.String("file"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	count, err := packages_vulnerability_service.ImportDatabase(ctx, f, fi.Size())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d vulnerabilities. The packages are rescanned by the scan_package_vulnerabilities cron task.\n", count)
+	return nil
+}
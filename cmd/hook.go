@@ -254,10 +254,11 @@ Gitea or set your environment appropriately.`, "")
 				hookOptions.OldCommitIDs = oldCommitIDs
 				hookOptions.NewCommitIDs = newCommitIDs
 				hookOptions.RefFullNames = refFullNames
-				extra := private.HookPreReceive(ctx, username, reponame, hookOptions)
+				res, extra := private.HookPreReceive(ctx, username, reponame, hookOptions)
 				if extra.HasError() {
 					return fail(ctx, extra.UserMsg, "HookPreReceive(batch) failed: %v", extra.Error)
 				}
+				hookPrintWarnings(res.Warnings)
 				count = 0
 				lastline = 0
 			}
@@ -277,10 +278,11 @@ Gitea or set your environment appropriately.`, "")
 
 		fmt.Fprintf(out, " Checking %d references\n", count)
 
-		extra := private.HookPreReceive(ctx, username, reponame, hookOptions)
+		res, extra := private.HookPreReceive(ctx, username, reponame, hookOptions)
 		if extra.HasError() {
 			return fail(ctx, extra.UserMsg, "HookPreReceive(last) failed: %v", extra.Error)
 		}
+		hookPrintWarnings(res.Warnings)
 	} else if lastline > 0 {
 		fmt.Fprintf(out, "\n")
 	}
@@ -456,6 +458,18 @@ Gitea or set your environment appropriately.`, "")
 	return nil
 }
 
+func hookPrintWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "")
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+	fmt.Fprintln(os.Stderr, "")
+	_ = os.Stderr.Sync()
+}
+
 func hookPrintResults(results []private.HookPostReceiveBranchResult) {
 	for _, res := range results {
 		hookPrintResult(res.Message, res.Create, res.Branch, res.URL)
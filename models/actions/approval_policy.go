@@ -0,0 +1,168 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ApprovalMode decides which runs triggered by outside contributors need an approval
+type ApprovalMode int
+
+const (
+	// ApprovalModeFirstTime requires an approval for the first run of a user without write access,
+	// once approved, later runs of the same user won't need approval again. It's the default behavior.
+	ApprovalModeFirstTime ApprovalMode = iota
+	// ApprovalModeEveryPush requires an approval for each new commit pushed by a user without write access
+	ApprovalModeEveryPush
+	// ApprovalModeAlways requires an approval for every run triggered by a user without write access
+	ApprovalModeAlways
+)
+
+var approvalModeNames = map[ApprovalMode]string{
+	ApprovalModeFirstTime: "first_time",
+	ApprovalModeEveryPush: "every_push",
+	ApprovalModeAlways:    "always",
+}
+
+// String returns the name of the approval mode
+func (m ApprovalMode) String() string {
+	return approvalModeNames[m]
+}
+
+// ParseApprovalMode converts a name to an approval mode, the empty name means the default mode
+func ParseApprovalMode(name string) (ApprovalMode, bool) {
+	if name == "" {
+		return ApprovalModeFirstTime, true
+	}
+	for mode, n := range approvalModeNames {
+		if n == name {
+			return mode, true
+		}
+	}
+	return 0, false
+}
+
+// ActionApprovalPolicy represents the policy deciding which workflow runs need an approval and who may approve them
+//
+// It can be:
+//  1. org/user level policy, OwnerID is org/user ID and RepoID is 0
+//  2. repo level policy, OwnerID is 0 and RepoID is repo ID
+//
+// A repo level policy takes precedence over the org/user level one, they are not merged.
+type ActionApprovalPolicy struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OwnerID int64 `xorm:"UNIQUE(owner_repo)"`
+	RepoID  int64 `xorm:"INDEX UNIQUE(owner_repo)"`
+	Mode    ApprovalMode
+	// RequireForSecrets requires an approval for every run whose workflow references secrets
+	RequireForSecrets bool `xorm:"NOT NULL DEFAULT false"`
+	// Environments requires an approval for every run whose jobs deploy to one of these environments
+	Environments []string `xorm:"JSON TEXT"`
+	// ApproverTeamID restricts the approvers to the members of the team, 0 means any user who can write actions
+	ApproverTeamID int64
+	CreatedUnix    timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionApprovalPolicy))
+}
+
+// MatchEnvironments returns true if any of the environments is protected by the policy
+func (p *ActionApprovalPolicy) MatchEnvironments(envs []string) bool {
+	for _, env := range envs {
+		if slices.ContainsFunc(p.Environments, func(s string) bool { return strings.EqualFold(s, env) }) {
+			return true
+		}
+	}
+	return false
+}
+
+type FindApprovalPoliciesOptions struct {
+	db.ListOptions
+	RepoID  int64
+	OwnerID int64 // it will be ignored if RepoID is set
+}
+
+func (opts FindApprovalPoliciesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	if opts.RepoID != 0 {
+		cond = cond.And(builder.Eq{"owner_id": 0})
+	} else {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	return cond
+}
+
+// GetApprovalPolicy returns the policy configured exactly on the owner or the repo
+func GetApprovalPolicy(ctx context.Context, ownerID, repoID int64) (*ActionApprovalPolicy, error) {
+	policies, err := db.Find[ActionApprovalPolicy](ctx, FindApprovalPoliciesOptions{OwnerID: ownerID, RepoID: repoID})
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, util.NewNotExistErrorf("approval policy does not exist")
+	}
+	return policies[0], nil
+}
+
+// GetEffectiveApprovalPolicy returns the policy applied to the runs of a repository.
+// It returns nil if neither the repository nor its owner has a policy.
+func GetEffectiveApprovalPolicy(ctx context.Context, ownerID, repoID int64) (*ActionApprovalPolicy, error) {
+	for _, opts := range []FindApprovalPoliciesOptions{{RepoID: repoID}, {OwnerID: ownerID}} {
+		policies, err := db.Find[ActionApprovalPolicy](ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(policies) > 0 {
+			return policies[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// UpsertApprovalPolicy creates or updates the policy of the owner or the repo
+func UpsertApprovalPolicy(ctx context.Context, policy *ActionApprovalPolicy) error {
+	if policy.OwnerID != 0 && policy.RepoID != 0 {
+		policy.OwnerID = 0
+	}
+	if _, ok := approvalModeNames[policy.Mode]; !ok {
+		return util.NewInvalidArgumentErrorf("invalid approval mode %d", policy.Mode)
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := db.Find[ActionApprovalPolicy](ctx, FindApprovalPoliciesOptions{OwnerID: policy.OwnerID, RepoID: policy.RepoID})
+		if err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			return db.Insert(ctx, policy)
+		}
+		policy.ID = existing[0].ID
+		_, err = db.GetEngine(ctx).ID(policy.ID).
+			Cols("mode", "require_for_secrets", "environments", "approver_team_id").
+			Update(policy)
+		return err
+	})
+}
+
+// DeleteApprovalPolicy deletes the policy of the owner or the repo
+func DeleteApprovalPolicy(ctx context.Context, ownerID, repoID int64) error {
+	policy, err := GetApprovalPolicy(ctx, ownerID, repoID)
+	if err != nil {
+		return err
+	}
+	_, err = db.DeleteByID[ActionApprovalPolicy](ctx, policy.ID)
+	return err
+}
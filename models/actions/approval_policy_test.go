@@ -0,0 +1,67 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalPolicy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	policy, err := GetEffectiveApprovalPolicy(db.DefaultContext, 3, 32)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	require.NoError(t, UpsertApprovalPolicy(db.DefaultContext, &ActionApprovalPolicy{OwnerID: 3, Mode: ApprovalModeAlways}))
+	policy, err = GetEffectiveApprovalPolicy(db.DefaultContext, 3, 32)
+	require.NoError(t, err)
+	assert.Equal(t, ApprovalModeAlways, policy.Mode)
+	assert.EqualValues(t, 3, policy.OwnerID)
+
+	// the repo level policy takes precedence, and OwnerID is cleared for it
+	require.NoError(t, UpsertApprovalPolicy(db.DefaultContext, &ActionApprovalPolicy{OwnerID: 3, RepoID: 32, Mode: ApprovalModeEveryPush, Environments: []string{"production"}}))
+	policy, err = GetEffectiveApprovalPolicy(db.DefaultContext, 3, 32)
+	require.NoError(t, err)
+	assert.Equal(t, ApprovalModeEveryPush, policy.Mode)
+	assert.EqualValues(t, 0, policy.OwnerID)
+	assert.True(t, policy.MatchEnvironments([]string{"staging", "Production"}))
+	assert.False(t, policy.MatchEnvironments([]string{"staging"}))
+
+	// updating keeps a single policy per repo
+	require.NoError(t, UpsertApprovalPolicy(db.DefaultContext, &ActionApprovalPolicy{RepoID: 32, Mode: ApprovalModeFirstTime, RequireForSecrets: true}))
+	unittest.AssertCount(t, &ActionApprovalPolicy{RepoID: 32}, 1)
+	policy, err = GetApprovalPolicy(db.DefaultContext, 0, 32)
+	require.NoError(t, err)
+	assert.True(t, policy.RequireForSecrets)
+	assert.Empty(t, policy.Environments)
+
+	assert.ErrorIs(t, UpsertApprovalPolicy(db.DefaultContext, &ActionApprovalPolicy{RepoID: 32, Mode: 100}), util.ErrInvalidArgument)
+
+	require.NoError(t, DeleteApprovalPolicy(db.DefaultContext, 0, 32))
+	policy, err = GetEffectiveApprovalPolicy(db.DefaultContext, 3, 32)
+	require.NoError(t, err)
+	assert.Equal(t, ApprovalModeAlways, policy.Mode)
+	assert.ErrorIs(t, DeleteApprovalPolicy(db.DefaultContext, 0, 32), util.ErrNotExist)
+}
+
+func TestParseApprovalMode(t *testing.T) {
+	for _, mode := range []ApprovalMode{ApprovalModeFirstTime, ApprovalModeEveryPush, ApprovalModeAlways} {
+		parsed, ok := ParseApprovalMode(mode.String())
+		assert.True(t, ok)
+		assert.Equal(t, mode, parsed)
+	}
+	parsed, ok := ParseApprovalMode("")
+	assert.True(t, ok)
+	assert.Equal(t, ApprovalModeFirstTime, parsed)
+	_, ok = ParseApprovalMode("never")
+	assert.False(t, ok)
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// QueueStat is the demand of the jobs which run on the same label set
+type QueueStat struct {
+	Labels     []string // sorted
+	Queued     int64    // the number of jobs waiting for a runner
+	InProgress int64    // the number of jobs running on a runner
+	// Stale is the number of queued jobs which have been waiting since before FindQueueStatsOptions.StaleBefore
+	Stale int64
+	// OldestQueued is the time when the oldest queued job started waiting, it's zero if there is no queued job
+	OldestQueued timeutil.TimeStamp
+}
+
+type FindQueueStatsOptions struct {
+	OwnerID     int64
+	RepoID      int64
+	StaleBefore timeutil.TimeStamp
+}
+
+// GetQueueStats returns the stats of the waiting and running jobs grouped by their label sets, ordered by the labels
+func GetQueueStats(ctx context.Context, opts FindQueueStatsOptions) ([]*QueueStat, error) {
+	jobOpts := FindRunJobOptions{
+		OwnerID:  opts.OwnerID,
+		RepoID:   opts.RepoID,
+		Statuses: []Status{StatusWaiting, StatusRunning},
+	}
+	sess := db.GetEngine(ctx).Where(jobOpts.ToConds()).Cols("`action_run_job`.runs_on", "`action_run_job`.status", "`action_run_job`.updated")
+	if opts.OwnerID > 0 {
+		sess = sess.Join("INNER", "repository", "repository.id = `action_run_job`.repo_id AND repository.owner_id = ?", opts.OwnerID)
+	}
+	var jobs []*ActionRunJob
+	if err := sess.Find(&jobs); err != nil {
+		return nil, err
+	}
+
+	statsMap := make(map[string]*QueueStat)
+	for _, job := range jobs {
+		labels := slices.Clone(job.RunsOn)
+		slices.Sort(labels)
+		labels = slices.Compact(labels)
+		key := strings.Join(labels, "\n")
+		stat, ok := statsMap[key]
+		if !ok {
+			stat = &QueueStat{Labels: labels}
+			statsMap[key] = stat
+		}
+		if job.Status == StatusRunning {
+			stat.InProgress++
+			continue
+		}
+		// a waiting job is updated when it's created or becomes waiting, so it's the time the job started waiting
+		stat.Queued++
+		if stat.OldestQueued.IsZero() || job.Updated < stat.OldestQueued {
+			stat.OldestQueued = job.Updated
+		}
+		if job.Updated < opts.StaleBefore {
+			stat.Stale++
+		}
+	}
+
+	stats := make([]*QueueStat, 0, len(statsMap))
+	for _, stat := range statsMap {
+		stats = append(stats, stat)
+	}
+	slices.SortFunc(stats, func(a, b *QueueStat) int {
+		return slices.Compare(a.Labels, b.Labels)
+	})
+	return stats, nil
+}
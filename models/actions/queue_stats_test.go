@@ -0,0 +1,45 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetQueueStats(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	jobs := []*ActionRunJob{
+		{RepoID: 4, RunID: 793, RunsOn: []string{"ubuntu", "gpu", "ubuntu"}, Status: StatusWaiting},
+		{RepoID: 4, RunID: 793, RunsOn: []string{"gpu", "ubuntu"}, Status: StatusWaiting},
+		{RepoID: 4, RunID: 793, RunsOn: []string{"gpu", "ubuntu"}, Status: StatusRunning},
+		{RepoID: 4, RunID: 793, RunsOn: []string{"ubuntu"}, Status: StatusSuccess},
+	}
+	for i, job := range jobs {
+		require.NoError(t, db.Insert(db.DefaultContext, job))
+		_, err := db.GetEngine(db.DefaultContext).Exec("UPDATE action_run_job SET updated = ? WHERE id = ?", 1000+i*100, job.ID)
+		require.NoError(t, err)
+	}
+
+	stats, err := GetQueueStats(db.DefaultContext, FindQueueStatsOptions{RepoID: 4, StaleBefore: 1050})
+	require.NoError(t, err)
+	assert.Equal(t, []*QueueStat{{
+		Labels:       []string{"gpu", "ubuntu"},
+		Queued:       2,
+		InProgress:   1,
+		Stale:        1,
+		OldestQueued: timeutil.TimeStamp(1000),
+	}}, stats)
+
+	stats, err = GetQueueStats(db.DefaultContext, FindQueueStatsOptions{RepoID: 1})
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+}
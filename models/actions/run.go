@@ -44,6 +44,9 @@ type ActionRun struct {
 	IsForkPullRequest bool                         // If this is triggered by a PR from a forked repository or an untrusted user, we need to check if it is approved and limit permissions when running the workflow.
 	NeedApproval      bool                         // may need approval if it's a fork pull request
 	ApprovedBy        int64                        `xorm:"index"` // who approved
+	ApprovalPolicyID  int64                        // the policy which required the approval, 0 means the default policy
+	ApprovalMode      ApprovalMode                 // the approval mode of the policy when the run was created
+	ApproverTeamID    int64                        // only the members of the team can approve the run if it's not 0
 	Event             webhook_module.HookEventType // the webhook event that causes the workflow to run
 	EventPayload      string                       `xorm:"LONGTEXT"`
 	TriggerEvent      string                       // the trigger event defined in the `on` configuration of the triggered workflow
@@ -48,6 +48,7 @@ type ActionRunner struct {
 	Description string                 `xorm:"TEXT"`
 	Base        int                    // 0 native 1 docker 2 virtual machine
 	RepoRange   string                 // glob match which repositories could use this runner
+	GroupID     int64                  `xorm:"index"` // the runner group deciding which jobs can be assigned, 0 means no group
 
 	Token     string `xorm:"-"`
 	TokenHash string `xorm:"UNIQUE"` // sha256 of token
@@ -192,6 +193,7 @@ type FindRunnerOptions struct {
 	Filter        string
 	IsOnline      optional.Option[bool]
 	WithAvailable bool // not only runners belong to, but also runners can be used
+	GroupID       int64
 }
 
 func (opts FindRunnerOptions) ToConds() builder.Cond {
@@ -224,6 +226,10 @@ func (opts FindRunnerOptions) ToConds() builder.Cond {
 		cond = cond.And(builder.Like{"name", opts.Filter})
 	}
 
+	if opts.GroupID > 0 {
+		cond = cond.And(builder.Eq{"group_id": opts.GroupID})
+	}
+
 	if opts.IsOnline.Has() {
 		if opts.IsOnline.Value() {
 			cond = cond.And(builder.Gt{"last_online": time.Now().Add(-RunnerOfflineTime).Unix()})
@@ -0,0 +1,185 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionRunnerGroup represents a named group of runners and the policy deciding which jobs can be assigned to them
+//
+// It can be:
+//  1. global group, OwnerID is 0, it can only contain global runners
+//  2. org/user level group, OwnerID is org/user ID, it can only contain runners of the owner
+//
+// Runners not in any group can be used by every repository in their scope, as before.
+type ActionRunnerGroup struct {
+	ID          int64
+	OwnerID     int64  `xorm:"UNIQUE(owner_name)"`
+	Name        string `xorm:"UNIQUE(owner_name) NOT NULL"`
+	Description string `xorm:"TEXT"`
+	// AllowAllRepos allows the jobs of all repositories in the scope, otherwise only the repositories in RepoIDs
+	AllowAllRepos bool    `xorm:"NOT NULL DEFAULT false"`
+	RepoIDs       []int64 `xorm:"JSON TEXT"`
+	// Workflows restricts the workflow files (e.g. "deploy.yml") whose jobs can be assigned, empty means all workflows
+	Workflows []string `xorm:"JSON TEXT"`
+
+	Created timeutil.TimeStamp `xorm:"created"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionRunnerGroup))
+}
+
+// RunCond returns the condition of the runs whose jobs can be assigned to the runners of the group
+func (g *ActionRunnerGroup) RunCond() builder.Cond {
+	cond := builder.NewCond()
+	if !g.AllowAllRepos {
+		if len(g.RepoIDs) == 0 {
+			return builder.Expr("1 = 0")
+		}
+		cond = cond.And(builder.In("repo_id", g.RepoIDs))
+	}
+	if len(g.Workflows) > 0 {
+		cond = cond.And(builder.In("workflow_id", g.Workflows))
+	}
+	return cond
+}
+
+// CanRun returns whether the jobs of the workflow in the repository can be assigned to the runners of the group
+func (g *ActionRunnerGroup) CanRun(repoID int64, workflowID string) bool {
+	if !g.AllowAllRepos && !slices.Contains(g.RepoIDs, repoID) {
+		return false
+	}
+	return len(g.Workflows) == 0 || slices.Contains(g.Workflows, workflowID)
+}
+
+type FindRunnerGroupOptions struct {
+	db.ListOptions
+	IDs     []int64
+	OwnerID int64
+	Name    string
+}
+
+func (opts FindRunnerGroupOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	if len(opts.IDs) > 0 {
+		cond = cond.And(builder.In("id", opts.IDs))
+	}
+	if opts.Name != "" {
+		cond = cond.And(builder.Eq{"name": opts.Name})
+	}
+	return cond
+}
+
+func (opts FindRunnerGroupOptions) ToOrders() string {
+	return "name ASC"
+}
+
+// GetRunnerGroupByID returns the runner group by id
+func GetRunnerGroupByID(ctx context.Context, id int64) (*ActionRunnerGroup, error) {
+	var group ActionRunnerGroup
+	has, err := db.GetEngine(ctx).ID(id).Get(&group)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("runner group with id %d: %w", id, util.ErrNotExist)
+	}
+	return &group, nil
+}
+
+// GetRunnerGroupByOwnerAndID returns the runner group by id if it belongs to the owner
+func GetRunnerGroupByOwnerAndID(ctx context.Context, ownerID, id int64) (*ActionRunnerGroup, error) {
+	group, err := GetRunnerGroupByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if group.OwnerID != ownerID {
+		return nil, fmt.Errorf("runner group with id %d: %w", id, util.ErrNotExist)
+	}
+	return group, nil
+}
+
+func validateRunnerGroup(ctx context.Context, group *ActionRunnerGroup) error {
+	if group.Name == "" {
+		return util.NewInvalidArgumentErrorf("runner group name is empty")
+	}
+	exist, err := db.Exist[ActionRunnerGroup](ctx, FindRunnerGroupOptions{OwnerID: group.OwnerID, Name: group.Name}.ToConds().And(builder.Neq{"id": group.ID}))
+	if err != nil {
+		return err
+	} else if exist {
+		return util.NewAlreadyExistErrorf("runner group %q already exists", group.Name)
+	}
+	return nil
+}
+
+// CreateRunnerGroup creates a runner group
+func CreateRunnerGroup(ctx context.Context, group *ActionRunnerGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := validateRunnerGroup(ctx, group); err != nil {
+			return err
+		}
+		return db.Insert(ctx, group)
+	})
+}
+
+// UpdateRunnerGroup updates the columns of the runner group
+func UpdateRunnerGroup(ctx context.Context, group *ActionRunnerGroup, cols ...string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := validateRunnerGroup(ctx, group); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(group.ID).Cols(cols...).Update(group)
+		return err
+	})
+}
+
+// DeleteRunnerGroup deletes the runner group, its runners are released from the group
+func DeleteRunnerGroup(ctx context.Context, group *ActionRunnerGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Table("action_runner").Where("group_id = ?", group.ID).
+			Update(map[string]any{"group_id": 0}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[ActionRunnerGroup](ctx, group.ID)
+		return err
+	})
+}
+
+// RemoveRepoFromRunnerGroups removes the repository from the repositories allowed by the runner groups
+// of its owner and the global runner groups, it is used when the repository is deleted
+func RemoveRepoFromRunnerGroups(ctx context.Context, ownerID, repoID int64) error {
+	for _, scope := range []int64{ownerID, 0} {
+		groups, err := db.Find[ActionRunnerGroup](ctx, FindRunnerGroupOptions{OwnerID: scope})
+		if err != nil {
+			return err
+		}
+		for _, group := range groups {
+			if !slices.Contains(group.RepoIDs, repoID) {
+				continue
+			}
+			group.RepoIDs = slices.DeleteFunc(group.RepoIDs, func(id int64) bool { return id == repoID })
+			if _, err := db.GetEngine(ctx).ID(group.ID).Cols("repo_i_ds").Update(group); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SetRunnerGroup moves the runner into the group, groupID 0 means removing the runner from its group
+func SetRunnerGroup(ctx context.Context, runner *ActionRunner, groupID int64) error {
+	runner.GroupID = groupID
+	return UpdateRunner(ctx, runner, "group_id")
+}
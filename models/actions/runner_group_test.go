@@ -0,0 +1,96 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"slices"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerGroupRunCond(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	runs := []*ActionRun{
+		{RepoID: 1, Index: 1, WorkflowID: "build.yml"},
+		{RepoID: 1, Index: 2, WorkflowID: "deploy.yml"},
+		{RepoID: 2, Index: 1, WorkflowID: "deploy.yml"},
+	}
+	for _, run := range runs {
+		require.NoError(t, db.Insert(db.DefaultContext, run))
+	}
+
+	allowedRuns := func(group *ActionRunnerGroup) []int64 {
+		var ids []int64
+		require.NoError(t, db.GetEngine(db.DefaultContext).Table("action_run").Where(group.RunCond()).Asc("id").Cols("id").Find(&ids))
+		for _, run := range runs {
+			assert.Equal(t, group.CanRun(run.RepoID, run.WorkflowID), slices.Contains(ids, run.ID))
+		}
+		return ids
+	}
+
+	assert.Empty(t, allowedRuns(&ActionRunnerGroup{}))
+	assert.Equal(t, []int64{runs[0].ID, runs[1].ID, runs[2].ID}, allowedRuns(&ActionRunnerGroup{AllowAllRepos: true}))
+	assert.Equal(t, []int64{runs[0].ID, runs[1].ID}, allowedRuns(&ActionRunnerGroup{RepoIDs: []int64{1}}))
+	assert.Equal(t, []int64{runs[1].ID, runs[2].ID}, allowedRuns(&ActionRunnerGroup{AllowAllRepos: true, Workflows: []string{"deploy.yml"}}))
+	assert.Equal(t, []int64{runs[2].ID}, allowedRuns(&ActionRunnerGroup{RepoIDs: []int64{2, 3}, Workflows: []string{"deploy.yml"}}))
+}
+
+func TestRunnerGroup(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	group := &ActionRunnerGroup{OwnerID: 3, Name: "production", RepoIDs: []int64{3}}
+	require.NoError(t, CreateRunnerGroup(db.DefaultContext, group))
+	assert.ErrorIs(t, CreateRunnerGroup(db.DefaultContext, &ActionRunnerGroup{OwnerID: 3, Name: "production"}), util.ErrAlreadyExist)
+	assert.ErrorIs(t, CreateRunnerGroup(db.DefaultContext, &ActionRunnerGroup{OwnerID: 3}), util.ErrInvalidArgument)
+	// the same name can be used by another owner
+	require.NoError(t, CreateRunnerGroup(db.DefaultContext, &ActionRunnerGroup{Name: "production"}))
+
+	_, err := GetRunnerGroupByOwnerAndID(db.DefaultContext, 0, group.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	group.Workflows = []string{"deploy.yml"}
+	require.NoError(t, UpdateRunnerGroup(db.DefaultContext, group, "workflows"))
+	group, err = GetRunnerGroupByOwnerAndID(db.DefaultContext, 3, group.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deploy.yml"}, group.Workflows)
+	assert.Equal(t, []int64{3}, group.RepoIDs)
+
+	runner := &ActionRunner{UUID: "runner-group-test", Name: "runner", OwnerID: 3, TokenHash: "runner-group-test"}
+	require.NoError(t, db.Insert(db.DefaultContext, runner))
+	require.NoError(t, SetRunnerGroup(db.DefaultContext, runner, group.ID))
+	unittest.AssertCount(t, &ActionRunner{GroupID: group.ID}, 1)
+
+	// deleting the group releases its runners
+	require.NoError(t, DeleteRunnerGroup(db.DefaultContext, group))
+	unittest.AssertNotExistsBean(t, &ActionRunnerGroup{ID: group.ID})
+	runner = unittest.AssertExistsAndLoadBean(t, &ActionRunner{ID: runner.ID})
+	assert.Zero(t, runner.GroupID)
+}
+
+func TestRemoveRepoFromRunnerGroups(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	orgGroup := &ActionRunnerGroup{OwnerID: 3, Name: "org", RepoIDs: []int64{3, 32}}
+	globalGroup := &ActionRunnerGroup{Name: "global", RepoIDs: []int64{32, 5}}
+	otherGroup := &ActionRunnerGroup{OwnerID: 2, Name: "other", RepoIDs: []int64{32}}
+	for _, group := range []*ActionRunnerGroup{orgGroup, globalGroup, otherGroup} {
+		require.NoError(t, CreateRunnerGroup(db.DefaultContext, group))
+	}
+
+	require.NoError(t, RemoveRepoFromRunnerGroups(db.DefaultContext, 3, 32))
+
+	orgGroup = unittest.AssertExistsAndLoadBean(t, &ActionRunnerGroup{ID: orgGroup.ID})
+	assert.Equal(t, []int64{3}, orgGroup.RepoIDs)
+	globalGroup = unittest.AssertExistsAndLoadBean(t, &ActionRunnerGroup{ID: globalGroup.ID})
+	assert.Equal(t, []int64{5}, globalGroup.RepoIDs)
+	otherGroup = unittest.AssertExistsAndLoadBean(t, &ActionRunnerGroup{ID: otherGroup.ID})
+	assert.Equal(t, []int64{32}, otherGroup.RepoIDs)
+}
@@ -236,6 +236,14 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 	if jobCond.IsValid() {
 		jobCond = builder.In("run_id", builder.Select("id").From("action_run").Where(jobCond))
 	}
+	if runner.GroupID != 0 {
+		// the runners in a group can only take the jobs allowed by the group, even when the labels match
+		group, err := GetRunnerGroupByID(ctx, runner.GroupID)
+		if err != nil {
+			return nil, false, fmt.Errorf("GetRunnerGroupByID: %w", err)
+		}
+		jobCond = jobCond.And(builder.In("run_id", builder.Select("id").From("action_run").Where(group.RunCond())))
+	}
 
 	var jobs []*ActionRunJob
 	if err := e.Where("task_id=? AND status=?", 0, StatusWaiting).And(jobCond).Asc("updated", "id").Find(&jobs); err != nil {
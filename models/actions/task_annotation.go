@@ -0,0 +1,137 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// MaxAnnotationsPerTask limits the annotations a task can report, the others are ignored
+const MaxAnnotationsPerTask = 50
+
+// AnnotationLevel is the severity of an annotation
+type AnnotationLevel int
+
+const (
+	AnnotationLevelNotice  AnnotationLevel = iota // 0
+	AnnotationLevelWarning                        // 1
+	AnnotationLevelError                          // 2
+)
+
+var annotationLevelNames = map[AnnotationLevel]string{
+	AnnotationLevelNotice:  "notice",
+	AnnotationLevelWarning: "warning",
+	AnnotationLevelError:   "error",
+}
+
+// String returns the name of the level, it's the same as the workflow command
+func (l AnnotationLevel) String() string {
+	return annotationLevelNames[l]
+}
+
+// ParseAnnotationLevel returns the level by the name of the workflow command
+func ParseAnnotationLevel(name string) (AnnotationLevel, bool) {
+	for level, n := range annotationLevelNames {
+		if n == name {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// ActionTaskAnnotation represents an annotation reported by a task with a workflow command like "::error file=app.js,line=1::msg"
+type ActionTaskAnnotation struct {
+	ID        int64
+	TaskID    int64  `xorm:"index"`
+	RepoID    int64  `xorm:"index(repo_commit)"`
+	CommitSHA string `xorm:"index(repo_commit)"`
+	// LogIndex is the index of the log line which reports the annotation
+	LogIndex int64
+	Level    AnnotationLevel
+	Path     string `xorm:"VARCHAR(500)"`
+	Line     int
+	EndLine  int
+	Title    string
+	Message  string             `xorm:"TEXT"`
+	Created  timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionTaskAnnotation))
+}
+
+type FindTaskAnnotationsOptions struct {
+	db.ListOptions
+	TaskID    int64
+	RepoID    int64
+	CommitSHA string
+	HasPath   bool
+}
+
+func (opts FindTaskAnnotationsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.TaskID > 0 {
+		cond = cond.And(builder.Eq{"task_id": opts.TaskID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.CommitSHA != "" {
+		cond = cond.And(builder.Eq{"commit_sha": opts.CommitSHA})
+	}
+	if opts.HasPath {
+		cond = cond.And(builder.Neq{"path": ""})
+	}
+	return cond
+}
+
+func (opts FindTaskAnnotationsOptions) ToOrders() string {
+	return "log_index ASC, id ASC"
+}
+
+// InsertTaskAnnotations saves the annotations of the task, the ones exceeding MaxAnnotationsPerTask are dropped
+func InsertTaskAnnotations(ctx context.Context, task *ActionTask, annotations []*ActionTaskAnnotation) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		count, err := db.Count[ActionTaskAnnotation](ctx, FindTaskAnnotationsOptions{TaskID: task.ID})
+		if err != nil {
+			return err
+		}
+		if remain := MaxAnnotationsPerTask - int(count); remain <= 0 {
+			return nil
+		} else if len(annotations) > remain {
+			annotations = annotations[:remain]
+		}
+		for _, annotation := range annotations {
+			annotation.TaskID = task.ID
+			annotation.RepoID = task.RepoID
+			annotation.CommitSHA = task.CommitSHA
+		}
+		return db.Insert(ctx, annotations)
+	})
+}
+
+// CountTaskAnnotations returns the number of annotations of the task by level
+func CountTaskAnnotations(ctx context.Context, taskID int64) (map[AnnotationLevel]int64, error) {
+	var results []struct {
+		Level AnnotationLevel
+		Count int64
+	}
+	if err := db.GetEngine(ctx).Table(ActionTaskAnnotation{}).Where("task_id=?", taskID).
+		Select("level, count(*) AS count").GroupBy("level").Find(&results); err != nil {
+		return nil, err
+	}
+	counts := make(map[AnnotationLevel]int64, len(results))
+	for _, result := range results {
+		counts[result.Level] = result.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertTaskAnnotations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	task := &ActionTask{ID: 100, RepoID: 4, CommitSHA: "c2d72f548424103f01ee1dc02889c1e2bff816b0"}
+	newAnnotations := func(level AnnotationLevel, n int) []*ActionTaskAnnotation {
+		annotations := make([]*ActionTaskAnnotation, n)
+		for i := range annotations {
+			annotations[i] = &ActionTaskAnnotation{Level: level, Path: "main.go", Line: i + 1, Message: "msg"}
+		}
+		return annotations
+	}
+
+	require.NoError(t, InsertTaskAnnotations(db.DefaultContext, task, newAnnotations(AnnotationLevelError, 2)))
+	require.NoError(t, InsertTaskAnnotations(db.DefaultContext, task, newAnnotations(AnnotationLevelWarning, MaxAnnotationsPerTask)))
+	require.NoError(t, InsertTaskAnnotations(db.DefaultContext, task, newAnnotations(AnnotationLevelNotice, 1)))
+
+	counts, err := CountTaskAnnotations(db.DefaultContext, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[AnnotationLevel]int64{
+		AnnotationLevelError:   2,
+		AnnotationLevelWarning: MaxAnnotationsPerTask - 2,
+	}, counts)
+
+	annotations, err := db.Find[ActionTaskAnnotation](db.DefaultContext, FindTaskAnnotationsOptions{RepoID: 4, CommitSHA: task.CommitSHA})
+	require.NoError(t, err)
+	require.Len(t, annotations, MaxAnnotationsPerTask)
+	assert.Equal(t, task.ID, annotations[0].TaskID)
+}
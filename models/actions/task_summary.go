@@ -0,0 +1,62 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// MaxStepSummarySize is the max size of the summary of a step, the same as GitHub
+const MaxStepSummarySize = 1024 * 1024
+
+// ActionTaskSummary represents the markdown a step of ActionTask writes to $GITHUB_STEP_SUMMARY.
+// Like ActionTaskOutput, it's bound to a task, so a rerun job starts with an empty summary.
+type ActionTaskSummary struct {
+	ID      int64
+	TaskID  int64              `xorm:"INDEX UNIQUE(task_id_step)"`
+	Step    int64              `xorm:"UNIQUE(task_id_step)"`
+	RepoID  int64              `xorm:"index"`
+	Content string             `xorm:"LONGTEXT"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionTaskSummary))
+}
+
+// SetTaskStepSummary saves the summary of the step, the previous summary of the step is replaced
+func SetTaskStepSummary(ctx context.Context, task *ActionTask, step int64, content string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		sess := db.GetEngine(ctx)
+		// the step could be 0, so don't use the bean as the condition
+		summary := &ActionTaskSummary{}
+		has, err := sess.Where("task_id=? AND step=?", task.ID, step).Get(summary)
+		if err != nil {
+			return err
+		}
+		summary.Content = content
+		if has {
+			_, err = sess.ID(summary.ID).Cols("content").Update(summary)
+			return err
+		}
+		summary.TaskID = task.ID
+		summary.Step = step
+		summary.RepoID = task.RepoID
+		_, err = sess.Insert(summary)
+		return err
+	})
+}
+
+// GetTaskSummary returns the summary of the job the task runs, it's the summaries of the steps joined in order
+func GetTaskSummary(ctx context.Context, taskID int64) (string, error) {
+	var contents []string
+	if err := db.GetEngine(ctx).Table(ActionTaskSummary{}).Where("task_id=?", taskID).Asc("step").Cols("content").Find(&contents); err != nil {
+		return "", err
+	}
+	return strings.Join(contents, "\n"), nil
+}
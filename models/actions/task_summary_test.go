@@ -0,0 +1,31 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskSummary(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	task := &ActionTask{ID: 100, RepoID: 4}
+	require.NoError(t, SetTaskStepSummary(db.DefaultContext, task, 2, "## Deploy"))
+	require.NoError(t, SetTaskStepSummary(db.DefaultContext, task, 0, "## Build"))
+	require.NoError(t, SetTaskStepSummary(db.DefaultContext, task, 0, "## Build\nok"))
+
+	summary, err := GetTaskSummary(db.DefaultContext, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "## Build\nok\n## Deploy", summary)
+
+	summary, err = GetTaskSummary(db.DefaultContext, 101)
+	require.NoError(t, err)
+	assert.Empty(t, summary)
+}
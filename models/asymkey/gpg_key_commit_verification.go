@@ -27,6 +27,7 @@ type CommitVerification struct {
 	SigningEmail   string
 	SigningKey     *GPGKey
 	SigningSSHKey  *PublicKey
+	SigningX509    *X509Certificate
 	TrustStatus    string
 }
 
@@ -152,16 +153,22 @@ func CalculateTrustStatus(verification *CommitVerification, repoTrustModel repo_
 		return nil
 	}
 
-	// Check we actually have a GPG SigningKey
-	var err error
+	// Check we actually have a GPG SigningKey or an X.509 certificate
+	var keyID string
 	if verification.SigningKey != nil {
+		keyID = verification.SigningKey.KeyID
+	} else if verification.SigningX509 != nil {
+		keyID = verification.SigningX509.Fingerprint
+	}
+	var err error
+	if keyID != "" {
 		var isMember bool
 		if keyMap != nil {
 			var has bool
-			isMember, has = (*keyMap)[verification.SigningKey.KeyID]
+			isMember, has = (*keyMap)[keyID]
 			if !has {
 				isMember, err = isOwnerMemberCollaborator(verification.SigningUser)
-				(*keyMap)[verification.SigningKey.KeyID] = isMember
+				(*keyMap)[keyID] = isMember
 			}
 		} else {
 			isMember, err = isOwnerMemberCollaborator(verification.SigningUser)
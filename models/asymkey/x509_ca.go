@@ -0,0 +1,170 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// X509TrustedCA is a bundle of CA certificates which is trusted to issue certificates for X.509 (S/MIME) commit signing
+type X509TrustedCA struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Name        string             `xorm:"UNIQUE NOT NULL"`
+	Content     string             `xorm:"MEDIUMTEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(X509TrustedCA))
+}
+
+// Certificates returns the parsed certificates of the bundle
+func (ca *X509TrustedCA) Certificates() ([]*x509.Certificate, error) {
+	return ParseX509CABundle(ca.Content)
+}
+
+// ParseX509CABundle parses a PEM bundle which must only contain CA certificates
+func ParseX509CABundle(content string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(content)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, util.NewInvalidArgumentErrorf("unexpected PEM block %q in CA bundle", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid certificate in CA bundle: %v", err)
+		}
+		if !cert.IsCA {
+			return nil, util.NewInvalidArgumentErrorf("certificate %q is not a CA certificate", cert.Subject.String())
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("CA bundle contains no PEM encoded certificates")
+	}
+	return certs, nil
+}
+
+type FindX509TrustedCAOptions struct {
+	db.ListOptions
+}
+
+func (opts FindX509TrustedCAOptions) ToConds() builder.Cond {
+	return builder.NewCond()
+}
+
+func (opts FindX509TrustedCAOptions) ToOrders() string {
+	return "id"
+}
+
+// GetX509TrustedCAByID returns the trusted CA bundle with the given id
+func GetX509TrustedCAByID(ctx context.Context, id int64) (*X509TrustedCA, error) {
+	ca, exist, err := db.GetByID[X509TrustedCA](ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, util.NewNotExistErrorf("trusted CA bundle %d does not exist", id)
+	}
+	return ca, nil
+}
+
+// CreateX509TrustedCA validates and stores a new trusted CA bundle
+func CreateX509TrustedCA(ctx context.Context, ca *X509TrustedCA) error {
+	ca.Name = strings.TrimSpace(ca.Name)
+	if ca.Name == "" {
+		return util.NewInvalidArgumentErrorf("name of the CA bundle must not be empty")
+	}
+	if _, err := ParseX509CABundle(ca.Content); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Exist(&X509TrustedCA{Name: ca.Name})
+		if err != nil {
+			return err
+		} else if exist {
+			return util.NewAlreadyExistErrorf("trusted CA bundle %q already exists", ca.Name)
+		}
+		return db.Insert(ctx, ca)
+	})
+}
+
+// DeleteX509TrustedCAByID deletes a trusted CA bundle
+func DeleteX509TrustedCAByID(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[X509TrustedCA](ctx, id)
+	return err
+}
+
+// GetX509TrustedCertPool returns a pool of all trusted CA certificates, or nil if no CA bundle has been configured
+func GetX509TrustedCertPool(ctx context.Context) (*x509.CertPool, error) {
+	cas, err := db.Find[X509TrustedCA](ctx, FindX509TrustedCAOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(cas) == 0 {
+		return nil, nil
+	}
+	pool := x509.NewCertPool()
+	for _, ca := range cas {
+		certs, err := ca.Certificates()
+		if err != nil {
+			// a bundle was validated on creation, so this is only possible if the database was edited
+			return nil, err
+		}
+		for _, cert := range certs {
+			pool.AddCert(cert)
+		}
+	}
+	return pool, nil
+}
+
+// X509Certificate holds the details of the X.509 certificate which made a commit or tag signature
+type X509Certificate struct {
+	Fingerprint  string
+	Subject      string
+	Issuer       string
+	SerialNumber string
+}
+
+// NewX509Certificate extracts the details of a certificate
+func NewX509Certificate(cert *x509.Certificate) *X509Certificate {
+	fingerprint := sha256.Sum256(cert.Raw)
+	return &X509Certificate{
+		Fingerprint:  hex.EncodeToString(fingerprint[:]),
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: cert.SerialNumber.Text(16),
+	}
+}
+
+var oidEmailAddress = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+
+// X509CertificateEmails returns the email addresses of the certificate,
+// from the subject alternative names and the legacy emailAddress attribute of the subject
+func X509CertificateEmails(cert *x509.Certificate) []string {
+	emails := append([]string{}, cert.EmailAddresses...)
+	for _, name := range cert.Subject.Names {
+		if email, ok := name.Value.(string); ok && name.Type.Equal(oidEmailAddress) {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificatePEM(t *testing.T, isCA bool) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestParseX509CABundle(t *testing.T) {
+	ca := newTestCertificatePEM(t, true)
+
+	certs, err := ParseX509CABundle(ca + newTestCertificatePEM(t, true))
+	require.NoError(t, err)
+	assert.Len(t, certs, 2)
+
+	_, err = ParseX509CABundle("")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = ParseX509CABundle(ca + newTestCertificatePEM(t, false))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument, "leaf certificates must not be trusted")
+}
+
+func TestX509TrustedCA(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pool, err := GetX509TrustedCertPool(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, pool)
+
+	ca := &X509TrustedCA{Name: "corp", Content: newTestCertificatePEM(t, true)}
+	require.NoError(t, CreateX509TrustedCA(t.Context(), ca))
+	assert.ErrorIs(t, CreateX509TrustedCA(t.Context(), &X509TrustedCA{Name: "corp", Content: ca.Content}), util.ErrAlreadyExist)
+	assert.ErrorIs(t, CreateX509TrustedCA(t.Context(), &X509TrustedCA{Name: "invalid", Content: "foo"}), util.ErrInvalidArgument)
+
+	pool, err = GetX509TrustedCertPool(t.Context())
+	require.NoError(t, err)
+	assert.NotNil(t, pool)
+
+	require.NoError(t, DeleteX509TrustedCAByID(t.Context(), ca.ID))
+	_, err = GetX509TrustedCAByID(t.Context(), ca.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
@@ -28,6 +28,9 @@ import (
 
 var ErrBranchIsProtected = errors.New("branch is protected")
 
+// DefaultMergeQueueBatchSize is the default maximum number of pull requests tested together in a merge queue
+const DefaultMergeQueueBatchSize int64 = 5
+
 // ProtectedBranch struct
 type ProtectedBranch struct {
 	ID                            int64                  `xorm:"pk autoincr"`
@@ -56,6 +59,7 @@ type ProtectedBranch struct {
 	ApprovalsWhitelistUserIDs     []int64  `xorm:"JSON TEXT"`
 	ApprovalsWhitelistTeamIDs     []int64  `xorm:"JSON TEXT"`
 	RequiredApprovals             int64    `xorm:"NOT NULL DEFAULT 0"`
+	RequireCodeOwnerReviews       bool     `xorm:"NOT NULL DEFAULT false"`
 	BlockOnRejectedReviews        bool     `xorm:"NOT NULL DEFAULT false"`
 	BlockOnOfficialReviewRequests bool     `xorm:"NOT NULL DEFAULT false"`
 	BlockOnOutdatedBranch         bool     `xorm:"NOT NULL DEFAULT false"`
@@ -65,6 +69,8 @@ type ProtectedBranch struct {
 	ProtectedFilePatterns         string   `xorm:"TEXT"`
 	UnprotectedFilePatterns       string   `xorm:"TEXT"`
 	BlockAdminMergeOverride       bool     `xorm:"NOT NULL DEFAULT false"`
+	EnableMergeQueue              bool     `xorm:"NOT NULL DEFAULT false"`
+	MergeQueueBatchSize           int64    `xorm:"NOT NULL DEFAULT 5"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
@@ -0,0 +1,172 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"regexp"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/gobwas/glob"
+	"xorm.io/builder"
+)
+
+// PushRule represents the content policies checked for every push to a repository
+//
+// It can be:
+//  1. instance level rule, OwnerID and RepoID are 0
+//  2. org/user level rule, OwnerID is org/user ID and RepoID is 0
+//  3. repo level rule, OwnerID is 0 and RepoID is repo ID
+//
+// All the rules applying to a repository are checked, so the stricter rule wins.
+type PushRule struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OwnerID int64 `xorm:"UNIQUE(owner_repo)"`
+	RepoID  int64 `xorm:"INDEX UNIQUE(owner_repo)"`
+	// MaxFileSize rejects the blobs larger than this size in bytes, 0 means no limit
+	MaxFileSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ForbiddenPathPatterns rejects the commits adding or changing a file matching any of the glob patterns
+	ForbiddenPathPatterns []string `xorm:"JSON TEXT"`
+	// CommitMessagePattern rejects the commits whose message doesn't match the regular expression, empty means no restriction
+	CommitMessagePattern string `xorm:"TEXT"`
+	// RequireVerifiedAuthorEmail rejects the commits whose author email isn't a verified email of a user
+	RequireVerifiedAuthorEmail bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix                timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix                timeutil.TimeStamp `xorm:"updated"`
+
+	forbiddenPathGlobs  []glob.Glob
+	commitMessageRegexp *regexp.Regexp
+	compiled            bool
+}
+
+func init() {
+	db.RegisterModel(new(PushRule))
+}
+
+func (rule *PushRule) compile() error {
+	if rule.compiled {
+		return nil
+	}
+	rule.forbiddenPathGlobs = make([]glob.Glob, 0, len(rule.ForbiddenPathPatterns))
+	for _, pattern := range rule.ForbiddenPathPatterns {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return util.NewInvalidArgumentErrorf("invalid forbidden path pattern %q: %v", pattern, err)
+		}
+		rule.forbiddenPathGlobs = append(rule.forbiddenPathGlobs, g)
+	}
+	if rule.CommitMessagePattern != "" {
+		re, err := regexp.Compile(rule.CommitMessagePattern)
+		if err != nil {
+			return util.NewInvalidArgumentErrorf("invalid commit message pattern %q: %v", rule.CommitMessagePattern, err)
+		}
+		rule.commitMessageRegexp = re
+	}
+	rule.compiled = true
+	return nil
+}
+
+// IsForbiddenPath returns the forbidden path pattern matching the file path, or an empty string
+func (rule *PushRule) IsForbiddenPath(path string) string {
+	if err := rule.compile(); err != nil {
+		return ""
+	}
+	for i, g := range rule.forbiddenPathGlobs {
+		if g.Match(path) {
+			return rule.ForbiddenPathPatterns[i]
+		}
+	}
+	return ""
+}
+
+// MatchCommitMessage returns true if the commit message is allowed by the rule
+func (rule *PushRule) MatchCommitMessage(message string) bool {
+	if err := rule.compile(); err != nil || rule.commitMessageRegexp == nil {
+		return true
+	}
+	return rule.commitMessageRegexp.MatchString(message)
+}
+
+type FindPushRulesOptions struct {
+	db.ListOptions
+	RepoID  int64
+	OwnerID int64 // it will be ignored if RepoID is set
+}
+
+func (opts FindPushRulesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	if opts.RepoID != 0 {
+		cond = cond.And(builder.Eq{"owner_id": 0})
+	} else {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	return cond
+}
+
+// GetPushRule returns the push rule configured exactly on the instance, the owner or the repo
+func GetPushRule(ctx context.Context, ownerID, repoID int64) (*PushRule, error) {
+	rules, err := db.Find[PushRule](ctx, FindPushRulesOptions{OwnerID: ownerID, RepoID: repoID})
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, util.NewNotExistErrorf("push rule does not exist")
+	}
+	return rules[0], nil
+}
+
+// GetPushRulesForRepo returns the instance, owner and repo level push rules applying to the repository
+func GetPushRulesForRepo(ctx context.Context, repo *repo_model.Repository) ([]*PushRule, error) {
+	rules := make([]*PushRule, 0, 3)
+	return rules, db.GetEngine(ctx).
+		Where(FindPushRulesOptions{}.ToConds().
+			Or(FindPushRulesOptions{OwnerID: repo.OwnerID}.ToConds()).
+			Or(FindPushRulesOptions{RepoID: repo.ID}.ToConds())).
+		Asc("id").
+		Find(&rules)
+}
+
+// UpsertPushRule creates or updates the push rule of the instance, the owner or the repo
+func UpsertPushRule(ctx context.Context, rule *PushRule) error {
+	if rule.OwnerID != 0 && rule.RepoID != 0 {
+		rule.OwnerID = 0
+	}
+	if rule.MaxFileSize < 0 {
+		return util.NewInvalidArgumentErrorf("max file size cannot be negative")
+	}
+	rule.compiled = false
+	if err := rule.compile(); err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := db.Find[PushRule](ctx, FindPushRulesOptions{OwnerID: rule.OwnerID, RepoID: rule.RepoID})
+		if err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			return db.Insert(ctx, rule)
+		}
+		rule.ID = existing[0].ID
+		_, err = db.GetEngine(ctx).ID(rule.ID).
+			Cols("max_file_size", "forbidden_path_patterns", "commit_message_pattern", "require_verified_author_email").
+			Update(rule)
+		return err
+	})
+}
+
+// DeletePushRule deletes the push rule of the instance, the owner or the repo
+func DeletePushRule(ctx context.Context, ownerID, repoID int64) error {
+	rule, err := GetPushRule(ctx, ownerID, repoID)
+	if err != nil {
+		return err
+	}
+	_, err = db.DeleteByID[PushRule](ctx, rule.ID)
+	return err
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushRuleMatch(t *testing.T) {
+	rule := &PushRule{
+		ForbiddenPathPatterns: []string{"*.exe", "**/secrets/**", ".env"},
+		CommitMessagePattern:  `^[A-Z]+-[0-9]+ `,
+	}
+	assert.Equal(t, "*.exe", rule.IsForbiddenPath("setup.exe"))
+	assert.Empty(t, rule.IsForbiddenPath("bin/setup.exe"))
+	assert.Equal(t, "**/secrets/**", rule.IsForbiddenPath("deploy/secrets/prod.yml"))
+	assert.Equal(t, ".env", rule.IsForbiddenPath(".env"))
+	assert.Empty(t, rule.IsForbiddenPath("docs/.env.example"))
+
+	assert.True(t, rule.MatchCommitMessage("GITEA-123 fix the bug\n"))
+	assert.False(t, rule.MatchCommitMessage("fix the bug\n"))
+	assert.True(t, (&PushRule{}).MatchCommitMessage("anything"))
+}
+
+func TestPushRules(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	require.NoError(t, UpsertPushRule(t.Context(), &PushRule{MaxFileSize: 100 << 20}))
+	require.NoError(t, UpsertPushRule(t.Context(), &PushRule{OwnerID: repo.OwnerID, CommitMessagePattern: `^[A-Z]+-[0-9]+`}))
+	require.NoError(t, UpsertPushRule(t.Context(), &PushRule{OwnerID: repo.OwnerID, RepoID: repo.ID, MaxFileSize: 1 << 20}))
+	// the rule of another repository of the same owner doesn't apply
+	require.NoError(t, UpsertPushRule(t.Context(), &PushRule{RepoID: 32, RequireVerifiedAuthorEmail: true}))
+
+	// OwnerID is cleared for a repo level rule
+	rule, err := GetPushRule(t.Context(), 0, repo.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1<<20, rule.MaxFileSize)
+
+	rules, err := GetPushRulesForRepo(t.Context(), repo)
+	require.NoError(t, err)
+	if assert.Len(t, rules, 3) {
+		assert.EqualValues(t, 100<<20, rules[0].MaxFileSize)
+		assert.Equal(t, `^[A-Z]+-[0-9]+`, rules[1].CommitMessagePattern)
+		assert.Equal(t, repo.ID, rules[2].RepoID)
+	}
+
+	// updating keeps a single rule per scope
+	require.NoError(t, UpsertPushRule(t.Context(), &PushRule{RepoID: repo.ID, ForbiddenPathPatterns: []string{"*.zip"}}))
+	rule, err = GetPushRule(t.Context(), 0, repo.ID)
+	require.NoError(t, err)
+	assert.Zero(t, rule.MaxFileSize)
+	assert.Equal(t, []string{"*.zip"}, rule.ForbiddenPathPatterns)
+
+	assert.ErrorIs(t, UpsertPushRule(t.Context(), &PushRule{RepoID: repo.ID, CommitMessagePattern: `(`}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, UpsertPushRule(t.Context(), &PushRule{RepoID: repo.ID, MaxFileSize: -1}), util.ErrInvalidArgument)
+
+	require.NoError(t, DeletePushRule(t.Context(), 0, repo.ID))
+	_, err = GetPushRule(t.Context(), 0, repo.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	assert.ErrorIs(t, DeletePushRule(t.Context(), 0, repo.ID), util.ErrNotExist)
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/gobwas/glob"
+	"xorm.io/builder"
+)
+
+// RulesetEnforcement represents how the rules of a ruleset are enforced
+type RulesetEnforcement string
+
+const (
+	// RulesetEnforcementDisabled means the ruleset is not evaluated at all
+	RulesetEnforcementDisabled RulesetEnforcement = "disabled"
+	// RulesetEnforcementEvaluate means the violations are logged but not blocked
+	RulesetEnforcementEvaluate RulesetEnforcement = "evaluate"
+	// RulesetEnforcementActive means the violations are blocked
+	RulesetEnforcementActive RulesetEnforcement = "active"
+)
+
+// IsValid returns true if the enforcement is known
+func (e RulesetEnforcement) IsValid() bool {
+	return e == RulesetEnforcementDisabled || e == RulesetEnforcementEvaluate || e == RulesetEnforcementActive
+}
+
+// Ruleset represents a set of rules protecting the refs of many repositories at once
+//
+// It can be:
+//  1. instance level ruleset, OwnerID is 0, it targets the repositories of all owners
+//  2. org level ruleset, OwnerID is the org ID, it targets the repositories of the org
+//
+// Rulesets are enforced in addition to the protected branches and tags of the repositories,
+// so the stricter rule always wins.
+type Ruleset struct {
+	ID          int64
+	OwnerID     int64              `xorm:"UNIQUE(owner_name)"`
+	Name        string             `xorm:"UNIQUE(owner_name) NOT NULL"`
+	Enforcement RulesetEnforcement `xorm:"VARCHAR(20) NOT NULL DEFAULT 'disabled'"`
+
+	// RepoNamePatterns are glob patterns of the names of the target repositories, empty means all repositories
+	RepoNamePatterns []string `xorm:"JSON TEXT"`
+	// RepoTopics restricts the target repositories to the ones with any of the topics, empty means no restriction
+	RepoTopics []string `xorm:"JSON TEXT"`
+	// RefPatterns are glob patterns of the full names of the target refs, e.g. "refs/heads/main" or "refs/tags/v*"
+	RefPatterns []string `xorm:"JSON TEXT"`
+
+	BlockCreation           bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockDeletion           bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockForcePush          bool     `xorm:"NOT NULL DEFAULT false"`
+	RequirePullRequest      bool     `xorm:"NOT NULL DEFAULT false"`
+	RequiredApprovals       int64    `xorm:"NOT NULL DEFAULT 0"`
+	BlockOnRejectedReviews  bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockOnOutdatedBranch   bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireCodeOwnerReviews bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedCommits    bool     `xorm:"NOT NULL DEFAULT false"`
+	StatusCheckContexts     []string `xorm:"JSON TEXT"`
+
+	// BypassUserIDs and BypassTeamIDs are the users and teams the ruleset doesn't apply to
+	BypassUserIDs []int64 `xorm:"JSON TEXT"`
+	BypassTeamIDs []int64 `xorm:"JSON TEXT"`
+	// BypassRepoAdmins lets the administrators of a target repository bypass the ruleset
+	BypassRepoAdmins bool `xorm:"NOT NULL DEFAULT false"`
+
+	Created timeutil.TimeStamp `xorm:"created"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(Ruleset))
+}
+
+// IsEvaluateOnly returns true if the violations of the ruleset are only logged
+func (rs *Ruleset) IsEvaluateOnly() bool {
+	return rs.Enforcement == RulesetEnforcementEvaluate
+}
+
+func matchAnyGlob(patterns []string, s string, separators ...rune) bool {
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern, separators...)
+		if err != nil {
+			log.Warn("Invalid glob pattern %q: %v", pattern, err)
+			continue
+		}
+		if g.Match(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchRepo returns true if the repository is a target of the ruleset
+func (rs *Ruleset) MatchRepo(repo *repo_model.Repository) bool {
+	if rs.OwnerID != 0 && repo.OwnerID != rs.OwnerID {
+		return false
+	}
+	if len(rs.RepoNamePatterns) > 0 && !matchAnyGlob(rs.RepoNamePatterns, repo.LowerName) {
+		return false
+	}
+	if len(rs.RepoTopics) > 0 && !slices.ContainsFunc(rs.RepoTopics, func(topic string) bool {
+		return slices.Contains(repo.Topics, topic)
+	}) {
+		return false
+	}
+	return true
+}
+
+// MatchRef returns true if the full ref name is a target of the ruleset
+func (rs *Ruleset) MatchRef(refFullName string) bool {
+	return matchAnyGlob(rs.RefPatterns, refFullName, '/')
+}
+
+// ToProtectedBranch returns a protected branch rule with the merge rules of the ruleset,
+// so they can be checked the same way as the rules of the repository
+func (rs *Ruleset) ToProtectedBranch(repo *repo_model.Repository) *ProtectedBranch {
+	return &ProtectedBranch{
+		RepoID:                  repo.ID,
+		Repo:                    repo,
+		RuleName:                rs.Name,
+		EnableStatusCheck:       len(rs.StatusCheckContexts) > 0,
+		StatusCheckContexts:     rs.StatusCheckContexts,
+		RequiredApprovals:       rs.RequiredApprovals,
+		BlockOnRejectedReviews:  rs.BlockOnRejectedReviews,
+		BlockOnOutdatedBranch:   rs.BlockOnOutdatedBranch,
+		RequireCodeOwnerReviews: rs.RequireCodeOwnerReviews,
+		RequireSignedCommits:    rs.RequireSignedCommits,
+	}
+}
+
+// CanBypass returns true if the user is a bypass actor of the ruleset
+func (rs *Ruleset) CanBypass(ctx context.Context, user *user_model.User, isRepoAdmin bool) (bool, error) {
+	if user == nil {
+		return false, nil
+	}
+	if rs.BypassRepoAdmins && isRepoAdmin {
+		return true, nil
+	}
+	if slices.Contains(rs.BypassUserIDs, user.ID) {
+		return true, nil
+	}
+	if len(rs.BypassTeamIDs) == 0 {
+		return false, nil
+	}
+	return organization.IsUserInTeams(ctx, user.ID, rs.BypassTeamIDs)
+}
+
+// GetRulesetByID returns the ruleset by id
+func GetRulesetByID(ctx context.Context, id int64) (*Ruleset, error) {
+	var rs Ruleset
+	has, err := db.GetEngine(ctx).ID(id).Get(&rs)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("ruleset with id %d: %w", id, util.ErrNotExist)
+	}
+	return &rs, nil
+}
+
+// GetRulesetByOwnerAndID returns the ruleset by id if it belongs to the owner
+func GetRulesetByOwnerAndID(ctx context.Context, ownerID, id int64) (*Ruleset, error) {
+	rs, err := GetRulesetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rs.OwnerID != ownerID {
+		return nil, fmt.Errorf("ruleset with id %d: %w", id, util.ErrNotExist)
+	}
+	return rs, nil
+}
+
+type FindRulesetOptions struct {
+	db.ListOptions
+	OwnerID int64
+	Name    string
+}
+
+func (opts FindRulesetOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	if opts.Name != "" {
+		cond = cond.And(builder.Eq{"name": opts.Name})
+	}
+	return cond
+}
+
+func (opts FindRulesetOptions) ToOrders() string {
+	return "name ASC"
+}
+
+// GetRulesetsForRef returns the enabled instance and owner level rulesets targeting the ref of the repository
+func GetRulesetsForRef(ctx context.Context, repo *repo_model.Repository, refFullName string) ([]*Ruleset, error) {
+	rulesets := make([]*Ruleset, 0, 2)
+	if err := db.GetEngine(ctx).
+		Where(builder.In("owner_id", 0, repo.OwnerID).And(builder.Neq{"enforcement": RulesetEnforcementDisabled})).
+		Asc("owner_id", "id").
+		Find(&rulesets); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(rulesets, func(rs *Ruleset) bool {
+		return !rs.MatchRepo(repo) || !rs.MatchRef(refFullName)
+	}), nil
+}
+
+func validateRuleset(ctx context.Context, rs *Ruleset) error {
+	rs.Name = strings.TrimSpace(rs.Name)
+	if rs.Name == "" {
+		return util.NewInvalidArgumentErrorf("ruleset name is empty")
+	}
+	if !rs.Enforcement.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid ruleset enforcement %q", rs.Enforcement)
+	}
+	if len(rs.RefPatterns) == 0 {
+		return util.NewInvalidArgumentErrorf("ruleset has no target ref patterns")
+	}
+	for _, pattern := range rs.RefPatterns {
+		if !strings.HasPrefix(pattern, "refs/") {
+			return util.NewInvalidArgumentErrorf("ref pattern %q must start with refs/", pattern)
+		}
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid ref pattern %q: %v", pattern, err)
+		}
+	}
+	for i, pattern := range rs.RepoNamePatterns {
+		rs.RepoNamePatterns[i] = strings.ToLower(pattern)
+		if _, err := glob.Compile(rs.RepoNamePatterns[i]); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid repository name pattern %q: %v", pattern, err)
+		}
+	}
+	if rs.RequiredApprovals < 0 {
+		return util.NewInvalidArgumentErrorf("required approvals cannot be negative")
+	}
+	if err := validateRulesetBypassActors(ctx, rs); err != nil {
+		return err
+	}
+	exist, err := db.Exist[Ruleset](ctx, FindRulesetOptions{OwnerID: rs.OwnerID, Name: rs.Name}.ToConds().And(builder.Neq{"id": rs.ID}))
+	if err != nil {
+		return err
+	} else if exist {
+		return util.NewAlreadyExistErrorf("ruleset %q already exists", rs.Name)
+	}
+	return nil
+}
+
+// validateRulesetBypassActors checks that the bypass users and teams exist,
+// the bypass teams of an org level ruleset must belong to the org
+func validateRulesetBypassActors(ctx context.Context, rs *Ruleset) error {
+	slices.Sort(rs.BypassUserIDs)
+	rs.BypassUserIDs = slices.Compact(rs.BypassUserIDs)
+	users, err := user_model.GetUsersMapByIDs(ctx, rs.BypassUserIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range rs.BypassUserIDs {
+		if _, ok := users[id]; !ok {
+			return util.NewInvalidArgumentErrorf("user %d does not exist", id)
+		}
+	}
+
+	slices.Sort(rs.BypassTeamIDs)
+	rs.BypassTeamIDs = slices.Compact(rs.BypassTeamIDs)
+	teams, err := organization.GetTeamsByIDs(ctx, rs.BypassTeamIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range rs.BypassTeamIDs {
+		team, ok := teams[id]
+		if !ok {
+			return util.NewInvalidArgumentErrorf("team %d does not exist", id)
+		}
+		if rs.OwnerID != 0 && team.OrgID != rs.OwnerID {
+			return util.NewInvalidArgumentErrorf("team %d does not belong to the owner of the ruleset", id)
+		}
+	}
+	return nil
+}
+
+// CreateRuleset creates a ruleset
+func CreateRuleset(ctx context.Context, rs *Ruleset) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := validateRuleset(ctx, rs); err != nil {
+			return err
+		}
+		return db.Insert(ctx, rs)
+	})
+}
+
+// UpdateRuleset updates the columns of the ruleset
+func UpdateRuleset(ctx context.Context, rs *Ruleset, cols ...string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := validateRuleset(ctx, rs); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(rs.ID).Cols(cols...).Update(rs)
+		return err
+	})
+}
+
+// DeleteRulesetByID deletes the ruleset
+func DeleteRulesetByID(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[Ruleset](ctx, id)
+	return err
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesetMatch(t *testing.T) {
+	repo := &repo_model.Repository{OwnerID: 3, LowerName: "service-api", Topics: []string{"go", "backend"}}
+
+	rs := &Ruleset{OwnerID: 3, RefPatterns: []string{"refs/heads/main", "refs/heads/release/*"}}
+	assert.True(t, rs.MatchRepo(repo))
+	assert.True(t, rs.MatchRef("refs/heads/main"))
+	assert.True(t, rs.MatchRef("refs/heads/release/v1"))
+	assert.False(t, rs.MatchRef("refs/heads/release/v1/hotfix"))
+	assert.False(t, rs.MatchRef("refs/tags/main"))
+
+	rs.RepoNamePatterns = []string{"service-*"}
+	assert.True(t, rs.MatchRepo(repo))
+	rs.RepoNamePatterns = []string{"web-*"}
+	assert.False(t, rs.MatchRepo(repo))
+
+	rs.RepoNamePatterns = nil
+	rs.RepoTopics = []string{"frontend", "backend"}
+	assert.True(t, rs.MatchRepo(repo))
+	rs.RepoTopics = []string{"frontend"}
+	assert.False(t, rs.MatchRepo(repo))
+
+	rs.RepoTopics = nil
+	rs.OwnerID = 2
+	assert.False(t, rs.MatchRepo(repo))
+	rs.OwnerID = 0
+	assert.True(t, rs.MatchRepo(repo))
+}
+
+func TestGetRulesetsForRef(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	global := &Ruleset{Name: "global", Enforcement: RulesetEnforcementActive, RefPatterns: []string{"refs/heads/*"}, BlockDeletion: true}
+	require.NoError(t, CreateRuleset(t.Context(), global))
+	org := &Ruleset{OwnerID: repo.OwnerID, Name: "tags", Enforcement: RulesetEnforcementEvaluate, RefPatterns: []string{"refs/tags/v*"}, BypassTeamIDs: []int64{1}}
+	require.NoError(t, CreateRuleset(t.Context(), org))
+	disabled := &Ruleset{OwnerID: repo.OwnerID, Name: "disabled", Enforcement: RulesetEnforcementDisabled, RefPatterns: []string{"refs/heads/*"}}
+	require.NoError(t, CreateRuleset(t.Context(), disabled))
+
+	rulesets, err := GetRulesetsForRef(t.Context(), repo, "refs/heads/master")
+	require.NoError(t, err)
+	if assert.Len(t, rulesets, 1) {
+		assert.Equal(t, global.ID, rulesets[0].ID)
+	}
+
+	rulesets, err = GetRulesetsForRef(t.Context(), repo, "refs/tags/v1.0")
+	require.NoError(t, err)
+	if assert.Len(t, rulesets, 1) {
+		assert.Equal(t, org.ID, rulesets[0].ID)
+		assert.True(t, rulesets[0].IsEvaluateOnly())
+	}
+
+	err = CreateRuleset(t.Context(), &Ruleset{Name: "global", Enforcement: RulesetEnforcementActive, RefPatterns: []string{"refs/heads/*"}})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	err = CreateRuleset(t.Context(), &Ruleset{Name: "invalid", Enforcement: RulesetEnforcementActive, RefPatterns: []string{"main"}})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	// team 1 doesn't belong to user 2
+	err = CreateRuleset(t.Context(), &Ruleset{OwnerID: 2, Name: "teams", Enforcement: RulesetEnforcementActive, RefPatterns: []string{"refs/heads/*"}, BypassTeamIDs: []int64{1}})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	require.NoError(t, DeleteRulesetByID(t.Context(), global.ID))
+	rulesets, err = GetRulesetsForRef(t.Context(), repo, "refs/heads/master")
+	require.NoError(t, err)
+	assert.Empty(t, rulesets)
+}
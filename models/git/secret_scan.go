@@ -0,0 +1,270 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/secretscan"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// SecretScanPattern is a custom secret pattern defined by an organization or the instance
+// OwnerID == 0 means an instance level pattern, it applies to all repositories
+type SecretScanPattern struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"UNIQUE(owner_name)"`
+	Name        string             `xorm:"UNIQUE(owner_name) NOT NULL"`
+	Pattern     string             `xorm:"TEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// SecretScanAlertState represents the state of a secret scanning alert
+type SecretScanAlertState string
+
+const (
+	SecretScanAlertStateOpen     SecretScanAlertState = "open"
+	SecretScanAlertStateResolved SecretScanAlertState = "resolved"
+)
+
+// SecretScanAlertResolution represents why a secret scanning alert has been resolved
+type SecretScanAlertResolution string
+
+const (
+	SecretScanAlertResolutionFalsePositive SecretScanAlertResolution = "false_positive"
+	SecretScanAlertResolutionRevoked       SecretScanAlertResolution = "revoked"
+	SecretScanAlertResolutionUsedInTests   SecretScanAlertResolution = "used_in_tests"
+	SecretScanAlertResolutionWontFix       SecretScanAlertResolution = "wont_fix"
+)
+
+// IsValid returns true if the resolution is known
+func (r SecretScanAlertResolution) IsValid() bool {
+	switch r {
+	case SecretScanAlertResolutionFalsePositive, SecretScanAlertResolutionRevoked, SecretScanAlertResolutionUsedInTests, SecretScanAlertResolutionWontFix:
+		return true
+	}
+	return false
+}
+
+// SecretScanAlert represents a secret found in a push to a repository.
+// The secret itself is never stored, an alert is identified by the hash of the secret in the repository.
+type SecretScanAlert struct {
+	ID             int64  `xorm:"pk autoincr"`
+	RepoID         int64  `xorm:"UNIQUE(repo_secret)"`
+	SecretHash     string `xorm:"VARCHAR(64) UNIQUE(repo_secret) NOT NULL"`
+	PatternID      string `xorm:"VARCHAR(255)"`
+	PatternName    string
+	RedactedSecret string
+	// the location where the secret has been found first
+	RefName  string `xorm:"TEXT"`
+	CommitID string `xorm:"VARCHAR(64)"`
+	Path     string `xorm:"TEXT"`
+	Line     int
+	PusherID int64
+	Pusher   *user_model.User `xorm:"-"`
+	// the reason given by the pusher to bypass the block
+	BypassReason string `xorm:"TEXT"`
+
+	State             SecretScanAlertState      `xorm:"VARCHAR(20) INDEX NOT NULL DEFAULT 'open'"`
+	Resolution        SecretScanAlertResolution `xorm:"VARCHAR(20)"`
+	ResolutionComment string                    `xorm:"TEXT"`
+	ResolvedByID      int64
+	ResolvedBy        *user_model.User `xorm:"-"`
+	ResolvedUnix      timeutil.TimeStamp
+	CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix       timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(SecretScanPattern))
+	db.RegisterModel(new(SecretScanAlert))
+}
+
+// ToPattern compiles the custom pattern
+func (p *SecretScanPattern) ToPattern() (*secretscan.Pattern, error) {
+	return secretscan.NewPattern(fmt.Sprintf("custom_%d", p.ID), p.Name, p.Pattern)
+}
+
+type FindSecretScanPatternOptions struct {
+	db.ListOptions
+	OwnerID int64
+	Name    string
+}
+
+func (opts FindSecretScanPatternOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	if opts.Name != "" {
+		cond = cond.And(builder.Eq{"name": opts.Name})
+	}
+	return cond
+}
+
+func (opts FindSecretScanPatternOptions) ToOrders() string {
+	return "name ASC"
+}
+
+// GetSecretScanPatternByOwnerAndID returns the custom pattern by id if it belongs to the owner
+func GetSecretScanPatternByOwnerAndID(ctx context.Context, ownerID, id int64) (*SecretScanPattern, error) {
+	var p SecretScanPattern
+	has, err := db.GetEngine(ctx).Where("id = ? AND owner_id = ?", id, ownerID).Get(&p)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("secret scanning pattern with id %d: %w", id, util.ErrNotExist)
+	}
+	return &p, nil
+}
+
+// GetSecretScanPatternsForRepo returns the custom patterns of the instance and of the owner of the repository
+func GetSecretScanPatternsForRepo(ctx context.Context, repo *repo_model.Repository) ([]*SecretScanPattern, error) {
+	patterns := make([]*SecretScanPattern, 0, 5)
+	return patterns, db.GetEngine(ctx).
+		Where(builder.In("owner_id", 0, repo.OwnerID)).
+		Asc("owner_id", "id").
+		Find(&patterns)
+}
+
+// CreateSecretScanPattern creates a custom pattern
+func CreateSecretScanPattern(ctx context.Context, p *SecretScanPattern) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return util.NewInvalidArgumentErrorf("pattern name is empty")
+	}
+	if _, err := p.ToPattern(); err != nil {
+		return util.NewInvalidArgumentErrorf("%v", err)
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.Exist[SecretScanPattern](ctx, FindSecretScanPatternOptions{OwnerID: p.OwnerID, Name: p.Name}.ToConds())
+		if err != nil {
+			return err
+		} else if exist {
+			return util.NewAlreadyExistErrorf("secret scanning pattern %q already exists", p.Name)
+		}
+		return db.Insert(ctx, p)
+	})
+}
+
+// DeleteSecretScanPatternByID deletes the custom pattern
+func DeleteSecretScanPatternByID(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[SecretScanPattern](ctx, id)
+	return err
+}
+
+type FindSecretScanAlertOptions struct {
+	db.ListOptions
+	RepoID int64
+	State  SecretScanAlertState
+}
+
+func (opts FindSecretScanAlertOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	if opts.State != "" {
+		cond = cond.And(builder.Eq{"state": opts.State})
+	}
+	return cond
+}
+
+func (opts FindSecretScanAlertOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// LoadAttributes loads the pusher and the resolver of the alert, the deleted users are replaced by the ghost user
+func (a *SecretScanAlert) LoadAttributes(ctx context.Context) error {
+	loadUser := func(id int64) (*user_model.User, error) {
+		u, err := user_model.GetPossibleUserByID(ctx, id)
+		if user_model.IsErrUserNotExist(err) {
+			return user_model.NewGhostUser(), nil
+		}
+		return u, err
+	}
+
+	var err error
+	if a.Pusher == nil {
+		if a.Pusher, err = loadUser(a.PusherID); err != nil {
+			return err
+		}
+	}
+	if a.ResolvedBy == nil && a.ResolvedByID != 0 {
+		if a.ResolvedBy, err = loadUser(a.ResolvedByID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSecretScanAlertByRepoAndID returns the alert by id if it belongs to the repository
+func GetSecretScanAlertByRepoAndID(ctx context.Context, repoID, id int64) (*SecretScanAlert, error) {
+	var a SecretScanAlert
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(&a)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("secret scanning alert with id %d: %w", id, util.ErrNotExist)
+	}
+	return &a, nil
+}
+
+// GetSecretScanAlertsByHashes returns the alerts of the repository for the secret hashes, keyed by hash
+func GetSecretScanAlertsByHashes(ctx context.Context, repoID int64, hashes []string) (map[string]*SecretScanAlert, error) {
+	alerts := make(map[string]*SecretScanAlert, len(hashes))
+	if len(hashes) == 0 {
+		return alerts, nil
+	}
+	list := make([]*SecretScanAlert, 0, len(hashes))
+	if err := db.GetEngine(ctx).Where("repo_id = ?", repoID).In("secret_hash", hashes).Find(&list); err != nil {
+		return nil, err
+	}
+	for _, a := range list {
+		alerts[a.SecretHash] = a
+	}
+	return alerts, nil
+}
+
+// CreateSecretScanAlerts opens the alerts whose secrets have no alert in their repository yet
+func CreateSecretScanAlerts(ctx context.Context, alerts []*SecretScanAlert) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for _, a := range alerts {
+			exist, err := db.GetEngine(ctx).Where("repo_id = ? AND secret_hash = ?", a.RepoID, a.SecretHash).Exist(new(SecretScanAlert))
+			if err != nil {
+				return err
+			} else if exist {
+				continue
+			}
+			a.State = SecretScanAlertStateOpen
+			if err := db.Insert(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ResolveSecretScanAlert resolves the alert, or reopens it if the resolution is empty
+func ResolveSecretScanAlert(ctx context.Context, a *SecretScanAlert, doer *user_model.User, resolution SecretScanAlertResolution, comment string) error {
+	if resolution == "" {
+		a.State = SecretScanAlertStateOpen
+		a.ResolvedByID, a.ResolvedBy, a.ResolvedUnix, a.ResolutionComment = 0, nil, 0, ""
+	} else {
+		if !resolution.IsValid() {
+			return util.NewInvalidArgumentErrorf("invalid resolution %q", resolution)
+		}
+		a.State = SecretScanAlertStateResolved
+		a.ResolvedByID, a.ResolvedBy, a.ResolvedUnix, a.ResolutionComment = doer.ID, doer, timeutil.TimeStampNow(), comment
+	}
+	a.Resolution = resolution
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols("state", "resolution", "resolution_comment", "resolved_by_id", "resolved_unix").Update(a)
+	return err
+}
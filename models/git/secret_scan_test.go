@@ -0,0 +1,78 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretScanPatterns(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	require.NoError(t, CreateSecretScanPattern(t.Context(), &SecretScanPattern{Name: "instance", Pattern: `\binst_[0-9a-f]{16}\b`}))
+	require.NoError(t, CreateSecretScanPattern(t.Context(), &SecretScanPattern{OwnerID: repo.OwnerID, Name: "org", Pattern: `\b(acme_[0-9a-f]{16})\b`}))
+	require.NoError(t, CreateSecretScanPattern(t.Context(), &SecretScanPattern{OwnerID: 2, Name: "other", Pattern: `other_[0-9]+`}))
+
+	assert.ErrorIs(t, CreateSecretScanPattern(t.Context(), &SecretScanPattern{OwnerID: repo.OwnerID, Name: "org", Pattern: `x+`}), util.ErrAlreadyExist)
+	assert.ErrorIs(t, CreateSecretScanPattern(t.Context(), &SecretScanPattern{OwnerID: repo.OwnerID, Name: "invalid", Pattern: `(`}), util.ErrInvalidArgument)
+
+	patterns, err := GetSecretScanPatternsForRepo(t.Context(), repo)
+	require.NoError(t, err)
+	if assert.Len(t, patterns, 2) {
+		assert.Equal(t, "instance", patterns[0].Name)
+		assert.Equal(t, "org", patterns[1].Name)
+	}
+}
+
+func TestSecretScanAlerts(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	alerts := []*SecretScanAlert{
+		{RepoID: 1, SecretHash: "hash1", PatternID: "github_token", PusherID: 2},
+		{RepoID: 1, SecretHash: "hash2", PatternID: "private_key", PusherID: 2},
+		{RepoID: 1, SecretHash: "hash1", PatternID: "github_token", PusherID: 2},
+	}
+	require.NoError(t, CreateSecretScanAlerts(t.Context(), alerts))
+	assert.Zero(t, alerts[2].ID, "the same secret is not reported twice")
+
+	byHash, err := GetSecretScanAlertsByHashes(t.Context(), 1, []string{"hash1", "hash2", "hash3"})
+	require.NoError(t, err)
+	assert.Len(t, byHash, 2)
+	assert.Equal(t, SecretScanAlertStateOpen, byHash["hash1"].State)
+
+	alert, err := GetSecretScanAlertByRepoAndID(t.Context(), 1, alerts[0].ID)
+	require.NoError(t, err)
+	_, err = GetSecretScanAlertByRepoAndID(t.Context(), 2, alerts[0].ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	assert.ErrorIs(t, ResolveSecretScanAlert(t.Context(), alert, doer, "unknown", ""), util.ErrInvalidArgument)
+	require.NoError(t, ResolveSecretScanAlert(t.Context(), alert, doer, SecretScanAlertResolutionRevoked, "rotated"))
+	alert = unittest.AssertExistsAndLoadBean(t, &SecretScanAlert{ID: alert.ID})
+	assert.Equal(t, SecretScanAlertStateResolved, alert.State)
+	assert.Equal(t, doer.ID, alert.ResolvedByID)
+	assert.Equal(t, "rotated", alert.ResolutionComment)
+
+	open, err := db.Find[SecretScanAlert](t.Context(), FindSecretScanAlertOptions{RepoID: 1, State: SecretScanAlertStateOpen})
+	require.NoError(t, err)
+	if assert.Len(t, open, 1) {
+		assert.Equal(t, "hash2", open[0].SecretHash)
+	}
+
+	require.NoError(t, ResolveSecretScanAlert(t.Context(), alert, doer, "", ""))
+	alert = unittest.AssertExistsAndLoadBean(t, &SecretScanAlert{ID: alert.ID})
+	assert.Equal(t, SecretScanAlertStateOpen, alert.State)
+	assert.Zero(t, alert.ResolvedByID)
+}
@@ -115,6 +115,11 @@ const (
 	CommentTypeUnpin // 37 unpin Issue/PullRequest
 
 	CommentTypeChangeTimeEstimate // 38 Change time estimate
+
+	CommentTypePRAddedToMergeQueue     // 39 pr was added to the merge queue
+	CommentTypePRRemovedFromMergeQueue // 40 pr was removed from the merge queue without being merged
+
+	CommentTypePullStackRebaseFailed // 41 pr could not be rebased after the pr it is stacked on was merged
 )
 
 var commentStrings = []string{
@@ -157,6 +162,9 @@ var commentStrings = []string{
 	"pin",
 	"unpin",
 	"change_time_estimate",
+	"pull_added_to_merge_queue",
+	"pull_removed_from_merge_queue",
+	"pull_stack_rebase_failed",
 }
 
 func (t CommentType) String() string {
@@ -279,7 +287,8 @@ type Comment struct {
 	DependentIssue   *Issue `xorm:"-"`
 
 	CommitID        int64
-	Line            int64 // - previous line / + proposed line
+	Line            int64 // - previous line / + proposed line, 0 for a code comment on the whole file
+	StartLine       int64 `xorm:"NOT NULL DEFAULT 0"` // first line of a multi-line code comment with the sign of Line, 0 for a single line
 	TreePath        string
 	Content         string        `xorm:"LONGTEXT"`
 	ContentVersion  int           `xorm:"NOT NULL DEFAULT 0"`
@@ -749,6 +758,27 @@ func (c *Comment) UnsignedLine() uint64 {
 	return uint64(c.Line)
 }
 
+// IsFileComment returns true if the code comment is on the whole file instead of some lines
+func (c *Comment) IsFileComment() bool {
+	return c.Type == CommentTypeCode && c.Line == 0
+}
+
+// IsMultiLine returns true if the code comment spans a range of lines ending at Line
+func (c *Comment) IsMultiLine() bool {
+	return c.StartLine != 0 && c.StartLine != c.Line
+}
+
+// UnsignedStartLine returns the first LOC of the code comment without + or -
+func (c *Comment) UnsignedStartLine() uint64 {
+	if !c.IsMultiLine() {
+		return c.UnsignedLine()
+	}
+	if c.StartLine < 0 {
+		return uint64(c.StartLine * -1)
+	}
+	return uint64(c.StartLine)
+}
+
 // CodeCommentLink returns the url to a comment in code
 func (c *Comment) CodeCommentLink(ctx context.Context) string {
 	err := c.LoadIssue(ctx)
@@ -798,6 +828,7 @@ func CreateComment(ctx context.Context, opts *CreateCommentOptions) (_ *Comment,
 			CommitID:         opts.CommitID,
 			CommitSHA:        opts.CommitSHA,
 			Line:             opts.LineNum,
+			StartLine:        opts.StartLineNum,
 			Content:          opts.Content,
 			OldTitle:         opts.OldTitle,
 			NewTitle:         opts.NewTitle,
@@ -968,6 +999,7 @@ type CreateCommentOptions struct {
 	CommitSHA          string
 	Patch              string
 	LineNum            int64
+	StartLineNum       int64
 	TreePath           string
 	ReviewID           int64
 	Content            string
@@ -1002,6 +1034,7 @@ type FindCommentsOptions struct {
 	Since       int64
 	Before      int64
 	Line        int64
+	FileLevel   bool // only the code comments on a whole file, which have no line
 	TreePath    string
 	Type        CommentType
 	IssueIDs    []int64
@@ -1034,6 +1067,8 @@ func (opts FindCommentsOptions) ToConds() builder.Cond {
 	}
 	if opts.Line != 0 {
 		cond = cond.And(builder.Eq{"comment.line": opts.Line})
+	} else if opts.FileLevel {
+		cond = cond.And(builder.Eq{"comment.line": 0})
 	}
 	if len(opts.TreePath) > 0 {
 		cond = cond.And(builder.Eq{"comment.tree_path": opts.TreePath})
@@ -1171,6 +1206,52 @@ func CreateAutoMergeComment(ctx context.Context, typ CommentType, pr *PullReques
 	return comment, err
 }
 
+// CreateMergeQueueComment is a internal function, only use it for CommentTypePRAddedToMergeQueue and CommentTypePRRemovedFromMergeQueue CommentTypes.
+// The reason is why the pull request was removed from the merge queue.
+func CreateMergeQueueComment(ctx context.Context, typ CommentType, pr *PullRequest, doer *user_model.User, reason string) (comment *Comment, err error) {
+	if typ != CommentTypePRAddedToMergeQueue && typ != CommentTypePRRemovedFromMergeQueue {
+		return nil, fmt.Errorf("comment type %d cannot be used to create a merge queue comment", typ)
+	}
+	if err = pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	comment, err = CreateComment(ctx, &CreateCommentOptions{
+		Type:    typ,
+		Doer:    doer,
+		Repo:    pr.BaseRepo,
+		Issue:   pr.Issue,
+		Content: reason,
+	})
+	return comment, err
+}
+
+// CreateStackRebaseFailedComment creates a comment telling that the pull request could not be rebased
+// after the pull request it is stacked on was merged. The index of the merged pull request is kept as the content.
+func CreateStackRebaseFailedComment(ctx context.Context, pr *PullRequest, doer *user_model.User, parent *PullRequest) (*Comment, error) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	if err := parent.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+
+	return CreateComment(ctx, &CreateCommentOptions{
+		Type:    CommentTypePullStackRebaseFailed,
+		Doer:    doer,
+		Repo:    pr.BaseRepo,
+		Issue:   pr.Issue,
+		Content: strconv.FormatInt(parent.Issue.Index, 10),
+	})
+}
+
 // RemapExternalUser ExternalUserRemappable interface
 func (c *Comment) RemapExternalUser(externalName string, externalID, userID int64) error {
 	c.OriginalAuthor = externalName
@@ -118,20 +118,21 @@ func findCodeComments(ctx context.Context, opts FindCommentsOptions, issue *Issu
 		rctx := renderhelper.NewRenderContextRepoComment(ctx, issue.Repo, renderhelper.RepoCommentOptions{
 			FootnoteContextID: strconv.FormatInt(comment.ID, 10),
 		})
-		if comment.RenderedContent, err = markdown.RenderString(rctx, comment.Content); err != nil {
+		if comment.RenderedContent, err = markdown.RenderString(rctx, comment.ContentWithSuggestionDiffs()); err != nil {
 			return nil, err
 		}
 	}
 	return comments[:n], nil
 }
 
-// FetchCodeCommentsByLine fetches the code comments for a given treePath and line number
+// FetchCodeCommentsByLine fetches the code comments for a given treePath and line number, line 0 fetches the comments on the whole file
 func FetchCodeCommentsByLine(ctx context.Context, issue *Issue, currentUser *user_model.User, treePath string, line int64, showOutdatedComments bool) (CommentList, error) {
 	opts := FindCommentsOptions{
-		Type:     CommentTypeCode,
-		IssueID:  issue.ID,
-		TreePath: treePath,
-		Line:     line,
+		Type:      CommentTypeCode,
+		IssueID:   issue.ID,
+		TreePath:  treePath,
+		Line:      line,
+		FileLevel: line == 0,
 	}
 	return findCodeComments(ctx, opts, issue, currentUser, nil, showOutdatedComments)
 }
@@ -0,0 +1,142 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"strings"
+)
+
+// suggestionInfoString is the info string of a fenced code block which proposes a replacement for the commented lines
+const suggestionInfoString = "suggestion"
+
+// Suggestion is a replacement for the commented lines, proposed in a ```suggestion block of a code comment
+type Suggestion struct {
+	// Lines replace the commented lines, no lines means the commented lines are deleted
+	Lines []string
+
+	fence      string
+	start, end int // line indexes of the opening and closing fence in the comment content
+}
+
+// parseFence returns the fence and the info string if the line opens or closes a fenced code block
+func parseFence(line string) (fence, info string) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 || (trimmed[0] != '`' && trimmed[0] != '~') {
+		return "", ""
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == trimmed[0] {
+		n++
+	}
+	if n < 3 {
+		return "", ""
+	}
+	return trimmed[:n], strings.TrimSpace(trimmed[n:])
+}
+
+// ParseSuggestions returns the suggestion blocks of the content of a code comment
+func ParseSuggestions(content string) []*Suggestion {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	var suggestions []*Suggestion
+	for i := 0; i < len(lines); i++ {
+		fence, info := parseFence(lines[i])
+		if fence == "" {
+			continue
+		}
+		s := &Suggestion{fence: fence, start: i, end: len(lines)}
+		for j := i + 1; j < len(lines); j++ {
+			closing, closingInfo := parseFence(lines[j])
+			if closing != "" && closing[0] == fence[0] && len(closing) >= len(fence) && closingInfo == "" {
+				s.end = j
+				break
+			}
+		}
+		if fields := strings.Fields(info); len(fields) > 0 && fields[0] == suggestionInfoString {
+			s.Lines = append([]string{}, lines[i+1:s.end]...)
+			suggestions = append(suggestions, s)
+		}
+		i = s.end // skip the content of all code blocks, a nested fence is not a suggestion
+	}
+	return suggestions
+}
+
+// Suggestions returns the suggested changes of a code comment on the proposed changes
+func (c *Comment) Suggestions() []*Suggestion {
+	if c.Type != CommentTypeCode || c.Line <= 0 {
+		return nil
+	}
+	return ParseSuggestions(c.Content)
+}
+
+// SuggestionLineRange returns the first and last line (1-based, inclusive) which a suggestion of the comment replaces
+func (c *Comment) SuggestionLineRange() (start, end int64) {
+	if c.IsMultiLine() {
+		return c.StartLine, c.Line
+	}
+	return c.Line, c.Line
+}
+
+// patchCommentedLines returns the commented lines taken from the end of the diff hunk which is stored with the comment,
+// ok is false if the hunk doesn't contain them
+func (c *Comment) patchCommentedLines() (lines []string, ok bool) {
+	start, end := c.SuggestionLineRange()
+	count := int(end - start + 1)
+	patch := strings.TrimRight(strings.ReplaceAll(c.Patch, "\r\n", "\n"), "\n")
+	if patch == "" {
+		return nil, false
+	}
+	patchLines := strings.Split(patch, "\n")
+	// walk backwards over the lines of the proposed side, the hunk ends at the (last) commented line
+	for i := len(patchLines) - 1; i >= 0 && len(lines) < count; i-- {
+		line := patchLines[i]
+		if line == "" || strings.HasPrefix(line, "@@") {
+			return nil, false
+		}
+		switch line[0] {
+		case '+', ' ':
+			lines = append([]string{line[1:]}, lines...)
+		case '-', '\\':
+		default:
+			return nil, false
+		}
+	}
+	return lines, len(lines) == count
+}
+
+// ContentWithSuggestionDiffs rewrites the suggestion blocks of the comment into diff blocks against the commented lines,
+// so that they are rendered as the change they propose
+func (c *Comment) ContentWithSuggestionDiffs() string {
+	suggestions := c.Suggestions()
+	if len(suggestions) == 0 {
+		return c.Content
+	}
+	commented, ok := c.patchCommentedLines()
+	if !ok {
+		return c.Content
+	}
+
+	lines := strings.Split(strings.ReplaceAll(c.Content, "\r\n", "\n"), "\n")
+	var sb strings.Builder
+	last := 0
+	for _, s := range suggestions {
+		sb.WriteString(strings.Join(lines[last:s.start], "\n"))
+		if s.start > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(s.fence + "diff\n")
+		for _, line := range commented {
+			sb.WriteString("-" + line + "\n")
+		}
+		for _, line := range s.Lines {
+			sb.WriteString("+" + line + "\n")
+		}
+		sb.WriteString(s.fence)
+		last = min(s.end+1, len(lines))
+		if last < len(lines) {
+			sb.WriteByte('\n')
+		}
+	}
+	sb.WriteString(strings.Join(lines[last:], "\n"))
+	return sb.String()
+}
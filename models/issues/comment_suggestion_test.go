@@ -0,0 +1,74 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSuggestions(t *testing.T) {
+	suggestions := issues_model.ParseSuggestions("Please rename it\n```suggestion\nfoo := bar()\n\treturn foo\n```\n\n~~~go\n```suggestion\nnot a suggestion\n```\n~~~\n````suggestion\n````")
+	if assert.Len(t, suggestions, 2) {
+		assert.Equal(t, []string{"foo := bar()", "\treturn foo"}, suggestions[0].Lines)
+		assert.Empty(t, suggestions[1].Lines, "an empty suggestion deletes the lines")
+	}
+
+	assert.Empty(t, issues_model.ParseSuggestions("```go\nfoo\n```"))
+	assert.Empty(t, issues_model.ParseSuggestions("    ```suggestion\n    foo\n    ```"), "indented code is not a fence")
+
+	unclosed := issues_model.ParseSuggestions("```suggestion\r\nfoo\r\n")
+	if assert.Len(t, unclosed, 1) {
+		assert.Equal(t, []string{"foo", ""}, unclosed[0].Lines)
+	}
+}
+
+func TestCommentContentWithSuggestionDiffs(t *testing.T) {
+	c := &issues_model.Comment{
+		Type:    issues_model.CommentTypeCode,
+		Line:    3,
+		Content: "Use a constant\n```suggestion\nconst a = 1\n```\nthanks",
+		Patch: `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,2 +1,3 @@
+ package a
+-var b = 1
++
++var a = 1`,
+	}
+	assert.Equal(t, "Use a constant\n```diff\n-var a = 1\n+const a = 1\n```\nthanks", c.ContentWithSuggestionDiffs())
+
+	c.StartLine = 2
+	assert.Equal(t, "Use a constant\n```diff\n-\n-var a = 1\n+const a = 1\n```\nthanks", c.ContentWithSuggestionDiffs(), "a multi-line suggestion replaces the whole range")
+	c.StartLine = 0
+
+	c.Line = -2
+	assert.Equal(t, c.Content, c.ContentWithSuggestionDiffs(), "suggestions on the previous side are not supported")
+
+	c.Line, c.Patch = 3, ""
+	assert.Equal(t, c.Content, c.ContentWithSuggestionDiffs(), "without a hunk the suggestion is rendered as is")
+}
+
+func TestCommentLineRange(t *testing.T) {
+	c := &issues_model.Comment{Type: issues_model.CommentTypeCode, Line: -58, StartLine: -40}
+	assert.True(t, c.IsMultiLine())
+	assert.False(t, c.IsFileComment())
+	assert.EqualValues(t, 40, c.UnsignedStartLine())
+	assert.EqualValues(t, 58, c.UnsignedLine())
+	start, end := c.SuggestionLineRange()
+	assert.EqualValues(t, -40, start)
+	assert.EqualValues(t, -58, end)
+
+	c.StartLine = 0
+	assert.False(t, c.IsMultiLine())
+	assert.EqualValues(t, 58, c.UnsignedStartLine())
+
+	c.Line = 0
+	assert.True(t, c.IsFileComment())
+	assert.Empty(t, c.Suggestions())
+}
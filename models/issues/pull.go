@@ -142,6 +142,8 @@ type PullRequest struct {
 	BaseBranch          string
 	MergeBase           string `xorm:"VARCHAR(64)"`
 	AllowMaintainerEdit bool   `xorm:"NOT NULL DEFAULT false"`
+	// StackParentID is the pull request this one is stacked on, its base branch is the head branch of the parent
+	StackParentID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
 
 	HasMerged      bool               `xorm:"INDEX"`
 	MergedCommitID string             `xorm:"VARCHAR(64)"`
@@ -168,6 +170,12 @@ func DeletePullsByBaseRepoID(ctx context.Context, repoID int64) error {
 		return err
 	}
 
+	// Delete merge queue entries
+	if _, err := db.GetEngine(ctx).In("pull_id", deleteCond).
+		Delete(&pull_model.MergeQueueEntry{}); err != nil {
+		return err
+	}
+
 	// Delete review states
 	if _, err := db.GetEngine(ctx).In("pull_id", deleteCond).
 		Delete(&pull_model.ReviewState{}); err != nil {
@@ -763,16 +771,22 @@ func HasEnoughApprovals(ctx context.Context, protectBranch *git_model.ProtectedB
 	return GetGrantedApprovalsCount(ctx, protectBranch, pr) >= protectBranch.RequiredApprovals
 }
 
-// GetGrantedApprovalsCount returns the number of granted approvals for pr. A granted approval must be authored by a user in an approval whitelist.
-func GetGrantedApprovalsCount(ctx context.Context, protectBranch *git_model.ProtectedBranch, pr *PullRequest) int64 {
-	sess := db.GetEngine(ctx).Where("issue_id = ?", pr.IssueID).
-		And("type = ?", ReviewTypeApprove).
-		And("official = ?", true).
-		And("dismissed = ?", false)
+func grantedApprovalsCond(protectBranch *git_model.ProtectedBranch, pr *PullRequest) builder.Cond {
+	cond := builder.Eq{
+		"issue_id":  pr.IssueID,
+		"type":      ReviewTypeApprove,
+		"official":  true,
+		"dismissed": false,
+	}
 	if protectBranch.IgnoreStaleApprovals {
-		sess = sess.And("stale = ?", false)
+		cond["stale"] = false
 	}
-	approvals, err := sess.Count(new(Review))
+	return cond
+}
+
+// GetGrantedApprovalsCount returns the number of granted approvals for pr. A granted approval must be authored by a user in an approval whitelist.
+func GetGrantedApprovalsCount(ctx context.Context, protectBranch *git_model.ProtectedBranch, pr *PullRequest) int64 {
+	approvals, err := db.GetEngine(ctx).Where(grantedApprovalsCond(protectBranch, pr)).Count(new(Review))
 	if err != nil {
 		log.Error("GetGrantedApprovalsCount: %v", err)
 		return 0
@@ -781,6 +795,13 @@ func GetGrantedApprovalsCount(ctx context.Context, protectBranch *git_model.Prot
 	return approvals
 }
 
+// GetGrantedApprovalReviewerIDs returns the IDs of the users who granted approvals for pr
+func GetGrantedApprovalReviewerIDs(ctx context.Context, protectBranch *git_model.ProtectedBranch, pr *PullRequest) ([]int64, error) {
+	reviewerIDs := make([]int64, 0, 5)
+	return reviewerIDs, db.GetEngine(ctx).Table("review").Where(grantedApprovalsCond(protectBranch, pr)).
+		Distinct("reviewer_id").Find(&reviewerIDs)
+}
+
 // MergeBlockedByRejectedReview returns true if merge is blocked by rejected reviews
 func MergeBlockedByRejectedReview(ctx context.Context, protectBranch *git_model.ProtectedBranch, pr *PullRequest) bool {
 	if !protectBranch.BlockOnRejectedReviews {
@@ -0,0 +1,67 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+)
+
+// maxPullRequestStackDepth limits how far a stack is walked, so a broken chain can't loop forever
+const maxPullRequestStackDepth = 50
+
+// GetStackChildren returns the pull requests stacked directly on the pull request
+func GetStackChildren(ctx context.Context, prID int64) (PullRequestList, error) {
+	prs := make([]*PullRequest, 0, 2)
+	return prs, db.GetEngine(ctx).Where("stack_parent_id = ?", prID).Asc("id").Find(&prs)
+}
+
+// GetPullRequestStack returns the chain of stacked pull requests the pull request belongs to, from the bottom to the top.
+// When a pull request has several children, the chain follows the first one.
+// The list contains only the pull request itself if it is not stacked.
+func GetPullRequestStack(ctx context.Context, pr *PullRequest) (PullRequestList, error) {
+	visited := map[int64]bool{pr.ID: true}
+
+	var parents PullRequestList
+	for parentID := pr.StackParentID; parentID > 0 && !visited[parentID] && len(parents) < maxPullRequestStackDepth; {
+		parent, err := GetPullRequestByID(ctx, parentID)
+		if IsErrPullRequestNotExist(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		visited[parent.ID] = true
+		parents = append(parents, parent)
+		parentID = parent.StackParentID
+	}
+
+	stack := make(PullRequestList, 0, len(parents)+2)
+	for i := len(parents) - 1; i >= 0; i-- {
+		stack = append(stack, parents[i])
+	}
+	stack = append(stack, pr)
+
+	for cur := pr; len(stack) < 2*maxPullRequestStackDepth; {
+		children, err := GetStackChildren(ctx, cur.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(children) == 0 || visited[children[0].ID] {
+			break
+		}
+		cur = children[0]
+		visited[cur.ID] = true
+		stack = append(stack, cur)
+	}
+
+	return stack, nil
+}
+
+// UpdateStackParent saves the pull request the pull request is stacked on
+func (pr *PullRequest) UpdateStackParent(ctx context.Context, parentID int64) error {
+	pr.StackParentID = parentID
+	_, err := db.GetEngine(ctx).ID(pr.ID).Cols("stack_parent_id").Update(pr)
+	return err
+}
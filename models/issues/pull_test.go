@@ -389,3 +389,37 @@ func TestMigrate_InsertPullRequests(t *testing.T) {
 
 	unittest.CheckConsistencyFor(t, &issues_model.Issue{}, &issues_model.PullRequest{})
 }
+
+func TestGetPullRequestStack(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	pr1 := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 1})
+	pr2 := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	pr3 := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 3})
+
+	stack, err := issues_model.GetPullRequestStack(db.DefaultContext, pr1)
+	assert.NoError(t, err)
+	assert.Len(t, stack, 1)
+
+	assert.NoError(t, pr2.UpdateStackParent(db.DefaultContext, pr1.ID))
+	assert.NoError(t, pr3.UpdateStackParent(db.DefaultContext, pr2.ID))
+
+	for _, pr := range []*issues_model.PullRequest{pr1, pr2, pr3} {
+		stack, err = issues_model.GetPullRequestStack(db.DefaultContext, pr)
+		assert.NoError(t, err)
+		if assert.Len(t, stack, 3) {
+			assert.EqualValues(t, []int64{1, 2, 3}, []int64{stack[0].ID, stack[1].ID, stack[2].ID})
+		}
+	}
+
+	children, err := issues_model.GetStackChildren(db.DefaultContext, pr1.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, children, 1) {
+		assert.EqualValues(t, 2, children[0].ID)
+	}
+
+	// a broken chain must not loop forever
+	assert.NoError(t, pr1.UpdateStackParent(db.DefaultContext, pr3.ID))
+	stack, err = issues_model.GetPullRequestStack(db.DefaultContext, pr2)
+	assert.NoError(t, err)
+	assert.Len(t, stack, 3)
+}
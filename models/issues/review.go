@@ -215,6 +215,11 @@ func (r *Review) LoadAttributes(ctx context.Context) (err error) {
 	return err
 }
 
+// IsPending returns true if the review has not been submitted yet
+func (r *Review) IsPending() bool {
+	return r.Type == ReviewTypePending
+}
+
 // HTMLTypeColorName returns the color used in the ui indicating the review
 func (r *Review) HTMLTypeColorName() string {
 	switch r.Type {
@@ -404,7 +409,13 @@ func GetCurrentReview(ctx context.Context, reviewer *user_model.User, issue *Iss
 
 // ReviewExists returns whether a review exists for a particular line of code in the PR
 func ReviewExists(ctx context.Context, issue *Issue, treePath string, line int64) (bool, error) {
-	return db.GetEngine(ctx).Cols("id").Exist(&Comment{IssueID: issue.ID, TreePath: treePath, Line: line, Type: CommentTypeCode})
+	// line 0 is a comment on the whole file, so the condition can't be built from a bean which would ignore it
+	return db.GetEngine(ctx).Table("comment").Where(builder.Eq{
+		"issue_id":  issue.ID,
+		"tree_path": treePath,
+		"line":      line,
+		"type":      CommentTypeCode,
+	}).Exist()
 }
 
 // ContentEmptyErr represents an content empty error
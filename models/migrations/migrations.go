@@ -389,6 +389,23 @@ func prepareMigrationTasks() []*migration {
 		
 		// GitVault 1.25.0+ - PhantomKit integration
 		newMigration(322, "Create phantomkit_keys table for API key management", v1_25.CreatePhantomKitKeysTable),
+		newMigration(323, "Add ActionApprovalPolicy table and approval columns to ActionRun", v1_25.AddActionApprovalPolicy),
+		newMigration(324, "Add ActionRunnerGroup table and GroupID to ActionRunner", v1_25.AddActionRunnerGroup),
+		newMigration(325, "Add ActionTaskSummary and ActionTaskAnnotation tables", v1_25.AddActionTaskSummaryAndAnnotation),
+		newMigration(326, "Add merge queue", v1_25.AddMergeQueue),
+		newMigration(327, "Add require_code_owner_reviews to protected_branch", v1_25.AddRequireCodeOwnerReviewsToProtectedBranch),
+		newMigration(328, "Add stack_parent_id to pull_request", v1_25.AddStackParentIDToPullRequest),
+		newMigration(329, "Add ruleset table", v1_25.AddRuleset),
+		newMigration(330, "Add push rule table", v1_25.AddPushRule),
+		newMigration(331, "Add secret scanning tables", v1_25.AddSecretScanning),
+		newMigration(332, "Add trusted X.509 CA bundles for signature verification", v1_25.AddX509TrustedCA),
+		newMigration(333, "Add start_line to comment for multi-line code comments", v1_25.AddStartLineToComment),
+		newMigration(334, "Add package_remote table for pull-through package registries", v1_25.AddPackageRemoteTable),
+		newMigration(335, "Add package_virtual table for virtual package repositories", v1_25.AddPackageVirtualTable),
+		newMigration(336, "Add terraform_state and terraform_state_version tables", v1_25.AddTerraformStateTables),
+		newMigration(337, "Add package vulnerability tables", v1_25.AddPackageVulnerabilityTables),
+		newMigration(338, "Add package download statistics", v1_25.AddPackageDownloadStatistics),
+		newMigration(339, "Add group_commit_id to pull_merge_queue", v1_25.AddGroupCommitIDToMergeQueue),
 	}
 	return preparedMigrations
 }
@@ -0,0 +1,39 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionApprovalPolicy(x *xorm.Engine) error {
+	type ActionApprovalPolicy struct {
+		ID                int64 `xorm:"pk autoincr"`
+		OwnerID           int64 `xorm:"UNIQUE(owner_repo)"`
+		RepoID            int64 `xorm:"INDEX UNIQUE(owner_repo)"`
+		Mode              int
+		RequireForSecrets bool     `xorm:"NOT NULL DEFAULT false"`
+		Environments      []string `xorm:"JSON TEXT"`
+		ApproverTeamID    int64
+		CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix       timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionRun struct {
+		ApprovalPolicyID int64
+		ApprovalMode     int
+		ApproverTeamID   int64
+	}
+
+	if err := x.Sync(new(ActionApprovalPolicy)); err != nil {
+		return err
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains: true,
+		IgnoreIndices:    true,
+	}, new(ActionRun))
+	return err
+}
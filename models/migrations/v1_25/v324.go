@@ -0,0 +1,37 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionRunnerGroup(x *xorm.Engine) error {
+	type ActionRunnerGroup struct {
+		ID            int64
+		OwnerID       int64    `xorm:"UNIQUE(owner_name)"`
+		Name          string   `xorm:"UNIQUE(owner_name) NOT NULL"`
+		Description   string   `xorm:"TEXT"`
+		AllowAllRepos bool     `xorm:"NOT NULL DEFAULT false"`
+		RepoIDs       []int64  `xorm:"JSON TEXT"`
+		Workflows     []string `xorm:"JSON TEXT"`
+
+		Created timeutil.TimeStamp `xorm:"created"`
+		Updated timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionRunner struct {
+		GroupID int64 `xorm:"index"`
+	}
+
+	if err := x.Sync(new(ActionRunnerGroup)); err != nil {
+		return err
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains: true,
+	}, new(ActionRunner))
+	return err
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionTaskSummaryAndAnnotation(x *xorm.Engine) error {
+	type ActionTaskSummary struct {
+		ID      int64
+		TaskID  int64              `xorm:"INDEX UNIQUE(task_id_step)"`
+		Step    int64              `xorm:"UNIQUE(task_id_step)"`
+		RepoID  int64              `xorm:"index"`
+		Content string             `xorm:"LONGTEXT"`
+		Updated timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionTaskAnnotation struct {
+		ID        int64
+		TaskID    int64  `xorm:"index"`
+		RepoID    int64  `xorm:"index(repo_commit)"`
+		CommitSHA string `xorm:"index(repo_commit)"`
+		LogIndex  int64
+		Level     int
+		Path      string `xorm:"VARCHAR(500)"`
+		Line      int
+		EndLine   int
+		Title     string
+		Message   string             `xorm:"TEXT"`
+		Created   timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(ActionTaskSummary), new(ActionTaskAnnotation))
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type addMergeQueueEntry struct {
+	ID                     int64  `xorm:"pk autoincr"`
+	RepoID                 int64  `xorm:"INDEX(repo_branch) NOT NULL"`
+	BaseBranch             string `xorm:"INDEX(repo_branch) NOT NULL"`
+	PullID                 int64  `xorm:"UNIQUE"`
+	DoerID                 int64  `xorm:"INDEX NOT NULL"`
+	MergeStyle             string `xorm:"varchar(30)"`
+	Message                string `xorm:"LONGTEXT"`
+	DeleteBranchAfterMerge bool
+	HeadCommitID           string             `xorm:"VARCHAR(64)"`
+	Status                 int                `xorm:"NOT NULL DEFAULT 0"`
+	GroupBaseCommitID      string             `xorm:"VARCHAR(64)"`
+	GroupHeadCommitID      string             `xorm:"VARCHAR(64) INDEX"`
+	CreatedUnix            timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName sets the name of this table
+func (*addMergeQueueEntry) TableName() string {
+	return "pull_merge_queue"
+}
+
+func AddMergeQueue(x *xorm.Engine) error {
+	type ProtectedBranch struct {
+		EnableMergeQueue    bool  `xorm:"NOT NULL DEFAULT false"`
+		MergeQueueBatchSize int64 `xorm:"NOT NULL DEFAULT 5"`
+	}
+
+	if err := x.Sync(new(ProtectedBranch)); err != nil {
+		return err
+	}
+	return x.Sync(new(addMergeQueueEntry))
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddRuleset(x *xorm.Engine) error {
+	type Ruleset struct {
+		ID          int64
+		OwnerID     int64  `xorm:"UNIQUE(owner_name)"`
+		Name        string `xorm:"UNIQUE(owner_name) NOT NULL"`
+		Enforcement string `xorm:"VARCHAR(20) NOT NULL DEFAULT 'disabled'"`
+
+		RepoNamePatterns []string `xorm:"JSON TEXT"`
+		RepoTopics       []string `xorm:"JSON TEXT"`
+		RefPatterns      []string `xorm:"JSON TEXT"`
+
+		BlockCreation           bool     `xorm:"NOT NULL DEFAULT false"`
+		BlockDeletion           bool     `xorm:"NOT NULL DEFAULT false"`
+		BlockForcePush          bool     `xorm:"NOT NULL DEFAULT false"`
+		RequirePullRequest      bool     `xorm:"NOT NULL DEFAULT false"`
+		RequiredApprovals       int64    `xorm:"NOT NULL DEFAULT 0"`
+		BlockOnRejectedReviews  bool     `xorm:"NOT NULL DEFAULT false"`
+		BlockOnOutdatedBranch   bool     `xorm:"NOT NULL DEFAULT false"`
+		RequireCodeOwnerReviews bool     `xorm:"NOT NULL DEFAULT false"`
+		RequireSignedCommits    bool     `xorm:"NOT NULL DEFAULT false"`
+		StatusCheckContexts     []string `xorm:"JSON TEXT"`
+
+		BypassUserIDs    []int64 `xorm:"JSON TEXT"`
+		BypassTeamIDs    []int64 `xorm:"JSON TEXT"`
+		BypassRepoAdmins bool    `xorm:"NOT NULL DEFAULT false"`
+
+		Created timeutil.TimeStamp `xorm:"created"`
+		Updated timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(Ruleset))
+}
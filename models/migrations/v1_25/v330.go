@@ -0,0 +1,26 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPushRule(x *xorm.Engine) error {
+	type PushRule struct {
+		ID                         int64              `xorm:"pk autoincr"`
+		OwnerID                    int64              `xorm:"UNIQUE(owner_repo)"`
+		RepoID                     int64              `xorm:"INDEX UNIQUE(owner_repo)"`
+		MaxFileSize                int64              `xorm:"NOT NULL DEFAULT 0"`
+		ForbiddenPathPatterns      []string           `xorm:"JSON TEXT"`
+		CommitMessagePattern       string             `xorm:"TEXT"`
+		RequireVerifiedAuthorEmail bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix                timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix                timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(PushRule))
+}
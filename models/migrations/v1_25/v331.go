@@ -0,0 +1,45 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddSecretScanning(x *xorm.Engine) error {
+	type SecretScanPattern struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"UNIQUE(owner_name)"`
+		Name        string             `xorm:"UNIQUE(owner_name) NOT NULL"`
+		Pattern     string             `xorm:"TEXT NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type SecretScanAlert struct {
+		ID                int64  `xorm:"pk autoincr"`
+		RepoID            int64  `xorm:"UNIQUE(repo_secret)"`
+		SecretHash        string `xorm:"VARCHAR(64) UNIQUE(repo_secret) NOT NULL"`
+		PatternID         string `xorm:"VARCHAR(255)"`
+		PatternName       string
+		RedactedSecret    string
+		RefName           string `xorm:"TEXT"`
+		CommitID          string `xorm:"VARCHAR(64)"`
+		Path              string `xorm:"TEXT"`
+		Line              int
+		PusherID          int64
+		BypassReason      string `xorm:"TEXT"`
+		State             string `xorm:"VARCHAR(20) INDEX NOT NULL DEFAULT 'open'"`
+		Resolution        string `xorm:"VARCHAR(20)"`
+		ResolutionComment string `xorm:"TEXT"`
+		ResolvedByID      int64
+		ResolvedUnix      timeutil.TimeStamp
+		CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix       timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(SecretScanPattern), new(SecretScanAlert))
+}
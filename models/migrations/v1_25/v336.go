@@ -0,0 +1,37 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddTerraformStateTables(x *xorm.Engine) error {
+	type TerraformState struct {
+		ID            int64  `xorm:"pk autoincr"`
+		RepoID        int64  `xorm:"UNIQUE(s) NOT NULL"`
+		Name          string `xorm:"UNIQUE(s) NOT NULL"`
+		LatestVersion int64  `xorm:"NOT NULL DEFAULT 0"`
+		LockID        string
+		LockInfo      string `xorm:"TEXT"`
+		LockedUnix    timeutil.TimeStamp
+		CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type TerraformStateVersion struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		StateID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Version     int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+		HashSHA256  string             `xorm:"hash_sha256 char(64)"`
+		CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(TerraformState), new(TerraformStateVersion))
+}
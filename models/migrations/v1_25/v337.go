@@ -0,0 +1,42 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageVulnerabilityTables(x *xorm.Engine) error {
+	type PackageVulnerability struct {
+		ID            int64  `xorm:"pk autoincr"`
+		AdvisoryID    string `xorm:"UNIQUE NOT NULL"`
+		Summary       string `xorm:"TEXT"`
+		Details       string `xorm:"LONGTEXT"`
+		Aliases       string `xorm:"TEXT"`
+		Severity      string
+		PublishedUnix timeutil.TimeStamp
+		ModifiedUnix  timeutil.TimeStamp
+	}
+
+	type PackageVulnerabilityAffected struct {
+		ID              int64  `xorm:"pk autoincr"`
+		VulnerabilityID int64  `xorm:"INDEX NOT NULL"`
+		Ecosystem       string `xorm:"INDEX(s) NOT NULL"`
+		LowerName       string `xorm:"INDEX(s) NOT NULL"`
+		Affected        string `xorm:"LONGTEXT"`
+	}
+
+	type PackageVulnerabilityFinding struct {
+		ID                int64  `xorm:"pk autoincr"`
+		VersionID         int64  `xorm:"INDEX NOT NULL"`
+		VulnerabilityID   int64  `xorm:"INDEX NOT NULL"`
+		DependencyName    string `xorm:"NOT NULL"`
+		DependencyVersion string
+		CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageVulnerability), new(PackageVulnerabilityAffected), new(PackageVulnerabilityFinding))
+}
@@ -0,0 +1,29 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageDownloadStatistics(x *xorm.Engine) error {
+	type PackageVersion struct {
+		LastDownloadUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type PackageVersionDownload struct {
+		ID            int64              `xorm:"pk autoincr"`
+		VersionID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		DayUnix       timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		DownloadCount int64              `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type PackageCleanupRule struct {
+		RemoveNotDownloadedDays int `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageVersion), new(PackageVersionDownload), new(PackageCleanupRule))
+}
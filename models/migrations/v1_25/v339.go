@@ -0,0 +1,21 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"xorm.io/xorm"
+)
+
+type addGroupCommitIDToMergeQueueEntry struct {
+	GroupCommitID string `xorm:"VARCHAR(64)"`
+}
+
+// TableName sets the name of this table
+func (*addGroupCommitIDToMergeQueueEntry) TableName() string {
+	return "pull_merge_queue"
+}
+
+func AddGroupCommitIDToMergeQueue(x *xorm.Engine) error {
+	return x.Sync(new(addGroupCommitIDToMergeQueueEntry))
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/secret"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var ErrPackageRemoteNotExist = util.NewNotExistErrorf("package remote does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageRemote))
+}
+
+// RemoteTypeList are the package types which can be proxied from a remote registry
+var RemoteTypeList = []Type{
+	TypeContainer,
+	TypeGo,
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// IsRemoteSupported checks if packages of the type can be proxied from a remote registry
+func (pt Type) IsRemoteSupported() bool {
+	for _, t := range RemoteTypeList {
+		if t == pt {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRemoteMetadataTTL is the default duration metadata fetched from a remote registry is cached
+const DefaultRemoteMetadataTTL = 30 * time.Minute
+
+// PackageRemote represents an upstream registry which is used if a package is not available locally
+type PackageRemote struct {
+	ID                int64              `xorm:"pk autoincr"`
+	Enabled           bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID           int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type              Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	URL               string             `xorm:"TEXT NOT NULL"`
+	Username          string             `xorm:"NOT NULL DEFAULT ''"`
+	PasswordEncrypted string             `xorm:"TEXT"`
+	MetadataTTL       int64              `xorm:"NOT NULL DEFAULT 0"` // seconds
+	CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix       timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// Password returns the decrypted password used to authenticate at the remote registry
+func (pr *PackageRemote) Password() (string, error) {
+	if pr.PasswordEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, pr.PasswordEncrypted)
+}
+
+// SetPassword encrypts and sets the password used to authenticate at the remote registry
+func (pr *PackageRemote) SetPassword(password string) error {
+	if password == "" {
+		pr.PasswordEncrypted = ""
+		return nil
+	}
+	encrypted, err := secret.EncryptSecret(setting.SecretKey, password)
+	if err != nil {
+		return err
+	}
+	pr.PasswordEncrypted = encrypted
+	return nil
+}
+
+// MetadataTTLDuration returns how long metadata fetched from the remote registry may be cached
+func (pr *PackageRemote) MetadataTTLDuration() time.Duration {
+	if pr.MetadataTTL <= 0 {
+		return DefaultRemoteMetadataTTL
+	}
+	return time.Duration(pr.MetadataTTL) * time.Second
+}
+
+func InsertRemote(ctx context.Context, pr *PackageRemote) (*PackageRemote, error) {
+	return pr, db.Insert(ctx, pr)
+}
+
+func GetRemoteByID(ctx context.Context, id int64) (*PackageRemote, error) {
+	pr := &PackageRemote{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(pr)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return pr, nil
+}
+
+// GetEnabledRemoteByOwnerAndType gets the enabled remote of the owner for the package type
+func GetEnabledRemoteByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) (*PackageRemote, error) {
+	pr := &PackageRemote{}
+
+	has, err := db.GetEngine(ctx).Where("owner_id = ? AND type = ? AND enabled = ?", ownerID, packageType, true).Get(pr)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return pr, nil
+}
+
+func UpdateRemote(ctx context.Context, pr *PackageRemote) error {
+	_, err := db.GetEngine(ctx).ID(pr.ID).AllCols().Update(pr)
+	return err
+}
+
+func GetRemotesByOwner(ctx context.Context, ownerID int64) ([]*PackageRemote, error) {
+	prs := make([]*PackageRemote, 0, 5)
+	return prs, db.GetEngine(ctx).Where("owner_id = ?", ownerID).Find(&prs)
+}
+
+func DeleteRemoteByID(ctx context.Context, remoteID int64) error {
+	_, err := db.GetEngine(ctx).ID(remoteID).Delete(&PackageRemote{})
+	return err
+}
+
+func HasOwnerRemoteForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Exist(&PackageRemote{})
+}
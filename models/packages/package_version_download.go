@@ -0,0 +1,76 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageVersionDownload))
+}
+
+// PackageVersionDownload represents the number of downloads of a package version on a day
+type PackageVersionDownload struct {
+	ID            int64              `xorm:"pk autoincr"`
+	VersionID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	DayUnix       timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"` // the start of the day in UTC
+	DownloadCount int64              `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// StartOfDay returns the start of the day in UTC which is used to aggregate the downloads
+func StartOfDay(t time.Time) timeutil.TimeStamp {
+	return timeutil.TimeStamp(t.UTC().Truncate(24 * time.Hour).Unix())
+}
+
+// incrementDailyDownloadCounter increments the download counter of the version for the day.
+// The row of the day is created on the first download.
+func incrementDailyDownloadCounter(ctx context.Context, versionID int64, day timeutil.TimeStamp) error {
+	increment := func() (bool, error) {
+		res, err := db.GetEngine(ctx).Exec("UPDATE `package_version_download` SET `download_count` = `download_count` + 1 WHERE `version_id` = ? AND `day_unix` = ?", versionID, day)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		return n > 0, err
+	}
+
+	if updated, err := increment(); err != nil || updated {
+		return err
+	}
+
+	_, err := db.GetEngine(ctx).Insert(&PackageVersionDownload{
+		VersionID:     versionID,
+		DayUnix:       day,
+		DownloadCount: 1,
+	})
+	if err != nil {
+		// another request may have inserted the row in the meantime
+		if updated, err2 := increment(); err2 == nil && updated {
+			return nil
+		}
+	}
+	return err
+}
+
+// GetDailyDownloadsByVersionID gets the daily downloads of the version since the day, ordered by day
+func GetDailyDownloadsByVersionID(ctx context.Context, versionID int64, since timeutil.TimeStamp) ([]*PackageVersionDownload, error) {
+	downloads := make([]*PackageVersionDownload, 0, 30)
+	return downloads, db.GetEngine(ctx).
+		Where(builder.Eq{"version_id": versionID}.And(builder.Gte{"day_unix": since})).
+		OrderBy("day_unix ASC").
+		Find(&downloads)
+}
+
+// DeleteDailyDownloadsByVersionID deletes the download statistics of the version
+func DeleteDailyDownloadsByVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageVersionDownload{})
+	return err
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var ErrPackageVirtualNotExist = util.NewNotExistErrorf("package virtual repository does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageVirtual))
+}
+
+// VirtualTypeList are the package types which can be served by a virtual repository
+var VirtualTypeList = []Type{
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// IsVirtualSupported checks if packages of the type can be served by a virtual repository
+func (pt Type) IsVirtualSupported() bool {
+	for _, t := range VirtualTypeList {
+		if t == pt {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageVirtual represents a virtual repository which serves the packages of other owners at the registry of its owner.
+// The packages of the owner are resolved first, followed by the packages of the members in the listed order.
+type PackageVirtual struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Enabled     bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type        Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	MemberIDs   []int64            `xorm:"JSON TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+func InsertVirtual(ctx context.Context, pv *PackageVirtual) (*PackageVirtual, error) {
+	return pv, db.Insert(ctx, pv)
+}
+
+func GetVirtualByID(ctx context.Context, id int64) (*PackageVirtual, error) {
+	pv := &PackageVirtual{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(pv)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageVirtualNotExist
+	}
+	return pv, nil
+}
+
+// GetEnabledVirtualByOwnerAndType gets the enabled virtual repository of the owner for the package type
+func GetEnabledVirtualByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) (*PackageVirtual, error) {
+	pv := &PackageVirtual{}
+
+	has, err := db.GetEngine(ctx).Where("owner_id = ? AND type = ? AND enabled = ?", ownerID, packageType, true).Get(pv)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageVirtualNotExist
+	}
+	return pv, nil
+}
+
+func UpdateVirtual(ctx context.Context, pv *PackageVirtual) error {
+	_, err := db.GetEngine(ctx).ID(pv.ID).AllCols().Update(pv)
+	return err
+}
+
+func GetVirtualsByOwner(ctx context.Context, ownerID int64) ([]*PackageVirtual, error) {
+	pvs := make([]*PackageVirtual, 0, 5)
+	return pvs, db.GetEngine(ctx).Where("owner_id = ?", ownerID).Find(&pvs)
+}
+
+func DeleteVirtualByID(ctx context.Context, virtualID int64) error {
+	_, err := db.GetEngine(ctx).ID(virtualID).Delete(&PackageVirtual{})
+	return err
+}
+
+func HasOwnerVirtualForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Exist(&PackageVirtual{})
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/packages/osv"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrPackageVulnerabilityNotExist = util.NewNotExistErrorf("package vulnerability does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageVulnerability))
+	db.RegisterModel(new(PackageVulnerabilityAffected))
+	db.RegisterModel(new(PackageVulnerabilityFinding))
+}
+
+// PackageVulnerability represents a known vulnerability imported from an OSV database
+type PackageVulnerability struct {
+	ID            int64    `xorm:"pk autoincr"`
+	AdvisoryID    string   `xorm:"UNIQUE NOT NULL"` // the id of the OSV entry, for example "GHSA-xxxx-xxxx-xxxx"
+	Summary       string   `xorm:"TEXT"`
+	Details       string   `xorm:"LONGTEXT"`
+	Aliases       []string `xorm:"TEXT JSON"`
+	Severity      string
+	PublishedUnix timeutil.TimeStamp
+	ModifiedUnix  timeutil.TimeStamp
+}
+
+// PackageVulnerabilityAffected describes which versions of a package are affected by a vulnerability
+type PackageVulnerabilityAffected struct {
+	ID              int64         `xorm:"pk autoincr"`
+	VulnerabilityID int64         `xorm:"INDEX NOT NULL"`
+	Ecosystem       string        `xorm:"INDEX(s) NOT NULL"`
+	LowerName       string        `xorm:"INDEX(s) NOT NULL"` // the name normalized by osv.NormalizeName
+	Affected        *osv.Affected `xorm:"LONGTEXT JSON"`
+}
+
+// PackageVulnerabilityFinding is a dependency of a package version which is affected by a vulnerability
+type PackageVulnerabilityFinding struct {
+	ID                int64                 `xorm:"pk autoincr"`
+	VersionID         int64                 `xorm:"INDEX NOT NULL"`
+	VulnerabilityID   int64                 `xorm:"INDEX NOT NULL"`
+	DependencyName    string                `xorm:"NOT NULL"`
+	DependencyVersion string                // the version constraint of the dependency
+	Vulnerability     *PackageVulnerability `xorm:"-"`
+	CreatedUnix       timeutil.TimeStamp    `xorm:"created NOT NULL DEFAULT 0"`
+}
+
+// GetVulnerabilityByAdvisoryID gets the vulnerability with the OSV id
+func GetVulnerabilityByAdvisoryID(ctx context.Context, advisoryID string) (*PackageVulnerability, error) {
+	v := &PackageVulnerability{}
+	has, err := db.GetEngine(ctx).Where("advisory_id = ?", advisoryID).Get(v)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageVulnerabilityNotExist
+	}
+	return v, nil
+}
+
+// InsertOrUpdateVulnerability inserts the vulnerability or updates the existing one with the same OSV id.
+// The affected packages of the vulnerability are replaced.
+func InsertOrUpdateVulnerability(ctx context.Context, v *PackageVulnerability, affected []*PackageVulnerabilityAffected) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetVulnerabilityByAdvisoryID(ctx, v.AdvisoryID)
+		if err == nil {
+			v.ID = existing.ID
+			if _, err := db.GetEngine(ctx).ID(v.ID).AllCols().Update(v); err != nil {
+				return err
+			}
+		} else if err == ErrPackageVulnerabilityNotExist {
+			if err := db.Insert(ctx, v); err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+
+		if _, err := db.GetEngine(ctx).Where("vulnerability_id = ?", v.ID).Delete(&PackageVulnerabilityAffected{}); err != nil {
+			return err
+		}
+		for _, a := range affected {
+			a.ID = 0
+			a.VulnerabilityID = v.ID
+		}
+		if len(affected) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, affected)
+	})
+}
+
+// GetVulnerabilityIDs gets the ids of all vulnerabilities
+func GetVulnerabilityIDs(ctx context.Context) ([]int64, error) {
+	ids := make([]int64, 0, 100)
+	return ids, db.GetEngine(ctx).Table("package_vulnerability").Cols("id").Find(&ids)
+}
+
+// CountVulnerabilities counts all vulnerabilities
+func CountVulnerabilities(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Count(&PackageVulnerability{})
+}
+
+// DeleteVulnerabilityByID deletes the vulnerability with its affected packages and findings
+func DeleteVulnerabilityByID(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		e := db.GetEngine(ctx)
+		if _, err := e.Where("vulnerability_id = ?", id).Delete(&PackageVulnerabilityFinding{}); err != nil {
+			return err
+		}
+		if _, err := e.Where("vulnerability_id = ?", id).Delete(&PackageVulnerabilityAffected{}); err != nil {
+			return err
+		}
+		_, err := e.ID(id).Delete(&PackageVulnerability{})
+		return err
+	})
+}
+
+// GetVulnerabilityAffectedByName gets the affected version information of a package of the ecosystem
+func GetVulnerabilityAffectedByName(ctx context.Context, ecosystem, lowerName string) ([]*PackageVulnerabilityAffected, error) {
+	affected := make([]*PackageVulnerabilityAffected, 0, 5)
+	return affected, db.GetEngine(ctx).Where(builder.Eq{"ecosystem": ecosystem, "lower_name": lowerName}).Find(&affected)
+}
+
+// ReplaceVulnerabilityFindings replaces the findings of the package version
+func ReplaceVulnerabilityFindings(ctx context.Context, versionID int64, findings []*PackageVulnerabilityFinding) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := DeleteVulnerabilityFindingsByVersionID(ctx, versionID); err != nil {
+			return err
+		}
+		for _, f := range findings {
+			f.ID = 0
+			f.VersionID = versionID
+		}
+		if len(findings) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, findings)
+	})
+}
+
+// DeleteVulnerabilityFindingsByVersionID deletes all findings of the package version
+func DeleteVulnerabilityFindingsByVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageVulnerabilityFinding{})
+	return err
+}
+
+// PackageVulnerabilityFindingList is a list of findings
+type PackageVulnerabilityFindingList []*PackageVulnerabilityFinding
+
+// LoadVulnerabilities loads the vulnerabilities of the findings
+func (l PackageVulnerabilityFindingList) LoadVulnerabilities(ctx context.Context) error {
+	ids := container.FilterSlice(l, func(f *PackageVulnerabilityFinding) (int64, bool) {
+		return f.VulnerabilityID, f.Vulnerability == nil
+	})
+	if len(ids) == 0 {
+		return nil
+	}
+
+	vulnerabilities := make(map[int64]*PackageVulnerability, len(ids))
+	if err := db.GetEngine(ctx).In("id", ids).Find(&vulnerabilities); err != nil {
+		return err
+	}
+	for _, f := range l {
+		if f.Vulnerability == nil {
+			f.Vulnerability = vulnerabilities[f.VulnerabilityID]
+		}
+	}
+	return nil
+}
+
+// GetVulnerabilityFindingsByVersionID gets the findings of the package version with their vulnerabilities
+func GetVulnerabilityFindingsByVersionID(ctx context.Context, versionID int64) (PackageVulnerabilityFindingList, error) {
+	findings := make(PackageVulnerabilityFindingList, 0, 5)
+	if err := db.GetEngine(ctx).Where("version_id = ?", versionID).OrderBy("dependency_name ASC, id ASC").Find(&findings); err != nil {
+		return nil, err
+	}
+	return findings, findings.LoadVulnerabilities(ctx)
+}
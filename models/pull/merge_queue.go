@@ -0,0 +1,205 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// MergeQueueStatus represents the status of a pull request in the merge queue
+type MergeQueueStatus int
+
+const (
+	MergeQueueStatusQueued  MergeQueueStatus = iota // 0 waiting to be tested
+	MergeQueueStatusTesting                         // 1 being tested in a merge group
+)
+
+// MergeGroupRefPrefix is the prefix of the refs the combined commits of merge groups are pushed to
+const MergeGroupRefPrefix = "refs/merge-queue/"
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its base branch.
+// The pull requests at the head of the queue are rebased onto the base branch together as a merge group,
+// they are merged only when the checks of the combined commit succeed.
+type MergeQueueEntry struct {
+	ID                     int64                 `xorm:"pk autoincr"`
+	RepoID                 int64                 `xorm:"INDEX(repo_branch) NOT NULL"`
+	BaseBranch             string                `xorm:"INDEX(repo_branch) NOT NULL"`
+	PullID                 int64                 `xorm:"UNIQUE"`
+	DoerID                 int64                 `xorm:"INDEX NOT NULL"`
+	Doer                   *user_model.User      `xorm:"-"`
+	MergeStyle             repo_model.MergeStyle `xorm:"varchar(30)"`
+	Message                string                `xorm:"LONGTEXT"`
+	DeleteBranchAfterMerge bool
+	// HeadCommitID is the head of the pull request when it was queued, the pull request leaves the queue if it changes
+	HeadCommitID string           `xorm:"VARCHAR(64)"`
+	Status       MergeQueueStatus `xorm:"NOT NULL DEFAULT 0"`
+	// GroupBaseCommitID and GroupHeadCommitID are the base commit and the combined commit of the merge group being tested
+	GroupBaseCommitID string `xorm:"VARCHAR(64)"`
+	GroupHeadCommitID string `xorm:"VARCHAR(64) INDEX"`
+	// GroupCommitID is the commit of the merge group after merging this pull request, the base branch is fast-forwarded to it
+	GroupCommitID string             `xorm:"VARCHAR(64)"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName return database table name for xorm
+func (MergeQueueEntry) TableName() string {
+	return "pull_merge_queue"
+}
+
+func init() {
+	db.RegisterModel(new(MergeQueueEntry))
+}
+
+// MergeGroup represents the entries of a merge queue which are tested together.
+// The first group is based on the branch, every following group is based on the combined commit of the group before it.
+type MergeGroup struct {
+	RepoID     int64
+	BaseBranch string
+	// Ref is the ref the combined commit is pushed to
+	Ref          string
+	BaseCommitID string
+	HeadCommitID string
+	Entries      []*MergeQueueEntry
+}
+
+// GroupRefName returns the ref the combined commit of the merge group headed by the entry is pushed to
+func (e *MergeQueueEntry) GroupRefName() string {
+	return fmt.Sprintf("%s%d", MergeGroupRefPrefix, e.ID)
+}
+
+// IsTesting returns true if the entry is being tested in a merge group
+func (e *MergeQueueEntry) IsTesting() bool {
+	return e.Status == MergeQueueStatusTesting
+}
+
+// LoadDoer loads the user who added the pull request to the merge queue
+func (e *MergeQueueEntry) LoadDoer(ctx context.Context) error {
+	if e.Doer != nil {
+		return nil
+	}
+	doer, err := user_model.GetPossibleUserByID(ctx, e.DoerID)
+	if errors.Is(err, util.ErrNotExist) {
+		doer, err = user_model.NewGhostUser(), nil
+	}
+	if err != nil {
+		return err
+	}
+	e.Doer = doer
+	return nil
+}
+
+// ErrAlreadyInMergeQueue represents an error if the pull request is already in the merge queue
+type ErrAlreadyInMergeQueue struct {
+	PullID int64
+}
+
+func (err ErrAlreadyInMergeQueue) Error() string {
+	return fmt.Sprintf("pull request is already in the merge queue [pull_id: %d]", err.PullID)
+}
+
+func (err ErrAlreadyInMergeQueue) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// AddToMergeQueue appends the pull request to the end of the merge queue of its base branch
+func AddToMergeQueue(ctx context.Context, entry *MergeQueueEntry) error {
+	if exists, _, err := GetMergeQueueEntryByPullID(ctx, entry.PullID); err != nil {
+		return err
+	} else if exists {
+		return ErrAlreadyInMergeQueue{PullID: entry.PullID}
+	}
+	entry.Status = MergeQueueStatusQueued
+	return db.Insert(ctx, entry)
+}
+
+// GetMergeQueueEntryByPullID gets the merge queue entry of the pull request
+func GetMergeQueueEntryByPullID(ctx context.Context, pullID int64) (bool, *MergeQueueEntry, error) {
+	entry := &MergeQueueEntry{}
+	exists, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Get(entry)
+	if err != nil || !exists {
+		return false, nil, err
+	}
+	return true, entry, nil
+}
+
+// GetMergeQueueEntriesByGroupHead gets the entries of the merge group whose combined commit is the given commit
+func GetMergeQueueEntriesByGroupHead(ctx context.Context, repoID int64, commitID string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 5)
+	return entries, db.GetEngine(ctx).
+		Where("repo_id = ? AND status = ? AND group_head_commit_id = ?", repoID, MergeQueueStatusTesting, commitID).
+		Asc("id").Find(&entries)
+}
+
+// GetMergeQueue returns the entries of the merge queue of the branch in order
+func GetMergeQueue(ctx context.Context, repoID int64, branch string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 10)
+	return entries, db.GetEngine(ctx).Where("repo_id = ? AND base_branch = ?", repoID, branch).Asc("id").Find(&entries)
+}
+
+// GetMergeQueuePosition returns the 1-based position of the entry in the merge queue of its base branch
+func GetMergeQueuePosition(ctx context.Context, entry *MergeQueueEntry) (int64, error) {
+	count, err := db.GetEngine(ctx).Where("repo_id = ? AND base_branch = ? AND id < ?", entry.RepoID, entry.BaseBranch, entry.ID).Count(new(MergeQueueEntry))
+	return count + 1, err
+}
+
+// GetTestingMergeGroups splits the entries being tested at the head of the queue into their merge groups in order
+func GetTestingMergeGroups(entries []*MergeQueueEntry) [][]*MergeQueueEntry {
+	var groups [][]*MergeQueueEntry
+	for i, entry := range entries {
+		if !entry.IsTesting() {
+			break
+		}
+		if i == 0 || entry.GroupHeadCommitID != entries[i-1].GroupHeadCommitID {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], entry)
+	}
+	return groups
+}
+
+// StartMergeGroup marks the entries of the group as being tested together with the combined commit,
+// the GroupCommitID of the entries must have been set
+func StartMergeGroup(ctx context.Context, group *MergeGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for _, entry := range group.Entries {
+			entry.Status = MergeQueueStatusTesting
+			entry.GroupBaseCommitID = group.BaseCommitID
+			entry.GroupHeadCommitID = group.HeadCommitID
+			if _, err := db.GetEngine(ctx).ID(entry.ID).Cols("status", "group_base_commit_id", "group_head_commit_id", "group_commit_id").Update(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ResetMergeGroup puts the entries of a merge group back to the queue, so they will be tested again
+func ResetMergeGroup(ctx context.Context, entries []*MergeQueueEntry) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for _, entry := range entries {
+			entry.Status = MergeQueueStatusQueued
+			entry.GroupBaseCommitID = ""
+			entry.GroupHeadCommitID = ""
+			entry.GroupCommitID = ""
+			if _, err := db.GetEngine(ctx).ID(entry.ID).Cols("status", "group_base_commit_id", "group_head_commit_id", "group_commit_id").Update(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteMergeQueueEntry removes the pull request from the merge queue
+func DeleteMergeQueueEntry(ctx context.Context, pullID int64) (bool, error) {
+	n, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Delete(&MergeQueueEntry{})
+	return n > 0, err
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeQueue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	queue := func() []*pull_model.MergeQueueEntry {
+		entries, err := pull_model.GetMergeQueue(db.DefaultContext, 1, "master")
+		require.NoError(t, err)
+		return entries
+	}
+
+	for _, pullID := range []int64{1, 2, 3} {
+		require.NoError(t, pull_model.AddToMergeQueue(db.DefaultContext, &pull_model.MergeQueueEntry{
+			RepoID:       1,
+			BaseBranch:   "master",
+			PullID:       pullID,
+			DoerID:       2,
+			MergeStyle:   repo_model.MergeStyleMerge,
+			HeadCommitID: "head",
+		}))
+	}
+	err := pull_model.AddToMergeQueue(db.DefaultContext, &pull_model.MergeQueueEntry{RepoID: 1, BaseBranch: "master", PullID: 2, DoerID: 2})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+	entries := queue()
+	require.Len(t, entries, 3)
+	for i, entry := range entries {
+		assert.EqualValues(t, i+1, entry.PullID)
+		assert.Equal(t, pull_model.MergeQueueStatusQueued, entry.Status)
+		position, err := pull_model.GetMergeQueuePosition(db.DefaultContext, entry)
+		require.NoError(t, err)
+		assert.EqualValues(t, i+1, position)
+	}
+	assert.Empty(t, pull_model.GetTestingMergeGroups(entries))
+
+	// the first group is based on the branch
+	entries[0].GroupCommitID = "c1"
+	entries[1].GroupCommitID = "c2"
+	require.NoError(t, pull_model.StartMergeGroup(db.DefaultContext, &pull_model.MergeGroup{
+		RepoID: 1, BaseBranch: "master", Ref: entries[0].GroupRefName(), BaseCommitID: "base", HeadCommitID: "c2", Entries: entries[:2],
+	}))
+	// the second group is tested on top of the first one
+	entries[2].GroupCommitID = "c3"
+	require.NoError(t, pull_model.StartMergeGroup(db.DefaultContext, &pull_model.MergeGroup{
+		RepoID: 1, BaseBranch: "master", Ref: entries[2].GroupRefName(), BaseCommitID: "c2", HeadCommitID: "c3", Entries: entries[2:],
+	}))
+
+	entries = queue()
+	groups := pull_model.GetTestingMergeGroups(entries)
+	require.Len(t, groups, 2)
+	require.Len(t, groups[0], 2)
+	require.Len(t, groups[1], 1)
+	assert.True(t, groups[0][1].IsTesting())
+	assert.Equal(t, "base", groups[0][1].GroupBaseCommitID)
+	assert.Equal(t, "c2", groups[0][1].GroupHeadCommitID)
+	assert.Equal(t, "c2", groups[0][1].GroupCommitID)
+	assert.Equal(t, "c2", groups[1][0].GroupBaseCommitID)
+	assert.Equal(t, fmt.Sprintf("refs/merge-queue/%d", groups[0][0].ID), groups[0][0].GroupRefName())
+
+	byHead, err := pull_model.GetMergeQueueEntriesByGroupHead(db.DefaultContext, 1, "c2")
+	require.NoError(t, err)
+	assert.Len(t, byHead, 2)
+
+	// landing the first pull request removes it from the queue
+	deleted, err := pull_model.DeleteMergeQueueEntry(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = pull_model.DeleteMergeQueueEntry(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+	exists, _, err := pull_model.GetMergeQueueEntryByPullID(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// resetting puts the entries back to the queue
+	entries = queue()
+	require.NoError(t, pull_model.ResetMergeGroup(db.DefaultContext, entries))
+	entries = queue()
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, pull_model.MergeQueueStatusQueued, entry.Status)
+		assert.Empty(t, entry.GroupBaseCommitID)
+		assert.Empty(t, entry.GroupHeadCommitID)
+		assert.Empty(t, entry.GroupCommitID)
+	}
+	assert.Empty(t, pull_model.GetTestingMergeGroups(entries))
+	position, err := pull_model.GetMergeQueuePosition(db.DefaultContext, entries[0])
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, position)
+}
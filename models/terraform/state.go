@@ -0,0 +1,157 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrStateNotExist        = util.NewNotExistErrorf("terraform state does not exist")
+	ErrStateVersionNotExist = util.NewNotExistErrorf("terraform state version does not exist")
+)
+
+func init() {
+	db.RegisterModel(new(TerraformState))
+	db.RegisterModel(new(TerraformStateVersion))
+}
+
+// TerraformState is a named Terraform state of a repository.
+// The content of every written revision is kept as TerraformStateVersion.
+type TerraformState struct {
+	ID            int64  `xorm:"pk autoincr"`
+	RepoID        int64  `xorm:"UNIQUE(s) NOT NULL"`
+	Name          string `xorm:"UNIQUE(s) NOT NULL"`
+	LatestVersion int64  `xorm:"NOT NULL DEFAULT 0"` // 0 if no content was written yet
+	LockID        string // the ID of the lock held by a Terraform client, empty if unlocked
+	LockInfo      string `xorm:"TEXT"` // the lock information sent by the client as JSON
+	LockedUnix    timeutil.TimeStamp
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+}
+
+// IsLocked returns true if a client holds the lock of the state
+func (s *TerraformState) IsLocked() bool {
+	return s.LockID != ""
+}
+
+// TerraformStateVersion is a revision of a Terraform state
+type TerraformStateVersion struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"INDEX NOT NULL"`
+	StateID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+	Version     int64              `xorm:"UNIQUE(s) NOT NULL"`
+	Size        int64              `xorm:"NOT NULL DEFAULT 0"` // the size of the unencrypted content
+	HashSHA256  string             `xorm:"hash_sha256 char(64)"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// StoragePath returns the path of the encrypted content in the storage
+func (v *TerraformStateVersion) StoragePath() string {
+	return fmt.Sprintf("%d/%d/%d", v.RepoID, v.StateID, v.Version)
+}
+
+// GetStateByName gets the state with the name of a repository
+func GetStateByName(ctx context.Context, repoID int64, name string) (*TerraformState, error) {
+	s := &TerraformState{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND name = ?", repoID, name).Get(s)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateNotExist
+	}
+	return s, nil
+}
+
+// GetOrInsertState gets the state with the name of a repository and creates it if it does not exist
+func GetOrInsertState(ctx context.Context, repoID int64, name string) (*TerraformState, error) {
+	s, err := GetStateByName(ctx, repoID, name)
+	if err == nil {
+		return s, nil
+	}
+	if err != ErrStateNotExist {
+		return nil, err
+	}
+
+	s = &TerraformState{
+		RepoID: repoID,
+		Name:   name,
+	}
+	if err := db.Insert(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// UpdateStateCols updates the columns of a state
+func UpdateStateCols(ctx context.Context, s *TerraformState, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(s.ID).Cols(cols...).Update(s)
+	return err
+}
+
+// GetStatesByRepo gets all states of a repository
+func GetStatesByRepo(ctx context.Context, repoID int64) ([]*TerraformState, error) {
+	states := make([]*TerraformState, 0, 10)
+	return states, db.GetEngine(ctx).Where("repo_id = ?", repoID).OrderBy("name ASC").Find(&states)
+}
+
+// DeleteStateByID deletes the state and all its versions
+func DeleteStateByID(ctx context.Context, stateID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("state_id = ?", stateID).Delete(&TerraformStateVersion{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(stateID).Delete(&TerraformState{})
+		return err
+	})
+}
+
+// InsertStateVersion inserts a new version of a state
+func InsertStateVersion(ctx context.Context, v *TerraformStateVersion) error {
+	return db.Insert(ctx, v)
+}
+
+// GetStateVersion gets the version of a state
+func GetStateVersion(ctx context.Context, stateID, version int64) (*TerraformStateVersion, error) {
+	v := &TerraformStateVersion{}
+	has, err := db.GetEngine(ctx).Where("state_id = ? AND version = ?", stateID, version).Get(v)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateVersionNotExist
+	}
+	return v, nil
+}
+
+// GetStateVersions gets all versions of a state, newest first
+func GetStateVersions(ctx context.Context, stateID int64) ([]*TerraformStateVersion, error) {
+	versions := make([]*TerraformStateVersion, 0, 10)
+	return versions, db.GetEngine(ctx).Where("state_id = ?", stateID).OrderBy("version DESC").Find(&versions)
+}
+
+// GetStateVersionsBefore gets the versions of a state which are older than the version
+func GetStateVersionsBefore(ctx context.Context, stateID, version int64) ([]*TerraformStateVersion, error) {
+	versions := make([]*TerraformStateVersion, 0, 10)
+	return versions, db.GetEngine(ctx).Where("state_id = ? AND version < ?", stateID, version).Find(&versions)
+}
+
+// DeleteStateVersionsBefore deletes the versions of a state which are older than the version
+func DeleteStateVersionsBefore(ctx context.Context, stateID, version int64) error {
+	_, err := db.GetEngine(ctx).Where("state_id = ? AND version < ?", stateID, version).Delete(&TerraformStateVersion{})
+	return err
+}
+
+// GetStateVersionsByRepo gets the versions of all states of a repository
+func GetStateVersionsByRepo(ctx context.Context, repoID int64) ([]*TerraformStateVersion, error) {
+	versions := make([]*TerraformStateVersion, 0, 10)
+	return versions, db.GetEngine(ctx).Where("repo_id = ?", repoID).Find(&versions)
+}
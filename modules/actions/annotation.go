@@ -0,0 +1,76 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Annotation is a message about a file which is reported by a workflow command, like
+//
+//	::error file=app.js,line=1,col=5,endColumn=7,title=Syntax error::Missing semicolon
+//
+// See https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/workflow-commands-for-github-actions
+type Annotation struct {
+	Level     string // "error", "warning" or "notice"
+	File      string
+	Line      int
+	EndLine   int
+	Col       int
+	EndColumn int
+	Title     string
+	Message   string
+}
+
+var (
+	commandDataUnescaper     = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%25", "%")
+	commandPropertyUnescaper = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%3A", ":", "%2C", ",", "%25", "%")
+)
+
+// ParseAnnotation parses the annotation from the workflow command in a log line, it returns nil if the line isn't an annotation command
+func ParseAnnotation(line string) *Annotation {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "::") {
+		return nil
+	}
+	command, data, ok := strings.Cut(line[2:], "::")
+	if !ok {
+		return nil
+	}
+	name, properties, _ := strings.Cut(command, " ")
+	if name != "error" && name != "warning" && name != "notice" {
+		return nil
+	}
+
+	annotation := &Annotation{
+		Level:   name,
+		Message: commandDataUnescaper.Replace(data),
+	}
+	for _, property := range strings.Split(properties, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(property), "=")
+		if !ok {
+			continue
+		}
+		value = commandPropertyUnescaper.Replace(value)
+		switch key {
+		case "file":
+			annotation.File = strings.TrimPrefix(value, "./")
+		case "line":
+			annotation.Line, _ = strconv.Atoi(value)
+		case "endLine":
+			annotation.EndLine, _ = strconv.Atoi(value)
+		case "col":
+			annotation.Col, _ = strconv.Atoi(value)
+		case "endColumn":
+			annotation.EndColumn, _ = strconv.Atoi(value)
+		case "title":
+			annotation.Title = value
+		}
+	}
+	if annotation.EndLine < annotation.Line {
+		annotation.EndLine = annotation.Line
+	}
+	return annotation
+}
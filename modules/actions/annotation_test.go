@@ -0,0 +1,39 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnnotation(t *testing.T) {
+	tests := []struct {
+		line string
+		want *Annotation
+	}{
+		{
+			line: "::error file=./src/app.js,line=10,col=5,endColumn=7,title=Syntax error::Missing semicolon",
+			want: &Annotation{Level: "error", File: "src/app.js", Line: 10, EndLine: 10, Col: 5, EndColumn: 7, Title: "Syntax error", Message: "Missing semicolon"},
+		},
+		{
+			line: "  ::warning file=a%2Cb.go,line=3,endLine=5::first%0Asecond 100%25",
+			want: &Annotation{Level: "warning", File: "a,b.go", Line: 3, EndLine: 5, Message: "first\nsecond 100%"},
+		},
+		{
+			line: "::notice::Deployed",
+			want: &Annotation{Level: "notice", Message: "Deployed"},
+		},
+		{line: "::group::Build"},
+		{line: "::debug::Something"},
+		{line: "error file=a.go::not a command"},
+		{line: "::error file=a.go"},
+	}
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			assert.Equal(t, test.want, ParseAnnotation(test.line))
+		})
+	}
+}
@@ -6,6 +6,7 @@ package actions
 import (
 	"bytes"
 	"io"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -98,6 +99,78 @@ func GetEventsFromContent(content []byte) ([]*jobparser.Event, error) {
 	return events, nil
 }
 
+// secretRefRegex matches the references to secrets in expressions, like `secrets.FOO` or `secrets['FOO']`
+var secretRefRegex = regexp.MustCompile(`\bsecrets\s*(?:\.\s*|\[\s*['"])([A-Za-z_][A-Za-z0-9_-]*)`)
+
+// UsesSecrets returns true if the workflow reads any secret or passes the secrets to a reusable workflow.
+// The automatic token (GITHUB_TOKEN or GITEA_TOKEN) is always available to the jobs, so it's not considered as a secret.
+func UsesSecrets(content []byte) (bool, error) {
+	for _, m := range secretRefRegex.FindAllSubmatch(content, -1) {
+		name := strings.ToUpper(string(m[1]))
+		if name != "GITHUB_TOKEN" && name != "GITEA_TOKEN" {
+			return true, nil
+		}
+	}
+
+	jobs, err := readWorkflowJobs(content)
+	if err != nil {
+		return false, err
+	}
+	for _, job := range jobs {
+		if job != nil && !job.Secrets.IsZero() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetEnvironmentsFromContent returns the environments which the jobs of the workflow deploy to
+func GetEnvironmentsFromContent(content []byte) ([]string, error) {
+	jobs, err := readWorkflowJobs(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var envs []string
+	for _, job := range jobs {
+		if job == nil {
+			continue
+		}
+		var name string
+		switch job.Environment.Kind {
+		case yaml.ScalarNode:
+			name = job.Environment.Value
+		case yaml.MappingNode:
+			var env struct {
+				Name string `yaml:"name"`
+			}
+			if err := job.Environment.Decode(&env); err != nil {
+				return nil, err
+			}
+			name = env.Name
+		}
+		if name != "" && !slices.Contains(envs, name) {
+			envs = append(envs, name)
+		}
+	}
+	return envs, nil
+}
+
+type workflowJobRefs struct {
+	Environment yaml.Node `yaml:"environment"`
+	Secrets     yaml.Node `yaml:"secrets"`
+}
+
+func readWorkflowJobs(content []byte) (map[string]*workflowJobRefs, error) {
+	var workflow struct {
+		Jobs map[string]*workflowJobRefs `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(content, &workflow); err != nil {
+		return nil, err
+	}
+	return workflow.Jobs, nil
+}
+
 func DetectWorkflows(
 	gitRepo *git.Repository,
 	commit *git.Commit,
@@ -250,6 +323,10 @@ func detectMatched(gitRepo *git.Repository, commit *git.Commit, triggedEvent web
 		webhook_module.HookEventWorkflowRun:
 		return matchWorkflowRunEvent(payload.(*api.WorkflowRunPayload), evt)
 
+	case // merge_group
+		webhook_module.HookEventMergeGroup:
+		return matchMergeGroupEvent(payload.(*api.MergeGroupPayload), evt)
+
 	default:
 		log.Warn("unsupported event %q", triggedEvent)
 		return false
@@ -745,3 +822,46 @@ func matchWorkflowRunEvent(payload *api.WorkflowRunPayload, evt *jobparser.Event
 	}
 	return matchTimes == len(evt.Acts())
 }
+
+func matchMergeGroupEvent(payload *api.MergeGroupPayload, evt *jobparser.Event) bool {
+	// with no special filter parameters
+	if len(evt.Acts()) == 0 {
+		return true
+	}
+
+	matchTimes := 0
+	// all acts conditions should be satisfied
+	for cond, vals := range evt.Acts() {
+		switch cond {
+		case "types":
+			// See https://docs.github.com/en/actions/using-workflows/events-that-trigger-workflows#merge_group
+			// Activity types with the same name:
+			// checks_requested
+			for _, val := range vals {
+				if glob.MustCompile(val, '/').Match(string(payload.Action)) {
+					matchTimes++
+					break
+				}
+			}
+		case "branches":
+			patterns, err := workflowpattern.CompilePatterns(vals...)
+			if err != nil {
+				break
+			}
+			if !workflowpattern.Skip(patterns, []string{git.RefName(payload.MergeGroup.BaseRef).ShortName()}, &workflowpattern.EmptyTraceWriter{}) {
+				matchTimes++
+			}
+		case "branches-ignore":
+			patterns, err := workflowpattern.CompilePatterns(vals...)
+			if err != nil {
+				break
+			}
+			if !workflowpattern.Filter(patterns, []string{git.RefName(payload.MergeGroup.BaseRef).ShortName()}, &workflowpattern.EmptyTraceWriter{}) {
+				matchTimes++
+			}
+		default:
+			log.Warn("merge group event unsupported condition %q", cond)
+		}
+	}
+	return matchTimes == len(evt.Acts())
+}
@@ -143,6 +143,20 @@ func TestDetectMatched(t *testing.T) {
 			yamlOn:   "on:\n  push:\n    paths:\n      - src/**",
 			expected: true,
 		},
+		{
+			desc:         "HookEventMergeGroup(merge_group) `checks_requested` action matches GithubEventMergeGroup(merge_group) with types",
+			triggedEvent: webhook_module.HookEventMergeGroup,
+			payload:      &api.MergeGroupPayload{Action: api.HookMergeGroupChecksRequested, MergeGroup: &api.MergeGroup{BaseRef: "refs/heads/main"}},
+			yamlOn:       "on:\n  merge_group:\n    types: [checks_requested]",
+			expected:     true,
+		},
+		{
+			desc:         "HookEventMergeGroup(merge_group) doesn't match GithubEventMergeGroup(merge_group) of other branches",
+			triggedEvent: webhook_module.HookEventMergeGroup,
+			payload:      &api.MergeGroupPayload{Action: api.HookMergeGroupChecksRequested, MergeGroup: &api.MergeGroup{BaseRef: "refs/heads/main"}},
+			yamlOn:       "on:\n  merge_group:\n    branches: [release/*]",
+			expected:     false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -154,3 +168,66 @@ func TestDetectMatched(t *testing.T) {
 		})
 	}
 }
+
+func TestUsesSecrets(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		content  string
+		expected bool
+	}{
+		{
+			desc:     "no secrets",
+			content:  "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make\n",
+			expected: false,
+		},
+		{
+			desc:     "automatic token only",
+			content:  "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo ${{ secrets.GITHUB_TOKEN }} ${{ secrets.gitea_token }}\n",
+			expected: false,
+		},
+		{
+			desc:     "dot reference",
+			content:  "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo ${{ secrets.DEPLOY_KEY }}\n",
+			expected: true,
+		},
+		{
+			desc:     "index reference",
+			content:  "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    env:\n      KEY: ${{ secrets['DEPLOY_KEY'] }}\n",
+			expected: true,
+		},
+		{
+			desc:     "inherited by reusable workflow",
+			content:  "on: push\njobs:\n  call:\n    uses: ./.gitea/workflows/deploy.yml\n    secrets: inherit\n",
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			uses, err := UsesSecrets([]byte(tc.content))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, uses)
+		})
+	}
+}
+
+func TestGetEnvironmentsFromContent(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  staging:
+    runs-on: ubuntu-latest
+    environment: staging
+  production:
+    runs-on: ubuntu-latest
+    environment:
+      name: production
+      url: https://example.com
+  production-again:
+    runs-on: ubuntu-latest
+    environment: production
+`
+	envs, err := GetEnvironmentsFromContent([]byte(content))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"staging", "production"}, envs)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cms
+
+import "errors"
+
+var errTruncated = errors.New("truncated BER data")
+
+// berToDER rewrites the indefinite-length encodings produced by some signers (e.g. gpgsm)
+// into definite-length encodings which encoding/asn1 can parse.
+func berToDER(b []byte) ([]byte, error) {
+	out, rest, err := convertElement(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after BER element")
+	}
+	return out, nil
+}
+
+func convertElement(b []byte) (out, rest []byte, err error) {
+	idLen := 1
+	if len(b) > 0 && b[0]&0x1f == 0x1f {
+		// high tag number form
+		for idLen < len(b) && b[idLen]&0x80 != 0 {
+			idLen++
+		}
+		idLen++
+	}
+	if len(b) <= idLen {
+		return nil, nil, errTruncated
+	}
+	id := b[:idLen]
+	constructed := b[0]&0x20 != 0
+	pos := idLen + 1
+
+	if b[idLen] == 0x80 {
+		if !constructed {
+			return nil, nil, errors.New("indefinite length on primitive BER element")
+		}
+		var content []byte
+		for {
+			if len(b) < pos+2 {
+				return nil, nil, errTruncated
+			}
+			if b[pos] == 0 && b[pos+1] == 0 {
+				pos += 2
+				break
+			}
+			child, r, err := convertElement(b[pos:])
+			if err != nil {
+				return nil, nil, err
+			}
+			content = append(content, child...)
+			pos = len(b) - len(r)
+		}
+		return encodeElement(id, content), b[pos:], nil
+	}
+
+	length := int(b[idLen])
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 || len(b) < pos+n {
+			return nil, nil, errTruncated
+		}
+		length = 0
+		for i := range n {
+			length = length<<8 | int(b[pos+i])
+		}
+		pos += n
+	}
+	if length < 0 || len(b)-pos < length {
+		return nil, nil, errTruncated
+	}
+	content := b[pos : pos+length]
+	if constructed {
+		var converted []byte
+		for len(content) > 0 {
+			child, r, err := convertElement(content)
+			if err != nil {
+				return nil, nil, err
+			}
+			converted = append(converted, child...)
+			content = r
+		}
+		content = converted
+	}
+	return encodeElement(id, content), b[pos+length:], nil
+}
+
+func encodeElement(id, content []byte) []byte {
+	out := append([]byte{}, id...)
+	n := len(content)
+	if n < 0x80 {
+		out = append(out, byte(n))
+	} else {
+		var lenBytes []byte
+		for ; n > 0; n >>= 8 {
+			lenBytes = append([]byte{byte(n)}, lenBytes...)
+		}
+		out = append(out, 0x80|byte(len(lenBytes)))
+		out = append(out, lenBytes...)
+	}
+	return append(out, content...)
+}
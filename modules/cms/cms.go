@@ -0,0 +1,297 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package cms implements the subset of the Cryptographic Message Syntax (RFC 5652)
+// needed to verify the detached X.509 signatures which git creates with gpg.format=x509
+// (gpgsm, smimesign, gitsign, ...).
+package cms
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidRSASSAPSS     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+
+	oidDigestSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidDigestSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidDigestSHA512 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+)
+
+// ErrInvalidSignature is returned when the signature does not match the signed payload
+var ErrInvalidSignature = util.NewInvalidArgumentErrorf("signature does not verify the payload")
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+type encapsulatedContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     asn1.RawValue `asn1:"optional,explicit,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type signerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// Signature is a parsed detached CMS signature
+type Signature struct {
+	// Certificates are all certificates embedded in the signature, usually the signer and its intermediates
+	Certificates []*x509.Certificate
+	// SigningTime is the signing time claimed by the signer, zero if the signature has none
+	SigningTime time.Time
+
+	signer signerInfo
+}
+
+// ParseSignature parses a detached signature, either PEM armored ("SIGNED MESSAGE" or "PKCS7") or raw BER/DER
+func ParseSignature(data []byte) (*Signature, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	der, err := berToDER(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var ci contentInfo
+	if rest, err := asn1.Unmarshal(der, &ci); err != nil {
+		return nil, fmt.Errorf("invalid content info: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after content info")
+	}
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("unsupported content type %s", ci.ContentType)
+	}
+
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("invalid signed data: %w", err)
+	}
+	if len(sd.EncapContentInfo.EContent.Bytes) != 0 {
+		return nil, errors.New("signature is not detached")
+	}
+	if len(sd.SignerInfos) != 1 {
+		return nil, fmt.Errorf("expected exactly one signer, got %d", len(sd.SignerInfos))
+	}
+
+	sig := &Signature{signer: sd.SignerInfos[0]}
+	if len(sd.Certificates.Bytes) != 0 {
+		if sig.Certificates, err = x509.ParseCertificates(sd.Certificates.Bytes); err != nil {
+			return nil, fmt.Errorf("invalid certificates: %w", err)
+		}
+	}
+
+	attrs, err := sig.signedAttributes()
+	if err != nil {
+		return nil, err
+	}
+	for _, attr := range attrs {
+		if attr.Type.Equal(oidSigningTime) && len(attr.Values) == 1 {
+			if _, err := asn1.Unmarshal(attr.Values[0].FullBytes, &sig.SigningTime); err != nil {
+				return nil, fmt.Errorf("invalid signing time: %w", err)
+			}
+		}
+	}
+	return sig, nil
+}
+
+func (s *Signature) signedAttributes() ([]attribute, error) {
+	if len(s.signer.SignedAttrs.FullBytes) == 0 {
+		return nil, nil
+	}
+	var attrs []attribute
+	if _, err := asn1.UnmarshalWithParams(s.signer.SignedAttrs.FullBytes, &attrs, "set,tag:0"); err != nil {
+		return nil, fmt.Errorf("invalid signed attributes: %w", err)
+	}
+	return attrs, nil
+}
+
+// SignerCertificate returns the embedded certificate identified by the signer info
+func (s *Signature) SignerCertificate() (*x509.Certificate, error) {
+	sid := s.signer.SID
+	for _, cert := range s.Certificates {
+		switch {
+		case sid.Class == asn1.ClassUniversal && sid.Tag == asn1.TagSequence:
+			var ias issuerAndSerialNumber
+			if _, err := asn1.Unmarshal(sid.FullBytes, &ias); err != nil {
+				return nil, fmt.Errorf("invalid signer identifier: %w", err)
+			}
+			if bytes.Equal(cert.RawIssuer, ias.Issuer.FullBytes) && cert.SerialNumber.Cmp(ias.SerialNumber) == 0 {
+				return cert, nil
+			}
+		case sid.Class == asn1.ClassContextSpecific && sid.Tag == 0:
+			if len(cert.SubjectKeyId) != 0 && bytes.Equal(cert.SubjectKeyId, sid.Bytes) {
+				return cert, nil
+			}
+		}
+	}
+	return nil, util.NewNotExistErrorf("signer certificate is not embedded in the signature")
+}
+
+// Verify checks that the signature was made over payload and returns the signer certificate.
+// It does not verify the certificate chain, see VerifyCertificate.
+func (s *Signature) Verify(payload []byte) (*x509.Certificate, error) {
+	cert, err := s.SignerCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := digestHash(s.signer.DigestAlgorithm.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if s.signer.SignatureAlgorithm.Algorithm.Equal(oidRSASSAPSS) {
+		return nil, util.NewInvalidArgumentErrorf("RSASSA-PSS signatures are not supported")
+	}
+	sigAlgo, err := signatureAlgorithm(cert.PublicKeyAlgorithm, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := payload
+	if len(s.signer.SignedAttrs.FullBytes) != 0 {
+		h := hash.New()
+		h.Write(payload)
+		if err := s.checkSignedAttributes(h.Sum(nil)); err != nil {
+			return nil, err
+		}
+		// the signature covers the DER encoding of the attributes with an explicit SET OF tag
+		signed = append([]byte{0x31}, s.signer.SignedAttrs.FullBytes[1:]...)
+	}
+
+	if err := cert.CheckSignature(sigAlgo, signed, s.signer.Signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return cert, nil
+}
+
+func (s *Signature) checkSignedAttributes(digest []byte) error {
+	attrs, err := s.signedAttributes()
+	if err != nil {
+		return err
+	}
+	var hasContentType, hasDigest bool
+	for _, attr := range attrs {
+		switch {
+		case attr.Type.Equal(oidContentType):
+			var contentType asn1.ObjectIdentifier
+			if len(attr.Values) != 1 {
+				return errors.New("invalid content type attribute")
+			}
+			if _, err := asn1.Unmarshal(attr.Values[0].FullBytes, &contentType); err != nil || !contentType.Equal(oidData) {
+				return errors.New("invalid content type attribute")
+			}
+			hasContentType = true
+		case attr.Type.Equal(oidMessageDigest):
+			var messageDigest []byte
+			if len(attr.Values) != 1 {
+				return errors.New("invalid message digest attribute")
+			}
+			if _, err := asn1.Unmarshal(attr.Values[0].FullBytes, &messageDigest); err != nil {
+				return fmt.Errorf("invalid message digest attribute: %w", err)
+			}
+			if !bytes.Equal(messageDigest, digest) {
+				return ErrInvalidSignature
+			}
+			hasDigest = true
+		}
+	}
+	if !hasContentType || !hasDigest {
+		return errors.New("signed attributes lack content type or message digest")
+	}
+	return nil
+}
+
+// VerifyCertificate verifies the chain of the signer certificate, using the embedded certificates as intermediates
+func (s *Signature) VerifyCertificate(cert *x509.Certificate, opts x509.VerifyOptions) ([][]*x509.Certificate, error) {
+	if opts.Intermediates == nil {
+		opts.Intermediates = x509.NewCertPool()
+	}
+	for _, c := range s.Certificates {
+		if c != cert {
+			opts.Intermediates.AddCert(c)
+		}
+	}
+	return cert.Verify(opts)
+}
+
+func digestHash(oid asn1.ObjectIdentifier) (crypto.Hash, error) {
+	switch {
+	case oid.Equal(oidDigestSHA256):
+		return crypto.SHA256, nil
+	case oid.Equal(oidDigestSHA384):
+		return crypto.SHA384, nil
+	case oid.Equal(oidDigestSHA512):
+		return crypto.SHA512, nil
+	}
+	return 0, util.NewInvalidArgumentErrorf("unsupported digest algorithm %s", oid)
+}
+
+func signatureAlgorithm(keyAlgo x509.PublicKeyAlgorithm, hash crypto.Hash) (x509.SignatureAlgorithm, error) {
+	switch keyAlgo {
+	case x509.RSA:
+		switch hash {
+		case crypto.SHA256:
+			return x509.SHA256WithRSA, nil
+		case crypto.SHA384:
+			return x509.SHA384WithRSA, nil
+		case crypto.SHA512:
+			return x509.SHA512WithRSA, nil
+		}
+	case x509.ECDSA:
+		switch hash {
+		case crypto.SHA256:
+			return x509.ECDSAWithSHA256, nil
+		case crypto.SHA384:
+			return x509.ECDSAWithSHA384, nil
+		case crypto.SHA512:
+			return x509.ECDSAWithSHA512, nil
+		}
+	case x509.Ed25519:
+		return x509.PureEd25519, nil
+	}
+	return x509.UnknownSignatureAlgorithm, util.NewInvalidArgumentErrorf("unsupported public key algorithm %s", keyAlgo)
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(t *testing.T, tmpl, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	b, err := asn1.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func signDetached(t *testing.T, payload []byte, cert *x509.Certificate, key crypto.Signer, signingTime time.Time) []byte {
+	digest := sha256.Sum256(payload)
+	attrs := []attribute{
+		{Type: oidContentType, Values: []asn1.RawValue{{FullBytes: mustMarshal(t, oidData)}}},
+		{Type: oidSigningTime, Values: []asn1.RawValue{{FullBytes: mustMarshal(t, signingTime.UTC())}}},
+		{Type: oidMessageDigest, Values: []asn1.RawValue{{FullBytes: mustMarshal(t, digest[:])}}},
+	}
+	attrsDER, err := asn1.MarshalWithParams(attrs, "set")
+	require.NoError(t, err)
+	attrsDigest := sha256.Sum256(attrsDER)
+	signature, err := key.Sign(rand.Reader, attrsDigest[:], crypto.SHA256)
+	require.NoError(t, err)
+
+	sha256Algo := pkix.AlgorithmIdentifier{Algorithm: oidDigestSHA256}
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{sha256Algo},
+		EncapContentInfo: encapsulatedContentInfo{EContentType: oidData},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: cert.Raw},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			SID: asn1.RawValue{FullBytes: mustMarshal(t, issuerAndSerialNumber{
+				Issuer:       asn1.RawValue{FullBytes: cert.RawIssuer},
+				SerialNumber: cert.SerialNumber,
+			})},
+			DigestAlgorithm:    sha256Algo,
+			SignedAttrs:        asn1.RawValue{FullBytes: append([]byte{0xa0}, attrsDER[1:]...)},
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+			Signature:          signature,
+		}},
+	}
+	ci := mustMarshal(t, struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue
+	}{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: mustMarshal(t, sd)},
+	})
+	return pem.EncodeToMemory(&pem.Block{Type: "SIGNED MESSAGE", Bytes: ci})
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ca, caKey := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	leaf, leafKey := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		Subject:        pkix.Name{CommonName: "User"},
+		EmailAddresses: []string{"user@example.com"},
+		NotBefore:      now.Add(-time.Hour),
+		NotAfter:       now.Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	}, ca, caKey)
+
+	payload := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ncommit message\n")
+	sig, err := ParseSignature(signDetached(t, payload, leaf, leafKey, now))
+	require.NoError(t, err)
+	assert.True(t, now.Equal(sig.SigningTime))
+	assert.Len(t, sig.Certificates, 1)
+
+	cert, err := sig.Verify(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user@example.com"}, cert.EmailAddresses)
+
+	_, err = sig.Verify(append(payload, '!'))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	opts := x509.VerifyOptions{Roots: roots, CurrentTime: sig.SigningTime, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}
+	_, err = sig.VerifyCertificate(cert, opts)
+	require.NoError(t, err)
+
+	opts.CurrentTime = now.Add(2 * time.Hour)
+	_, err = sig.VerifyCertificate(cert, opts)
+	assert.Error(t, err, "the certificate had expired at that time")
+
+	opts.CurrentTime = now
+	opts.Roots = x509.NewCertPool()
+	_, err = sig.VerifyCertificate(cert, opts)
+	assert.Error(t, err, "the certificate is not issued by a trusted CA")
+}
+
+func TestParseSignatureBER(t *testing.T) {
+	// SEQUENCE (indefinite) { INTEGER 1, SEQUENCE (indefinite) { BOOLEAN true } }
+	ber := []byte{0x30, 0x80, 0x02, 0x01, 0x01, 0x30, 0x80, 0x01, 0x01, 0xff, 0x00, 0x00, 0x00, 0x00}
+	der, err := berToDER(ber)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x30, 0x08, 0x02, 0x01, 0x01, 0x30, 0x03, 0x01, 0x01, 0xff}, der)
+
+	_, err = berToDER([]byte{0x30, 0x80, 0x02, 0x01})
+	assert.Error(t, err)
+
+	_, err = ParseSignature([]byte("-----BEGIN SIGNED MESSAGE-----\nMAA=\n-----END SIGNED MESSAGE-----\n"))
+	assert.Error(t, err)
+}
@@ -22,7 +22,19 @@ func NewBatch(ctx context.Context, repoPath string) (*Batch, error) {
 	}
 
 	var batch Batch
-	batch.Writer, batch.Reader, batch.cancel = catFileBatch(ctx, repoPath)
+	batch.Writer, batch.Reader, batch.cancel = catFileBatch(ctx, repoPath, nil)
+	return &batch, nil
+}
+
+// NewBatchWithEnv creates a new batch running with the extra environment variables,
+// e.g. to read the quarantined objects of a push in the pre-receive hook
+func NewBatchWithEnv(ctx context.Context, repoPath string, env []string) (*Batch, error) {
+	if err := ensureValidGitRepository(ctx, repoPath); err != nil {
+		return nil, err
+	}
+
+	var batch Batch
+	batch.Writer, batch.Reader, batch.cancel = catFileBatch(ctx, repoPath, env)
 	return &batch, nil
 }
 
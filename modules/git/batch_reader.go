@@ -87,7 +87,7 @@ func catFileBatchCheck(ctx context.Context, repoPath string) (WriteCloserError,
 }
 
 // catFileBatch opens git cat-file --batch in the provided repo and returns a stdin pipe, a stdout reader and cancel function
-func catFileBatch(ctx context.Context, repoPath string) (WriteCloserError, *bufio.Reader, func()) {
+func catFileBatch(ctx context.Context, repoPath string, env []string) (WriteCloserError, *bufio.Reader, func()) {
 	// We often want to feed the commits in order into cat-file --batch, followed by their trees and sub trees as necessary.
 	// so let's create a batch stdin and stdout
 	batchStdinReader, batchStdinWriter := io.Pipe()
@@ -112,6 +112,7 @@ func catFileBatch(ctx context.Context, repoPath string) (WriteCloserError, *bufi
 		err := NewCommand("cat-file", "--batch").
 			Run(ctx, &RunOpts{
 				Dir:    repoPath,
+				Env:    env,
 				Stdin:  batchStdinReader,
 				Stdout: batchStdoutWriter,
 				Stderr: &stderr,
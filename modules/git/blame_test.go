@@ -147,3 +147,21 @@ func TestReadingBlameOutput(t *testing.T) {
 		}
 	})
 }
+
+func TestLineRangeBlame(t *testing.T) {
+	repo, err := OpenRepository(t.Context(), "./tests/repos/repo5_pulls")
+	assert.NoError(t, err)
+	defer repo.Close()
+
+	const revision = "f32b0a9dfd09a60f616f29158f772cedd89942d2"
+	commit, err := repo.LineRangeBlame(revision, repo.Path, "README.md", 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "72866af952e98d02a73003501836074b286a78f6", commit.ID.String())
+
+	commit, err = repo.LineRangeBlame(revision, repo.Path, "README.md", 2, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, revision, commit.ID.String(), "the latest commit of the range")
+
+	_, err = repo.LineRangeBlame(revision, repo.Path, "README.md", 3, 10)
+	assert.ErrorContains(t, err, "has only 4 lines")
+}
@@ -5,6 +5,8 @@ package git
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // LineBlame returns the latest commit at the given line
@@ -21,3 +23,51 @@ func (repo *Repository) LineBlame(revision, path, file string, line uint) (*Comm
 	}
 	return repo.GetCommit(res[:40])
 }
+
+// LineRangeBlame returns the latest commit which changed one of the lines from start to end (inclusive)
+func (repo *Repository) LineRangeBlame(revision, path, file string, start, end uint) (*Commit, error) {
+	if start == end {
+		return repo.LineBlame(revision, path, file, start)
+	}
+	res, _, err := NewCommand("blame").
+		AddOptionFormat("-L %d,%d", start, end).
+		AddOptionValues("-p", revision).
+		AddDashesAndList(file).RunStdString(repo.Ctx, &RunOpts{Dir: path})
+	if err != nil {
+		return nil, err
+	}
+
+	// the porcelain output has a header line "<sha> <orig line> <final line> [<lines>]" for every blamed line,
+	// the first header of a commit is followed by its information like the committer time
+	var latestID, currentID string
+	var latestTime int64
+	blamed := uint(0)
+	for _, line := range strings.Split(res, "\n") {
+		if line == "" || line[0] == '\t' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && IsStringLikelyCommitID(nil, fields[0], 40) {
+			currentID = fields[0]
+			blamed++
+			continue
+		}
+		if fields[0] == "committer-time" && len(fields) == 2 {
+			committed, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid committer time of blame: %s", line)
+			}
+			if latestID == "" || committed > latestTime {
+				latestID, latestTime = currentID, committed
+			}
+		}
+	}
+	if latestID == "" {
+		return nil, fmt.Errorf("invalid result of blame: %s", res)
+	}
+	if blamed < end-start+1 {
+		// git clips a range running past the end of the file, report it like a start line past the end
+		return nil, fmt.Errorf("fatal: file %s has only %d lines", file, start+blamed-1)
+	}
+	return repo.GetCommit(latestID)
+}
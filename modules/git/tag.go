@@ -31,10 +31,12 @@ func parsePayloadSignature(data []byte, messageStart int) (payload, msg, sign st
 		}
 		line := data[pos : pos+eol]
 		signType, hasPrefix := bytes.CutPrefix(line, []byte("-----BEGIN "))
-		signType, hasSuffix := bytes.CutSuffix(signType, []byte(" SIGNATURE-----"))
-		if hasPrefix && hasSuffix {
+		signType, hasSuffix := bytes.CutSuffix(signType, []byte("-----"))
+		// "PGP SIGNATURE" and "SSH SIGNATURE", or "SIGNED MESSAGE" for X.509 (gpgsm)
+		isSignType := bytes.HasSuffix(signType, []byte(" SIGNATURE")) || bytes.Equal(signType, []byte("SIGNED MESSAGE"))
+		if hasPrefix && hasSuffix && isSignType {
 			signEndBytes := append([]byte("\n-----END "), signType...)
-			signEndBytes = append(signEndBytes, []byte(" SIGNATURE-----")...)
+			signEndBytes = append(signEndBytes, []byte("-----")...)
 			signEnd = bytes.Index(data[pos:], signEndBytes)
 			if signEnd != -1 {
 				signStart = pos
@@ -83,6 +83,37 @@ dummy message`,
 				},
 			},
 		},
+		{
+			data: `object 7cdf42c0b1cc763ab7e4c33c47a24e27c66bfaaa
+type commit
+tag v1
+tagger dummy user <dummy-email@example.com> 1484491741 +0100
+
+x509 message
+-----BEGIN SIGNED MESSAGE-----
+dummy signature
+-----END SIGNED MESSAGE-----
+`,
+			expected: Tag{
+				Name:    "",
+				ID:      Sha1ObjectFormat.EmptyObjectID(),
+				Object:  MustIDFromString("7cdf42c0b1cc763ab7e4c33c47a24e27c66bfaaa"),
+				Type:    "commit",
+				Tagger:  &Signature{Name: "dummy user", Email: "dummy-email@example.com", When: time.Unix(1484491741, 0).In(time.FixedZone("", 3600))},
+				Message: "x509 message",
+				Signature: &CommitSignature{
+					Signature: `-----BEGIN SIGNED MESSAGE-----
+dummy signature
+-----END SIGNED MESSAGE-----`,
+					Payload: `object 7cdf42c0b1cc763ab7e4c33c47a24e27c66bfaaa
+type commit
+tag v1
+tagger dummy user <dummy-email@example.com> 1484491741 +0100
+
+x509 message`,
+				},
+			},
+		},
 	}
 
 	for _, test := range testData {
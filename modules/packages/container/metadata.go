@@ -21,6 +21,7 @@ const (
 	PropertyMediaType         = "container.mediatype"
 	PropertyManifestTagged    = "container.manifest.tagged"
 	PropertyManifestReference = "container.manifest.reference"
+	PropertyManifestSubject   = "container.manifest.subject"
 
 	DefaultPlatform = "linux/amd64"
 
@@ -63,6 +64,9 @@ type Metadata struct {
 	Labels           map[string]string `json:"labels,omitempty"`
 	ImageLayers      []string          `json:"layer_creation,omitempty"`
 	Manifests        []*Manifest       `json:"manifests,omitempty"`
+	ArtifactType     string            `json:"artifact_type,omitempty"`
+	Subject          string            `json:"subject,omitempty"` // the digest of the manifest this manifest refers to
+	Annotations      map[string]string `json:"annotations,omitempty"`
 }
 
 type Manifest struct {
@@ -88,6 +92,10 @@ func ParseImageConfig(mediaType string, r io.Reader) (*Metadata, error) {
 	if strings.EqualFold(mediaType, helm.ConfigMediaType) {
 		return parseHelmConfig(r)
 	}
+	if strings.EqualFold(mediaType, oci.MediaTypeEmptyJSON) {
+		// artifacts without a config use the empty descriptor
+		return &Metadata{Type: TypeOCI, Platform: "unknown/unknown"}, nil
+	}
 
 	// fallback to OCI Image Config
 	// FIXME: this fallback is not right, we should strictly check the media type in the future
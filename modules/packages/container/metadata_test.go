@@ -63,4 +63,8 @@ func TestParseImageConfig(t *testing.T) {
 	metadata, err = ParseImageConfig("anything-unknown", strings.NewReader(""))
 	require.NoError(t, err)
 	assert.Equal(t, &Metadata{Platform: "unknown/unknown"}, metadata)
+
+	metadata, err = ParseImageConfig(oci.MediaTypeEmptyJSON, strings.NewReader("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{Type: TypeOCI, Platform: "unknown/unknown"}, metadata)
 }
@@ -0,0 +1,144 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// External term format tags
+// https://www.erlang.org/doc/apps/erts/erl_ext_dist.html
+const (
+	etfVersion        = 131
+	etfSmallInteger   = 97
+	etfInteger        = 98
+	etfNil            = 106
+	etfList           = 108
+	etfBinary         = 109
+	etfSmallBigInt    = 110
+	etfMap            = 116
+	etfSmallAtomUTF8  = 119
+	etfAtomUTF8       = 118
+	etfNewFloat       = 70
+	maxSmallAtomBytes = 255
+)
+
+// EncodeTerm encodes a value in the Erlang external term format which is used by the Hex API if the client requests
+// "application/vnd.hex+erlang". Strings are encoded as binaries, nil and booleans as atoms and maps need string keys.
+func EncodeTerm(v any) ([]byte, error) {
+	return appendTerm([]byte{etfVersion}, v)
+}
+
+func appendTerm(b []byte, v any) ([]byte, error) {
+	var err error
+
+	switch t := v.(type) {
+	case nil:
+		return appendAtom(b, "nil"), nil
+	case bool:
+		if t {
+			return appendAtom(b, "true"), nil
+		}
+		return appendAtom(b, "false"), nil
+	case Atom:
+		return appendAtom(b, string(t)), nil
+	case string:
+		b = append(b, etfBinary)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		return append(b, t...), nil
+	case int:
+		return appendInteger(b, int64(t)), nil
+	case int64:
+		return appendInteger(b, t), nil
+	case float64:
+		b = append(b, etfNewFloat)
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(t)), nil
+	case []string:
+		b = appendListHeader(b, len(t))
+		for _, e := range t {
+			if b, err = appendTerm(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, etfNil), nil
+	case []any:
+		b = appendListHeader(b, len(t))
+		for _, e := range t {
+			if b, err = appendTerm(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, etfNil), nil
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		b = append(b, etfMap)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		for _, k := range keys {
+			if b, err = appendTerm(b, k); err != nil {
+				return nil, err
+			}
+			if b, err = appendTerm(b, t[k]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]string:
+		m := make(map[string]any, len(t))
+		for k, v := range t {
+			m[k] = v
+		}
+		return appendTerm(b, m)
+	}
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
+
+func appendListHeader(b []byte, length int) []byte {
+	if length == 0 {
+		return b
+	}
+	b = append(b, etfList)
+	return binary.BigEndian.AppendUint32(b, uint32(length))
+}
+
+func appendAtom(b []byte, atom string) []byte {
+	if len(atom) <= maxSmallAtomBytes {
+		b = append(b, etfSmallAtomUTF8, byte(len(atom)))
+	} else {
+		b = append(b, etfAtomUTF8)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(atom)))
+	}
+	return append(b, atom...)
+}
+
+func appendInteger(b []byte, i int64) []byte {
+	if i >= 0 && i <= math.MaxUint8 {
+		return append(b, etfSmallInteger, byte(i))
+	}
+	if i >= math.MinInt32 && i <= math.MaxInt32 {
+		b = append(b, etfInteger)
+		return binary.BigEndian.AppendUint32(b, uint32(int32(i)))
+	}
+
+	sign := byte(0)
+	u := uint64(i)
+	if i < 0 {
+		sign = 1
+		u = uint64(-i)
+	}
+	digits := make([]byte, 0, 8)
+	for u > 0 {
+		digits = append(digits, byte(u))
+		u >>= 8
+	}
+	b = append(b, etfSmallBigInt, byte(len(digits)), sign)
+	return append(b, digits...)
+}
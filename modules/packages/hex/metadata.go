@@ -0,0 +1,336 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+)
+
+var (
+	ErrInvalidTarball       = util.NewInvalidArgumentErrorf("package tarball is invalid")
+	ErrUnsupportedVersion   = util.NewInvalidArgumentErrorf("package tarball version is not supported")
+	ErrMissingMetadataFile  = util.NewInvalidArgumentErrorf("metadata.config file is missing")
+	ErrMetadataFileTooLarge = util.NewInvalidArgumentErrorf("metadata.config file is too large")
+	ErrInvalidMetadataFile  = util.NewInvalidArgumentErrorf("metadata.config file is invalid")
+	ErrMissingContentsFile  = util.NewInvalidArgumentErrorf("contents.tar.gz file is missing")
+	ErrChecksumMismatch     = util.NewInvalidArgumentErrorf("package tarball checksum does not match")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("package version is invalid")
+	ErrInvalidRequirement   = util.NewInvalidArgumentErrorf("package requirement is invalid")
+)
+
+const (
+	// PropertyInnerChecksum is the version property which contains the checksum of the tarball contents
+	PropertyInnerChecksum = "hex.inner_checksum"
+
+	tarballVersion      = "3"
+	maxMetadataFileSize = 128 * 1024
+	maxReadmeFileSize   = 1024 * 1024
+)
+
+// https://github.com/hexpm/hex_core/blob/main/src/hex_tarball.erl
+var (
+	namePattern    = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+	versionPattern = regexp.MustCompile(`\A(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?\z`)
+)
+
+// Package represents a Hex package
+type Package struct {
+	Name          string
+	Version       string
+	InnerChecksum string
+	Metadata      *Metadata
+}
+
+// Metadata represents the metadata of a Hex package
+type Metadata struct {
+	App          string            `json:"app,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Licenses     []string          `json:"licenses,omitempty"`
+	Links        map[string]string `json:"links,omitempty"`
+	BuildTools   []string          `json:"build_tools,omitempty"`
+	Elixir       string            `json:"elixir,omitempty"`
+	Requirements []*Requirement    `json:"requirements,omitempty"`
+	Readme       string            `json:"readme,omitempty"`
+}
+
+// Requirement represents a dependency of a Hex package
+type Requirement struct {
+	Name        string `json:"name"`
+	App         string `json:"app,omitempty"`
+	Requirement string `json:"requirement"`
+	Optional    bool   `json:"optional,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+}
+
+// ParsePackage parses the Hex package tarball.
+// The tarball contains the files VERSION, CHECKSUM, metadata.config and contents.tar.gz.
+// The inner checksum is the SHA256 of the VERSION, metadata.config and contents.tar.gz files in this order.
+// https://github.com/hexpm/specifications/blob/main/package_tarball.md
+func ParsePackage(r io.Reader) (*Package, error) {
+	var tarballVersionContent, metadataContent []byte
+	var checksum string
+	var readme string
+	hasContents := false
+
+	h := sha256.New()
+
+	tr := tar.NewReader(r)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidTarball
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch hd.Name {
+		case "VERSION":
+			tarballVersionContent, err = io.ReadAll(io.LimitReader(tr, 10))
+			if err != nil {
+				return nil, err
+			}
+			if string(tarballVersionContent) != tarballVersion {
+				return nil, ErrUnsupportedVersion
+			}
+		case "CHECKSUM":
+			content, err := io.ReadAll(io.LimitReader(tr, 100))
+			if err != nil {
+				return nil, err
+			}
+			checksum = strings.ToLower(strings.TrimSpace(string(content)))
+		case "metadata.config":
+			if hd.Size > maxMetadataFileSize {
+				return nil, ErrMetadataFileTooLarge
+			}
+			metadataContent, err = io.ReadAll(io.LimitReader(tr, maxMetadataFileSize))
+			if err != nil {
+				return nil, err
+			}
+		case "contents.tar.gz":
+			if tarballVersionContent == nil || metadataContent == nil {
+				return nil, ErrInvalidTarball
+			}
+
+			h.Write(tarballVersionContent)
+			h.Write(metadataContent)
+
+			readme, err = readReadme(io.TeeReader(tr, h))
+			if err != nil {
+				return nil, err
+			}
+			if _, err := io.Copy(h, tr); err != nil {
+				return nil, err
+			}
+			hasContents = true
+		}
+	}
+
+	if tarballVersionContent == nil {
+		return nil, ErrInvalidTarball
+	}
+	if metadataContent == nil {
+		return nil, ErrMissingMetadataFile
+	}
+	if !hasContents {
+		return nil, ErrMissingContentsFile
+	}
+
+	innerChecksum := hex.EncodeToString(h.Sum(nil))
+	if checksum != "" && checksum != innerChecksum {
+		return nil, ErrChecksumMismatch
+	}
+
+	p, err := ParseMetadata(metadataContent)
+	if err != nil {
+		return nil, err
+	}
+
+	p.InnerChecksum = innerChecksum
+	p.Metadata.Readme = readme
+
+	return p, nil
+}
+
+// readReadme reads the README.md file from the gzipped contents archive
+func readReadme(r io.Reader) (string, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return "", ErrInvalidTarball
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			return "", nil
+		}
+		if err != nil {
+			return "", ErrInvalidTarball
+		}
+
+		if hd.Typeflag == tar.TypeReg && strings.EqualFold(hd.Name, "readme.md") {
+			if hd.Size > maxReadmeFileSize {
+				return "", nil
+			}
+			data, err := io.ReadAll(io.LimitReader(tr, maxReadmeFileSize))
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		}
+	}
+}
+
+// ParseMetadata parses the metadata.config file which contains Erlang terms
+func ParseMetadata(data []byte) (*Package, error) {
+	terms, err := parseConsult(string(bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})))
+	if err != nil {
+		return nil, ErrInvalidMetadataFile
+	}
+
+	fields := make(map[string]any, len(terms))
+	for _, term := range terms {
+		t, ok := term.(Tuple)
+		if !ok || len(t) != 2 {
+			return nil, ErrInvalidMetadataFile
+		}
+		key, ok := termToString(t[0])
+		if !ok {
+			return nil, ErrInvalidMetadataFile
+		}
+		fields[key] = t[1]
+	}
+
+	name, _ := termToString(fields["name"])
+	if !namePattern.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+
+	versionString, _ := termToString(fields["version"])
+	if !versionPattern.MatchString(versionString) {
+		return nil, ErrInvalidVersion
+	}
+
+	m := &Metadata{
+		Links: make(map[string]string),
+	}
+	m.App, _ = termToString(fields["app"])
+	m.Description, _ = termToString(fields["description"])
+	m.Elixir, _ = termToString(fields["elixir"])
+	m.Licenses = termToStrings(fields["licenses"])
+	m.BuildTools = termToStrings(fields["build_tools"])
+
+	if links, ok := termToMap(fields["links"]); ok {
+		for k, v := range links {
+			if link, ok := termToString(v); ok && validation.IsValidURL(link) {
+				m.Links[k] = link
+			}
+		}
+	}
+
+	if m.Requirements, err = parseRequirements(fields["requirements"]); err != nil {
+		return nil, err
+	}
+
+	return &Package{
+		Name:     name,
+		Version:  versionString,
+		Metadata: m,
+	}, nil
+}
+
+// parseRequirements parses the requirements which are either a list of property lists or maps (current format)
+// or a map or property list keyed by the name of the dependency (old format)
+func parseRequirements(term any) ([]*Requirement, error) {
+	if term == nil {
+		return nil, nil
+	}
+
+	type entry struct {
+		name   string
+		fields map[string]any
+	}
+
+	var entries []entry
+	if list, ok := term.([]any); ok && (len(list) == 0 || !isTuple(list[0])) {
+		for _, e := range list {
+			fields, ok := termToMap(e)
+			if !ok {
+				return nil, ErrInvalidRequirement
+			}
+			name, _ := termToString(fields["name"])
+			entries = append(entries, entry{name, fields})
+		}
+	} else {
+		m, ok := termToMap(term)
+		if !ok {
+			return nil, ErrInvalidRequirement
+		}
+		for name, v := range m {
+			fields, ok := termToMap(v)
+			if !ok {
+				return nil, ErrInvalidRequirement
+			}
+			entries = append(entries, entry{name, fields})
+		}
+	}
+
+	requirements := make([]*Requirement, 0, len(entries))
+	for _, e := range entries {
+		if !namePattern.MatchString(e.name) {
+			return nil, ErrInvalidRequirement
+		}
+
+		r := &Requirement{
+			Name: e.name,
+		}
+		r.App, _ = termToString(e.fields["app"])
+		r.Requirement, _ = termToString(e.fields["requirement"])
+		r.Optional, _ = e.fields["optional"].(bool)
+		r.Repository, _ = termToString(e.fields["repository"])
+
+		requirements = append(requirements, r)
+	}
+	slices.SortFunc(requirements, func(a, b *Requirement) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return requirements, nil
+}
+
+func isTuple(term any) bool {
+	_, ok := term.(Tuple)
+	return ok
+}
+
+func termToStrings(term any) []string {
+	list, ok := term.([]any)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(list))
+	for _, e := range list {
+		if s, ok := termToString(e); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
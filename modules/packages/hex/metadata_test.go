@@ -0,0 +1,223 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packageName    = "gitea_test"
+	packageVersion = "1.0.1-rc.1"
+	description    = "Package Description"
+	readme         = "# Gitea Test"
+)
+
+const metadataContent = `{<<"app">>,<<"` + packageName + `">>}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"description">>,<<"` + description + `"/utf8>>}.
+{<<"elixir">>,<<"~> 1.15">>}.
+{<<"files">>,[<<"lib">>,<<"lib/gitea_test.ex">>,<<"mix.exs">>,<<"README.md">>]}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"links">>,[{<<"GitHub">>,<<"https://gitea.com/gitea/test">>},{<<"Invalid">>,<<"not an url">>}]}.
+{<<"name">>,<<"` + packageName + `">>}.
+{<<"requirements">>,
+ [[{<<"name">>,<<"jason">>},
+   {<<"app">>,<<"jason">>},
+   {<<"optional">>,false},
+   {<<"requirement">>,<<"~> 1.4">>},
+   {<<"repository">>,<<"hexpm">>}],
+  [{<<"name">>,<<"decimal">>},
+   {<<"app">>,<<"decimal">>},
+   {<<"optional">>,true},
+   {<<"requirement">>,<<"~> 2.0">>}]]}.
+{<<"version">>,<<"` + packageVersion + `">>}.
+`
+
+func createTar(files [][2]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, file := range files {
+		hdr := &tar.Header{
+			Name: file[0],
+			Mode: 0o600,
+			Size: int64(len(file[1])),
+		}
+		tw.WriteHeader(hdr)
+		tw.Write([]byte(file[1]))
+	}
+	tw.Close()
+	return buf.Bytes()
+}
+
+func createContents() string {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(createTar([][2]string{
+		{"lib/gitea_test.ex", "defmodule GiteaTest do\nend\n"},
+		{"README.md", readme},
+	}))
+	zw.Close()
+	return buf.String()
+}
+
+func createTarball(metadata, contents, checksum string) []byte {
+	if checksum == "" {
+		sum := sha256.Sum256([]byte(tarballVersion + metadata + contents))
+		checksum = strings.ToUpper(hex.EncodeToString(sum[:]))
+	}
+
+	return createTar([][2]string{
+		{"VERSION", tarballVersion},
+		{"CHECKSUM", checksum},
+		{"metadata.config", metadata},
+		{"contents.tar.gz", contents},
+	})
+}
+
+func TestParsePackage(t *testing.T) {
+	contents := createContents()
+
+	t.Run("InvalidTarball", func(t *testing.T) {
+		p, err := ParsePackage(bytes.NewReader([]byte("invalid")))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidTarball)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		data := createTar([][2]string{{"VERSION", "2"}})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("MissingMetadataFile", func(t *testing.T) {
+		data := createTar([][2]string{{"VERSION", tarballVersion}})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingMetadataFile)
+	})
+
+	t.Run("MissingContentsFile", func(t *testing.T) {
+		data := createTar([][2]string{{"VERSION", tarballVersion}, {"metadata.config", metadataContent}})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingContentsFile)
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		data := createTarball(metadataContent, contents, strings.Repeat("A", 64))
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+	})
+
+	t.Run("InvalidMetadataFile", func(t *testing.T) {
+		data := createTarball(`{<<"name">>,<<"test">>`, contents, "")
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidMetadataFile)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		data := createTarball(metadataContent, contents, "")
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		sum := sha256.Sum256([]byte(tarballVersion + metadataContent + contents))
+
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, hex.EncodeToString(sum[:]), p.InnerChecksum)
+		assert.Equal(t, packageName, p.Metadata.App)
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+		assert.Equal(t, []string{"mix"}, p.Metadata.BuildTools)
+		assert.Equal(t, "~> 1.15", p.Metadata.Elixir)
+		assert.Equal(t, map[string]string{"GitHub": "https://gitea.com/gitea/test"}, p.Metadata.Links)
+		assert.Equal(t, readme, p.Metadata.Readme)
+		assert.Equal(t, []*Requirement{
+			{Name: "decimal", App: "decimal", Requirement: "~> 2.0", Optional: true},
+			{Name: "jason", App: "jason", Requirement: "~> 1.4", Repository: "hexpm"},
+		}, p.Metadata.Requirements)
+	})
+}
+
+func TestParseMetadata(t *testing.T) {
+	t.Run("InvalidName", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`{<<"name">>,<<"Invalid-Name">>}. {<<"version">>,<<"1.0.0">>}.`))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidName)
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`{<<"name">>,<<"test">>}. {<<"version">>,<<"1.0">>}.`))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("RequirementsMap", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`% comment
+{<<"name">>,<<"test">>}.
+{<<"version">>,<<"1.0.0">>}.
+{<<"requirements">>,#{<<"jason">> => #{<<"app">> => <<"jason">>,<<"optional">> => false,<<"requirement">> => <<"~> 1.4">>}}}.`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Requirement{{Name: "jason", App: "jason", Requirement: "~> 1.4"}}, p.Metadata.Requirements)
+	})
+
+	t.Run("RequirementsProplist", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`{<<"name">>,<<"test">>}.
+{<<"version">>,<<"1.0.0">>}.
+{<<"requirements">>,[{<<"jason">>,[{<<"app">>,<<"jason">>},{<<"optional">>,true},{<<"requirement">>,<<"~> 1.4">>}]}]}.`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Requirement{{Name: "jason", App: "jason", Requirement: "~> 1.4", Optional: true}}, p.Metadata.Requirements)
+	})
+
+	t.Run("Strings", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`{<<"name">>,<<"test">>}.
+{<<"version">>,<<"1.0.0">>}.
+{<<"description">>,<<"Caf\x{E9} \"quoted\""/utf8>>}.
+{<<"licenses">>,["Apache-2.0", <<77,73,84>>]}.`))
+		require.NoError(t, err)
+		assert.Equal(t, `Café "quoted"`, p.Metadata.Description)
+		assert.Equal(t, []string{"Apache-2.0", "MIT"}, p.Metadata.Licenses)
+	})
+}
+
+func TestEncodeTerm(t *testing.T) {
+	data, err := EncodeTerm(map[string]any{
+		"b": []any{int64(1), 300, true, nil},
+		"a": "x",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		131, 116, 0, 0, 0, 2,
+		109, 0, 0, 0, 1, 'a', 109, 0, 0, 0, 1, 'x',
+		109, 0, 0, 0, 1, 'b', 108, 0, 0, 0, 4,
+		97, 1,
+		98, 0, 0, 1, 44,
+		119, 4, 't', 'r', 'u', 'e',
+		119, 3, 'n', 'i', 'l',
+		106,
+	}, data)
+
+	_, err = EncodeTerm(map[string]any{"a": struct{}{}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The registry resources are protobuf messages which are signed and gzipped.
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+// https://github.com/hexpm/hex_core/tree/main/proto
+
+// NamesPackage is an entry of the /names resource
+type NamesPackage struct {
+	Name string
+}
+
+// VersionsPackage is an entry of the /versions resource
+type VersionsPackage struct {
+	Name     string
+	Versions []string
+}
+
+// Release is a release of the /packages/<name> resource
+type Release struct {
+	Version       string
+	InnerChecksum []byte
+	OuterChecksum []byte
+	Dependencies  []*Requirement
+}
+
+// EncodeNames encodes the Names message
+func EncodeNames(repository string, packages []*NamesPackage) []byte {
+	var b []byte
+	for _, p := range packages {
+		var pb []byte
+		pb = appendString(pb, 1, p.Name)
+		b = appendBytes(b, 1, pb)
+	}
+	return appendString(b, 2, repository)
+}
+
+// EncodeVersions encodes the Versions message
+func EncodeVersions(repository string, packages []*VersionsPackage) []byte {
+	var b []byte
+	for _, p := range packages {
+		var pb []byte
+		pb = appendString(pb, 1, p.Name)
+		for _, v := range p.Versions {
+			pb = appendString(pb, 2, v)
+		}
+		b = appendBytes(b, 1, pb)
+	}
+	return appendString(b, 2, repository)
+}
+
+// EncodePackage encodes the Package message
+func EncodePackage(repository, name string, releases []*Release) []byte {
+	var b []byte
+	for _, r := range releases {
+		var rb []byte
+		rb = appendString(rb, 1, r.Version)
+		rb = appendBytes(rb, 2, r.InnerChecksum)
+		for _, dep := range r.Dependencies {
+			var db []byte
+			db = appendString(db, 1, dep.Name)
+			db = appendString(db, 2, dep.Requirement)
+			if dep.Optional {
+				db = protowire.AppendTag(db, 3, protowire.VarintType)
+				db = protowire.AppendVarint(db, 1)
+			}
+			if dep.App != "" {
+				db = appendString(db, 4, dep.App)
+			}
+			if dep.Repository != "" {
+				db = appendString(db, 5, dep.Repository)
+			}
+			rb = appendBytes(rb, 3, db)
+		}
+		if len(r.OuterChecksum) > 0 {
+			rb = appendBytes(rb, 5, r.OuterChecksum)
+		}
+		b = appendBytes(b, 1, rb)
+	}
+	b = appendString(b, 2, name)
+	return appendString(b, 3, repository)
+}
+
+// SignAndCompress wraps the payload in a Signed message and gzips it.
+// The signature is a RSA PKCS #1 v1.5 signature of the SHA-512 hash of the payload.
+func SignAndCompress(payload []byte, key *rsa.PrivateKey) ([]byte, error) {
+	hash := sha512.Sum512(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var signed []byte
+	signed = appendBytes(signed, 1, payload)
+	signed = appendBytes(signed, 2, signature)
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(signed); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
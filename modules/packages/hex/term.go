@@ -0,0 +1,365 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var errInvalidTerm = errors.New("invalid term")
+
+// Atom is an Erlang atom
+type Atom string
+
+// Tuple is an Erlang tuple
+type Tuple []any
+
+// parseConsult parses a sequence of Erlang terms which are terminated by a dot, like the files read by file:consult/1.
+// Binaries and strings are returned as string, lists as []any and maps as map[string]any.
+// Only the subset of the term syntax used by the metadata of Hex packages is supported.
+func parseConsult(data string) ([]any, error) {
+	p := &termParser{data: data}
+
+	var terms []any
+	for {
+		p.skipWhitespace()
+		if p.pos >= len(p.data) {
+			return terms, nil
+		}
+
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		p.skipWhitespace()
+		if !p.consume(".") {
+			return nil, p.error("expected dot")
+		}
+		terms = append(terms, term)
+	}
+}
+
+type termParser struct {
+	data string
+	pos  int
+}
+
+func (p *termParser) error(message string) error {
+	return fmt.Errorf("%w at position %d: %s", errInvalidTerm, p.pos, message)
+}
+
+func (p *termParser) skipWhitespace() {
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+				p.pos++
+			}
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *termParser) consume(s string) bool {
+	p.skipWhitespace()
+	if strings.HasPrefix(p.data[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *termParser) parseTerm() (any, error) {
+	p.skipWhitespace()
+	if p.pos >= len(p.data) {
+		return nil, p.error("unexpected end")
+	}
+
+	switch c := p.data[p.pos]; {
+	case strings.HasPrefix(p.data[p.pos:], "<<"):
+		return p.parseBinary()
+	case strings.HasPrefix(p.data[p.pos:], "#{"):
+		return p.parseMap()
+	case c == '{':
+		p.pos++
+		elements, err := p.parseSequence("}")
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(elements), nil
+	case c == '[':
+		p.pos++
+		return p.parseSequence("]")
+	case c == '"':
+		return p.parseQuoted('"')
+	case c == '\'':
+		s, err := p.parseQuoted('\'')
+		if err != nil {
+			return nil, err
+		}
+		return Atom(s), nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for p.pos < len(p.data) && isAtomChar(p.data[p.pos]) {
+			p.pos++
+		}
+		switch atom := p.data[start:p.pos]; atom {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		default:
+			return Atom(atom), nil
+		}
+	}
+	return nil, p.error("unexpected character")
+}
+
+func isAtomChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '@'
+}
+
+func (p *termParser) parseSequence(end string) ([]any, error) {
+	elements := make([]any, 0, 5)
+	if p.consume(end) {
+		return elements, nil
+	}
+	for {
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, term)
+
+		if p.consume(end) {
+			return elements, nil
+		}
+		if !p.consume(",") {
+			return nil, p.error("expected comma")
+		}
+	}
+}
+
+func (p *termParser) parseMap() (map[string]any, error) {
+	p.pos += 2
+
+	m := make(map[string]any)
+	if p.consume("}") {
+		return m, nil
+	}
+	for {
+		key, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume("=>") {
+			return nil, p.error("expected =>")
+		}
+		value, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		k, ok := termToString(key)
+		if !ok {
+			return nil, p.error("unsupported map key")
+		}
+		m[k] = value
+
+		if p.consume("}") {
+			return m, nil
+		}
+		if !p.consume(",") {
+			return nil, p.error("expected comma")
+		}
+	}
+}
+
+// parseBinary parses binaries like <<"text">>, <<"text"/utf8>> or <<1,2,3>>
+func (p *termParser) parseBinary() (string, error) {
+	p.pos += 2
+
+	var sb strings.Builder
+	if p.consume(">>") {
+		return "", nil
+	}
+	for {
+		p.skipWhitespace()
+		if p.pos < len(p.data) && p.data[p.pos] == '"' {
+			s, err := p.parseQuoted('"')
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+			if p.consume("/") {
+				if !p.consume("utf8") {
+					return "", p.error("unsupported binary segment type")
+				}
+			}
+		} else {
+			n, err := p.parseNumber()
+			if err != nil {
+				return "", err
+			}
+			i, ok := n.(int64)
+			if !ok || i < 0 || i > 255 {
+				return "", p.error("invalid byte")
+			}
+			sb.WriteByte(byte(i))
+		}
+
+		if p.consume(">>") {
+			return sb.String(), nil
+		}
+		if !p.consume(",") {
+			return "", p.error("expected comma")
+		}
+	}
+}
+
+func (p *termParser) parseQuoted(quote byte) (string, error) {
+	p.pos++
+
+	var sb strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+
+		switch c {
+		case quote:
+			return sb.String(), nil
+		case '\\':
+			if p.pos >= len(p.data) {
+				return "", p.error("unexpected end")
+			}
+			c = p.data[p.pos]
+			p.pos++
+			switch c {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 's':
+				sb.WriteByte(' ')
+			case 'e':
+				sb.WriteByte(0x1b)
+			case 'x':
+				if err := p.parseHexEscape(&sb); err != nil {
+					return "", err
+				}
+			default:
+				sb.WriteByte(c)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", p.error("unterminated string")
+}
+
+// parseHexEscape parses the \xHH and \x{H...} escape sequences
+func (p *termParser) parseHexEscape(sb *strings.Builder) error {
+	var digits string
+	if p.pos < len(p.data) && p.data[p.pos] == '{' {
+		end := strings.IndexByte(p.data[p.pos:], '}')
+		if end == -1 {
+			return p.error("invalid escape sequence")
+		}
+		digits = p.data[p.pos+1 : p.pos+end]
+		p.pos += end + 1
+	} else {
+		if p.pos+2 > len(p.data) {
+			return p.error("invalid escape sequence")
+		}
+		digits = p.data[p.pos : p.pos+2]
+		p.pos += 2
+	}
+
+	r, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || !utf8.ValidRune(rune(r)) {
+		return p.error("invalid escape sequence")
+	}
+	sb.WriteRune(rune(r))
+	return nil
+}
+
+func (p *termParser) parseNumber() (any, error) {
+	start := p.pos
+	if p.pos < len(p.data) && p.data[p.pos] == '-' {
+		p.pos++
+	}
+	isFloat := false
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if c >= '0' && c <= '9' || c == '_' {
+			p.pos++
+		} else if c == '.' && p.pos+1 < len(p.data) && p.data[p.pos+1] >= '0' && p.data[p.pos+1] <= '9' {
+			isFloat = true
+			p.pos++
+		} else if isFloat && (c == 'e' || c == 'E' || ((c == '-' || c == '+') && (p.data[p.pos-1] == 'e' || p.data[p.pos-1] == 'E'))) {
+			p.pos++
+		} else {
+			break
+		}
+	}
+
+	s := strings.ReplaceAll(p.data[start:p.pos], "_", "")
+	if isFloat {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, p.error("invalid float")
+		}
+		return f, nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, p.error("invalid integer")
+	}
+	return i, nil
+}
+
+// termToString returns the value of a binary, string or atom
+func termToString(term any) (string, bool) {
+	switch v := term.(type) {
+	case string:
+		return v, true
+	case Atom:
+		return string(v), true
+	}
+	return "", false
+}
+
+// termToMap converts a map or a property list of 2-tuples to a map
+func termToMap(term any) (map[string]any, bool) {
+	switch v := term.(type) {
+	case map[string]any:
+		return v, true
+	case []any:
+		m := make(map[string]any, len(v))
+		for _, e := range v {
+			t, ok := e.(Tuple)
+			if !ok || len(t) != 2 {
+				return nil, false
+			}
+			k, ok := termToString(t[0])
+			if !ok {
+				return nil, false
+			}
+			m[k] = t[1]
+		}
+		return m, true
+	}
+	return nil, false
+}
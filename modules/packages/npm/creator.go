@@ -195,41 +195,13 @@ func ParsePackage(r io.Reader) (*Package, error) {
 			return nil, ErrInvalidPackageVersion
 		}
 
-		scope := ""
-		name := meta.Name
-		nameParts := strings.SplitN(meta.Name, "/", 2)
-		if len(nameParts) == 2 {
-			scope = nameParts[0]
-			name = nameParts[1]
-		}
-
-		if !validation.IsValidURL(meta.Homepage) {
-			meta.Homepage = ""
-		}
-
 		p := &Package{
 			Name:     meta.Name,
 			Version:  v.String(),
 			DistTags: make([]string, 0, 1),
-			Metadata: Metadata{
-				Scope:                   scope,
-				Name:                    name,
-				Description:             meta.Description,
-				Author:                  meta.Author.Name,
-				License:                 meta.License,
-				ProjectURL:              meta.Homepage,
-				Keywords:                meta.Keywords,
-				Dependencies:            meta.Dependencies,
-				BundleDependencies:      meta.BundleDependencies,
-				DevelopmentDependencies: meta.DevDependencies,
-				PeerDependencies:        meta.PeerDependencies,
-				PeerDependenciesMeta:    meta.PeerDependenciesMeta,
-				OptionalDependencies:    meta.OptionalDependencies,
-				Bin:                     meta.Bin,
-				Readme:                  meta.Readme,
-				Repository:              meta.Repository,
-			},
+			Metadata: NewMetadata(meta),
 		}
+		name := p.Metadata.Name
 
 		for tag := range upload.DistTags {
 			p.DistTags = append(p.DistTags, tag)
@@ -280,6 +252,41 @@ func ParsePackage(r io.Reader) (*Package, error) {
 	return nil, ErrInvalidPackage
 }
 
+// NewMetadata creates the metadata stored for a package version
+func NewMetadata(meta *PackageMetadataVersion) Metadata {
+	scope := ""
+	name := meta.Name
+	nameParts := strings.SplitN(meta.Name, "/", 2)
+	if len(nameParts) == 2 {
+		scope = nameParts[0]
+		name = nameParts[1]
+	}
+
+	projectURL := meta.Homepage
+	if !validation.IsValidURL(projectURL) {
+		projectURL = ""
+	}
+
+	return Metadata{
+		Scope:                   scope,
+		Name:                    name,
+		Description:             meta.Description,
+		Author:                  meta.Author.Name,
+		License:                 meta.License,
+		ProjectURL:              projectURL,
+		Keywords:                meta.Keywords,
+		Dependencies:            meta.Dependencies,
+		BundleDependencies:      meta.BundleDependencies,
+		DevelopmentDependencies: meta.DevDependencies,
+		PeerDependencies:        meta.PeerDependencies,
+		PeerDependenciesMeta:    meta.PeerDependenciesMeta,
+		OptionalDependencies:    meta.OptionalDependencies,
+		Bin:                     meta.Bin,
+		Readme:                  meta.Readme,
+		Repository:              meta.Repository,
+	}
+}
+
 func validateName(name string) bool {
 	if strings.TrimSpace(name) != name {
 		return false
@@ -0,0 +1,220 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package osv
+
+import (
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/hashicorp/go-version"
+)
+
+// https://ossf.github.io/osv-schema/
+
+// The ecosystems of the package types which are scanned
+const (
+	EcosystemCargo    = "crates.io"
+	EcosystemComposer = "Packagist"
+	EcosystemMaven    = "Maven"
+	EcosystemNpm      = "npm"
+	EcosystemPyPI     = "PyPI"
+)
+
+var ErrInvalidEntry = util.NewInvalidArgumentErrorf("vulnerability entry is invalid")
+
+// Entry represents a vulnerability in the OSV format
+type Entry struct {
+	ID               string      `json:"id"`
+	Summary          string      `json:"summary"`
+	Details          string      `json:"details"`
+	Aliases          []string    `json:"aliases"`
+	Modified         time.Time   `json:"modified"`
+	Published        time.Time   `json:"published"`
+	Withdrawn        *time.Time  `json:"withdrawn"`
+	Affected         []*Affected `json:"affected"`
+	DatabaseSpecific struct {
+		Severity any `json:"severity"`
+	} `json:"database_specific"`
+}
+
+// Severity returns the severity assigned by the database (for example "HIGH") if available
+func (e *Entry) Severity() string {
+	if s, ok := e.DatabaseSpecific.Severity.(string); ok {
+		return strings.ToUpper(s)
+	}
+	return ""
+}
+
+// Affected describes the affected versions of a package
+type Affected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges   []*Range `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Range describes a range of affected versions by a list of events
+type Range struct {
+	Type   string   `json:"type"`
+	Events []*Event `json:"events"`
+}
+
+// Event marks the start or end of an affected version range
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// ParseEntry parses a vulnerability in the OSV format
+func ParseEntry(r io.Reader) (*Entry, error) {
+	var e Entry
+	if err := json.NewDecoder(r).Decode(&e); err != nil {
+		return nil, err
+	}
+	if e.ID == "" {
+		return nil, ErrInvalidEntry
+	}
+	return &e, nil
+}
+
+var pypiNameReplacer = regexp.MustCompile(`[-_.]+`)
+
+// NormalizeName normalizes the package name according to the rules of the ecosystem
+func NormalizeName(ecosystem, name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if ecosystem == EcosystemPyPI {
+		// https://peps.python.org/pep-0503/#normalized-names
+		name = pypiNameReplacer.ReplaceAllString(name, "-")
+	}
+	return name
+}
+
+// IsVersionAffected checks if the version of the package is affected
+func (a *Affected) IsVersionAffected(v string) bool {
+	if slices.Contains(a.Versions, v) {
+		return true
+	}
+
+	ver, err := version.NewVersion(v)
+	if err != nil {
+		return false
+	}
+
+	for _, r := range a.Ranges {
+		// git commit ranges can't be compared with package versions
+		if r.Type == "GIT" {
+			continue
+		}
+		if r.isVersionAffected(ver) {
+			return true
+		}
+	}
+	return false
+}
+
+type parsedEvent struct {
+	*Event
+	version *version.Version // nil represents the lowest possible version "0"
+}
+
+func (r *Range) isVersionAffected(ver *version.Version) bool {
+	events := make([]*parsedEvent, 0, len(r.Events))
+	for _, e := range r.Events {
+		s := e.Introduced + e.Fixed + e.LastAffected + e.Limit
+		if s == "0" {
+			events = append(events, &parsedEvent{Event: e})
+			continue
+		}
+		v, err := version.NewVersion(s)
+		if err != nil {
+			return false
+		}
+		events = append(events, &parsedEvent{Event: e, version: v})
+	}
+
+	slices.SortStableFunc(events, func(a, b *parsedEvent) int {
+		switch {
+		case a.version == nil && b.version == nil:
+			return 0
+		case a.version == nil:
+			return -1
+		case b.version == nil:
+			return 1
+		}
+		return a.version.Compare(b.version)
+	})
+
+	affected := false
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			if e.version == nil || ver.GreaterThanOrEqual(e.version) {
+				affected = true
+			}
+		case e.Fixed != "", e.Limit != "":
+			if e.version != nil && ver.GreaterThanOrEqual(e.version) {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if e.version != nil && ver.GreaterThan(e.version) {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+var (
+	comparatorPattern   = regexp.MustCompile(`\A(\^|~>|~=|~|===|==|=|>=|v)?\s*([0-9][0-9A-Za-z.+*-]*)\z`)
+	comparatorSeparator = regexp.MustCompile(`[\s,]+`)
+	operatorSpacing     = regexp.MustCompile(`([<>=!~^])\s+`)
+)
+
+// MinimumVersion returns the lowest version allowed by a dependency version constraint.
+// An empty string is returned if the constraint has no (inclusive) lower bound.
+// Examples: "^1.2.3" => "1.2.3", ">=2.0, <3" => "2.0", "[1.0,2.0)" => "1.0", "1.4.x" => "1.4.0"
+func MinimumVersion(constraint string) string {
+	constraint = strings.TrimSpace(constraint)
+
+	// only the first alternative of "a || b" is considered
+	constraint, _, _ = strings.Cut(constraint, "||")
+
+	// Maven version ranges
+	if strings.HasPrefix(constraint, "[") {
+		lower, _, _ := strings.Cut(constraint[1:], ",")
+		constraint = strings.TrimSuffix(strings.TrimSpace(lower), "]")
+	}
+
+	constraint = operatorSpacing.ReplaceAllString(constraint, "$1")
+
+	for _, comparator := range comparatorSeparator.Split(strings.TrimSpace(constraint), -1) {
+		m := comparatorPattern.FindStringSubmatch(comparator)
+		if m == nil {
+			continue
+		}
+
+		parts := strings.Split(m[2], ".")
+		for i, p := range parts {
+			if p == "x" || p == "X" || p == "*" {
+				parts[i] = "0"
+			}
+		}
+		v := strings.Join(parts, ".")
+		if _, err := version.NewVersion(v); err != nil {
+			return ""
+		}
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package osv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEntry(t *testing.T) {
+	_, err := ParseEntry(strings.NewReader(`{}`))
+	assert.ErrorIs(t, err, ErrInvalidEntry)
+
+	e, err := ParseEntry(strings.NewReader(`{"id":"GHSA-1234","summary":"Prototype Pollution","aliases":["CVE-2021-1234"],"modified":"2024-01-02T03:04:05Z","affected":[{"package":{"ecosystem":"npm","name":"lodash"},"ranges":[{"type":"SEMVER","events":[{"introduced":"0"},{"fixed":"4.17.21"}]}]}],"database_specific":{"severity":"high"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "GHSA-1234", e.ID)
+	assert.Equal(t, "Prototype Pollution", e.Summary)
+	assert.Equal(t, []string{"CVE-2021-1234"}, e.Aliases)
+	assert.Equal(t, "HIGH", e.Severity())
+	assert.Nil(t, e.Withdrawn)
+	assert.Len(t, e.Affected, 1)
+	assert.Equal(t, "lodash", e.Affected[0].Package.Name)
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "django-rest-framework", NormalizeName(EcosystemPyPI, "Django_REST.framework"))
+	assert.Equal(t, "@scope/package", NormalizeName(EcosystemNpm, "@Scope/Package"))
+}
+
+func TestIsVersionAffected(t *testing.T) {
+	a := &Affected{
+		Ranges: []*Range{
+			{
+				Type: "ECOSYSTEM",
+				Events: []*Event{
+					{Fixed: "1.5.0"},
+					{Introduced: "0"},
+					{Introduced: "2.0.0"},
+					{LastAffected: "2.3.1"},
+				},
+			},
+			{
+				Type:   "GIT",
+				Events: []*Event{{Introduced: "0"}},
+			},
+		},
+		Versions: []string{"3.0.0-special"},
+	}
+
+	cases := map[string]bool{
+		"0.1.0":         true,
+		"1.4.9":         true,
+		"1.5.0":         false,
+		"1.9.0":         false,
+		"2.0.0":         true,
+		"2.3.1":         true,
+		"2.3.2":         false,
+		"3.0.0-special": true,
+		"invalid":       false,
+	}
+	for v, expected := range cases {
+		assert.Equal(t, expected, a.IsVersionAffected(v), "version %s", v)
+	}
+}
+
+func TestMinimumVersion(t *testing.T) {
+	cases := map[string]string{
+		"1.2.3":                            "1.2.3",
+		"^1.2.3":                           "1.2.3",
+		"~1.2":                             "1.2",
+		">= 2.0, < 3":                      "2.0",
+		"<3 >=2.1":                         "2.1",
+		"~=1.4":                            "1.4",
+		"==2.31.0":                         "2.31.0",
+		"1.4.x":                            "1.4.0",
+		"[1.0,2.0)":                        "1.0",
+		"(1.0,2.0)":                        "",
+		"1.0.0 || ^2.0.0":                  "1.0.0",
+		"v1.0.0":                           "1.0.0",
+		">1.0":                             "",
+		"*":                                "",
+		"latest":                           "",
+		"${project.version}":               "",
+		"git+https://example.com/repo.git": "",
+	}
+	for constraint, expected := range cases {
+		assert.Equal(t, expected, MinimumVersion(constraint), "constraint %s", constraint)
+	}
+}
@@ -3,13 +3,92 @@
 
 package pypi
 
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/mail"
+	"path"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	// ErrMissingMetadataFile indicates a missing METADATA file
+	ErrMissingMetadataFile = util.NewInvalidArgumentErrorf("METADATA file is missing")
+	// ErrMetadataFileTooLarge indicates a METADATA file which is too large
+	ErrMetadataFileTooLarge = util.NewInvalidArgumentErrorf("METADATA file is too large")
+)
+
+const (
+	// PropertyYanked is the version property which marks a yanked version, the value contains the reason
+	PropertyYanked = "pypi.yanked"
+
+	// MetadataFileSuffix is appended to the name of a distribution file to get the name of its core metadata file
+	// https://peps.python.org/pep-0658/
+	MetadataFileSuffix = ".metadata"
+)
+
+const maxMetadataFileSize = 3 * 1024 * 1024
+
 // Metadata represents the metadata of a PyPI package
 type Metadata struct {
-	Author          string `json:"author,omitempty"`
-	Description     string `json:"description,omitempty"`
-	LongDescription string `json:"long_description,omitempty"`
-	Summary         string `json:"summary,omitempty"`
-	ProjectURL      string `json:"project_url,omitempty"`
-	License         string `json:"license,omitempty"`
-	RequiresPython  string `json:"requires_python,omitempty"`
+	Author          string   `json:"author,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	LongDescription string   `json:"long_description,omitempty"`
+	Summary         string   `json:"summary,omitempty"`
+	ProjectURL      string   `json:"project_url,omitempty"`
+	License         string   `json:"license,omitempty"`
+	RequiresPython  string   `json:"requires_python,omitempty"`
+	RequiresDist    []string `json:"requires_dist,omitempty"`
+}
+
+// CoreMetadata represents the core metadata file of a wheel
+type CoreMetadata struct {
+	Content        []byte
+	RequiresPython string
+}
+
+// ExtractWheelMetadata extracts the {name}-{version}.dist-info/METADATA file of a wheel
+// https://packaging.python.org/en/latest/specifications/binary-distribution-format/
+func ExtractWheelMetadata(r io.ReaderAt, size int64) (*CoreMetadata, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range archive.File {
+		dir, name := path.Split(file.Name)
+		if name != "METADATA" || strings.Count(dir, "/") != 1 || !strings.HasSuffix(dir, ".dist-info/") {
+			continue
+		}
+
+		if file.UncompressedSize64 > maxMetadataFileSize {
+			return nil, ErrMetadataFileTooLarge
+		}
+
+		f, err := archive.Open(file.Name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		content, err := io.ReadAll(io.LimitReader(f, maxMetadataFileSize))
+		if err != nil {
+			return nil, err
+		}
+
+		cm := &CoreMetadata{
+			Content: content,
+		}
+
+		// The metadata uses the email header format. The body contains the description and is not needed.
+		if msg, err := mail.ReadMessage(bytes.NewReader(content)); err == nil {
+			cm.RequiresPython = msg.Header.Get("Requires-Python")
+		}
+
+		return cm, nil
+	}
+	return nil, ErrMissingMetadataFile
 }
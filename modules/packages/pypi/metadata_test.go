@@ -0,0 +1,67 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const metadataContent = `Metadata-Version: 2.1
+Name: test-package
+Version: 1.0.1
+Requires-Python: >=3.8
+Requires-Dist: requests
+
+Test Description`
+
+func TestExtractWheelMetadata(t *testing.T) {
+	createArchive := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		archive := zip.NewWriter(&buf)
+		for name, content := range files {
+			w, _ := archive.Create(name)
+			_, _ = w.Write([]byte(content))
+		}
+		_ = archive.Close()
+		return buf.Bytes()
+	}
+
+	t.Run("InvalidArchive", func(t *testing.T) {
+		data := []byte("test")
+
+		cm, err := ExtractWheelMetadata(bytes.NewReader(data), int64(len(data)))
+		assert.Error(t, err)
+		assert.Nil(t, cm)
+	})
+
+	t.Run("MissingMetadataFile", func(t *testing.T) {
+		data := createArchive(map[string]string{
+			"test_package/__init__.py": "",
+			"METADATA":                 metadataContent,
+			"sub/test_package-1.0.1.dist-info/METADATA": metadataContent,
+		})
+
+		cm, err := ExtractWheelMetadata(bytes.NewReader(data), int64(len(data)))
+		assert.ErrorIs(t, err, ErrMissingMetadataFile)
+		assert.Nil(t, cm)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		data := createArchive(map[string]string{
+			"test_package/__init__.py":              "",
+			"test_package-1.0.1.dist-info/METADATA": metadataContent,
+			"test_package-1.0.1.dist-info/RECORD":   "",
+		})
+
+		cm, err := ExtractWheelMetadata(bytes.NewReader(data), int64(len(data)))
+		assert.NoError(t, err)
+		assert.NotNil(t, cm)
+		assert.Equal(t, metadataContent, string(cm.Content))
+		assert.Equal(t, ">=3.8", cm.RequiresPython)
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/hex"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrInvalidArchive      = util.NewInvalidArgumentErrorf("module archive is invalid")
+	ErrInvalidName         = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidFilename     = util.NewInvalidArgumentErrorf("provider filename is invalid")
+	ErrInvalidChecksumFile = util.NewInvalidArgumentErrorf("checksum file is invalid")
+	ErrInvalidManifest     = util.NewInvalidArgumentErrorf("provider manifest is invalid")
+)
+
+const (
+	KindModule   = "module"
+	KindProvider = "provider"
+
+	PropertyOS         = "terraform.os"
+	PropertyArch       = "terraform.arch"
+	PropertyProtocols  = "terraform.protocols"
+	PropertySigningKey = "terraform.signing_key"
+
+	// DefaultProtocols is used if a provider version has no manifest
+	DefaultProtocols = "5.0"
+)
+
+const maxReadmeFileSize = 1024 * 1024
+
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol
+var (
+	moduleNamePattern   = regexp.MustCompile(`\A[0-9A-Za-z](?:[0-9A-Za-z-_]{0,62}[0-9A-Za-z])?\z`)
+	moduleSystemPattern = regexp.MustCompile(`\A[0-9a-z]{1,64}\z`)
+	providerTypePattern = regexp.MustCompile(`\A[0-9a-z](?:[0-9a-z-]{0,62}[0-9a-z])?\z`)
+	platformPattern     = regexp.MustCompile(`\A[0-9a-z]+\z`)
+)
+
+// Metadata represents the metadata of a Terraform module or provider
+type Metadata struct {
+	Kind   string `json:"kind"`
+	Readme string `json:"readme,omitempty"`
+}
+
+// ModulePackageName returns the package name of a module
+func ModulePackageName(name, system string) (string, error) {
+	if !moduleNamePattern.MatchString(name) || !moduleSystemPattern.MatchString(system) {
+		return "", ErrInvalidName
+	}
+	return name + "/" + system, nil
+}
+
+// IsValidProviderType checks if the provider type is valid
+func IsValidProviderType(providerType string) bool {
+	return providerTypePattern.MatchString(providerType)
+}
+
+// ParseModuleArchive parses a gzipped tar archive of a module
+func ParseModuleArchive(r io.Reader) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+	defer gzr.Close()
+
+	metadata := &Metadata{
+		Kind: KindModule,
+	}
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if strings.EqualFold(path.Clean(hd.Name), "README.md") {
+			data, err := io.ReadAll(io.LimitReader(tr, maxReadmeFileSize))
+			if err != nil {
+				return nil, err
+			}
+			metadata.Readme = string(data)
+		}
+	}
+
+	return metadata, nil
+}
+
+// ProviderFileType is the type of file of a provider release
+type ProviderFileType int
+
+const (
+	ProviderFilePackage ProviderFileType = iota
+	ProviderFileChecksums
+	ProviderFileSignature
+	ProviderFileManifest
+)
+
+// ProviderFile describes a file of a provider release
+type ProviderFile struct {
+	Type ProviderFileType
+	OS   string
+	Arch string
+}
+
+// ProviderFilePrefix returns the prefix of all files of a provider release
+func ProviderFilePrefix(providerType, version string) string {
+	return "terraform-provider-" + providerType + "_" + version + "_"
+}
+
+// ParseProviderFilename parses the filename of a file of a provider release.
+// The names follow the conventions of the release tooling used by the provider ecosystem:
+// terraform-provider-{type}_{version}_{os}_{arch}.zip, terraform-provider-{type}_{version}_SHA256SUMS,
+// terraform-provider-{type}_{version}_SHA256SUMS.sig and terraform-provider-{type}_{version}_manifest.json
+func ParseProviderFilename(providerType, version, filename string) (*ProviderFile, error) {
+	rest, ok := strings.CutPrefix(filename, ProviderFilePrefix(providerType, version))
+	if !ok {
+		return nil, ErrInvalidFilename
+	}
+
+	switch rest {
+	case "SHA256SUMS":
+		return &ProviderFile{Type: ProviderFileChecksums}, nil
+	case "SHA256SUMS.sig":
+		return &ProviderFile{Type: ProviderFileSignature}, nil
+	case "manifest.json":
+		return &ProviderFile{Type: ProviderFileManifest}, nil
+	}
+
+	platform, ok := strings.CutSuffix(rest, ".zip")
+	if !ok {
+		return nil, ErrInvalidFilename
+	}
+	goos, goarch, ok := strings.Cut(platform, "_")
+	if !ok || !platformPattern.MatchString(goos) || !platformPattern.MatchString(goarch) {
+		return nil, ErrInvalidFilename
+	}
+	return &ProviderFile{Type: ProviderFilePackage, OS: goos, Arch: goarch}, nil
+}
+
+// ParseChecksums parses a SHA256SUMS file and returns the checksums by filename
+func ParseChecksums(r io.Reader) (map[string]string, error) {
+	checksums := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, ErrInvalidChecksumFile
+		}
+		if checksum, err := hex.DecodeString(fields[0]); err != nil || len(checksum) != 32 {
+			return nil, ErrInvalidChecksumFile
+		}
+		checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(checksums) == 0 {
+		return nil, ErrInvalidChecksumFile
+	}
+	return checksums, nil
+}
+
+type manifest struct {
+	Version  int `json:"version"`
+	Metadata struct {
+		ProtocolVersions []string `json:"protocol_versions"`
+	} `json:"metadata"`
+}
+
+// ParseManifest parses the manifest of a provider release and returns the supported protocol versions
+func ParseManifest(r io.Reader) ([]string, error) {
+	var m manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, ErrInvalidManifest
+	}
+	if m.Version != 1 || len(m.Metadata.ProtocolVersions) == 0 {
+		return nil, ErrInvalidManifest
+	}
+	return m.Metadata.ProtocolVersions, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createArchive(files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range files {
+		hdr := &tar.Header{
+			Name: name,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		}
+		tw.WriteHeader(hdr)
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	zw.Close()
+	return &buf
+}
+
+func TestModulePackageName(t *testing.T) {
+	name, err := ModulePackageName("vpc", "aws")
+	assert.NoError(t, err)
+	assert.Equal(t, "vpc/aws", name)
+
+	for _, c := range [][2]string{{"-vpc", "aws"}, {"vpc", "AWS"}, {"vpc/x", "aws"}, {"", "aws"}, {"vpc", ""}} {
+		_, err := ModulePackageName(c[0], c[1])
+		assert.ErrorIs(t, err, ErrInvalidName, "%v", c)
+	}
+}
+
+func TestIsValidProviderType(t *testing.T) {
+	assert.True(t, IsValidProviderType("aws"))
+	assert.True(t, IsValidProviderType("my-provider"))
+	assert.False(t, IsValidProviderType("My"))
+	assert.False(t, IsValidProviderType("a_b"))
+	assert.False(t, IsValidProviderType("-a"))
+}
+
+func TestParseModuleArchive(t *testing.T) {
+	t.Run("InvalidArchive", func(t *testing.T) {
+		_, err := ParseModuleArchive(strings.NewReader("dummy"))
+		assert.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(createArchive(map[string]string{
+			"main.tf":     `resource "null_resource" "test" {}`,
+			"./README.md": "# Module",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, KindModule, metadata.Kind)
+		assert.Equal(t, "# Module", metadata.Readme)
+	})
+}
+
+func TestParseProviderFilename(t *testing.T) {
+	cases := map[string]*ProviderFile{
+		"terraform-provider-test_1.0.0_linux_amd64.zip":  {Type: ProviderFilePackage, OS: "linux", Arch: "amd64"},
+		"terraform-provider-test_1.0.0_darwin_arm64.zip": {Type: ProviderFilePackage, OS: "darwin", Arch: "arm64"},
+		"terraform-provider-test_1.0.0_SHA256SUMS":       {Type: ProviderFileChecksums},
+		"terraform-provider-test_1.0.0_SHA256SUMS.sig":   {Type: ProviderFileSignature},
+		"terraform-provider-test_1.0.0_manifest.json":    {Type: ProviderFileManifest},
+	}
+	for filename, expected := range cases {
+		pf, err := ParseProviderFilename("test", "1.0.0", filename)
+		assert.NoError(t, err, filename)
+		assert.Equal(t, expected, pf, filename)
+	}
+
+	for _, filename := range []string{
+		"terraform-provider-other_1.0.0_linux_amd64.zip",
+		"terraform-provider-test_1.0.1_linux_amd64.zip",
+		"terraform-provider-test_1.0.0_linux.zip",
+		"terraform-provider-test_1.0.0_linux_amd64.tar.gz",
+		"terraform-provider-test_1.0.0_Linux_amd64.zip",
+	} {
+		_, err := ParseProviderFilename("test", "1.0.0", filename)
+		assert.ErrorIs(t, err, ErrInvalidFilename, filename)
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	checksums, err := ParseChecksums(strings.NewReader(`
+e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  terraform-provider-test_1.0.0_linux_amd64.zip
+E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855 *terraform-provider-test_1.0.0_darwin_arm64.zip
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"terraform-provider-test_1.0.0_linux_amd64.zip":  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"terraform-provider-test_1.0.0_darwin_arm64.zip": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}, checksums)
+
+	for _, content := range []string{"", "abc  file.zip", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"} {
+		_, err := ParseChecksums(strings.NewReader(content))
+		assert.ErrorIs(t, err, ErrInvalidChecksumFile, content)
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	protocols, err := ParseManifest(strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["5.0","6.0"]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5.0", "6.0"}, protocols)
+
+	_, err = ParseManifest(strings.NewReader(`{"version":2,"metadata":{"protocol_versions":["5.0"]}}`))
+	assert.ErrorIs(t, err, ErrInvalidManifest)
+	_, err = ParseManifest(strings.NewReader(`invalid`))
+	assert.ErrorIs(t, err, ErrInvalidManifest)
+}
@@ -18,6 +18,9 @@ const (
 	GitPushOptionRepoPrivate  = "repo.private"
 	GitPushOptionRepoTemplate = "repo.template"
 	GitPushOptionForcePush    = "force-push"
+
+	// GitPushOptionSecretScanningBypass gives the reason to push the secrets found by the secret scanning anyway
+	GitPushOptionSecretScanningBypass = "secret_scanning.bypass"
 )
 
 // Bool checks for a key in the map and parses as a boolean
@@ -0,0 +1,73 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Pattern describes a kind of secret
+type Pattern struct {
+	ID   string
+	Name string
+	// Regexp matches the secret, if it has a capture group, the first group is the secret itself
+	Regexp *regexp.Regexp
+	// MinEntropy is the min Shannon entropy of the secret, it filters out the placeholders matched by generic patterns
+	MinEntropy float64
+}
+
+// NewPattern compiles a pattern, the custom patterns are validated by it
+func NewPattern(id, name, expr string) (*Pattern, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret pattern %q: %w", expr, err)
+	}
+	if re.MatchString("") {
+		return nil, fmt.Errorf("secret pattern %q matches the empty string", expr)
+	}
+	return &Pattern{ID: id, Name: name, Regexp: re}, nil
+}
+
+// secretOf returns the secret in a match of the pattern
+func (p *Pattern) secretOf(content []byte, loc []int) []byte {
+	if len(loc) >= 4 && loc[2] >= 0 {
+		return content[loc[2]:loc[3]]
+	}
+	return content[loc[0]:loc[1]]
+}
+
+// GenericSecretPatternID is the id of the pattern matching the high entropy values assigned to secret-like names
+const GenericSecretPatternID = "generic_secret"
+
+var builtinPatterns = []*Pattern{
+	{ID: "aws_access_key_id", Name: "AWS Access Key ID", Regexp: regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{ID: "aws_secret_access_key", Name: "AWS Secret Access Key", Regexp: regexp.MustCompile(`(?i)aws.{0,20}?(?:secret|private).{0,20}?["'=:\s]([0-9a-zA-Z/+]{40})\b`), MinEntropy: 4},
+	{ID: "github_token", Name: "GitHub Token", Regexp: regexp.MustCompile(`\b(gh[pousr]_[0-9A-Za-z]{36})\b`)},
+	{ID: "github_fine_grained_token", Name: "GitHub Fine-grained Personal Access Token", Regexp: regexp.MustCompile(`\b(github_pat_[0-9A-Za-z_]{82})\b`)},
+	{ID: "gitlab_token", Name: "GitLab Personal Access Token", Regexp: regexp.MustCompile(`\b(glpat-[0-9A-Za-z_\-]{20})\b`)},
+	{ID: "phantomkit_key", Name: "PhantomKit API Key", Regexp: regexp.MustCompile(`\b(pkit_[0-9a-f]{64})\b`)},
+	{ID: "slack_token", Name: "Slack Token", Regexp: regexp.MustCompile(`\b(xox[abposr]-[0-9A-Za-z\-]{10,})\b`)},
+	{ID: "slack_webhook", Name: "Slack Webhook URL", Regexp: regexp.MustCompile(`(https://hooks\.slack\.com/services/T[0-9A-Za-z_]+/B[0-9A-Za-z_]+/[0-9A-Za-z_]{24})`)},
+	{ID: "stripe_secret_key", Name: "Stripe Secret Key", Regexp: regexp.MustCompile(`\b((?:sk|rk)_live_[0-9A-Za-z]{24,})\b`)},
+	{ID: "google_api_key", Name: "Google API Key", Regexp: regexp.MustCompile(`\b(AIza[0-9A-Za-z_\-]{35})\b`)},
+	{ID: "npm_token", Name: "npm Access Token", Regexp: regexp.MustCompile(`\b(npm_[0-9A-Za-z]{36})\b`)},
+	{ID: "pypi_token", Name: "PyPI API Token", Regexp: regexp.MustCompile(`\b(pypi-AgEIcHlwaS5vcmc[0-9A-Za-z_\-]{50,})`)},
+	{ID: "private_key", Name: "Private Key", Regexp: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |ENCRYPTED |PGP )?PRIVATE KEY(?: BLOCK)?-----`)},
+	{ID: GenericSecretPatternID, Name: "Generic Secret", Regexp: regexp.MustCompile(`(?i)(?:secret|token|passw(?:or)?d|api[_\-]?key|access[_\-]?key|client[_\-]?secret)[A-Za-z0-9_\-]*["']?\s*[:=]\s*["']([^"'\s]{16,})["']`)},
+}
+
+// BuiltinPatterns returns the catalog of the built-in patterns,
+// the min entropy of the generic secret pattern is set to the given threshold
+func BuiltinPatterns(entropyThreshold float64) []*Pattern {
+	patterns := make([]*Pattern, len(builtinPatterns))
+	for i, p := range builtinPatterns {
+		cp := *p
+		if cp.ID == GenericSecretPatternID {
+			cp.MinEntropy = entropyThreshold
+		}
+		patterns[i] = &cp
+	}
+	return patterns
+}
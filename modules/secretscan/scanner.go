@@ -0,0 +1,91 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strings"
+)
+
+// Finding is a secret found in a content
+type Finding struct {
+	PatternID   string
+	PatternName string
+	Line        int // 1-based
+	Secret      string
+}
+
+// Hash returns the hash identifying the secret, the secret itself is never stored
+func (f *Finding) Hash() string {
+	sum := sha256.Sum256([]byte(f.Secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Redacted returns the secret with most of its characters masked
+func (f *Finding) Redacted() string {
+	return Redact(f.Secret)
+}
+
+// Redact masks all the characters of the secret but the first four ones
+func Redact(secret string) string {
+	const visible = 4
+	runes := []rune(secret)
+	if len(runes) <= visible*2 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:visible]) + strings.Repeat("*", min(len(runes)-visible, 16))
+}
+
+// ShannonEntropy returns the Shannon entropy of the string in bits per character
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// IsBinary returns true if the content looks like a binary file, such files are not scanned
+func IsBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0
+}
+
+// Scan returns the secrets found in the content, a secret matched by several patterns is reported by the first one
+func Scan(content []byte, patterns []*Pattern) []Finding {
+	if len(content) == 0 || IsBinary(content) {
+		return nil
+	}
+
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, p := range patterns {
+		for _, loc := range p.Regexp.FindAllSubmatchIndex(content, -1) {
+			secret := string(p.secretOf(content, loc))
+			if seen[secret] || (p.MinEntropy > 0 && ShannonEntropy(secret) < p.MinEntropy) {
+				continue
+			}
+			seen[secret] = true
+			findings = append(findings, Finding{
+				PatternID:   p.ID,
+				PatternName: p.Name,
+				Line:        bytes.Count(content[:loc[0]], []byte{'\n'}) + 1,
+				Secret:      secret,
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	// the fake secrets are split, so this file doesn't trigger the scanners itself
+	awsKeyID := "AKIA" + "IOSFODNN7EXAMPLE"
+	githubToken := "ghp_" + strings.Repeat("aB3x", 9)
+	pkitKey := "pkit_" + strings.Repeat("0123456789abcdef", 4)
+
+	content := strings.Join([]string{
+		"[default]",
+		"aws_access_key_id = " + awsKeyID,
+		`token: "` + githubToken + `"`,
+		"PHANTOMKIT_KEY=" + pkitKey,
+		"-----BEGIN " + "OPENSSH PRIVATE KEY-----",
+		`password = "changeme-changeme"`,
+		`client_secret = "Zk8#qL2!vR9@wT4$"`,
+		"aws_access_key_id = " + awsKeyID,
+		`api_key = "` + githubToken + `"`,
+	}, "\n")
+
+	findings := Scan([]byte(content), BuiltinPatterns(3.5))
+	type result struct {
+		PatternID string
+		Line      int
+		Secret    string
+	}
+	results := make([]result, 0, len(findings))
+	for _, f := range findings {
+		results = append(results, result{f.PatternID, f.Line, f.Secret})
+	}
+	assert.ElementsMatch(t, []result{
+		{"aws_access_key_id", 2, awsKeyID},
+		{"github_token", 3, githubToken},
+		{"phantomkit_key", 4, pkitKey},
+		{"private_key", 5, "-----BEGIN " + "OPENSSH PRIVATE KEY-----"},
+		// the generic pattern reports the high entropy values only
+		{GenericSecretPatternID, 7, "Zk8#qL2!vR9@wT4$"},
+	}, results)
+
+	assert.Empty(t, Scan([]byte("token\x00"+githubToken), BuiltinPatterns(3.5)), "binary content is not scanned")
+}
+
+func TestCustomPattern(t *testing.T) {
+	p, err := NewPattern("custom_1", "Internal Token", `\b(acme_[0-9a-f]{16})\b`)
+	require.NoError(t, err)
+	findings := Scan([]byte("key: acme_0123456789abcdef\n"), []*Pattern{p})
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, "acme_0123456789abcdef", findings[0].Secret)
+		assert.Equal(t, "Internal Token", findings[0].PatternName)
+		assert.Equal(t, "acme****************", findings[0].Redacted())
+		assert.Len(t, findings[0].Hash(), 64)
+	}
+
+	_, err = NewPattern("custom_2", "Invalid", `(`)
+	assert.Error(t, err)
+	_, err = NewPattern("custom_3", "Empty", `a*`)
+	assert.Error(t, err)
+}
+
+func TestShannonEntropy(t *testing.T) {
+	assert.Zero(t, ShannonEntropy(""))
+	assert.Zero(t, ShannonEntropy("aaaa"))
+	assert.InDelta(t, 1.0, ShannonEntropy("abab"), 0.001)
+	assert.InDelta(t, 4.0, ShannonEntropy("0123456789abcdef"), 0.001)
+}
@@ -4,6 +4,7 @@
 package setting
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -30,6 +31,20 @@ var (
 		DefaultActionsURL:   defaultActionsURLGitHub,
 		SkipWorkflowStrings: []string{"[skip ci]", "[ci skip]", "[no ci]", "[skip actions]", "[actions skip]"},
 	}
+
+	// ActionsAutoscaler settings, the autoscaler asks an external scaler for more runners when jobs wait too long
+	ActionsAutoscaler = struct {
+		Enabled            bool
+		Type               string        `ini:"TYPE"` // "command" or "http"
+		Command            string        `ini:"COMMAND"`
+		URL                string        `ini:"URL"`
+		Authorization      string        `ini:"AUTHORIZATION"`
+		QueueTimeThreshold time.Duration `ini:"QUEUE_TIME_THRESHOLD"`
+		Cooldown           time.Duration `ini:"COOLDOWN"`
+		Timeout            time.Duration `ini:"TIMEOUT"`
+	}{
+		Type: "command",
+	}
 )
 
 type defaultActionsURL string
@@ -119,5 +134,34 @@ func loadActionsFrom(rootCfg ConfigProvider) error {
 		return fmt.Errorf("invalid [actions] LOG_COMPRESSION: %q", Actions.LogCompression)
 	}
 
+	return loadActionsAutoscalerFrom(rootCfg)
+}
+
+func loadActionsAutoscalerFrom(rootCfg ConfigProvider) error {
+	sec := rootCfg.Section("actions.autoscaler")
+	if err := sec.MapTo(&ActionsAutoscaler); err != nil {
+		return fmt.Errorf("failed to map Actions autoscaler settings: %v", err)
+	}
+	// don't inherit ENABLED from [actions]
+	ActionsAutoscaler.Enabled = ConfigSectionKeyBool(sec, "ENABLED")
+	ActionsAutoscaler.QueueTimeThreshold = sec.Key("QUEUE_TIME_THRESHOLD").MustDuration(time.Minute)
+	ActionsAutoscaler.Cooldown = sec.Key("COOLDOWN").MustDuration(5 * time.Minute)
+	ActionsAutoscaler.Timeout = sec.Key("TIMEOUT").MustDuration(30 * time.Second)
+
+	if !ActionsAutoscaler.Enabled {
+		return nil
+	}
+	switch ActionsAutoscaler.Type {
+	case "command":
+		if ActionsAutoscaler.Command == "" {
+			return errors.New("[actions.autoscaler] COMMAND is required when TYPE is command")
+		}
+	case "http":
+		if ActionsAutoscaler.URL == "" {
+			return errors.New("[actions.autoscaler] URL is required when TYPE is http")
+		}
+	default:
+		return fmt.Errorf("unsupported [actions.autoscaler] TYPE: %q", ActionsAutoscaler.Type)
+	}
 	return nil
 }
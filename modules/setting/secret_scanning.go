@@ -0,0 +1,43 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"code.gitea.io/gitea/modules/log"
+)
+
+const (
+	// SecretScanningModeBlock rejects the pushes containing secrets unless a bypass reason is given
+	SecretScanningModeBlock = "block"
+	// SecretScanningModeWarn accepts the pushes containing secrets and warns the pusher
+	SecretScanningModeWarn = "warn"
+)
+
+// SecretScanning settings
+var SecretScanning = struct {
+	Enabled          bool
+	Mode             string
+	MaxBlobSize      int64   `ini:"-"`
+	EntropyThreshold float64 // the min Shannon entropy of a generic secret, in bits per character
+}{
+	Enabled:          false,
+	Mode:             SecretScanningModeBlock,
+	MaxBlobSize:      1024 * 1024,
+	EntropyThreshold: 3.5,
+}
+
+func loadSecretScanningFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("secret_scanning")
+	if err := sec.MapTo(&SecretScanning); err != nil {
+		log.Fatal("Failed to map SecretScanning settings: %v", err)
+	}
+
+	if sec.HasKey("MAX_BLOB_SIZE") {
+		SecretScanning.MaxBlobSize = mustBytes(sec, "MAX_BLOB_SIZE")
+	}
+	if SecretScanning.Mode != SecretScanningModeBlock && SecretScanning.Mode != SecretScanningModeWarn {
+		log.Warn("Unknown secret scanning mode %q, set to %q", SecretScanning.Mode, SecretScanningModeBlock)
+		SecretScanning.Mode = SecretScanningModeBlock
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+// TerraformState settings for the Terraform HTTP state backend
+var TerraformState = struct {
+	Enabled bool
+	Storage *Storage
+	MaxSize int64
+	// MaxVersions is the number of versions kept per state, 0 keeps all versions
+	MaxVersions int64
+}{
+	Enabled: true,
+	MaxSize: 64,
+}
+
+func loadTerraformStateFrom(rootCfg ConfigProvider) (err error) {
+	sec, _ := rootCfg.GetSection("terraform_state")
+	if sec == nil {
+		TerraformState.Storage, err = getStorage(rootCfg, "terraform_state", "", nil)
+		return err
+	}
+
+	TerraformState.Enabled = sec.Key("ENABLED").MustBool(TerraformState.Enabled)
+	TerraformState.MaxSize = sec.Key("MAX_SIZE").MustInt64(TerraformState.MaxSize)
+	TerraformState.MaxVersions = sec.Key("MAX_VERSIONS").MustInt64(TerraformState.MaxVersions)
+	TerraformState.Storage, err = getStorage(rootCfg, "terraform_state", "", sec)
+	return err
+}
@@ -133,6 +133,9 @@ var (
 	Actions ObjectStorage = uninitializedStorage
 	// Actions Artifacts represents actions artifacts storage
 	ActionsArtifacts ObjectStorage = uninitializedStorage
+
+	// TerraformStates represents the storage of the encrypted Terraform states
+	TerraformStates ObjectStorage = uninitializedStorage
 )
 
 // Init init the storage
@@ -145,6 +148,7 @@ func Init() error {
 		initRepoArchives,
 		initPackages,
 		initActions,
+		initTerraformStates,
 	} {
 		if err := f(); err != nil {
 			return err
@@ -228,3 +232,13 @@ func initActions() (err error) {
 	ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage)
 	return err
 }
+
+func initTerraformStates() (err error) {
+	if !setting.TerraformState.Enabled {
+		TerraformStates = discardStorage("TerraformState isn't enabled")
+		return nil
+	}
+	log.Info("Initialising TerraformState storage with type: %s", setting.TerraformState.Storage.Type)
+	TerraformStates, err = NewStorage(setting.TerraformState.Storage.Type, setting.TerraformState.Storage)
+	return err
+}
@@ -489,6 +489,36 @@ func (p *WorkflowRunPayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
 
+// HookMergeGroupAction an action that happens to a merge group
+type HookMergeGroupAction string
+
+// HookMergeGroupChecksRequested the checks of a merge group are requested
+const HookMergeGroupChecksRequested HookMergeGroupAction = "checks_requested"
+
+// MergeGroup represents the pull requests of a merge queue which are tested together
+type MergeGroup struct {
+	// the combined commit of the pull requests rebased onto the base branch
+	HeadSHA string `json:"head_sha"`
+	HeadRef string `json:"head_ref"`
+	BaseSHA string `json:"base_sha"`
+	BaseRef string `json:"base_ref"`
+	// the indexes of the pull requests in the group
+	PullRequests []int64 `json:"pull_requests"`
+}
+
+// MergeGroupPayload represents a payload information of merge group event.
+type MergeGroupPayload struct {
+	Action     HookMergeGroupAction `json:"action"`
+	MergeGroup *MergeGroup          `json:"merge_group"`
+	Repo       *Repository          `json:"repository"`
+	Sender     *User                `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *MergeGroupPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
 // WorkflowJobPayload represents a payload information of workflow job event.
 type WorkflowJobPayload struct {
 	Action       string             `json:"action"`
@@ -31,3 +31,46 @@ type PackageFile struct {
 	HashSHA256 string `json:"sha256"`
 	HashSHA512 string `json:"sha512"`
 }
+
+// PackageVulnerability represents a dependency of a package version which is affected by a known vulnerability
+type PackageVulnerability struct {
+	// ID of the vulnerability in the OSV database
+	ID       string   `json:"id"`
+	Summary  string   `json:"summary"`
+	Details  string   `json:"details"`
+	Aliases  []string `json:"aliases"`
+	Severity string   `json:"severity"`
+	// name of the affected dependency
+	DependencyName string `json:"dependency_name"`
+	// version constraint of the affected dependency
+	DependencyVersion string `json:"dependency_version"`
+	// swagger:strfmt date-time
+	PublishedAt time.Time `json:"published_at"`
+	// swagger:strfmt date-time
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+// PromotePackageVersionOption options for promoting a package version to another owner
+type PromotePackageVersionOption struct {
+	// name of the user or organization the package version is copied to
+	// required: true
+	Owner string `json:"owner" binding:"Required"`
+}
+
+// PackageDownloadStatistics represents the download statistics of a package version
+type PackageDownloadStatistics struct {
+	// total number of downloads
+	TotalCount int64 `json:"total_count"`
+	// time of the last download, not set if the version was never downloaded
+	// swagger:strfmt date-time
+	LastDownloadedAt *time.Time `json:"last_downloaded_at,omitempty"`
+	// number of downloads per day (UTC), days without downloads are included
+	Days []*PackageDownloadDay `json:"days"`
+}
+
+// PackageDownloadDay represents the number of downloads of a package version on a day
+type PackageDownloadDay struct {
+	// swagger:strfmt date
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
@@ -91,6 +91,9 @@ type CreatePullRequestOption struct {
 	Deadline      *time.Time `json:"due_date"`
 	Reviewers     []string   `json:"reviewers"`
 	TeamReviewers []string   `json:"team_reviewers"`
+	// index of the open pull request to stack the pull request on, its head branch must be the base branch.
+	// When it is merged, the pull request is retargeted to its base branch and rebased onto the merge result.
+	StackParent int64 `json:"stack_parent"`
 }
 
 // EditPullRequestOption options when modify pull request
@@ -107,6 +110,8 @@ type EditPullRequestOption struct {
 	Deadline            *time.Time `json:"due_date"`
 	RemoveDeadline      *bool      `json:"unset_due_date"`
 	AllowMaintainerEdit *bool      `json:"allow_maintainer_edit"`
+	// index of the open pull request to stack the pull request on, its head branch must be the base branch. 0 unstacks the pull request.
+	StackParent *int64 `json:"stack_parent"`
 }
 
 // ChangedFile store information about files affected by the pull request
@@ -65,6 +65,13 @@ type PullReviewComment struct {
 	DiffHunk     string `json:"diff_hunk"`
 	LineNum      uint64 `json:"position"`
 	OldLineNum   uint64 `json:"original_position"`
+	// first line of a multi-line comment on the new file, 0 for a single line
+	StartLineNum uint64 `json:"start_position"`
+	// first line of a multi-line comment on the old file, 0 for a single line
+	OldStartLineNum uint64 `json:"original_start_position"`
+	// whether the comment is on some lines or on the whole file
+	// enum: line,file
+	SubjectType string `json:"subject_type"`
 
 	HTMLURL     string `json:"html_url"`
 	HTMLPullURL string `json:"pull_request_url"`
@@ -87,6 +94,13 @@ type CreatePullReviewComment struct {
 	OldLineNum int64 `json:"old_position"`
 	// if comment to new file line or 0
 	NewLineNum int64 `json:"new_position"`
+	// first old file line of a multi-line comment ending at old_position, or 0
+	OldStartLineNum int64 `json:"old_start_position"`
+	// first new file line of a multi-line comment ending at new_position, or 0
+	NewStartLineNum int64 `json:"new_start_position"`
+	// "file" comments on the whole file and ignores the positions, defaults to "line"
+	// enum: line,file
+	SubjectType string `json:"subject_type"`
 }
 
 // SubmitPullReviewOptions are options to submit a pending pull review
@@ -106,3 +120,12 @@ type PullReviewRequestOptions struct {
 	Reviewers     []string `json:"reviewers"`
 	TeamReviewers []string `json:"team_reviewers"`
 }
+
+// ApplyPullReviewSuggestionsOptions are options to apply the suggested changes of review comments
+type ApplyPullReviewSuggestionsOptions struct {
+	// ids of the review comments whose suggestions are applied as one commit
+	// required: true
+	CommentIDs []int64 `json:"comment_ids" binding:"Required"`
+	// commit message, defaults to "Apply suggestion(s) from code review"
+	Message string `json:"message"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// PushRule represents the content policies checked for every push
+type PushRule struct {
+	// the owner to which the rule belongs, 0 for a repository or instance level rule
+	OwnerID int64 `json:"owner_id"`
+	// the repository to which the rule belongs, 0 for an owner or instance level rule
+	RepoID int64 `json:"repo_id"`
+	// files larger than this size in bytes are rejected, 0 means no limit
+	MaxFileSize int64 `json:"max_file_size"`
+	// commits adding or changing a file matching any of these glob patterns are rejected
+	ForbiddenPathPatterns []string `json:"forbidden_path_patterns"`
+	// commits whose message doesn't match this regular expression are rejected
+	CommitMessagePattern string `json:"commit_message_pattern"`
+	// commits whose author email isn't a verified email address of a user are rejected
+	RequireVerifiedAuthorEmail bool `json:"require_verified_author_email"`
+}
+
+// UpdatePushRuleOption options when creating or updating a push rule
+// swagger:model
+type UpdatePushRuleOption struct {
+	// files larger than this size in bytes are rejected, 0 means no limit
+	MaxFileSize int64 `json:"max_file_size"`
+	// commits adding or changing a file matching any of these glob patterns are rejected
+	ForbiddenPathPatterns []string `json:"forbidden_path_patterns"`
+	// commits whose message doesn't match this regular expression are rejected, e.g. `[A-Z]+-[0-9]+`
+	CommitMessagePattern string `json:"commit_message_pattern"`
+	// commits whose author email isn't a verified email address of a user are rejected
+	RequireVerifiedAuthorEmail bool `json:"require_verified_author_email"`
+}
@@ -179,6 +179,8 @@ type ActionRunner struct {
 	Busy      bool                 `json:"busy"`
 	Ephemeral bool                 `json:"ephemeral"`
 	Labels    []*ActionRunnerLabel `json:"labels"`
+	// the runner group, 0 means the runner is not in any group
+	GroupID int64 `json:"group_id"`
 }
 
 // ActionRunnersResponse returns Runners
@@ -186,3 +188,90 @@ type ActionRunnersResponse struct {
 	Entries    []*ActionRunner `json:"runners"`
 	TotalCount int64           `json:"total_count"`
 }
+
+// ActionApprovalPolicy represents the policy deciding which workflow runs need an approval
+type ActionApprovalPolicy struct {
+	// the owner to which the policy belongs, 0 for a repository level policy
+	OwnerID int64 `json:"owner_id"`
+	// the repository to which the policy belongs, 0 for an owner level policy
+	RepoID int64 `json:"repo_id"`
+	// which runs triggered by users without write access need an approval
+	// enum: first_time,every_push,always
+	Mode string `json:"mode"`
+	// whether every run of a workflow using secrets needs an approval
+	RequireForSecrets bool `json:"require_for_secrets"`
+	// every run of a workflow deploying to one of these environments needs an approval
+	Environments []string `json:"environments"`
+	// the team whose members can approve the runs, 0 means any user who can write actions
+	ApproverTeamID int64 `json:"approver_team_id"`
+}
+
+// UpdateActionApprovalPolicyOption options when creating or updating the approval policy
+// swagger:model
+type UpdateActionApprovalPolicyOption struct {
+	// which runs triggered by users without write access need an approval, default is first_time
+	// enum: first_time,every_push,always
+	Mode string `json:"mode"`
+	// whether every run of a workflow using secrets needs an approval
+	RequireForSecrets bool `json:"require_for_secrets"`
+	// every run of a workflow deploying to one of these environments needs an approval
+	Environments []string `json:"environments"`
+	// the team whose members can approve the runs, 0 means any user who can write actions
+	ApproverTeamID int64 `json:"approver_team_id"`
+}
+
+// ActionRunnerGroup represents a named group of runners and the jobs which can be assigned to them
+type ActionRunnerGroup struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// whether the jobs of all repositories in the scope can use the runners of the group
+	AllowAllRepos bool `json:"allow_all_repos"`
+	// the repositories which can use the runners of the group when allow_all_repos is false
+	RepoIDs []int64 `json:"repo_ids"`
+	// the workflow files whose jobs can use the runners of the group, empty means all workflows
+	Workflows []string `json:"workflows"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateActionRunnerGroupOption options when creating a runner group
+// swagger:model
+type CreateActionRunnerGroupOption struct {
+	// required: true
+	Name        string `json:"name" binding:"Required;MaxSize(255)"`
+	Description string `json:"description"`
+	// whether the jobs of all repositories in the scope can use the runners of the group
+	AllowAllRepos bool `json:"allow_all_repos"`
+	// the repositories which can use the runners of the group when allow_all_repos is false
+	RepoIDs []int64 `json:"repo_ids"`
+	// the workflow files (e.g. deploy.yml) whose jobs can use the runners of the group, empty means all workflows
+	Workflows []string `json:"workflows"`
+}
+
+// EditActionRunnerGroupOption options when editing a runner group
+// swagger:model
+type EditActionRunnerGroupOption struct {
+	Name          *string `json:"name" binding:"MaxSize(255)"`
+	Description   *string `json:"description"`
+	AllowAllRepos *bool   `json:"allow_all_repos"`
+	// the repositories which can use the runners of the group when allow_all_repos is false
+	RepoIDs *[]int64 `json:"repo_ids"`
+	// the workflow files (e.g. deploy.yml) whose jobs can use the runners of the group, empty means all workflows
+	Workflows *[]string `json:"workflows"`
+}
+
+// ActionQueueStat represents the demand of the jobs which run on the same labels
+type ActionQueueStat struct {
+	// the sorted labels of the jobs
+	Labels []string `json:"labels"`
+	// the number of jobs waiting for a runner
+	Queued int64 `json:"queued"`
+	// the number of jobs running on a runner
+	InProgress int64 `json:"in_progress"`
+	// the time when the oldest queued job started waiting, null if no job is queued
+	// swagger:strfmt date-time
+	OldestQueuedAt *time.Time `json:"oldest_queued_at"`
+}
@@ -50,10 +50,13 @@ type BranchProtection struct {
 	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
 	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
 	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
+	RequireCodeOwnerReviews       bool     `json:"require_code_owner_reviews"`
 	RequireSignedCommits          bool     `json:"require_signed_commits"`
 	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
 	BlockAdminMergeOverride       bool     `json:"block_admin_merge_override"`
+	EnableMergeQueue              bool     `json:"enable_merge_queue"`
+	MergeQueueBatchSize           int64    `json:"merge_queue_batch_size"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
@@ -90,10 +93,13 @@ type CreateBranchProtectionOption struct {
 	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
 	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
 	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
+	RequireCodeOwnerReviews       bool     `json:"require_code_owner_reviews"`
 	RequireSignedCommits          bool     `json:"require_signed_commits"`
 	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
 	BlockAdminMergeOverride       bool     `json:"block_admin_merge_override"`
+	EnableMergeQueue              bool     `json:"enable_merge_queue"`
+	MergeQueueBatchSize           int64    `json:"merge_queue_batch_size"`
 }
 
 // EditBranchProtectionOption options for editing a branch protection
@@ -123,10 +129,13 @@ type EditBranchProtectionOption struct {
 	BlockOnOutdatedBranch         *bool    `json:"block_on_outdated_branch"`
 	DismissStaleApprovals         *bool    `json:"dismiss_stale_approvals"`
 	IgnoreStaleApprovals          *bool    `json:"ignore_stale_approvals"`
+	RequireCodeOwnerReviews       *bool    `json:"require_code_owner_reviews"`
 	RequireSignedCommits          *bool    `json:"require_signed_commits"`
 	ProtectedFilePatterns         *string  `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       *string  `json:"unprotected_file_patterns"`
 	BlockAdminMergeOverride       *bool    `json:"block_admin_merge_override"`
+	EnableMergeQueue              *bool    `json:"enable_merge_queue"`
+	MergeQueueBatchSize           *int64   `json:"merge_queue_batch_size"`
 }
 
 // UpdateBranchProtectionPriories a list to update the branch protection rule priorities
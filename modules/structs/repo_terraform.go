@@ -0,0 +1,31 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// TerraformState represents a Terraform state stored in a repository
+type TerraformState struct {
+	Name string `json:"name"`
+	// the latest version, 0 if no content was written yet
+	Version int64 `json:"version"`
+	Locked  bool  `json:"locked"`
+	// the lock information sent by the client holding the lock
+	LockInfo string `json:"lock_info,omitempty"`
+	// swagger:strfmt date-time
+	LockedAt *time.Time `json:"locked_at,omitempty"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TerraformStateVersion represents a version of a Terraform state
+type TerraformStateVersion struct {
+	Version int64  `json:"version"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// Ruleset represents a set of rules protecting the refs of many repositories at once
+type Ruleset struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// disabled rulesets are ignored, the violations of evaluate rulesets are logged without being blocked
+	// enum: disabled,evaluate,active
+	Enforcement string `json:"enforcement"`
+	// glob patterns of the names of the target repositories, empty means all repositories
+	RepoNamePatterns []string `json:"repo_name_patterns"`
+	// the target repositories must have any of the topics, empty means no restriction
+	RepoTopics []string `json:"repo_topics"`
+	// glob patterns of the full names of the target refs, e.g. refs/heads/main or refs/tags/v*
+	RefPatterns             []string `json:"ref_patterns"`
+	BlockCreation           bool     `json:"block_creation"`
+	BlockDeletion           bool     `json:"block_deletion"`
+	BlockForcePush          bool     `json:"block_force_push"`
+	RequirePullRequest      bool     `json:"require_pull_request"`
+	RequiredApprovals       int64    `json:"required_approvals"`
+	BlockOnRejectedReviews  bool     `json:"block_on_rejected_reviews"`
+	BlockOnOutdatedBranch   bool     `json:"block_on_outdated_branch"`
+	RequireCodeOwnerReviews bool     `json:"require_code_owner_reviews"`
+	RequireSignedCommits    bool     `json:"require_signed_commits"`
+	StatusCheckContexts     []string `json:"status_check_contexts"`
+	// the users and teams the ruleset doesn't apply to
+	BypassUserIDs []int64 `json:"bypass_user_ids"`
+	BypassTeamIDs []int64 `json:"bypass_team_ids"`
+	// whether the administrators of a target repository can bypass the ruleset
+	BypassRepoAdmins bool `json:"bypass_repo_admins"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateRulesetOption options when creating a ruleset
+// swagger:model
+type CreateRulesetOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// enum: disabled,evaluate,active
+	Enforcement string `json:"enforcement"`
+	// glob patterns of the names of the target repositories, empty means all repositories
+	RepoNamePatterns []string `json:"repo_name_patterns"`
+	// the target repositories must have any of the topics, empty means no restriction
+	RepoTopics []string `json:"repo_topics"`
+	// glob patterns of the full names of the target refs, e.g. refs/heads/main or refs/tags/v*
+	// required: true
+	RefPatterns             []string `json:"ref_patterns" binding:"Required"`
+	BlockCreation           bool     `json:"block_creation"`
+	BlockDeletion           bool     `json:"block_deletion"`
+	BlockForcePush          bool     `json:"block_force_push"`
+	RequirePullRequest      bool     `json:"require_pull_request"`
+	RequiredApprovals       int64    `json:"required_approvals"`
+	BlockOnRejectedReviews  bool     `json:"block_on_rejected_reviews"`
+	BlockOnOutdatedBranch   bool     `json:"block_on_outdated_branch"`
+	RequireCodeOwnerReviews bool     `json:"require_code_owner_reviews"`
+	RequireSignedCommits    bool     `json:"require_signed_commits"`
+	StatusCheckContexts     []string `json:"status_check_contexts"`
+	BypassUserIDs           []int64  `json:"bypass_user_ids"`
+	BypassTeamIDs           []int64  `json:"bypass_team_ids"`
+	BypassRepoAdmins        bool     `json:"bypass_repo_admins"`
+}
+
+// EditRulesetOption options when editing a ruleset
+// swagger:model
+type EditRulesetOption struct {
+	Name *string `json:"name" binding:"MaxSize(255)"`
+	// enum: disabled,evaluate,active
+	Enforcement             *string   `json:"enforcement"`
+	RepoNamePatterns        *[]string `json:"repo_name_patterns"`
+	RepoTopics              *[]string `json:"repo_topics"`
+	RefPatterns             *[]string `json:"ref_patterns"`
+	BlockCreation           *bool     `json:"block_creation"`
+	BlockDeletion           *bool     `json:"block_deletion"`
+	BlockForcePush          *bool     `json:"block_force_push"`
+	RequirePullRequest      *bool     `json:"require_pull_request"`
+	RequiredApprovals       *int64    `json:"required_approvals"`
+	BlockOnRejectedReviews  *bool     `json:"block_on_rejected_reviews"`
+	BlockOnOutdatedBranch   *bool     `json:"block_on_outdated_branch"`
+	RequireCodeOwnerReviews *bool     `json:"require_code_owner_reviews"`
+	RequireSignedCommits    *bool     `json:"require_signed_commits"`
+	StatusCheckContexts     *[]string `json:"status_check_contexts"`
+	BypassUserIDs           *[]int64  `json:"bypass_user_ids"`
+	BypassTeamIDs           *[]int64  `json:"bypass_team_ids"`
+	BypassRepoAdmins        *bool     `json:"bypass_repo_admins"`
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// SecretScanAlert represents a secret found in a push to a repository
+type SecretScanAlert struct {
+	ID int64 `json:"id"`
+	// the id of the pattern which matched the secret, custom patterns are named custom_<id>
+	PatternID   string `json:"pattern_id"`
+	PatternName string `json:"pattern_name"`
+	// the secret with most of its characters masked
+	RedactedSecret string `json:"redacted_secret"`
+	// the location where the secret has been found first
+	RefName  string `json:"ref"`
+	CommitID string `json:"commit_id"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Pusher   *User  `json:"pusher"`
+	// the reason given by the pusher to push the secret anyway
+	BypassReason string `json:"bypass_reason"`
+	// enum: open,resolved
+	State string `json:"state"`
+	// enum: false_positive,revoked,used_in_tests,wont_fix
+	Resolution        string `json:"resolution"`
+	ResolutionComment string `json:"resolution_comment"`
+	ResolvedBy        *User  `json:"resolved_by"`
+	// swagger:strfmt date-time
+	Resolved *time.Time `json:"resolved_at"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// EditSecretScanAlertOption options when resolving or reopening a secret scanning alert
+// swagger:model
+type EditSecretScanAlertOption struct {
+	// required: true
+	// enum: open,resolved
+	State string `json:"state" binding:"Required;In(open,resolved)"`
+	// required when the state is resolved
+	// enum: false_positive,revoked,used_in_tests,wont_fix
+	Resolution        string `json:"resolution"`
+	ResolutionComment string `json:"resolution_comment"`
+}
+
+// SecretScanPattern represents a custom pattern of secrets
+type SecretScanPattern struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// regular expression matching the secret, if it has a capture group, the first group is the secret itself
+	Pattern string `json:"pattern"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CreateSecretScanPatternOption options when creating a custom pattern of secrets
+// swagger:model
+type CreateSecretScanPatternOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// regular expression matching the secret, if it has a capture group, the first group is the secret itself
+	// required: true
+	Pattern string `json:"pattern" binding:"Required"`
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// X509TrustedCA represents a bundle of CA certificates which is trusted to verify X.509 commit and tag signatures
+type X509TrustedCA struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// PEM encoded certificates of the bundle
+	Content      string             `json:"content"`
+	Certificates []*X509Certificate `json:"certificates"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// X509Certificate represents the details of an X.509 certificate
+type X509Certificate struct {
+	Subject      string `json:"subject"`
+	Issuer       string `json:"issuer"`
+	SerialNumber string `json:"serial_number"`
+	// hex encoded SHA256 fingerprint of the certificate
+	Fingerprint string `json:"fingerprint"`
+	// swagger:strfmt date-time
+	NotBefore time.Time `json:"not_before"`
+	// swagger:strfmt date-time
+	NotAfter time.Time `json:"not_after"`
+}
+
+// CreateX509TrustedCAOption options when adding a trusted CA bundle
+// swagger:model
+type CreateX509TrustedCAOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// PEM encoded CA certificates
+	// required: true
+	Content string `json:"content" binding:"Required"`
+}
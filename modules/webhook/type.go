@@ -40,6 +40,7 @@ const (
 	HookEventSchedule    HookEventType = "schedule"
 	HookEventWorkflowRun HookEventType = "workflow_run"
 	HookEventWorkflowJob HookEventType = "workflow_job"
+	HookEventMergeGroup  HookEventType = "merge_group"
 )
 
 func AllEvents() []HookEventType {
@@ -119,6 +119,7 @@ func ArtifactsRoutes(prefix string) *web.Router {
 		m.Get("/{artifact_hash}/download_url", r.getDownloadArtifactURL)
 		m.Get("/{artifact_id}/download", r.downloadArtifact)
 	})
+	m.Put(stepSummaryRouteBase, uploadStepSummary)
 
 	return m
 }
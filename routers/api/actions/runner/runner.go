@@ -272,6 +272,9 @@ func (s *Service) UpdateLog(
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "write logs: %v", err)
 	}
+	if err := actions_service.SaveTaskAnnotations(ctx, task, ack, rows); err != nil {
+		log.Error("SaveTaskAnnotations for task %d: %v", task.ID, err)
+	}
 	task.LogLength += int64(len(rows))
 	for _, n := range ns {
 		task.LogIndexes = append(task.LogIndexes, task.LogSize)
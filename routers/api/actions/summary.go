@@ -0,0 +1,58 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+// Job Summary API
+//
+// The runner uploads the markdown a step writes to $GITHUB_STEP_SUMMARY after the step finishes,
+// with the same ACTIONS_RUNTIME_TOKEN as the artifacts API:
+//
+// PUT: /api/actions_pipeline/_apis/pipelines/workflows/{run_id}/summary?step={step_index}
+// Content-Type: text/markdown
+// Body: the markdown content, at most 1 MiB
+//
+// Uploading the summary of a step again replaces the previous one.
+// The summary of the job is the summaries of its steps joined in order.
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/log"
+)
+
+const stepSummaryRouteBase = "/_apis/pipelines/workflows/{run_id}/summary"
+
+func uploadStepSummary(ctx *ArtifactContext) {
+	task, _, ok := validateRunID(ctx)
+	if !ok {
+		return
+	}
+
+	step, err := strconv.ParseInt(ctx.Req.URL.Query().Get("step"), 10, 64)
+	if err != nil || step < 0 {
+		ctx.HTTPError(http.StatusBadRequest, "Invalid step")
+		return
+	}
+
+	content, err := io.ReadAll(io.LimitReader(ctx.Req.Body, actions.MaxStepSummarySize+1))
+	if err != nil {
+		log.Error("Error reading step summary: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error reading step summary")
+		return
+	}
+	if len(content) > actions.MaxStepSummarySize {
+		ctx.HTTPError(http.StatusRequestEntityTooLarge, "Step summary is too large")
+		return
+	}
+
+	if err := actions.SetTaskStepSummary(ctx, task, step, string(content)); err != nil {
+		log.Error("Error saving step summary: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error saving step summary")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
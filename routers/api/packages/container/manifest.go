@@ -38,6 +38,8 @@ type manifestCreationInfo struct {
 	Image      string
 	Reference  string
 	IsTagged   bool
+	Subject    string // the digest of the manifest this manifest refers to
+	Digest     string // the digest of the manifest itself
 	Properties map[string]string
 }
 
@@ -49,6 +51,10 @@ func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packag
 	if index.SchemaVersion != 2 {
 		return "", errUnsupported.WithMessage("Schema version is not supported")
 	}
+	if index.Subject != nil {
+		mci.Subject = string(index.Subject.Digest)
+	}
+	mci.Digest = digestFromHashSummer(buf)
 	if _, err := buf.Seek(0, io.SeekStart); err != nil {
 		return "", err
 	}
@@ -83,9 +89,11 @@ type processManifestTxRet struct {
 }
 
 func handleCreateManifestResult(ctx context.Context, err error, mci *manifestCreationInfo, contentStore *packages_module.ContentStore, txRet *processManifestTxRet) (string, error) {
-	if err != nil && txRet.created && txRet.pb != nil {
-		if err := contentStore.Delete(packages_module.BlobHash256Key(txRet.pb.HashSHA256)); err != nil {
-			log.Error("Error deleting package blob from content store: %v", err)
+	if err != nil {
+		if txRet.created && txRet.pb != nil {
+			if err := contentStore.Delete(packages_module.BlobHash256Key(txRet.pb.HashSHA256)); err != nil {
+				log.Error("Error deleting package blob from content store: %v", err)
+			}
 		}
 		return "", err
 	}
@@ -103,6 +111,12 @@ func processOciImageManifest(ctx context.Context, mci *manifestCreationInfo, buf
 	if err != nil {
 		return "", err
 	}
+	metadata.Annotations = manifest.Annotations
+	metadata.ArtifactType = manifest.ArtifactType
+	if mci.Subject != "" && metadata.ArtifactType == "" {
+		// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+		metadata.ArtifactType = manifest.Config.MediaType
+	}
 	if _, err = buf.Seek(0, io.SeekStart); err != nil {
 		return "", err
 	}
@@ -172,8 +186,10 @@ func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *p
 	var txRet processManifestTxRet
 	err := db.WithTx(ctx, func(ctx context.Context) (err error) {
 		metadata := &container_module.Metadata{
-			Type:      container_module.TypeOCI,
-			Manifests: make([]*container_module.Manifest, 0, len(index.Manifests)),
+			Type:         container_module.TypeOCI,
+			Manifests:    make([]*container_module.Manifest, 0, len(index.Manifests)),
+			ArtifactType: index.ArtifactType,
+			Annotations:  index.Annotations,
 		}
 
 		for _, manifest := range index.Manifests {
@@ -252,8 +268,15 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 			return nil, err
 		}
 	}
+	for name, value := range mci.Properties {
+		if err := packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypePackage, p.ID, name, value); err != nil {
+			log.Error("Error setting package property: %v", err)
+			return nil, err
+		}
+	}
 
 	metadata.IsTagged = mci.IsTagged
+	metadata.Subject = mci.Subject
 
 	metadataJSON, err := json.Marshal(metadata)
 	if err != nil {
@@ -274,6 +297,12 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 			return nil, err
 		}
 
+		if mci.IsTagged {
+			if err := checkTagOverwrite(ctx, mci, pv); err != nil {
+				return nil, err
+			}
+		}
+
 		if container_module.IsMediaTypeImageIndex(mci.MediaType) {
 			if pv.CreatedUnix.AsTime().Before(time.Now().Add(-24 * time.Hour)) {
 				if err = packages_service.DeletePackageVersionAndReferences(ctx, pv); err != nil {
@@ -319,6 +348,15 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 		}
 	}
 
+	if err = packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject); err != nil {
+		return nil, err
+	}
+	if metadata.Subject != "" {
+		if _, err = packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject, metadata.Subject); err != nil {
+			return nil, err
+		}
+	}
+
 	return pv, nil
 }
 
@@ -331,6 +369,25 @@ type blobReference struct {
 	IsLead       bool
 }
 
+// checkTagOverwrite rejects pointing an existing tag to another manifest if the owner enabled the immutable versions policy
+func checkTagOverwrite(ctx context.Context, mci *manifestCreationInfo, pv *packages_model.PackageVersion) error {
+	err := packages_service.CheckPackageVersionMutable(ctx, mci.Creator, mci.Owner.ID)
+	if !errors.Is(err, packages_service.ErrImmutablePackageVersion) {
+		return err
+	}
+
+	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
+	if err != nil {
+		return err
+	}
+	for _, pf := range pfs {
+		if pf.IsLead && pf.LowerName == container_module.ManifestFilename && pf.CompositeKey != mci.Digest {
+			return packages_service.ErrImmutablePackageVersion
+		}
+	}
+	return nil
+}
+
 func createFileFromBlobReference(ctx context.Context, pv, uploadVersion *packages_model.PackageVersion, ref *blobReference) (*packages_model.PackageFile, error) {
 	if ref.File.Blob.Size != ref.ExpectedSize {
 		return nil, errSizeInvalid
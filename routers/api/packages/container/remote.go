@@ -0,0 +1,236 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/optional"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var remoteManifestAccept = strings.Join([]string{
+	oci.MediaTypeImageManifest,
+	oci.MediaTypeImageIndex,
+	container_module.ContentTypeDockerDistributionManifestV2,
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}, ", ")
+
+// remoteImageName returns the name of the image in the remote registry.
+// Official images of Docker Hub live in the "library" namespace.
+func remoteImageName(c *remote_service.Client, image string) string {
+	if strings.Contains(image, "/") {
+		return image
+	}
+	u, err := url.Parse(c.Remote.URL)
+	if err == nil && (u.Host == "registry-1.docker.io" || u.Host == "index.docker.io" || u.Host == "docker.io") {
+		return "library/" + image
+	}
+	return image
+}
+
+func getRemoteClient(ctx *context.Context) (*remote_service.Client, error) {
+	return remote_service.GetClient(ctx, ctx.Package.Owner.ID, packages_model.TypeContainer)
+}
+
+// cacheRemoteBlob fetches a blob from the remote registry and stores it as upload blob of the image
+func cacheRemoteBlob(ctx *context.Context, c *remote_service.Client, image string, d digest.Digest) error {
+	if _, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+		OwnerID: ctx.Package.Owner.ID,
+		Image:   image,
+		Digest:  string(d),
+	}); err == nil {
+		return nil
+	} else if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		return err
+	}
+
+	if d.Algorithm() != digest.SHA256 {
+		return container_model.ErrContainerBlobNotExist
+	}
+
+	resp, err := c.Get(ctx, http.MethodGet, c.URL("v2/"+remoteImageName(c, image)+"/blobs/"+string(d)), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(resp.Body)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	if err := remote_service.VerifyChecksum(buf, "sha256", d.Encoded()); err != nil {
+		return err
+	}
+
+	_, err = saveAsPackageBlob(ctx, buf, &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Owner: ctx.Package.Owner,
+			Name:  image,
+		},
+		Creator: remote_service.Creator(ctx.Doer),
+	})
+	return err
+}
+
+// serveRemoteBlob caches a blob of the remote registry and serves it
+func serveRemoteBlob(ctx *context.Context, serveContent bool) bool {
+	c, err := getRemoteClient(ctx)
+	if err != nil {
+		if !errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return true
+		}
+		return false
+	}
+
+	if err := cacheRemoteBlob(ctx, c, ctx.PathParam("image"), digest.Digest(ctx.PathParam("digest"))); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiErrorDefined(ctx, errBlobUnknown)
+		} else {
+			apiError(ctx, helper.RemoteErrorStatus(err), err)
+		}
+		return true
+	}
+
+	blob, err := getBlobFromContext(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return true
+	}
+	if serveContent {
+		serveBlob(ctx, blob)
+	} else {
+		setResponseHeaders(ctx.Resp, &containerHeaders{
+			ContentDigest: blob.Properties.GetByName(container_module.PropertyDigest),
+			ContentLength: optional.Some(blob.Blob.Size),
+			Status:        http.StatusOK,
+		})
+	}
+	return true
+}
+
+// serveRemoteManifest fetches a manifest of the remote registry. Image manifests are stored locally
+// together with their blobs, image indexes are passed through because they reference all platforms.
+func serveRemoteManifest(ctx *context.Context, serveContent bool) bool {
+	c, err := getRemoteClient(ctx)
+	if err != nil {
+		if !errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return true
+		}
+		return false
+	}
+
+	image := ctx.PathParam("image")
+	reference := ctx.PathParam("reference")
+
+	m, err := c.GetMetadata(ctx, c.URL("v2/"+remoteImageName(c, image)+"/manifests/"+reference), http.Header{"Accept": []string{remoteManifestAccept}})
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiErrorDefined(ctx, errManifestUnknown)
+		} else {
+			apiError(ctx, helper.RemoteErrorStatus(err), err)
+		}
+		return true
+	}
+
+	hash := sha256.Sum256(m.Content)
+	manifestDigest := digest.NewDigestFromEncoded(digest.SHA256, hex.EncodeToString(hash[:]))
+	if d := digest.Digest(reference); d.Validate() == nil && d != manifestDigest {
+		apiError(ctx, http.StatusBadGateway, remote_service.ErrRemoteRequestFailed)
+		return true
+	}
+
+	mediaType, _, _ := strings.Cut(m.ContentType, ";")
+	if !container_module.IsMediaTypeImageManifest(mediaType) {
+		headers := &containerHeaders{
+			ContentDigest: string(manifestDigest),
+			ContentType:   mediaType,
+			ContentLength: optional.Some(int64(len(m.Content))),
+			Status:        http.StatusOK,
+		}
+		setResponseHeaders(ctx.Resp, headers)
+		if serveContent {
+			_, _ = ctx.Resp.Write(m.Content)
+		}
+		return true
+	}
+
+	var manifest oci.Manifest
+	if err := json.Unmarshal(m.Content, &manifest); err != nil {
+		apiError(ctx, http.StatusBadGateway, err)
+		return true
+	}
+	for _, d := range append([]oci.Descriptor{manifest.Config}, manifest.Layers...) {
+		if err := cacheRemoteBlob(ctx, c, image, d.Digest); err != nil {
+			apiError(ctx, helper.RemoteErrorStatus(err), err)
+			return true
+		}
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(m.Content))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return true
+	}
+	defer buf.Close()
+
+	if _, err := processManifest(ctx, &manifestCreationInfo{
+		MediaType: mediaType,
+		Owner:     ctx.Package.Owner,
+		Creator:   remote_service.Creator(ctx.Doer),
+		Image:     image,
+		Reference: reference,
+		IsTagged:  digest.Digest(reference).Validate() != nil,
+		Properties: map[string]string{
+			remote_service.PropertyRemote: c.Remote.URL,
+		},
+	}, buf); err != nil {
+		var namedError *namedError
+		if errors.As(err, &namedError) {
+			apiErrorDefined(ctx, namedError)
+		} else {
+			apiError(ctx, helper.RemoteErrorStatus(err), err)
+		}
+		return true
+	}
+
+	pfd, err := getManifestFromContext(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return true
+	}
+	if serveContent {
+		serveBlob(ctx, pfd)
+	} else {
+		setResponseHeaders(ctx.Resp, &containerHeaders{
+			ContentDigest: pfd.Properties.GetByName(container_module.PropertyDigest),
+			ContentType:   pfd.Properties.GetByName(container_module.PropertyMediaType),
+			ContentLength: optional.Some(pfd.Blob.Size),
+			Status:        http.StatusOK,
+		})
+	}
+	return true
+}
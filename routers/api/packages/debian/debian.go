@@ -248,10 +248,15 @@ func DeletePackageFile(ctx *context.Context) {
 	architecture := ctx.PathParam("architecture")
 
 	owner := ctx.Package.Owner
+	doer := ctx.Doer
 
 	var pd *packages_model.PackageDescriptor
 
 	err := db.WithTx(ctx, func(ctx stdctx.Context) error {
+		if err := packages_service.CheckPackageVersionMutable(ctx, doer, owner.ID); err != nil {
+			return err
+		}
+
 		pv, err := packages_model.GetVersionByNameAndVersion(ctx, owner.ID, packages_model.TypeDebian, name, version)
 		if err != nil {
 			return err
@@ -291,6 +296,8 @@ func DeletePackageFile(ctx *context.Context) {
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
+		} else if errors.Is(err, util.ErrPermissionDenied) {
+			apiError(ctx, http.StatusForbidden, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -19,6 +19,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
 )
 
 func apiError(ctx *context.Context, status int, obj any) {
@@ -32,6 +33,16 @@ func EnumeratePackageVersions(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+
+	isCachedOrMissing, err := remote_service.IsCachedOrMissing(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if isCachedOrMissing && serveRemoteMetadata(ctx, ctx.PathParam("name"), "@v/list", "text/plain;charset=utf-8", len(pvs) > 0) {
+		return
+	}
+
 	if len(pvs) == 0 {
 		apiError(ctx, http.StatusNotFound, err)
 		return
@@ -49,7 +60,23 @@ func EnumeratePackageVersions(ctx *context.Context) {
 }
 
 func PackageVersionMetadata(ctx *context.Context) {
-	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"), ctx.PathParam("version"))
+	name, version := ctx.PathParam("name"), ctx.PathParam("version")
+
+	if version == "latest" {
+		isCachedOrMissing, hasCachedVersions, err := isCachedOrMissing(ctx, name)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if isCachedOrMissing && serveRemoteMetadata(ctx, name, "@latest", "application/json", hasCachedVersions) {
+			return
+		}
+	}
+
+	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, name, version)
+	if errors.Is(err, util.ErrNotExist) && version != "latest" && serveRemoteMetadata(ctx, name, "@v/"+version+".info", "application/json", false) {
+		return
+	}
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -69,7 +96,12 @@ func PackageVersionMetadata(ctx *context.Context) {
 }
 
 func PackageVersionGoModContent(ctx *context.Context) {
-	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"), ctx.PathParam("version"))
+	name, version := ctx.PathParam("name"), ctx.PathParam("version")
+
+	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, name, version)
+	if errors.Is(err, util.ErrNotExist) && serveRemoteMetadata(ctx, name, "@v/"+version+".mod", "text/plain;charset=utf-8", false) {
+		return
+	}
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -89,7 +121,19 @@ func PackageVersionGoModContent(ctx *context.Context) {
 }
 
 func DownloadPackageFile(ctx *context.Context) {
-	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"), ctx.PathParam("version"))
+	name, version := ctx.PathParam("name"), ctx.PathParam("version")
+
+	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, name, version)
+	if errors.Is(err, util.ErrNotExist) {
+		if cacheErr := cacheRemotePackageFile(ctx, name, version); cacheErr != nil {
+			if !errors.Is(cacheErr, packages_model.ErrPackageRemoteNotExist) {
+				apiError(ctx, helper.RemoteErrorStatus(cacheErr), cacheErr)
+				return
+			}
+		} else {
+			pv, err = resolvePackage(ctx, ctx.Package.Owner.ID, name, version)
+		}
+	}
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
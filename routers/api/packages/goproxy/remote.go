@@ -0,0 +1,92 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package goproxy
+
+import (
+	"errors"
+	"net/http"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+	goproxy_module "code.gitea.io/gitea/modules/packages/goproxy"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+)
+
+// isCachedOrMissing checks if the module is not available locally or was cached from a remote registry
+func isCachedOrMissing(ctx *context.Context, name string) (bool, bool, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeGo, name)
+	if err != nil {
+		return false, false, err
+	}
+	isCachedOrMissing, err := remote_service.IsCachedOrMissing(ctx, pvs)
+	return isCachedOrMissing, len(pvs) > 0, err
+}
+
+// serveRemoteMetadata serves a document of the remote registry for the module.
+// The path is relative to the module path, e.g. "@v/list".
+// It returns false if nothing was written because there is no remote or the remote failed but a cached version exists.
+func serveRemoteMetadata(ctx *context.Context, name, p, contentType string, hasCachedVersions bool) bool {
+	c, err := remote_service.GetClient(ctx, ctx.Package.Owner.ID, packages_model.TypeGo)
+	if err != nil {
+		if !errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return true
+		}
+		return false
+	}
+
+	m, err := c.GetMetadata(ctx, c.URL(name+"/"+p), nil)
+	if err != nil {
+		if hasCachedVersions {
+			log.Warn("Unable to fetch %s/%s from remote, serving cached versions: %v", name, p, err)
+			return false
+		}
+		apiError(ctx, helper.RemoteErrorStatus(err), err)
+		return true
+	}
+
+	ctx.Resp.Header().Set("Content-Type", contentType)
+	ctx.Status(http.StatusOK)
+	_, _ = ctx.Resp.Write(m.Content)
+	return true
+}
+
+// cacheRemotePackageFile fetches the module zip and go.mod of a version from the remote registry
+func cacheRemotePackageFile(ctx *context.Context, name, version string) error {
+	c, err := remote_service.GetClient(ctx, ctx.Package.Owner.ID, packages_model.TypeGo)
+	if err != nil {
+		return err
+	}
+
+	goMod, err := c.GetMetadata(ctx, c.URL(name+"/@v/"+version+".mod"), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.CacheFile(ctx, &remote_service.CacheFileOptions{
+		URL: c.URL(name + "/@v/" + version + ".zip"),
+		PackageCreation: &packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeGo,
+				Name:        name,
+				Version:     version,
+			},
+			Creator: remote_service.Creator(ctx.Doer),
+			VersionProperties: map[string]string{
+				goproxy_module.PropertyGoMod: string(goMod.Content),
+			},
+		},
+		FileCreation: &packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: version + ".zip",
+			},
+			Creator: remote_service.Creator(ctx.Doer),
+			IsLead:  true,
+		},
+	})
+}
@@ -4,6 +4,7 @@
 package helper
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,7 +13,10 @@ import (
 	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
 )
 
 // ProcessErrorForUser logs the error and returns a user-error message for the end user.
@@ -59,3 +63,17 @@ func ServePackageFile(ctx *context.Context, s io.ReadSeekCloser, u *url.URL, pf
 
 	ctx.ServeContent(s, opts)
 }
+
+// RemoteErrorStatus returns the response status for an error which occurred while using a remote registry
+func RemoteErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		return http.StatusNotFound
+	case errors.Is(err, remote_service.ErrRemoteRequestFailed):
+		return http.StatusBadGateway
+	case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
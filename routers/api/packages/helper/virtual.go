@@ -0,0 +1,100 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package helper
+
+import (
+	"errors"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetVirtualSources returns the owners whose packages are served at the registry of the package owner, ordered by their priority.
+// The package owner always comes first and is the only source if there is no enabled virtual repository for the package type.
+// Members whose packages are not readable by the doer are skipped.
+func GetVirtualSources(ctx *context.Context, packageType packages_model.Type) ([]*user_model.User, error) {
+	sources := []*user_model.User{ctx.Package.Owner}
+
+	pv, err := packages_model.GetEnabledVirtualByOwnerAndType(ctx, ctx.Package.Owner.ID, packageType)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageVirtualNotExist) {
+			return sources, nil
+		}
+		return nil, err
+	}
+
+	seen := container.SetOf(ctx.Package.Owner.ID)
+	for _, memberID := range pv.MemberIDs {
+		if !seen.Add(memberID) {
+			continue
+		}
+
+		member, err := user_model.GetUserByID(ctx, memberID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		accessMode, err := context.DeterminePackageAccessMode(ctx, member, ctx.Doer)
+		if err != nil {
+			return nil, err
+		}
+		if accessMode < perm.AccessModeRead {
+			continue
+		}
+
+		sources = append(sources, member)
+	}
+	return sources, nil
+}
+
+// GetVirtualVersionsByPackageName gets the versions of the package of all virtual sources.
+// If a version exists in multiple sources, the version of the source with the highest priority is returned.
+func GetVirtualVersionsByPackageName(ctx *context.Context, packageType packages_model.Type, name string) ([]*packages_model.PackageVersion, error) {
+	sources, err := GetVirtualSources(ctx, packageType)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(container.Set[string])
+	result := make([]*packages_model.PackageVersion, 0, 10)
+	for _, source := range sources {
+		pvs, err := packages_model.GetVersionsByPackageName(ctx, source.ID, packageType, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, pv := range pvs {
+			if seen.Add(strings.ToLower(pv.Version)) {
+				result = append(result, pv)
+			}
+		}
+	}
+	return result, nil
+}
+
+// ResolveVirtualOwner returns the virtual source with the highest priority which contains the package version.
+// If no source contains the version, the package owner is returned.
+func ResolveVirtualOwner(ctx *context.Context, packageType packages_model.Type, name, version string) (*user_model.User, error) {
+	sources, err := GetVirtualSources(ctx, packageType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, source := range sources {
+		_, err := packages_model.GetVersionByNameAndVersion(ctx, source.ID, packageType, name, version)
+		if err == nil {
+			return source, nil
+		}
+		if !errors.Is(err, packages_model.ErrPackageNotExist) {
+			return nil, err
+		}
+	}
+	return ctx.Package.Owner, nil
+}
@@ -0,0 +1,411 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	std_ctx "context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	notify_service "code.gitea.io/gitea/services/notify"
+	packages_service "code.gitea.io/gitea/services/packages"
+	hex_service "code.gitea.io/gitea/services/packages/hex"
+
+	"github.com/hashicorp/go-version"
+)
+
+const erlangContentType = "application/vnd.hex+erlang"
+
+// apiResponse writes the response of the HTTP API. The Hex clients request the Erlang external term format.
+// https://github.com/hexpm/specifications/blob/main/http_api.md
+func apiResponse(ctx *context.Context, status int, obj map[string]any) {
+	if strings.Contains(ctx.Req.Header.Get("Accept"), erlangContentType) {
+		data, err := hex_module.EncodeTerm(obj)
+		if err != nil {
+			ctx.HTTPError(http.StatusInternalServerError, err.Error())
+			return
+		}
+		ctx.Resp.Header().Set("Content-Type", erlangContentType)
+		ctx.Resp.WriteHeader(status)
+		_, _ = ctx.Resp.Write(data)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Resp.WriteHeader(status)
+	_ = json.NewEncoder(ctx.Resp).Encode(obj)
+}
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	apiResponse(ctx, status, map[string]any{
+		"status":  status,
+		"message": message,
+	})
+}
+
+func registryURL(ctx *context.Context) string {
+	return setting.AppURL + "api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/hex"
+}
+
+// serveSignedResource signs and serves a registry resource. The owner name is used as repository name.
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+func serveSignedResource(ctx *context.Context, payload []byte) {
+	key, err := hex_service.GetOrCreatePrivateKey(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	data, err := hex_module.SignAndCompress(payload, key)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/octet-stream")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(data)
+}
+
+// getPackageVersions gets all versions of all packages grouped by the package name
+func getPackageVersions(ctx *context.Context) (map[string][]*packages_model.PackageVersion, error) {
+	ps, err := packages_model.GetPackagesByType(ctx, ctx.Package.Owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, ctx.Package.Owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(ps))
+	for _, p := range ps {
+		names[p.ID] = p.Name
+	}
+
+	versions := make(map[string][]*packages_model.PackageVersion, len(ps))
+	for _, pv := range pvs {
+		if name, ok := names[pv.PackageID]; ok {
+			versions[name] = append(versions[name], pv)
+		}
+	}
+	return versions, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func compareVersions(a, b string) int {
+	va, erra := version.NewSemver(a)
+	vb, errb := version.NewSemver(b)
+	if erra != nil || errb != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
+
+// EnumeratePackageNames serves the /names resource
+func EnumeratePackageNames(ctx *context.Context) {
+	versions, err := getPackageVersions(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	packages := make([]*hex_module.NamesPackage, 0, len(versions))
+	for _, name := range sortedKeys(versions) {
+		packages = append(packages, &hex_module.NamesPackage{Name: name})
+	}
+
+	serveSignedResource(ctx, hex_module.EncodeNames(ctx.Package.Owner.Name, packages))
+}
+
+// EnumeratePackageVersions serves the /versions resource
+func EnumeratePackageVersions(ctx *context.Context) {
+	versions, err := getPackageVersions(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	packages := make([]*hex_module.VersionsPackage, 0, len(versions))
+	for _, name := range sortedKeys(versions) {
+		vs := make([]string, 0, len(versions[name]))
+		for _, pv := range versions[name] {
+			vs = append(vs, pv.Version)
+		}
+		slices.SortFunc(vs, compareVersions)
+
+		packages = append(packages, &hex_module.VersionsPackage{
+			Name:     name,
+			Versions: vs,
+		})
+	}
+
+	serveSignedResource(ctx, hex_module.EncodeVersions(ctx.Package.Owner.Name, packages))
+}
+
+// PackageReleases serves the /packages/<name> resource
+func PackageReleases(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, ctx.PathParam("name"))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	slices.SortFunc(pds, func(a, b *packages_model.PackageDescriptor) int {
+		return compareVersions(a.Version.Version, b.Version.Version)
+	})
+
+	releases := make([]*hex_module.Release, 0, len(pds))
+	for _, pd := range pds {
+		if len(pd.Files) == 0 {
+			continue
+		}
+
+		innerChecksum, err := hex.DecodeString(pd.VersionProperties.GetByName(hex_module.PropertyInnerChecksum))
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		outerChecksum, err := hex.DecodeString(pd.Files[0].Blob.HashSHA256)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		releases = append(releases, &hex_module.Release{
+			Version:       pd.Version.Version,
+			InnerChecksum: innerChecksum,
+			OuterChecksum: outerChecksum,
+			Dependencies:  pd.Metadata.(*hex_module.Metadata).Requirements,
+		})
+	}
+
+	serveSignedResource(ctx, hex_module.EncodePackage(ctx.Package.Owner.Name, pds[0].Package.Name, releases))
+}
+
+// PublicKey serves the public key to verify the registry resources
+func PublicKey(ctx *context.Context) {
+	pub, err := hex_service.GetOrCreatePublicKey(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.PlainText(http.StatusOK, pub)
+}
+
+func createFilename(packageName, packageVersion string) string {
+	return fmt.Sprintf("%s-%s.tar", packageName, packageVersion)
+}
+
+// DownloadPackageFile serves the package tarball
+func DownloadPackageFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+
+	// package names can't contain a dash
+	packageName, packageVersion, ok := strings.Cut(strings.TrimSuffix(filename, ".tar"), "-")
+	if !ok || !strings.HasSuffix(filename, ".tar") {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        packageName,
+			Version:     packageVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// releaseResponse creates the release object returned by the HTTP API
+func releaseResponse(ctx *context.Context, packageName, packageVersion string) map[string]any {
+	return map[string]any{
+		"version":     packageVersion,
+		"url":         registryURL(ctx) + "/api/packages/" + url.PathEscape(packageName) + "/releases/" + url.PathEscape(packageVersion),
+		"package_url": registryURL(ctx) + "/api/packages/" + url.PathEscape(packageName),
+		"html_url":    fmt.Sprintf("%s%s/-/packages/%s/%s/%s", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name), packages_model.TypeHex, url.PathEscape(packageName), url.PathEscape(packageVersion)),
+	}
+}
+
+// UploadPackage publishes a package tarball. An existing release is replaced if the "replace" parameter is set.
+// https://github.com/hexpm/hex_core/blob/main/src/hex_api_release.erl
+func UploadPackage(ctx *context.Context) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	pck, err := hex_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pvi := packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypeHex,
+		Name:        pck.Name,
+		Version:     pck.Version,
+	}
+
+	// the replaced release is deleted in the same transaction, so it is kept if the new one is rejected
+	var replaced *packages_model.PackageDescriptor
+
+	err = db.WithTx(ctx, func(dbCtx std_ctx.Context) error {
+		if ctx.FormBool("replace") {
+			pv, err := packages_model.GetVersionByNameAndVersion(dbCtx, pvi.Owner.ID, pvi.PackageType, pvi.Name, pvi.Version)
+			if err == nil {
+				if err := packages_service.CheckPackageVersionMutable(dbCtx, ctx.Doer, pvi.Owner.ID); err != nil {
+					return err
+				}
+				if replaced, err = packages_model.GetPackageDescriptor(dbCtx, pv); err != nil {
+					return err
+				}
+				if err := packages_service.DeletePackageVersionAndReferences(dbCtx, pv); err != nil {
+					return err
+				}
+			} else if !errors.Is(err, packages_model.ErrPackageNotExist) {
+				return err
+			}
+		}
+
+		_, _, err := packages_service.CreatePackageAndAddFile(
+			dbCtx,
+			&packages_service.PackageCreationInfo{
+				PackageInfo:      pvi,
+				SemverCompatible: true,
+				Creator:          ctx.Doer,
+				Metadata:         pck.Metadata,
+				VersionProperties: map[string]string{
+					hex_module.PropertyInnerChecksum: pck.InnerChecksum,
+				},
+			},
+			&packages_service.PackageFileCreationInfo{
+				PackageFileInfo: packages_service.PackageFileInfo{
+					Filename: createFilename(pck.Name, pck.Version),
+				},
+				Creator: ctx.Doer,
+				Data:    buf,
+				IsLead:  true,
+			},
+		)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, packages_model.ErrDuplicatePackageVersion):
+			apiError(ctx, http.StatusConflict, errors.New("release already exists, use the replace option to overwrite it"))
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			apiError(ctx, http.StatusForbidden, err)
+		case errors.Is(err, packages_service.ErrImmutablePackageVersion):
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if replaced != nil {
+		notify_service.PackageDelete(ctx, ctx.Doer, replaced)
+	}
+
+	apiResponse(ctx, http.StatusCreated, releaseResponse(ctx, pck.Name, pck.Version))
+}
+
+// DeletePackageVersion reverts a release
+func DeletePackageVersion(ctx *context.Context) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        ctx.PathParam("name"),
+			Version:     ctx.PathParam("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else if errors.Is(err, packages_service.ErrImmutablePackageVersion) {
+			apiError(ctx, http.StatusForbidden, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
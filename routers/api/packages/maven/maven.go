@@ -28,6 +28,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
 )
 
 const (
@@ -80,18 +81,27 @@ func handlePackageFile(ctx *context.Context, serveContent bool) {
 func serveMavenMetadata(ctx *context.Context, params parameters) {
 	// path pattern: /com/foo/project/maven-metadata.xml[.md5/.sha1/.sha256/.sha512]
 	// in case there are legacy package names ("GroupID-ArtifactID") we need to check both, new packages always use ":" as separator("GroupID:ArtifactID")
-	pvsLegacy, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy())
+	pvsLegacy, err := helper.GetVirtualVersionsByPackageName(ctx, packages_model.TypeMaven, params.toInternalPackageNameLegacy())
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName())
+	pvs, err := helper.GetVirtualVersionsByPackageName(ctx, packages_model.TypeMaven, params.toInternalPackageName())
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	pvs = append(pvsLegacy, pvs...)
 
+	isCachedOrMissing, err := remote_service.IsCachedOrMissing(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if isCachedOrMissing && serveRemoteMavenMetadata(ctx, len(pvs) > 0) {
+		return
+	}
+
 	if len(pvs) == 0 {
 		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
 		return
@@ -147,20 +157,36 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	_, _ = ctx.Resp.Write(xmlMetadataWithHeader)
 }
 
-func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
-	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
+// getPackageFile gets the file of the first virtual source which contains it
+func getPackageFile(ctx *context.Context, params parameters, filename string) (*packages_model.PackageFile, error) {
+	sources, err := helper.GetVirtualSources(ctx, packages_model.TypeMaven)
+	if err != nil {
+		return nil, err
+	}
+
+	err = packages_model.ErrPackageNotExist
+	for _, source := range sources {
+		var pf *packages_model.PackageFile
+		pf, err = getOwnerPackageFile(ctx, source.ID, params, filename)
+		if !errors.Is(err, util.ErrNotExist) {
+			return pf, err
+		}
+	}
+	return nil, err
+}
+
+func getOwnerPackageFile(ctx *context.Context, ownerID int64, params parameters, filename string) (*packages_model.PackageFile, error) {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ownerID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
 	if errors.Is(err, util.ErrNotExist) {
-		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
+		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ownerID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
 	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
-		}
-		return
+		return nil, err
 	}
+	return packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+}
 
+func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
 	filename := params.Filename
 
 	ext := strings.ToLower(path.Ext(filename))
@@ -168,9 +194,24 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 		filename = filename[:len(filename)-len(ext)]
 	}
 
-	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+	pf, err := getPackageFile(ctx, params, filename)
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		// snapshot metadata changes over time and is not cached as a file
+		if params.IsMeta {
+			if serveRemoteMavenMetadata(ctx, false) {
+				return
+			}
+		} else if cacheErr := cacheRemotePackageFile(ctx, params, filename); cacheErr != nil {
+			if !errors.Is(cacheErr, packages_model.ErrPackageRemoteNotExist) {
+				apiError(ctx, helper.RemoteErrorStatus(cacheErr), cacheErr)
+				return
+			}
+		} else {
+			pf, err = getPackageFile(ctx, params, filename)
+		}
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -0,0 +1,114 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package maven
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	maven_module "code.gitea.io/gitea/modules/packages/maven"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+)
+
+// serveRemoteMavenMetadata serves the maven-metadata.xml (or its checksum) of the remote registry.
+// It returns false if nothing was written because there is no remote or the remote failed but a cached version exists.
+func serveRemoteMavenMetadata(ctx *context.Context, hasCachedVersions bool) bool {
+	c, err := remote_service.GetClient(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven)
+	if err != nil {
+		if !errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return true
+		}
+		return false
+	}
+
+	m, err := c.GetMetadata(ctx, c.EscapedURL(ctx.PathParam("*")), nil)
+	if err != nil {
+		if hasCachedVersions {
+			log.Warn("Unable to fetch %s from remote, serving cached versions: %v", ctx.PathParam("*"), err)
+			return false
+		}
+		apiError(ctx, helper.RemoteErrorStatus(err), err)
+		return true
+	}
+
+	if isChecksumExtension(strings.ToLower(path.Ext(ctx.PathParam("*")))) {
+		ctx.PlainText(http.StatusOK, strings.TrimSpace(string(m.Content)))
+		return true
+	}
+
+	ctx.Resp.Header().Set("Content-Length", strconv.Itoa(len(m.Content)))
+	ctx.Resp.Header().Set("Content-Type", contentTypeXML)
+	_, _ = ctx.Resp.Write(m.Content)
+	return true
+}
+
+// cacheRemotePackageFile fetches a file of a package version from the remote registry
+func cacheRemotePackageFile(ctx *context.Context, params parameters, filename string) error {
+	c, err := remote_service.GetClient(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven)
+	if err != nil {
+		return err
+	}
+
+	// the remote uses the same layout: /com/group/id/artifactId/version/filename
+	fileURL := c.EscapedURL(path.Join(path.Dir(ctx.PathParam("*")), filename))
+
+	var expectedSHA1 string
+	if m, err := c.GetMetadata(ctx, fileURL+extensionSHA1, nil); err == nil {
+		// checksum files may contain the filename after the hash
+		expectedSHA1, _, _ = strings.Cut(strings.TrimSpace(string(m.Content)), " ")
+	} else if !errors.Is(err, util.ErrNotExist) {
+		return err
+	}
+
+	pvci := &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeMaven,
+			Name:        params.toInternalPackageName(),
+			Version:     params.Version,
+		},
+		SemverCompatible: false,
+		Creator:          remote_service.Creator(ctx.Doer),
+	}
+	isPom := strings.ToLower(path.Ext(filename)) == extensionPom
+
+	return c.CacheFile(ctx, &remote_service.CacheFileOptions{
+		URL: fileURL,
+		Verify: func(buf *packages_module.HashedBuffer) error {
+			if expectedSHA1 != "" {
+				if err := remote_service.VerifyChecksum(buf, "sha1", expectedSHA1); err != nil {
+					return err
+				}
+			}
+			if isPom {
+				metadata, err := maven_module.ParsePackageMetaData(buf)
+				if err != nil {
+					log.Warn("Unable to parse pom %s of remote: %v", filename, err)
+					return nil
+				}
+				pvci.Metadata = metadata
+			}
+			return nil
+		},
+		PackageCreation: pvci,
+		FileCreation: &packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator: remote_service.Creator(ctx.Doer),
+			IsLead:  isPom,
+		},
+	})
+}
@@ -15,21 +15,25 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 )
 
+// createPackageMetadataResponse creates the packument of the package versions.
+// If the versions come from multiple sources, a dist tag points to the version of the first source which has the tag.
 func createPackageMetadataResponse(registryURL string, pds []*packages_model.PackageDescriptor) *npm_module.PackageMetadata {
+	distTags := make(map[string]string)
+	for _, pd := range pds {
+		for _, pvp := range pd.VersionProperties {
+			if _, has := distTags[pvp.Value]; pvp.Name == npm_module.TagProperty && !has {
+				distTags[pvp.Value] = pd.Version.Version
+			}
+		}
+	}
+
 	sort.Slice(pds, func(i, j int) bool {
 		return pds[i].SemVer.LessThan(pds[j].SemVer)
 	})
 
 	versions := make(map[string]*npm_module.PackageMetadataVersion)
-	distTags := make(map[string]string)
 	for _, pd := range pds {
 		versions[pd.SemVer.String()] = createPackageMetadataVersion(registryURL, pd)
-
-		for _, pvp := range pd.VersionProperties {
-			if pvp.Name == npm_module.TagProperty {
-				distTags[pvp.Value] = pd.Version.Version
-			}
-		}
 	}
 
 	latest := pds[len(pds)-1]
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"code.gitea.io/gitea/models/db"
@@ -25,6 +26,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
 
 	"github.com/hashicorp/go-version"
 )
@@ -54,21 +56,38 @@ func packageNameFromParams(ctx *context.Context) string {
 func PackageMetadata(ctx *context.Context) {
 	packageName := packageNameFromParams(ctx)
 
-	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
+	pvs, err := helper.GetVirtualVersionsByPackageName(ctx, packages_model.TypeNpm, packageName)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	// the remote registry of the owner is used if the owner has no local package, the versions of the virtual members are merged into it
+	ownerPvs := make([]*packages_model.PackageVersion, 0, len(pds))
+	for _, pd := range pds {
+		if pd.Owner.ID == ctx.Package.Owner.ID {
+			ownerPvs = append(ownerPvs, pd.Version)
+		}
+	}
+	isCachedOrMissing, err := remote_service.IsCachedOrMissing(ctx, ownerPvs)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	if isCachedOrMissing && serveRemotePackageMetadata(ctx, packageName, pds) {
+		return
+	}
+
+	if len(pds) == 0 {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
 
 	resp := createPackageMetadataResponse(
 		setting.AppURL+"api/packages/"+ctx.Package.Owner.Name+"/npm",
@@ -84,19 +103,39 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypeNpm,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	owner, err := helper.ResolveVirtualOwner(ctx, packages_model.TypeNpm, packageName, packageVersion)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	openFile := func() (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+		return packages_service.OpenFileForDownloadByPackageNameAndVersion(
+			ctx,
+			&packages_service.PackageInfo{
+				Owner:       owner,
+				PackageType: packages_model.TypeNpm,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			&packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			ctx.Req.Method,
+		)
+	}
+
+	s, u, pf, err := openFile()
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if cacheErr := cacheRemotePackageFile(ctx, packageName, packageVersion, filename); cacheErr != nil {
+			if !errors.Is(cacheErr, packages_model.ErrPackageRemoteNotExist) {
+				apiError(ctx, helper.RemoteErrorStatus(cacheErr), cacheErr)
+				return
+			}
+		} else {
+			s, u, pf, err = openFile()
+		}
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -113,20 +152,32 @@ func DownloadPackageFile(ctx *context.Context) {
 func DownloadPackageFileByName(ctx *context.Context) {
 	filename := ctx.PathParam("filename")
 
-	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
-		OwnerID: ctx.Package.Owner.ID,
-		Type:    packages_model.TypeNpm,
-		Name: packages_model.SearchValue{
-			ExactMatch: true,
-			Value:      packageNameFromParams(ctx),
-		},
-		HasFileWithName: filename,
-		IsInternal:      optional.Some(false),
-	})
+	sources, err := helper.GetVirtualSources(ctx, packages_model.TypeNpm)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+
+	var pvs []*packages_model.PackageVersion
+	for _, source := range sources {
+		pvs, _, err = packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+			OwnerID: source.ID,
+			Type:    packages_model.TypeNpm,
+			Name: packages_model.SearchValue{
+				ExactMatch: true,
+				Value:      packageNameFromParams(ctx),
+			},
+			HasFileWithName: filename,
+			IsInternal:      optional.Some(false),
+		})
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if len(pvs) != 0 {
+			break
+		}
+	}
 	if len(pvs) != 1 {
 		apiError(ctx, http.StatusNotFound, nil)
 		return
@@ -214,10 +265,12 @@ func UploadPackage(ctx *context.Context) {
 		},
 	)
 	if err != nil {
-		switch err {
-		case packages_model.ErrDuplicatePackageVersion:
+		switch {
+		case errors.Is(err, packages_model.ErrDuplicatePackageVersion):
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			apiError(ctx, http.StatusForbidden, err)
+		case errors.Is(err, packages_service.ErrImmutablePackageVersion):
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -272,6 +325,10 @@ func DeletePackageVersion(ctx *context.Context) {
 			apiError(ctx, http.StatusNotFound, err)
 			return
 		}
+		if errors.Is(err, util.ErrPermissionDenied) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
@@ -296,7 +353,11 @@ func DeletePackage(ctx *context.Context) {
 
 	for _, pv := range pvs {
 		if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
-			apiError(ctx, http.StatusInternalServerError, err)
+			if errors.Is(err, util.ErrPermissionDenied) {
+				apiError(ctx, http.StatusForbidden, err)
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
 			return
 		}
 	}
@@ -0,0 +1,155 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+
+	"github.com/hashicorp/go-version"
+)
+
+func getRemotePackageMetadata(ctx *context.Context, c *remote_service.Client, packageName string) (*npm_module.PackageMetadata, error) {
+	// scoped packages are requested as @scope%2fname
+	m, err := c.GetMetadata(ctx, c.EscapedURL(strings.Replace(url.PathEscape(packageName), "%40", "@", 1)), http.Header{"Accept": []string{"application/json"}})
+	if err != nil {
+		return nil, err
+	}
+	var metadata npm_module.PackageMetadata
+	if err := json.Unmarshal(m.Content, &metadata); err != nil {
+		return nil, fmt.Errorf("%w: invalid package metadata: %w", remote_service.ErrRemoteRequestFailed, err)
+	}
+	return &metadata, nil
+}
+
+// serveRemotePackageMetadata serves the package metadata of the remote registry with tarballs pointing to this registry.
+// The local versions of the virtual sources take precedence over the versions of the remote with the same version string.
+// It returns false if nothing was written because there is no remote or the remote failed but a local version exists.
+func serveRemotePackageMetadata(ctx *context.Context, packageName string, pds []*packages_model.PackageDescriptor) bool {
+	c, err := remote_service.GetClient(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm)
+	if err != nil {
+		if !errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return true
+		}
+		return false
+	}
+
+	metadata, err := getRemotePackageMetadata(ctx, c, packageName)
+	if err != nil {
+		if len(pds) > 0 {
+			log.Warn("Unable to fetch metadata of npm package %s from remote, serving local versions: %v", packageName, err)
+			return false
+		}
+		apiError(ctx, helper.RemoteErrorStatus(err), err)
+		return true
+	}
+
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/npm"
+	for v, pmv := range metadata.Versions {
+		if pmv == nil {
+			delete(metadata.Versions, v)
+			continue
+		}
+		pmv.Dist.Tarball = fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(packageName), url.PathEscape(v), url.PathEscape(path.Base(pmv.Dist.Tarball)))
+	}
+
+	if len(pds) > 0 {
+		local := createPackageMetadataResponse(registryURL, pds)
+		if metadata.Versions == nil {
+			metadata.Versions = make(map[string]*npm_module.PackageMetadataVersion, len(local.Versions))
+		}
+		maps.Copy(metadata.Versions, local.Versions)
+		// the tags of the remote are current, the cached versions may carry outdated ones
+		if metadata.DistTags == nil {
+			metadata.DistTags = make(map[string]string, len(local.DistTags))
+		}
+		for tag, version := range local.DistTags {
+			if _, has := metadata.DistTags[tag]; !has {
+				metadata.DistTags[tag] = version
+			}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, metadata)
+	return true
+}
+
+func verifyIntegrity(dist *npm_module.PackageDistribution, buf *packages_module.HashedBuffer) error {
+	if algorithm, value, ok := strings.Cut(dist.Integrity, "-"); ok && algorithm == "sha512" {
+		hash, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("%w: invalid integrity", remote_service.ErrRemoteRequestFailed)
+		}
+		return remote_service.VerifyChecksum(buf, algorithm, hex.EncodeToString(hash))
+	}
+	if dist.Shasum != "" {
+		return remote_service.VerifyChecksum(buf, "sha1", dist.Shasum)
+	}
+	return nil
+}
+
+// cacheRemotePackageFile fetches the tarball of a package version from the remote registry
+func cacheRemotePackageFile(ctx *context.Context, packageName, packageVersion, filename string) error {
+	c, err := remote_service.GetClient(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := getRemotePackageMetadata(ctx, c, packageName)
+	if err != nil {
+		return err
+	}
+	pmv, ok := metadata.Versions[packageVersion]
+	if !ok || pmv == nil || path.Base(pmv.Dist.Tarball) != filename {
+		return packages_model.ErrPackageFileNotExist
+	}
+	v, err := version.NewSemver(packageVersion)
+	if err != nil {
+		return packages_model.ErrPackageNotExist
+	}
+
+	return c.CacheFile(ctx, &remote_service.CacheFileOptions{
+		URL: c.URL(pmv.Dist.Tarball),
+		Verify: func(buf *packages_module.HashedBuffer) error {
+			return verifyIntegrity(&pmv.Dist, buf)
+		},
+		PackageCreation: &packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeNpm,
+				Name:        packageName,
+				Version:     v.String(),
+			},
+			SemverCompatible: true,
+			Creator:          remote_service.Creator(ctx.Doer),
+			Metadata:         npm_module.NewMetadata(pmv),
+		},
+		FileCreation: &packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator: remote_service.Creator(ctx.Doer),
+			IsLead:  true,
+		},
+	})
+}
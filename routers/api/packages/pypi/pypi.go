@@ -4,16 +4,22 @@
 package pypi
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
 	"code.gitea.io/gitea/modules/setting"
@@ -21,6 +27,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
 )
 
 // https://peps.python.org/pep-0426/#name
@@ -39,6 +46,14 @@ var versionMatcher = regexp.MustCompile(`\Av?` +
 	`(?:\+[a-z0-9]+(?:[-_\.][a-z0-9]+)*)?` + // local version
 	`\z`)
 
+const (
+	simpleAPIVersion            = "1.0"
+	simpleJSONContentType       = "application/vnd.pypi.simple.v1+json"
+	simpleJSONLatestContentType = "application/vnd.pypi.simple.latest+json"
+	simpleHTMLContentType       = "application/vnd.pypi.simple.v1+html"
+	simpleHTMLLatestContentType = "application/vnd.pypi.simple.latest+html"
+)
+
 func apiError(ctx *context.Context, status int, obj any) {
 	message := helper.ProcessErrorForUser(ctx, status, obj)
 	ctx.PlainText(status, message)
@@ -48,11 +63,21 @@ func apiError(ctx *context.Context, status int, obj any) {
 func PackageMetadata(ctx *context.Context) {
 	packageName := normalizer.Replace(ctx.PathParam("id"))
 
-	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI, packageName)
+	pvs, err := helper.GetVirtualVersionsByPackageName(ctx, packages_model.TypePyPI, packageName)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	isCachedOrMissing, err := remote_service.IsCachedOrMissing(ctx, pvs)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	if isCachedOrMissing && serveRemotePackageMetadata(ctx, packageName, len(pvs) > 0) {
+		return
+	}
+
 	if len(pvs) == 0 {
 		apiError(ctx, http.StatusNotFound, err)
 		return
@@ -69,10 +94,182 @@ func PackageMetadata(ctx *context.Context) {
 		return strings.Compare(pds[i].Version.Version, pds[j].Version.Version) < 0
 	})
 
-	ctx.Data["RegistryURL"] = setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
-	ctx.Data["PackageDescriptor"] = pds[0]
-	ctx.Data["PackageDescriptors"] = pds
-	ctx.HTML(http.StatusOK, "api/packages/pypi/simple")
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
+	links := make([]*simpleLink, 0, len(pds))
+	for _, pd := range pds {
+		metadata := pd.Metadata.(*pypi_module.Metadata)
+		yanked, yankedReason := getYanked(pd)
+
+		metadataFiles := make(map[string]*packages_model.PackageFileDescriptor)
+		for _, pf := range pd.Files {
+			if name, ok := strings.CutSuffix(pf.File.Name, pypi_module.MetadataFileSuffix); ok {
+				metadataFiles[name] = pf
+			}
+		}
+
+		for _, pf := range pd.Files {
+			if strings.HasSuffix(pf.File.Name, pypi_module.MetadataFileSuffix) {
+				continue
+			}
+
+			link := &simpleLink{
+				URL:            fileURL(registryURL, pd.Package.LowerName, pd.Version.Version, pf.File.Name),
+				Name:           pf.File.Name,
+				SHA256:         pf.Blob.HashSHA256,
+				RequiresPython: metadata.RequiresPython,
+				Yanked:         yanked,
+				YankedReason:   yankedReason,
+			}
+			if mf, ok := metadataFiles[pf.File.Name]; ok {
+				link.MetadataSHA256 = mf.Blob.HashSHA256
+			}
+			links = append(links, link)
+		}
+	}
+
+	serveSimplePage(ctx, pds[0].Package.Name, links)
+}
+
+// getYanked returns if the version is yanked and the optional reason
+// https://peps.python.org/pep-0592/
+func getYanked(pd *packages_model.PackageDescriptor) (bool, string) {
+	for _, pp := range pd.VersionProperties {
+		if pp.Name == pypi_module.PropertyYanked {
+			return true, pp.Value
+		}
+	}
+	return false, ""
+}
+
+// simpleLink is a file entry of the simple repository API
+type simpleLink struct {
+	URL            string
+	Name           string
+	SHA256         string
+	RequiresPython string
+	MetadataSHA256 string
+	Yanked         bool
+	YankedReason   string
+}
+
+// https://peps.python.org/pep-0691/#json-serialization
+type simpleJSONResponse struct {
+	Meta  simpleJSONMeta    `json:"meta"`
+	Name  string            `json:"name"`
+	Files []*simpleJSONFile `json:"files"`
+}
+
+type simpleJSONMeta struct {
+	APIVersion string `json:"api-version"`
+}
+
+type simpleJSONFile struct {
+	Filename       string            `json:"filename"`
+	URL            string            `json:"url"`
+	Hashes         map[string]string `json:"hashes"`
+	RequiresPython string            `json:"requires-python,omitempty"`
+	// PEP 714 renamed dist-info-metadata to core-metadata, both are sent for older clients
+	CoreMetadata     any `json:"core-metadata,omitempty"`
+	DistInfoMetadata any `json:"dist-info-metadata,omitempty"`
+	// false, true or the reason why the file was yanked
+	Yanked any `json:"yanked,omitempty"`
+}
+
+func fileURL(registryURL, packageName, packageVersion, filename string) string {
+	return registryURL + "/files/" + packageName + "/" + packageVersion + "/" + filename
+}
+
+// negotiateSimpleContentType selects the content type of the simple repository API response from the Accept header.
+// An empty string is returned if no supported content type is acceptable.
+// https://peps.python.org/pep-0691/#version-format-selection
+func negotiateSimpleContentType(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return "text/html"
+	}
+
+	var contentType string
+	var quality float64
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		if q <= quality {
+			continue
+		}
+
+		switch mediaType {
+		case simpleJSONContentType, simpleJSONLatestContentType:
+			contentType = simpleJSONContentType
+		case simpleHTMLContentType, simpleHTMLLatestContentType:
+			contentType = simpleHTMLContentType
+		case "text/html", "text/*", "*/*":
+			contentType = "text/html"
+		default:
+			continue
+		}
+		quality = q
+	}
+	return contentType
+}
+
+func serveSimplePage(ctx *context.Context, packageName string, links []*simpleLink) {
+	contentType := negotiateSimpleContentType(ctx.Req.Header.Get("Accept"))
+
+	ctx.Resp.Header().Add("Vary", "Accept")
+
+	switch contentType {
+	case "":
+		apiError(ctx, http.StatusNotAcceptable, "unsupported content type")
+	case simpleJSONContentType:
+		files := make([]*simpleJSONFile, 0, len(links))
+		for _, link := range links {
+			f := &simpleJSONFile{
+				Filename:       link.Name,
+				URL:            link.URL,
+				Hashes:         map[string]string{},
+				RequiresPython: link.RequiresPython,
+			}
+			if link.SHA256 != "" {
+				f.Hashes["sha256"] = link.SHA256
+			}
+			if link.MetadataSHA256 != "" {
+				f.CoreMetadata = map[string]string{"sha256": link.MetadataSHA256}
+				f.DistInfoMetadata = f.CoreMetadata
+			}
+			if link.Yanked {
+				if link.YankedReason != "" {
+					f.Yanked = link.YankedReason
+				} else {
+					f.Yanked = true
+				}
+			}
+			files = append(files, f)
+		}
+
+		ctx.Resp.Header().Set("Content-Type", simpleJSONContentType)
+		ctx.Resp.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(ctx.Resp).Encode(&simpleJSONResponse{
+			Meta:  simpleJSONMeta{APIVersion: simpleAPIVersion},
+			Name:  packageName,
+			Files: files,
+		}); err != nil {
+			log.Error("Render JSON failed: %v", err)
+		}
+	default:
+		ctx.Resp.Header().Set("Content-Type", contentType+"; charset=utf-8")
+		ctx.Data["APIVersion"] = simpleAPIVersion
+		ctx.Data["PackageName"] = packageName
+		ctx.Data["Links"] = links
+		ctx.HTML(http.StatusOK, "api/packages/pypi/simple")
+	}
 }
 
 // DownloadPackageFile serves the content of a package
@@ -81,19 +278,39 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypePyPI,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	owner, err := helper.ResolveVirtualOwner(ctx, packages_model.TypePyPI, packageName, packageVersion)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	openFile := func() (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+		return packages_service.OpenFileForDownloadByPackageNameAndVersion(
+			ctx,
+			&packages_service.PackageInfo{
+				Owner:       owner,
+				PackageType: packages_model.TypePyPI,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			&packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			ctx.Req.Method,
+		)
+	}
+
+	s, u, pf, err := openFile()
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if cacheErr := cacheRemotePackageFile(ctx, packageName, packageVersion, filename); cacheErr != nil {
+			if !errors.Is(cacheErr, packages_model.ErrPackageRemoteNotExist) {
+				apiError(ctx, helper.RemoteErrorStatus(cacheErr), cacheErr)
+				return
+			}
+		} else {
+			s, u, pf, err = openFile()
+		}
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -167,15 +384,34 @@ func UploadPackageFile(ctx *context.Context) {
 		homepageURL = ""
 	}
 
+	var coreMetadata *pypi_module.CoreMetadata
+	if strings.HasSuffix(strings.ToLower(fileHeader.Filename), ".whl") {
+		coreMetadata, err = pypi_module.ExtractWheelMetadata(buf, buf.Size())
+		if err != nil {
+			log.Debug("Unable to extract the metadata of wheel %s: %v", fileHeader.Filename, err)
+		}
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	requiresPython := ctx.Req.FormValue("requires_python")
+	if requiresPython == "" && coreMetadata != nil {
+		requiresPython = coreMetadata.RequiresPython
+	}
+
+	pi := packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypePyPI,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+
 	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
 		ctx,
 		&packages_service.PackageCreationInfo{
-			PackageInfo: packages_service.PackageInfo{
-				Owner:       ctx.Package.Owner,
-				PackageType: packages_model.TypePyPI,
-				Name:        packageName,
-				Version:     packageVersion,
-			},
+			PackageInfo:      pi,
 			SemverCompatible: false,
 			Creator:          ctx.Doer,
 			Metadata: &pypi_module.Metadata{
@@ -185,7 +421,8 @@ func UploadPackageFile(ctx *context.Context) {
 				Summary:         ctx.Req.FormValue("summary"),
 				ProjectURL:      homepageURL,
 				License:         ctx.Req.FormValue("license"),
-				RequiresPython:  ctx.Req.FormValue("requires_python"),
+				RequiresPython:  requiresPython,
+				RequiresDist:    ctx.Req.Form["requires_dist"],
 			},
 		},
 		&packages_service.PackageFileCreationInfo{
@@ -209,9 +446,48 @@ func UploadPackageFile(ctx *context.Context) {
 		return
 	}
 
+	if coreMetadata != nil {
+		// the distribution file is already stored, so an exceeded quota must not fail the upload because of the metadata file.
+		// The index only advertises the core metadata if the file exists.
+		if err := addCoreMetadataFile(ctx, &pi, fileHeader.Filename, coreMetadata); err != nil {
+			switch {
+			case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+				log.Warn("Unable to store the core metadata of %s: %v", fileHeader.Filename, err)
+			default:
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+
 	ctx.Status(http.StatusCreated)
 }
 
+// addCoreMetadataFile stores the core metadata of a distribution file as {filename}.metadata
+// https://peps.python.org/pep-0658/
+func addCoreMetadataFile(ctx *context.Context, pi *packages_service.PackageInfo, filename string, coreMetadata *pypi_module.CoreMetadata) error {
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(coreMetadata.Content))
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	_, err = packages_service.AddFileToExistingPackage(
+		ctx,
+		pi,
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename + pypi_module.MetadataFileSuffix,
+			},
+			Creator:           ctx.Doer,
+			Data:              buf,
+			IsLead:            false,
+			OverwriteExisting: true,
+		},
+	)
+	return err
+}
+
 // Normalizes a Project-URL label.
 // See https://packaging.python.org/en/latest/specifications/well-known-project-urls/#label-normalization.
 func normalizeLabel(label string) string {
@@ -4,9 +4,13 @@
 package pypi
 
 import (
+	"net/url"
 	"testing"
 
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsValidNameAndVersion(t *testing.T) {
@@ -46,3 +50,65 @@ func TestNormalizeLabel(t *testing.T) {
 	assert.Equal(t, "whatsnew", normalizeLabel("What's New?"))
 	assert.Equal(t, "github", normalizeLabel("github"))
 }
+
+func TestParseSimplePage(t *testing.T) {
+	baseURL, _ := url.Parse("https://pypi.example.com/simple/test-package/")
+
+	t.Run("HTML", func(t *testing.T) {
+		files, err := parseSimplePage(baseURL, &remote_service.Metadata{
+			ContentType: "text/html",
+			Content: []byte(`<html><body>
+<a href="../../files/test_package-1.0.1-py3-none-any.whl#sha256=abc" data-requires-python="&gt;=3.8">test_package-1.0.1-py3-none-any.whl</a><br>
+<a href="https://cdn.example.com/test-package-1.0.2.tar.gz" data-yanked="broken build">test-package-1.0.2.tar.gz</a><br>
+<a href="invalid.txt">invalid.txt</a>
+</body></html>`),
+		})
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+
+		assert.Equal(t, "test_package-1.0.1-py3-none-any.whl", files[0].Filename)
+		assert.Equal(t, "https://pypi.example.com/files/test_package-1.0.1-py3-none-any.whl", files[0].URL)
+		assert.Equal(t, "abc", files[0].SHA256)
+		assert.Equal(t, ">=3.8", files[0].RequiresPython)
+		assert.Equal(t, "1.0.1", files[0].Version)
+		assert.False(t, files[0].Yanked)
+
+		assert.Equal(t, "https://cdn.example.com/test-package-1.0.2.tar.gz", files[1].URL)
+		assert.Empty(t, files[1].SHA256)
+		assert.Equal(t, "1.0.2", files[1].Version)
+		assert.True(t, files[1].Yanked)
+		assert.Equal(t, "broken build", files[1].YankedReason)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		files, err := parseSimplePage(baseURL, &remote_service.Metadata{
+			ContentType: simpleJSONContentType,
+			Content:     []byte(`{"name":"test-package","files":[{"filename":"test-package-1.0.1.tar.gz","url":"/files/test-package-1.0.1.tar.gz","hashes":{"sha256":"def"},"requires-python":">=3.9","yanked":true}]}`),
+		})
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		assert.Equal(t, "https://pypi.example.com/files/test-package-1.0.1.tar.gz", files[0].URL)
+		assert.Equal(t, "def", files[0].SHA256)
+		assert.Equal(t, ">=3.9", files[0].RequiresPython)
+		assert.Equal(t, "1.0.1", files[0].Version)
+		assert.True(t, files[0].Yanked)
+		assert.Empty(t, files[0].YankedReason)
+	})
+}
+
+func TestNegotiateSimpleContentType(t *testing.T) {
+	cases := map[string]string{
+		"": "text/html",
+		"application/vnd.pypi.simple.v1+json, application/vnd.pypi.simple.v1+html; q=0.1, text/html; q=0.01": simpleJSONContentType,
+		"application/vnd.pypi.simple.latest+json":                                                            simpleJSONContentType,
+		"application/vnd.pypi.simple.v1+html":                                                                simpleHTMLContentType,
+		"text/html;q=0.5, application/vnd.pypi.simple.v1+json;q=0.2":                                         "text/html",
+		"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8":                                    "text/html",
+		"application/vnd.pypi.simple.v2+json":                                                                "",
+		"application/json":                                                                                   "",
+	}
+	for accept, expected := range cases {
+		assert.Equal(t, expected, negotiateSimpleContentType(accept), "Accept: %s", accept)
+	}
+}
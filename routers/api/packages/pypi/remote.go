@@ -0,0 +1,232 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+)
+
+var (
+	anchorMatcher         = regexp.MustCompile(`(?is)<a\s([^>]*)>(.*?)</a>`)
+	hrefMatcher           = regexp.MustCompile(`(?is)\bhref\s*=\s*"([^"]*)"`)
+	requiresPythonMatcher = regexp.MustCompile(`(?is)\bdata-requires-python\s*=\s*"([^"]*)"`)
+	yankedMatcher         = regexp.MustCompile(`(?is)\bdata-yanked(?:\s*=\s*"([^"]*)")?`)
+)
+
+// remoteFile is a file listed by the simple repository API of the remote registry
+type remoteFile struct {
+	Filename       string
+	URL            string
+	SHA256         string
+	RequiresPython string
+	Version        string
+	Yanked         bool
+	YankedReason   string
+}
+
+// versionFromFilename extracts the version of a wheel or source distribution filename
+func versionFromFilename(filename string) string {
+	if name, ok := strings.CutSuffix(filename, ".whl"); ok {
+		parts := strings.Split(name, "-")
+		if len(parts) < 5 {
+			return ""
+		}
+		return parts[1]
+	}
+	for _, ext := range []string{".tar.gz", ".tar.bz2", ".zip"} {
+		if name, ok := strings.CutSuffix(filename, ext); ok {
+			if pos := strings.LastIndexByte(name, '-'); pos != -1 {
+				return name[pos+1:]
+			}
+		}
+	}
+	return ""
+}
+
+func newRemoteFile(baseURL *url.URL, filename, rawURL, sha256, requiresPython string) *remoteFile {
+	version := versionFromFilename(filename)
+	if !versionMatcher.MatchString(version) {
+		return nil
+	}
+	u, err := baseURL.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	if sha256 == "" {
+		if algorithm, value, ok := strings.Cut(u.Fragment, "="); ok && algorithm == "sha256" {
+			sha256 = value
+		}
+	}
+	u.Fragment = ""
+	return &remoteFile{
+		Filename:       filename,
+		URL:            u.String(),
+		SHA256:         sha256,
+		RequiresPython: requiresPython,
+		Version:        version,
+	}
+}
+
+func parseSimplePage(baseURL *url.URL, m *remote_service.Metadata) ([]*remoteFile, error) {
+	files := make([]*remoteFile, 0, 10)
+	if strings.HasPrefix(m.ContentType, simpleJSONContentType) {
+		var resp simpleJSONResponse
+		if err := json.Unmarshal(m.Content, &resp); err != nil {
+			return nil, fmt.Errorf("%w: invalid simple page: %w", remote_service.ErrRemoteRequestFailed, err)
+		}
+		for _, f := range resp.Files {
+			if rf := newRemoteFile(baseURL, f.Filename, f.URL, f.Hashes["sha256"], f.RequiresPython); rf != nil {
+				switch yanked := f.Yanked.(type) {
+				case bool:
+					rf.Yanked = yanked
+				case string:
+					rf.Yanked, rf.YankedReason = true, yanked
+				}
+				files = append(files, rf)
+			}
+		}
+		return files, nil
+	}
+
+	for _, match := range anchorMatcher.FindAllStringSubmatch(string(m.Content), -1) {
+		href := hrefMatcher.FindStringSubmatch(match[1])
+		if href == nil {
+			continue
+		}
+		var requiresPython string
+		if rp := requiresPythonMatcher.FindStringSubmatch(match[1]); rp != nil {
+			requiresPython = html.UnescapeString(rp[1])
+		}
+		filename := strings.TrimSpace(html.UnescapeString(match[2]))
+		if rf := newRemoteFile(baseURL, filename, html.UnescapeString(href[1]), "", requiresPython); rf != nil {
+			if yanked := yankedMatcher.FindStringSubmatch(match[1]); yanked != nil {
+				rf.Yanked, rf.YankedReason = true, html.UnescapeString(yanked[1])
+			}
+			files = append(files, rf)
+		}
+	}
+	return files, nil
+}
+
+func getRemoteFiles(ctx *context.Context, c *remote_service.Client, packageName string) ([]*remoteFile, error) {
+	pageURL := c.EscapedURL(url.PathEscape(packageName)) + "/"
+	baseURL, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	m, err := c.GetMetadata(ctx, pageURL, http.Header{"Accept": []string{simpleJSONContentType + ", text/html;q=0.1"}})
+	if err != nil {
+		return nil, err
+	}
+	return parseSimplePage(baseURL, m)
+}
+
+// serveRemotePackageMetadata serves the simple page of the remote registry with links pointing to this registry.
+// It returns false if nothing was written because there is no remote or the remote failed but a cached version exists.
+func serveRemotePackageMetadata(ctx *context.Context, packageName string, hasCachedVersions bool) bool {
+	c, err := remote_service.GetClient(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI)
+	if err != nil {
+		if !errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return true
+		}
+		return false
+	}
+
+	files, err := getRemoteFiles(ctx, c, packageName)
+	if err != nil {
+		if hasCachedVersions {
+			log.Warn("Unable to fetch metadata of PyPI package %s from remote, serving cached versions: %v", packageName, err)
+			return false
+		}
+		apiError(ctx, helper.RemoteErrorStatus(err), err)
+		return true
+	}
+
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
+	links := make([]*simpleLink, 0, len(files))
+	for _, f := range files {
+		links = append(links, &simpleLink{
+			URL:            fileURL(registryURL, packageName, f.Version, f.Filename),
+			Name:           f.Filename,
+			SHA256:         f.SHA256,
+			RequiresPython: f.RequiresPython,
+			Yanked:         f.Yanked,
+			YankedReason:   f.YankedReason,
+		})
+	}
+
+	serveSimplePage(ctx, packageName, links)
+	return true
+}
+
+// cacheRemotePackageFile fetches a file of a package version from the remote registry
+func cacheRemotePackageFile(ctx *context.Context, packageName, packageVersion, filename string) error {
+	c, err := remote_service.GetClient(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI)
+	if err != nil {
+		return err
+	}
+
+	files, err := getRemoteFiles(ctx, c, packageName)
+	if err != nil {
+		return err
+	}
+
+	var file *remoteFile
+	for _, f := range files {
+		if f.Filename == filename && f.Version == packageVersion {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return packages_model.ErrPackageFileNotExist
+	}
+
+	return c.CacheFile(ctx, &remote_service.CacheFileOptions{
+		URL: file.URL,
+		Verify: func(buf *packages_module.HashedBuffer) error {
+			if file.SHA256 == "" {
+				return nil
+			}
+			return remote_service.VerifyChecksum(buf, "sha256", file.SHA256)
+		},
+		PackageCreation: &packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypePyPI,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			SemverCompatible: false,
+			Creator:          remote_service.Creator(ctx.Doer),
+			Metadata: &pypi_module.Metadata{
+				RequiresPython: file.RequiresPython,
+			},
+		},
+		FileCreation: &packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator: remote_service.Creator(ctx.Doer),
+		},
+	})
+}
@@ -255,6 +255,10 @@ func DeletePackageFile(webctx *context.Context) {
 	var pd *packages_model.PackageDescriptor
 
 	err := db.WithTx(webctx, func(ctx stdctx.Context) error {
+		if err := packages_service.CheckPackageVersionMutable(ctx, webctx.Doer, webctx.Package.Owner.ID); err != nil {
+			return err
+		}
+
 		pv, err := packages_model.GetVersionByNameAndVersion(ctx,
 			webctx.Package.Owner.ID,
 			packages_model.TypeRpm,
@@ -299,6 +303,8 @@ func DeletePackageFile(webctx *context.Context) {
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(webctx, http.StatusNotFound, err)
+		} else if errors.Is(err, util.ErrPermissionDenied) {
+			apiError(webctx, http.StatusForbidden, err)
 		} else {
 			apiError(webctx, http.StatusInternalServerError, err)
 		}
@@ -0,0 +1,596 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	terraform_service "code.gitea.io/gitea/services/packages/terraform"
+
+	"github.com/hashicorp/go-version"
+)
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.JSON(status, struct {
+		Errors []string `json:"errors"`
+	}{
+		Errors: []string{
+			message,
+		},
+	})
+}
+
+func registryURL(ctx *context.Context) string {
+	return setting.AppURL + "api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/terraform"
+}
+
+func modulePackageNameFromParams(ctx *context.Context) (string, error) {
+	return terraform_module.ModulePackageName(ctx.PathParam("name"), ctx.PathParam("system"))
+}
+
+func getSortedDescriptors(ctx *context.Context, packageName string) ([]*packages_model.PackageDescriptor, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, packageName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+	return pds, nil
+}
+
+type moduleVersions struct {
+	Modules []*moduleVersionList `json:"modules"`
+}
+
+type moduleVersionList struct {
+	Versions []*moduleVersion `json:"versions"`
+}
+
+type moduleVersion struct {
+	Version string `json:"version"`
+}
+
+// EnumerateModuleVersions lists the available versions of a module
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#list-available-versions-for-a-specific-module
+func EnumerateModuleVersions(ctx *context.Context) {
+	packageName, err := modulePackageNameFromParams(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	pds, err := getSortedDescriptors(ctx, packageName)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	versions := make([]*moduleVersion, 0, len(pds))
+	for _, pd := range pds {
+		versions = append(versions, &moduleVersion{Version: pd.Version.Version})
+	}
+
+	ctx.JSON(http.StatusOK, &moduleVersions{
+		Modules: []*moduleVersionList{{Versions: versions}},
+	})
+}
+
+// DownloadModule returns the location of the archive of a module version
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#download-source-code-for-a-specific-module-version
+func DownloadModule(ctx *context.Context) {
+	packageName, err := modulePackageNameFromParams(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, packageName, ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pfs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	ctx.Resp.Header().Set("X-Terraform-Get", fmt.Sprintf(
+		"%s/modules/%s/%s/%s/%s",
+		registryURL(ctx),
+		url.PathEscape(ctx.PathParam("name")),
+		url.PathEscape(ctx.PathParam("system")),
+		url.PathEscape(pv.Version),
+		url.PathEscape(pfs[0].Name),
+	))
+	ctx.Status(http.StatusNoContent)
+}
+
+// UploadModule creates a new module version from a gzipped tar archive
+func UploadModule(ctx *context.Context) {
+	moduleName := ctx.PathParam("name")
+	moduleSystem := ctx.PathParam("system")
+	packageName, err := terraform_module.ModulePackageName(moduleName, moduleSystem)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	packageVersion := ctx.PathParam("version")
+	if _, err := version.NewSemver(packageVersion); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := terraform_module.ParseModuleArchive(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: strings.ToLower(fmt.Sprintf("%s-%s-%s.tar.gz", moduleName, moduleSystem, packageVersion)),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadModuleFile serves the archive of a module version
+func DownloadModuleFile(ctx *context.Context) {
+	packageName, err := modulePackageNameFromParams(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	downloadPackageFile(ctx, packageName)
+}
+
+type providerVersions struct {
+	Versions []*providerVersion `json:"versions"`
+}
+
+type providerVersion struct {
+	Version   string              `json:"version"`
+	Protocols []string            `json:"protocols"`
+	Platforms []*providerPlatform `json:"platforms"`
+}
+
+type providerPlatform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+func getProtocols(pd *packages_model.PackageDescriptor) []string {
+	protocols := pd.VersionProperties.GetByName(terraform_module.PropertyProtocols)
+	if protocols == "" {
+		protocols = terraform_module.DefaultProtocols
+	}
+	return strings.Split(protocols, ",")
+}
+
+// EnumerateProviderVersions lists the available versions of a provider
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#list-available-versions
+func EnumerateProviderVersions(ctx *context.Context) {
+	pds, err := getSortedDescriptors(ctx, ctx.PathParam("provider"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	versions := make([]*providerVersion, 0, len(pds))
+	for _, pd := range pds {
+		platforms := make([]*providerPlatform, 0, len(pd.Files))
+		for _, pfd := range pd.Files {
+			if goos := pfd.Properties.GetByName(terraform_module.PropertyOS); goos != "" {
+				platforms = append(platforms, &providerPlatform{
+					OS:   goos,
+					Arch: pfd.Properties.GetByName(terraform_module.PropertyArch),
+				})
+			}
+		}
+		if len(platforms) == 0 {
+			continue
+		}
+
+		versions = append(versions, &providerVersion{
+			Version:   pd.Version.Version,
+			Protocols: getProtocols(pd),
+			Platforms: platforms,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, &providerVersions{
+		Versions: versions,
+	})
+}
+
+type providerPackage struct {
+	Protocols           []string           `json:"protocols"`
+	OS                  string             `json:"os"`
+	Arch                string             `json:"arch"`
+	Filename            string             `json:"filename"`
+	DownloadURL         string             `json:"download_url"`
+	ShasumsURL          string             `json:"shasums_url"`
+	ShasumsSignatureURL string             `json:"shasums_signature_url"`
+	Shasum              string             `json:"shasum"`
+	SigningKeys         providerSigningKey `json:"signing_keys"`
+}
+
+type providerSigningKey struct {
+	GPGPublicKeys []*providerGPGKey `json:"gpg_public_keys"`
+}
+
+type providerGPGKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+// ProviderPackage returns the download information of a provider version for a platform
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#find-a-provider-package
+func ProviderPackage(ctx *context.Context) {
+	providerType := ctx.PathParam("provider")
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, providerType, ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	prefix := terraform_module.ProviderFilePrefix(pd.Package.Name, pd.Version.Version)
+
+	var packageFile, signatureFile *packages_model.PackageFileDescriptor
+	var hasChecksums bool
+	for _, pfd := range pd.Files {
+		switch {
+		case pfd.Properties.GetByName(terraform_module.PropertyOS) == ctx.PathParam("os") && pfd.Properties.GetByName(terraform_module.PropertyArch) == ctx.PathParam("arch"):
+			packageFile = pfd
+		case pfd.File.Name == prefix+"SHA256SUMS":
+			hasChecksums = true
+		case pfd.File.Name == prefix+"SHA256SUMS.sig":
+			signatureFile = pfd
+		}
+	}
+	if packageFile == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+	if !hasChecksums || signatureFile == nil {
+		apiError(ctx, http.StatusNotFound, "the provider version is not signed")
+		return
+	}
+
+	keyID := signatureFile.Properties.GetByName(terraform_module.PropertySigningKey)
+	armoredKey, err := terraform_service.GetArmoredPublicKey(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	versionURL := fmt.Sprintf("%s/providers/%s/%s", registryURL(ctx), url.PathEscape(pd.Package.Name), url.PathEscape(pd.Version.Version))
+
+	ctx.JSON(http.StatusOK, &providerPackage{
+		Protocols:           getProtocols(pd),
+		OS:                  ctx.PathParam("os"),
+		Arch:                ctx.PathParam("arch"),
+		Filename:            packageFile.File.Name,
+		DownloadURL:         versionURL + "/" + url.PathEscape(packageFile.File.Name),
+		ShasumsURL:          versionURL + "/" + url.PathEscape(prefix+"SHA256SUMS"),
+		ShasumsSignatureURL: versionURL + "/" + url.PathEscape(prefix+"SHA256SUMS.sig"),
+		Shasum:              packageFile.Blob.HashSHA256,
+		SigningKeys: providerSigningKey{
+			GPGPublicKeys: []*providerGPGKey{
+				{
+					KeyID:      keyID,
+					ASCIIArmor: armoredKey,
+				},
+			},
+		},
+	})
+}
+
+// UploadProviderFile adds a file of a provider release. The signature of the checksums
+// must be uploaded after the checksums and is verified with the GPG keys of the uploader.
+func UploadProviderFile(ctx *context.Context) {
+	providerType := ctx.PathParam("provider")
+	if !terraform_module.IsValidProviderType(providerType) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	packageVersion := ctx.PathParam("version")
+	if _, err := version.NewSemver(packageVersion); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	filename := ctx.PathParam("filename")
+	providerFile, err := terraform_module.ParseProviderFilename(providerType, packageVersion, filename)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	properties := map[string]string{}
+	var protocols []string
+
+	switch providerFile.Type {
+	case terraform_module.ProviderFilePackage:
+		properties[terraform_module.PropertyOS] = providerFile.OS
+		properties[terraform_module.PropertyArch] = providerFile.Arch
+	case terraform_module.ProviderFileChecksums:
+		if _, err := terraform_module.ParseChecksums(buf); err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	case terraform_module.ProviderFileSignature:
+		checksums, err := readProviderFile(ctx, providerType, packageVersion, terraform_module.ProviderFilePrefix(providerType, packageVersion)+"SHA256SUMS")
+		if err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				apiError(ctx, http.StatusBadRequest, "the checksums must be uploaded before the signature")
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
+			return
+		}
+
+		signature, err := io.ReadAll(buf)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		keyID, err := terraform_service.VerifySignature(ctx, ctx.Doer, checksums, signature)
+		if err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				apiError(ctx, http.StatusBadRequest, err)
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
+			return
+		}
+		properties[terraform_module.PropertySigningKey] = keyID
+	case terraform_module.ProviderFileManifest:
+		if protocols, err = terraform_module.ParseManifest(buf); err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        providerType,
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata: &terraform_module.Metadata{
+				Kind: terraform_module.KindProvider,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator:    ctx.Doer,
+			Data:       buf,
+			IsLead:     providerFile.Type == terraform_module.ProviderFilePackage,
+			Properties: properties,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageFile:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if len(protocols) != 0 {
+		if err := packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, terraform_module.PropertyProtocols, strings.Join(protocols, ",")); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadProviderFile serves a file of a provider release
+func DownloadProviderFile(ctx *context.Context) {
+	downloadPackageFile(ctx, ctx.PathParam("provider"))
+}
+
+func readProviderFile(ctx *context.Context, providerType, packageVersion, filename string) ([]byte, error) {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, providerType, packageVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	var content bytes.Buffer
+	if _, err := io.Copy(&content, s); err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+func downloadPackageFile(ctx *context.Context, packageName string) {
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        packageName,
+			Version:     ctx.PathParam("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ctx.PathParam("filename"),
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
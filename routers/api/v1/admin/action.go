@@ -91,3 +91,19 @@ func ListWorkflowRuns(ctx *context.APIContext) {
 
 	shared.ListRuns(ctx, 0, 0)
 }
+
+// GetQueueStats returns the queue depth of all jobs per label set
+func GetQueueStats(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/queue admin getAdminActionsQueueStats
+	// ---
+	// summary: Get the number of queued and in progress jobs of the instance per label set
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueueStatList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	shared.GetQueueStats(ctx, 0, 0)
+}
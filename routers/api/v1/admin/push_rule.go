@@ -0,0 +1,66 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetPushRule gets the push rule of the instance
+func GetPushRule(ctx *context.APIContext) {
+	// swagger:operation GET /admin/push_rule admin getAdminPushRule
+	// ---
+	// summary: Get the instance's push rule
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetPushRule(ctx, 0, 0)
+}
+
+// UpdatePushRule creates or updates the push rule of the instance
+func UpdatePushRule(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/push_rule admin updateAdminPushRule
+	// ---
+	// summary: Create or update the instance's push rule
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UpdatePushRuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.UpdatePushRule(ctx, 0, 0)
+}
+
+// DeletePushRule deletes the push rule of the instance
+func DeletePushRule(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/push_rule admin deleteAdminPushRule
+	// ---
+	// summary: Delete the instance's push rule
+	// produces:
+	// - application/json
+	// responses:
+	//   "204":
+	//     description: push rule has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeletePushRule(ctx, 0, 0)
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListRulesets lists the global rulesets
+func ListRulesets(ctx *context.APIContext) {
+	// swagger:operation GET /admin/rulesets admin adminListRulesets
+	// ---
+	// summary: List the global rulesets
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RulesetList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListRulesets(ctx, 0)
+}
+
+// CreateRuleset creates a global ruleset
+func CreateRuleset(ctx *context.APIContext) {
+	// swagger:operation POST /admin/rulesets admin adminCreateRuleset
+	// ---
+	// summary: Create a global ruleset
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRulesetOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Ruleset"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	shared.CreateRuleset(ctx, 0)
+}
+
+// GetRuleset gets a global ruleset
+func GetRuleset(ctx *context.APIContext) {
+	// swagger:operation GET /admin/rulesets/{id} admin adminGetRuleset
+	// ---
+	// summary: Get a global ruleset
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Ruleset"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetRuleset(ctx, 0, ctx.PathParamInt64("id"))
+}
+
+// EditRuleset edits a global ruleset
+func EditRuleset(ctx *context.APIContext) {
+	// swagger:operation PATCH /admin/rulesets/{id} admin adminEditRuleset
+	// ---
+	// summary: Edit a global ruleset
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditRulesetOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Ruleset"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	shared.EditRuleset(ctx, 0, ctx.PathParamInt64("id"))
+}
+
+// DeleteRuleset deletes a global ruleset
+func DeleteRuleset(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/rulesets/{id} admin adminDeleteRuleset
+	// ---
+	// summary: Delete a global ruleset
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteRuleset(ctx, 0, ctx.PathParamInt64("id"))
+}
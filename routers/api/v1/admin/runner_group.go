@@ -0,0 +1,200 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListRunnerGroups lists the global runner groups
+func ListRunnerGroups(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/runner-groups admin adminListRunnerGroups
+	// ---
+	// summary: List the global runner groups
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroupList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListRunnerGroups(ctx, 0)
+}
+
+// CreateRunnerGroup creates a global runner group
+func CreateRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation POST /admin/actions/runner-groups admin adminCreateRunnerGroup
+	// ---
+	// summary: Create a global runner group
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateActionRunnerGroupOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	shared.CreateRunnerGroup(ctx, 0)
+}
+
+// GetRunnerGroup gets a global runner group
+func GetRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/runner-groups/{group_id} admin adminGetRunnerGroup
+	// ---
+	// summary: Get a global runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetRunnerGroup(ctx, 0, ctx.PathParamInt64("group_id"))
+}
+
+// EditRunnerGroup edits a global runner group
+func EditRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation PATCH /admin/actions/runner-groups/{group_id} admin adminEditRunnerGroup
+	// ---
+	// summary: Edit a global runner group
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionRunnerGroupOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	shared.EditRunnerGroup(ctx, 0, ctx.PathParamInt64("group_id"))
+}
+
+// DeleteRunnerGroup deletes a global runner group
+func DeleteRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/actions/runner-groups/{group_id} admin adminDeleteRunnerGroup
+	// ---
+	// summary: Delete a global runner group, its runners are released from the group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: runner group has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteRunnerGroup(ctx, 0, ctx.PathParamInt64("group_id"))
+}
+
+// AddRunnerToGroup moves a global runner into a runner group
+func AddRunnerToGroup(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/actions/runner-groups/{group_id}/runners/{runner_id} admin adminAddRunnerToGroup
+	// ---
+	// summary: Move a global runner into a runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: runner has been moved into the group
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.AddRunnerToGroup(ctx, 0, ctx.PathParamInt64("group_id"), ctx.PathParamInt64("runner_id"))
+}
+
+// RemoveRunnerFromGroup removes a global runner from a runner group
+func RemoveRunnerFromGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/actions/runner-groups/{group_id}/runners/{runner_id} admin adminRemoveRunnerFromGroup
+	// ---
+	// summary: Remove a global runner from a runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: runner has been removed from the group
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.RemoveRunnerFromGroup(ctx, 0, ctx.PathParamInt64("group_id"), ctx.PathParamInt64("runner_id"))
+}
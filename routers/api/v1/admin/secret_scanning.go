@@ -0,0 +1,84 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListSecretScanPatterns lists the global custom secret scanning patterns
+func ListSecretScanPatterns(ctx *context.APIContext) {
+	// swagger:operation GET /admin/secret-scanning/patterns admin adminListSecretScanPatterns
+	// ---
+	// summary: List the global custom secret scanning patterns
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanPatternList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListSecretScanPatterns(ctx, 0)
+}
+
+// CreateSecretScanPattern creates a global custom secret scanning pattern
+func CreateSecretScanPattern(ctx *context.APIContext) {
+	// swagger:operation POST /admin/secret-scanning/patterns admin adminCreateSecretScanPattern
+	// ---
+	// summary: Create a global custom secret scanning pattern
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSecretScanPatternOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SecretScanPattern"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	shared.CreateSecretScanPattern(ctx, 0)
+}
+
+// DeleteSecretScanPattern deletes a global custom secret scanning pattern
+func DeleteSecretScanPattern(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/secret-scanning/patterns/{id} admin adminDeleteSecretScanPattern
+	// ---
+	// summary: Delete a global custom secret scanning pattern
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the pattern
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: pattern has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteSecretScanPattern(ctx, 0, ctx.PathParamInt64("id"))
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/models/db"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListX509TrustedCAs lists the CA bundles trusted for X.509 signature verification
+func ListX509TrustedCAs(ctx *context.APIContext) {
+	// swagger:operation GET /admin/x509-trusted-cas admin adminListX509TrustedCAs
+	// ---
+	// summary: List the CA bundles trusted for X.509 signature verification
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/X509TrustedCAList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	cas, total, err := db.FindAndCount[asymkey_model.X509TrustedCA](ctx, asymkey_model.FindX509TrustedCAOptions{
+		ListOptions: utils.GetListOptions(ctx),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiCAs := make([]*api.X509TrustedCA, len(cas))
+	for i, ca := range cas {
+		if apiCAs[i], err = convert.ToX509TrustedCA(ca); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiCAs)
+}
+
+// CreateX509TrustedCA adds a CA bundle trusted for X.509 signature verification
+func CreateX509TrustedCA(ctx *context.APIContext) {
+	// swagger:operation POST /admin/x509-trusted-cas admin adminCreateX509TrustedCA
+	// ---
+	// summary: Add a CA bundle trusted for X.509 signature verification
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateX509TrustedCAOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/X509TrustedCA"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	opt := web.GetForm(ctx).(*api.CreateX509TrustedCAOption)
+	ca := &asymkey_model.X509TrustedCA{
+		Name:    opt.Name,
+		Content: opt.Content,
+	}
+	if err := asymkey_model.CreateX509TrustedCA(ctx, ca); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusBadRequest, err)
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.APIError(http.StatusConflict, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	apiCA, err := convert.ToX509TrustedCA(ca)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, apiCA)
+}
+
+// GetX509TrustedCA gets a CA bundle trusted for X.509 signature verification
+func GetX509TrustedCA(ctx *context.APIContext) {
+	// swagger:operation GET /admin/x509-trusted-cas/{id} admin adminGetX509TrustedCA
+	// ---
+	// summary: Get a CA bundle trusted for X.509 signature verification
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the CA bundle
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/X509TrustedCA"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	ca, err := asymkey_model.GetX509TrustedCAByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	apiCA, err := convert.ToX509TrustedCA(ca)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiCA)
+}
+
+// DeleteX509TrustedCA removes a CA bundle trusted for X.509 signature verification
+func DeleteX509TrustedCA(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/x509-trusted-cas/{id} admin adminDeleteX509TrustedCA
+	// ---
+	// summary: Remove a CA bundle trusted for X.509 signature verification
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the CA bundle
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	ca, err := asymkey_model.GetX509TrustedCAByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	if err := asymkey_model.DeleteX509TrustedCAByID(ctx, ca.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
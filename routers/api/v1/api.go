@@ -332,7 +332,8 @@ func tokenRequiresScopes(requiredScopeCategories ...auth_model.AccessTokenScopeC
 
 		// use the http method to determine the access level
 		requiredScopeLevel := auth_model.Read
-		if ctx.Req.Method == http.MethodPost || ctx.Req.Method == http.MethodPut || ctx.Req.Method == http.MethodPatch || ctx.Req.Method == http.MethodDelete {
+		if ctx.Req.Method == http.MethodPost || ctx.Req.Method == http.MethodPut || ctx.Req.Method == http.MethodPatch || ctx.Req.Method == http.MethodDelete ||
+			ctx.Req.Method == repo.MethodLock || ctx.Req.Method == repo.MethodUnlock {
 			requiredScopeLevel = auth_model.Write
 		}
 
@@ -758,6 +759,13 @@ func mustEnableAttachments(ctx *context.APIContext) {
 	}
 }
 
+func mustEnableTerraformState(ctx *context.APIContext) {
+	if !setting.TerraformState.Enabled {
+		ctx.APIErrorNotFound()
+		return
+	}
+}
+
 // bind binding an obj to a func(ctx *context.APIContext)
 func bind[T any](_ T) any {
 	return func(ctx *context.APIContext) {
@@ -944,6 +952,13 @@ func Routes() *web.Router {
 			})
 			m.Get("/runs", reqToken(), reqChecker, act.ListWorkflowRuns)
 			m.Get("/jobs", reqToken(), reqChecker, act.ListWorkflowJobs)
+
+			m.Combo("/approval-policy").
+				Get(reqToken(), reqChecker, act.GetApprovalPolicy).
+				Put(reqToken(), reqChecker, bind(api.UpdateActionApprovalPolicyOption{}), act.UpdateApprovalPolicy).
+				Delete(reqToken(), reqChecker, act.DeleteApprovalPolicy)
+
+			m.Get("/queue", reqToken(), reqChecker, act.GetQueueStats)
 		})
 	}
 
@@ -1218,6 +1233,9 @@ func Routes() *web.Router {
 				m.Group("/actions/jobs", func() {
 					m.Get("/{job_id}", repo.GetWorkflowJob)
 					m.Get("/{job_id}/logs", repo.DownloadActionsRunJobLogs)
+					m.Get("/{job_id}/logs/follow", repo.FollowActionsRunJobLogs)
+					m.Post("/{job_id}/rerun", reqRepoWriter(unit.TypeActions), repo.RerunWorkflowJob)
+					m.Post("/{job_id}/cancel", reqRepoWriter(unit.TypeActions), repo.CancelWorkflowJob)
 				}, reqToken(), reqRepoReader(unit.TypeActions))
 
 				m.Group("/hooks/git", func() {
@@ -1278,6 +1296,14 @@ func Routes() *web.Router {
 					})
 					m.Post("/priority", bind(api.UpdateBranchProtectionPriories{}), mustNotBeArchived, repo.UpdateBranchProtectionPriories)
 				}, reqToken(), reqAdmin())
+				m.Combo("/push_rule", reqToken(), reqAdmin()).Get(repo.GetPushRule).
+					Put(bind(api.UpdatePushRuleOption{}), mustNotBeArchived, repo.UpdatePushRule).
+					Delete(repo.DeletePushRule)
+				m.Group("/secret-scanning/alerts", func() {
+					m.Get("", repo.ListSecretScanAlerts)
+					m.Combo("/{id}").Get(repo.GetSecretScanAlert).
+						Patch(bind(api.EditSecretScanAlertOption{}), repo.EditSecretScanAlert)
+				}, reqToken(), reqAdmin())
 				m.Group("/tags", func() {
 					m.Get("", repo.ListTags)
 					m.Get("/*", repo.GetTag)
@@ -1293,6 +1319,18 @@ func Routes() *web.Router {
 							Delete(repo.DeleteTagProtection)
 					})
 				}, reqToken(), reqAdmin())
+				m.Group("/terraform/states", func() {
+					m.Get("", repo.ListTerraformStates)
+					m.Group("/{name}", func() {
+						m.Combo("").Get(repo.GetTerraformState).
+							Post(repo.UpdateTerraformState).
+							Delete(repo.DeleteTerraformState)
+						m.Methods(repo.MethodLock, "", repo.LockTerraformState)
+						m.Methods(repo.MethodUnlock, "", repo.UnlockTerraformState)
+						m.Get("/versions", repo.ListTerraformStateVersions)
+						m.Get("/versions/{version}", repo.GetTerraformStateVersion)
+					})
+				}, reqToken(), reqRepoWriter(unit.TypeCode), mustEnableTerraformState)
 				m.Group("/actions", func() {
 					m.Get("/tasks", repo.ListActionTasks)
 					m.Group("/runs", func() {
@@ -1301,6 +1339,12 @@ func Routes() *web.Router {
 							m.Delete("", reqToken(), reqRepoWriter(unit.TypeActions), repo.DeleteActionRun)
 							m.Get("/jobs", repo.ListWorkflowRunJobs)
 							m.Get("/artifacts", repo.GetArtifactsOfRun)
+							m.Group("", func() {
+								m.Post("/rerun", repo.RerunWorkflowRun)
+								m.Post("/rerun-failed-jobs", repo.RerunFailedWorkflowRunJobs)
+								m.Post("/cancel", repo.CancelWorkflowRun)
+								m.Post("/approve", repo.ApproveWorkflowRun)
+							}, reqToken(), reqRepoWriter(unit.TypeActions))
 						})
 					})
 					m.Get("/artifacts", repo.GetArtifacts)
@@ -1383,9 +1427,11 @@ func Routes() *web.Router {
 						m.Post("/update", reqToken(), repo.UpdatePullRequest)
 						m.Get("/commits", repo.GetPullRequestCommits)
 						m.Get("/files", repo.GetPullRequestFiles)
+						m.Get("/stack", repo.GetPullRequestStack)
 						m.Combo("/merge").Get(repo.IsPullRequestMerged).
 							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), repo.MergePullRequest).
 							Delete(reqToken(), mustNotBeArchived, repo.CancelScheduledAutoMerge)
+						m.Delete("/merge-queue", reqToken(), mustNotBeArchived, repo.RemoveFromMergeQueue)
 						m.Group("/reviews", func() {
 							m.Combo("").
 								Get(repo.ListPullReviews).
@@ -1404,6 +1450,7 @@ func Routes() *web.Router {
 						m.Combo("/requested_reviewers", reqToken()).
 							Delete(bind(api.PullReviewRequestOptions{}), repo.DeleteReviewRequests).
 							Post(bind(api.PullReviewRequestOptions{}), repo.CreateReviewRequests)
+						m.Post("/suggestions", reqToken(), mustNotBeArchived, bind(api.ApplyPullReviewSuggestionsOptions{}), repo.ApplyPullReviewSuggestions)
 					})
 					m.Get("/{base}/*", repo.GetPullRequestByBaseHead)
 				}, mustAllowPulls, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo())
@@ -1621,6 +1668,9 @@ func Routes() *web.Router {
 					m.Get("", packages.GetPackage)
 					m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
 					m.Get("/files", packages.ListPackageFiles)
+					m.Get("/vulnerabilities", packages.ListPackageVulnerabilities)
+					m.Get("/downloads", packages.GetPackageDownloadStatistics)
+					m.Post("/promote", bind(api.PromotePackageVersionOption{}), packages.PromotePackageVersion)
 				})
 
 				m.Group("/-", func() {
@@ -1658,6 +1708,32 @@ func Routes() *web.Router {
 				reqOrgOwnership(),
 				org.NewAction(),
 			)
+			m.Group("/actions/runner-groups", func() {
+				m.Combo("").Get(org.ListRunnerGroups).
+					Post(bind(api.CreateActionRunnerGroupOption{}), org.CreateRunnerGroup)
+				m.Group("/{group_id}", func() {
+					m.Combo("").Get(org.GetRunnerGroup).
+						Patch(bind(api.EditActionRunnerGroupOption{}), org.EditRunnerGroup).
+						Delete(org.DeleteRunnerGroup)
+					m.Combo("/runners/{runner_id}").Put(org.AddRunnerToGroup).
+						Delete(org.RemoveRunnerFromGroup)
+				})
+			}, reqToken(), reqOrgOwnership())
+			m.Group("/rulesets", func() {
+				m.Combo("").Get(org.ListRulesets).
+					Post(bind(api.CreateRulesetOption{}), org.CreateRuleset)
+				m.Combo("/{id}").Get(org.GetRuleset).
+					Patch(bind(api.EditRulesetOption{}), org.EditRuleset).
+					Delete(org.DeleteRuleset)
+			}, reqToken(), reqOrgOwnership())
+			m.Combo("/push_rule", reqToken(), reqOrgOwnership()).Get(org.GetPushRule).
+				Put(bind(api.UpdatePushRuleOption{}), org.UpdatePushRule).
+				Delete(org.DeletePushRule)
+			m.Group("/secret-scanning/patterns", func() {
+				m.Combo("").Get(org.ListSecretScanPatterns).
+					Post(bind(api.CreateSecretScanPatternOption{}), org.CreateSecretScanPattern)
+				m.Delete("/{id}", org.DeleteSecretScanPattern)
+			}, reqToken(), reqOrgOwnership())
 			m.Group("/public_members", func() {
 				m.Get("", org.ListPublicMembers)
 				m.Combo("/{username}").Get(org.IsPublicMember).
@@ -1767,8 +1843,41 @@ func Routes() *web.Router {
 					m.Get("/{runner_id}", admin.GetRunner)
 					m.Delete("/{runner_id}", admin.DeleteRunner)
 				})
+				m.Group("/runner-groups", func() {
+					m.Combo("").Get(admin.ListRunnerGroups).
+						Post(bind(api.CreateActionRunnerGroupOption{}), admin.CreateRunnerGroup)
+					m.Group("/{group_id}", func() {
+						m.Combo("").Get(admin.GetRunnerGroup).
+							Patch(bind(api.EditActionRunnerGroupOption{}), admin.EditRunnerGroup).
+							Delete(admin.DeleteRunnerGroup)
+						m.Combo("/runners/{runner_id}").Put(admin.AddRunnerToGroup).
+							Delete(admin.RemoveRunnerFromGroup)
+					})
+				})
 				m.Get("/runs", admin.ListWorkflowRuns)
 				m.Get("/jobs", admin.ListWorkflowJobs)
+				m.Get("/queue", admin.GetQueueStats)
+			})
+			m.Group("/rulesets", func() {
+				m.Combo("").Get(admin.ListRulesets).
+					Post(bind(api.CreateRulesetOption{}), admin.CreateRuleset)
+				m.Combo("/{id}").Get(admin.GetRuleset).
+					Patch(bind(api.EditRulesetOption{}), admin.EditRuleset).
+					Delete(admin.DeleteRuleset)
+			})
+			m.Combo("/push_rule").Get(admin.GetPushRule).
+				Put(bind(api.UpdatePushRuleOption{}), admin.UpdatePushRule).
+				Delete(admin.DeletePushRule)
+			m.Group("/secret-scanning/patterns", func() {
+				m.Combo("").Get(admin.ListSecretScanPatterns).
+					Post(bind(api.CreateSecretScanPatternOption{}), admin.CreateSecretScanPattern)
+				m.Delete("/{id}", admin.DeleteSecretScanPattern)
+			})
+			m.Group("/x509-trusted-cas", func() {
+				m.Combo("").Get(admin.ListX509TrustedCAs).
+					Post(bind(api.CreateX509TrustedCAOption{}), admin.CreateX509TrustedCA)
+				m.Combo("/{id}").Get(admin.GetX509TrustedCA).
+					Delete(admin.DeleteX509TrustedCA)
 			})
 			m.Group("/runners", func() {
 				m.Get("/registration-token", admin.GetRegistrationToken)
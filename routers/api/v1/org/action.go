@@ -660,6 +660,102 @@ func (Action) ListWorkflowRuns(ctx *context.APIContext) {
 	shared.ListRuns(ctx, ctx.Org.Organization.ID, 0)
 }
 
+// GetApprovalPolicy gets the approval policy of the organization
+func (Action) GetApprovalPolicy(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/approval-policy organization getOrgActionsApprovalPolicy
+	// ---
+	// summary: Get the organization's actions approval policy
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionApprovalPolicy"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetApprovalPolicy(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// UpdateApprovalPolicy creates or updates the approval policy of the organization
+func (Action) UpdateApprovalPolicy(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/actions/approval-policy organization updateOrgActionsApprovalPolicy
+	// ---
+	// summary: Create or update the organization's actions approval policy, it applies to the repositories without their own policy
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UpdateActionApprovalPolicyOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionApprovalPolicy"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.UpdateApprovalPolicy(ctx, ctx.Org.Organization.ID, 0, ctx.Org.Organization.ID)
+}
+
+// DeleteApprovalPolicy deletes the approval policy of the organization
+func (Action) DeleteApprovalPolicy(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/actions/approval-policy organization deleteOrgActionsApprovalPolicy
+	// ---
+	// summary: Delete the organization's actions approval policy
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: approval policy has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteApprovalPolicy(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// GetQueueStats returns the queue depth of the organization's jobs per label set
+func (Action) GetQueueStats(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/queue organization getOrgActionsQueueStats
+	// ---
+	// summary: Get the number of queued and in progress jobs of the organization per label set
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueueStatList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetQueueStats(ctx, ctx.Org.Organization.ID, 0)
+}
+
 var _ actions_service.API = new(Action)
 
 // Action implements actions_service.API
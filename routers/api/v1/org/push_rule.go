@@ -0,0 +1,83 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetPushRule gets the push rule of the organization
+func GetPushRule(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/push_rule organization getOrgPushRule
+	// ---
+	// summary: Get the organization's push rule
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetPushRule(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// UpdatePushRule creates or updates the push rule of the organization
+func UpdatePushRule(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/push_rule organization updateOrgPushRule
+	// ---
+	// summary: Create or update the organization's push rule
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UpdatePushRuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.UpdatePushRule(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// DeletePushRule deletes the push rule of the organization
+func DeletePushRule(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/push_rule organization deleteOrgPushRule
+	// ---
+	// summary: Delete the organization's push rule
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: push rule has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeletePushRule(ctx, ctx.Org.Organization.ID, 0)
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListRulesets lists the organization's rulesets
+func ListRulesets(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/rulesets organization orgListRulesets
+	// ---
+	// summary: List the organization's rulesets
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RulesetList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListRulesets(ctx, ctx.Org.Organization.ID)
+}
+
+// CreateRuleset creates a organization's ruleset
+func CreateRuleset(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/rulesets organization orgCreateRuleset
+	// ---
+	// summary: Create a organization's ruleset
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRulesetOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Ruleset"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	shared.CreateRuleset(ctx, ctx.Org.Organization.ID)
+}
+
+// GetRuleset gets a organization's ruleset
+func GetRuleset(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/rulesets/{id} organization orgGetRuleset
+	// ---
+	// summary: Get a organization's ruleset
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Ruleset"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetRuleset(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+}
+
+// EditRuleset edits a organization's ruleset
+func EditRuleset(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/rulesets/{id} organization orgEditRuleset
+	// ---
+	// summary: Edit a organization's ruleset
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditRulesetOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Ruleset"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	shared.EditRuleset(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+}
+
+// DeleteRuleset deletes a organization's ruleset
+func DeleteRuleset(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/rulesets/{id} organization orgDeleteRuleset
+	// ---
+	// summary: Delete a organization's ruleset
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteRuleset(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+}
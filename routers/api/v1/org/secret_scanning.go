@@ -0,0 +1,99 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListSecretScanPatterns lists the organization's custom secret scanning patterns
+func ListSecretScanPatterns(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/secret-scanning/patterns organization orgListSecretScanPatterns
+	// ---
+	// summary: List the organization's custom secret scanning patterns
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanPatternList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListSecretScanPatterns(ctx, ctx.Org.Organization.ID)
+}
+
+// CreateSecretScanPattern creates a organization's custom secret scanning pattern
+func CreateSecretScanPattern(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/secret-scanning/patterns organization orgCreateSecretScanPattern
+	// ---
+	// summary: Create a organization's custom secret scanning pattern
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSecretScanPatternOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SecretScanPattern"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	shared.CreateSecretScanPattern(ctx, ctx.Org.Organization.ID)
+}
+
+// DeleteSecretScanPattern deletes a organization's custom secret scanning pattern
+func DeleteSecretScanPattern(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/secret-scanning/patterns/{id} organization orgDeleteSecretScanPattern
+	// ---
+	// summary: Delete a organization's custom secret scanning pattern
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the pattern
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: pattern has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteSecretScanPattern(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+}
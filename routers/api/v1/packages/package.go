@@ -8,14 +8,18 @@ import (
 	"net/http"
 
 	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/optional"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	packages_service "code.gitea.io/gitea/services/packages"
+	packages_promote_service "code.gitea.io/gitea/services/packages/promote"
 )
 
 // ListPackages gets all packages of an owner
@@ -43,7 +47,7 @@ func ListPackages(ctx *context.APIContext) {
 	//   in: query
 	//   description: package type filter
 	//   type: string
-	//   enum: [alpine, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, vagrant]
+	//   enum: [alpine, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, hex, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, terraform, vagrant]
 	// - name: q
 	//   in: query
 	//   description: name filter
@@ -145,17 +149,121 @@ func DeletePackage(ctx *context.APIContext) {
 	// responses:
 	//   "204":
 	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
 	err := packages_service.RemovePackageVersion(ctx, ctx.Doer, ctx.Package.Descriptor.Version)
 	if err != nil {
-		ctx.APIErrorInternal(err)
+		if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.APIError(http.StatusForbidden, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
 		return
 	}
 	ctx.Status(http.StatusNoContent)
 }
 
+// PromotePackageVersion copies a package version to another owner
+func PromotePackageVersion(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/promote package promotePackageVersion
+	// ---
+	// summary: Copy a package version with all its files to another owner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/PromotePackageVersionOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Package"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	form := web.GetForm(ctx).(*api.PromotePackageVersionOption)
+
+	target, err := user_model.GetUserByName(ctx, form.Owner)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	accessMode, err := context.DeterminePackageAccessMode(ctx, target, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if accessMode < perm.AccessModeWrite {
+		ctx.APIError(http.StatusForbidden, "no write access to the packages of the target owner")
+		return
+	}
+
+	pv, err := packages_promote_service.PromotePackageVersion(ctx, ctx.Doer, ctx.Package.Descriptor, target)
+	if err != nil {
+		switch {
+		case errors.Is(err, packages.ErrDuplicatePackageVersion):
+			ctx.APIError(http.StatusConflict, err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusBadRequest, err)
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			ctx.APIError(http.StatusForbidden, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	pd, err := packages.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiPackage, err := convert.ToPackage(ctx, pd, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, apiPackage)
+}
+
 // ListPackageFiles gets all files of a package
 func ListPackageFiles(ctx *context.APIContext) {
 	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/files package listPackageFiles
@@ -198,6 +306,110 @@ func ListPackageFiles(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, apiPackageFiles)
 }
 
+// ListPackageVulnerabilities gets the vulnerable dependencies of a package version
+func ListPackageVulnerabilities(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/vulnerabilities package listPackageVulnerabilities
+	// ---
+	// summary: Gets the dependencies of a package which are affected by known vulnerabilities
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageVulnerabilityList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	findings, err := packages.GetVulnerabilityFindingsByVersionID(ctx, ctx.Package.Descriptor.Version.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiVulnerabilities := make([]*api.PackageVulnerability, 0, len(findings))
+	for _, f := range findings {
+		apiVulnerabilities = append(apiVulnerabilities, convert.ToPackageVulnerability(f))
+	}
+
+	ctx.JSON(http.StatusOK, apiVulnerabilities)
+}
+
+// GetPackageDownloadStatistics gets the daily download statistics of a package version
+func GetPackageDownloadStatistics(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/downloads package getPackageDownloadStatistics
+	// ---
+	// summary: Gets the download statistics of a package version
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: days
+	//   in: query
+	//   description: number of days to return the daily downloads for (default 30, max 365)
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageDownloadStatistics"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	days := ctx.FormInt("days")
+	if days <= 0 {
+		days = 30
+	} else if days > 365 {
+		days = 365
+	}
+
+	pv := ctx.Package.Descriptor.Version
+
+	downloads, err := packages_service.GetDailyDownloads(ctx, pv, days)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToPackageDownloadStatistics(pv, downloads))
+}
+
 // ListPackageVersions gets all versions of a package
 func ListPackageVersions(ctx *context.APIContext) {
 	// swagger:operation GET /packages/{owner}/{type}/{name} package listPackageVersions
@@ -758,6 +758,122 @@ func (Action) ListWorkflowRuns(ctx *context.APIContext) {
 	shared.ListRuns(ctx, 0, repoID)
 }
 
+// GetApprovalPolicy gets the approval policy of the repository
+func (Action) GetApprovalPolicy(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/approval-policy repository getRepoActionsApprovalPolicy
+	// ---
+	// summary: Get the repository's actions approval policy
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionApprovalPolicy"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetApprovalPolicy(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// UpdateApprovalPolicy creates or updates the approval policy of the repository
+func (Action) UpdateApprovalPolicy(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/approval-policy repository updateRepoActionsApprovalPolicy
+	// ---
+	// summary: Create or update the repository's actions approval policy
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UpdateActionApprovalPolicyOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionApprovalPolicy"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.UpdateApprovalPolicy(ctx, 0, ctx.Repo.Repository.ID, ctx.Repo.Repository.OwnerID)
+}
+
+// DeleteApprovalPolicy deletes the approval policy of the repository
+func (Action) DeleteApprovalPolicy(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/approval-policy repository deleteRepoActionsApprovalPolicy
+	// ---
+	// summary: Delete the repository's actions approval policy, the owner's policy will be applied if there is one
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: approval policy has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteApprovalPolicy(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// GetQueueStats returns the queue depth of the repository's jobs per label set
+func (Action) GetQueueStats(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/queue repository getRepoActionsQueueStats
+	// ---
+	// summary: Get the number of queued and in progress jobs of the repository per label set
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueueStatList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetQueueStats(ctx, 0, ctx.Repo.Repository.ID)
+}
+
 var _ actions_service.API = new(Action)
 
 // Action implements actions_service.API
@@ -5,11 +5,15 @@ package repo
 
 import (
 	"errors"
+	"net/http"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/common"
+	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
 )
 
 func DownloadActionsRunJobLogs(ctx *context.APIContext) {
@@ -62,3 +66,454 @@ func DownloadActionsRunJobLogs(ctx *context.APIContext) {
 		}
 	}
 }
+
+// getRunAndJobs loads the run in the path and its jobs, the jobs' Run is set
+func getRunAndJobs(ctx *context.APIContext) (*actions_model.ActionRun, actions_model.ActionJobList) {
+	runID := ctx.PathParamInt64("run")
+	run, err := actions_model.GetRunByRepoAndID(ctx, ctx.Repo.Repository.ID, runID)
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err)
+		return nil, nil
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, nil
+	}
+	run.Repo = ctx.Repo.Repository
+
+	jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, nil
+	}
+	for _, job := range jobs {
+		job.Run = run
+	}
+	return run, jobs
+}
+
+// getRunJob loads the job in the path with its run and the jobs of the run
+func getRunJob(ctx *context.APIContext) (*actions_model.ActionRunJob, actions_model.ActionJobList) {
+	jobID := ctx.PathParamInt64("job_id")
+	job, has, err := db.GetByID[actions_model.ActionRunJob](ctx, jobID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, nil
+	}
+	if !has || job.RepoID != ctx.Repo.Repository.ID {
+		ctx.APIErrorNotFound(util.ErrNotExist)
+		return nil, nil
+	}
+
+	run, err := actions_model.GetRunByRepoAndID(ctx, ctx.Repo.Repository.ID, job.RunID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, nil
+	}
+	run.Repo = ctx.Repo.Repository
+
+	jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, nil
+	}
+	for _, j := range jobs {
+		j.Run = run
+		if j.ID == job.ID {
+			job = j
+		}
+	}
+	return job, jobs
+}
+
+// respondWorkflowRun responds the latest state of the run
+func respondWorkflowRun(ctx *context.APIContext, status int, runID int64) {
+	run, err := actions_model.GetRunByRepoAndID(ctx, ctx.Repo.Repository.ID, runID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	convertedRun, err := convert.ToActionWorkflowRun(ctx, ctx.Repo.Repository, run)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(status, convertedRun)
+}
+
+// respondWorkflowJob responds the latest state of the job
+func respondWorkflowJob(ctx *context.APIContext, status int, jobID int64) {
+	job, err := actions_model.GetRunJobByID(ctx, jobID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	convertedJob, err := convert.ToActionWorkflowJob(ctx, ctx.Repo.Repository, nil, job)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(status, convertedJob)
+}
+
+func handleRerunError(ctx *context.APIContext, err error) {
+	if errors.Is(err, actions_service.ErrWorkflowDisabled) {
+		ctx.APIError(http.StatusBadRequest, err)
+	} else {
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// RerunWorkflowRun reruns all jobs of a workflow run
+func RerunWorkflowRun(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/rerun repository rerunWorkflowRun
+	// ---
+	// summary: Reruns all jobs of a workflow run
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/WorkflowRun"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run, jobs := getRunAndJobs(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !run.Status.IsDone() {
+		ctx.APIError(http.StatusBadRequest, "this workflow run is not done")
+		return
+	}
+
+	if err := actions_service.RerunRun(ctx, run, jobs); err != nil {
+		handleRerunError(ctx, err)
+		return
+	}
+	respondWorkflowRun(ctx, http.StatusCreated, run.ID)
+}
+
+// RerunFailedWorkflowRunJobs reruns the failed or cancelled jobs of a workflow run
+func RerunFailedWorkflowRunJobs(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/rerun-failed-jobs repository rerunFailedWorkflowRunJobs
+	// ---
+	// summary: Reruns the failed or cancelled jobs of a workflow run and the jobs depending on them
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/WorkflowRun"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run, jobs := getRunAndJobs(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !run.Status.IsDone() {
+		ctx.APIError(http.StatusBadRequest, "this workflow run is not done")
+		return
+	}
+
+	if err := actions_service.RerunFailedJobs(ctx, run, jobs); err != nil {
+		handleRerunError(ctx, err)
+		return
+	}
+	respondWorkflowRun(ctx, http.StatusCreated, run.ID)
+}
+
+// CancelWorkflowRun cancels the jobs of a workflow run which are not done
+func CancelWorkflowRun(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/cancel repository cancelWorkflowRun
+	// ---
+	// summary: Cancels a workflow run
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WorkflowRun"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run, jobs := getRunAndJobs(ctx)
+	if ctx.Written() {
+		return
+	}
+	if run.Status.IsDone() {
+		ctx.APIError(http.StatusBadRequest, "this workflow run is already done")
+		return
+	}
+
+	if err := actions_service.CancelJobs(ctx, jobs); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	respondWorkflowRun(ctx, http.StatusOK, run.ID)
+}
+
+// ApproveWorkflowRun approves a workflow run which needs approval, e.g. triggered by a pull request from a fork
+func ApproveWorkflowRun(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/approve repository approveWorkflowRun
+	// ---
+	// summary: Approves a workflow run which needs approval
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WorkflowRun"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run, jobs := getRunAndJobs(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !run.NeedApproval {
+		ctx.APIError(http.StatusBadRequest, "this workflow run doesn't need approval")
+		return
+	}
+
+	if err := actions_service.ApproveRun(ctx, run, jobs, ctx.Doer); err != nil {
+		if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.APIError(http.StatusForbidden, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	respondWorkflowRun(ctx, http.StatusOK, run.ID)
+}
+
+// RerunWorkflowJob reruns a job of a workflow run and the jobs depending on it
+func RerunWorkflowJob(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/jobs/{job_id}/rerun repository rerunWorkflowJob
+	// ---
+	// summary: Reruns a job of a workflow run and the jobs depending on it
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: job_id
+	//   in: path
+	//   description: id of the job
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/WorkflowJob"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	job, jobs := getRunJob(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !job.Run.Status.IsDone() {
+		ctx.APIError(http.StatusBadRequest, "this workflow run is not done")
+		return
+	}
+
+	if err := actions_service.RerunJob(ctx, job.Run, job, jobs); err != nil {
+		handleRerunError(ctx, err)
+		return
+	}
+	respondWorkflowJob(ctx, http.StatusCreated, job.ID)
+}
+
+// CancelWorkflowJob cancels a job of a workflow run
+func CancelWorkflowJob(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/jobs/{job_id}/cancel repository cancelWorkflowJob
+	// ---
+	// summary: Cancels a job of a workflow run
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: job_id
+	//   in: path
+	//   description: id of the job
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WorkflowJob"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	job, _ := getRunJob(ctx)
+	if ctx.Written() {
+		return
+	}
+	if job.Status.IsDone() {
+		ctx.APIError(http.StatusBadRequest, "this job is already done")
+		return
+	}
+
+	if err := actions_service.CancelJobs(ctx, []*actions_model.ActionRunJob{job}); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	respondWorkflowJob(ctx, http.StatusOK, job.ID)
+}
+
+// FollowActionsRunJobLogs streams the logs of a job until it's done
+func FollowActionsRunJobLogs(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs/follow repository followActionsRunJobLogs
+	// ---
+	// summary: Streams the job logs for a workflow run, the response is kept open until the job is done
+	// produces:
+	// - text/plain
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: name of the owner
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: job_id
+	//   in: path
+	//   description: id of the job
+	//   type: integer
+	//   required: true
+	// - name: offset
+	//   in: query
+	//   description: number of the log lines to skip, the lines already read can be skipped when reconnecting
+	//   type: integer
+	// responses:
+	//   "200":
+	//     description: log lines
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	jobID := ctx.PathParamInt64("job_id")
+	curJob, err := actions_model.GetRunJobByID(ctx, jobID)
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err)
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	err = common.FollowActionsRunJobLogs(ctx.Base, ctx.Repo.Repository, curJob, ctx.FormInt64("offset"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+	}
+}
@@ -595,10 +595,19 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		return
 	}
 
+	if form.EnableMergeQueue && !form.EnableStatusCheck {
+		ctx.APIError(http.StatusUnprocessableEntity, "merge queue requires status checks")
+		return
+	}
+
 	var requiredApprovals int64
 	if form.RequiredApprovals > 0 {
 		requiredApprovals = form.RequiredApprovals
 	}
+	mergeQueueBatchSize := git_model.DefaultMergeQueueBatchSize
+	if form.MergeQueueBatchSize > 0 {
+		mergeQueueBatchSize = form.MergeQueueBatchSize
+	}
 
 	whitelistUsers, err := user_model.GetUserIDsByNames(ctx, form.PushWhitelistUsernames, false)
 	if err != nil {
@@ -695,11 +704,14 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		BlockOnOfficialReviewRequests: form.BlockOnOfficialReviewRequests,
 		DismissStaleApprovals:         form.DismissStaleApprovals,
 		IgnoreStaleApprovals:          form.IgnoreStaleApprovals,
+		RequireCodeOwnerReviews:       form.RequireCodeOwnerReviews,
 		RequireSignedCommits:          form.RequireSignedCommits,
 		ProtectedFilePatterns:         form.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       form.UnprotectedFilePatterns,
 		BlockOnOutdatedBranch:         form.BlockOnOutdatedBranch,
 		BlockAdminMergeOverride:       form.BlockAdminMergeOverride,
+		EnableMergeQueue:              form.EnableMergeQueue,
+		MergeQueueBatchSize:           mergeQueueBatchSize,
 	}
 
 	if err := pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
@@ -863,6 +875,10 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.IgnoreStaleApprovals = *form.IgnoreStaleApprovals
 	}
 
+	if form.RequireCodeOwnerReviews != nil {
+		protectBranch.RequireCodeOwnerReviews = *form.RequireCodeOwnerReviews
+	}
+
 	if form.RequireSignedCommits != nil {
 		protectBranch.RequireSignedCommits = *form.RequireSignedCommits
 	}
@@ -883,6 +899,18 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.BlockAdminMergeOverride = *form.BlockAdminMergeOverride
 	}
 
+	if form.EnableMergeQueue != nil {
+		protectBranch.EnableMergeQueue = *form.EnableMergeQueue
+	}
+
+	if form.MergeQueueBatchSize != nil && *form.MergeQueueBatchSize > 0 {
+		protectBranch.MergeQueueBatchSize = *form.MergeQueueBatchSize
+	}
+	if protectBranch.EnableMergeQueue && !protectBranch.EnableStatusCheck {
+		ctx.APIError(http.StatusUnprocessableEntity, "merge queue requires status checks")
+		return
+	}
+
 	var whitelistUsers, forcePushAllowlistUsers, mergeWhitelistUsers, approvalsWhitelistUsers []int64
 	if form.PushWhitelistUsernames != nil {
 		whitelistUsers, err = user_model.GetUserIDsByNames(ctx, form.PushWhitelistUsernames, false)
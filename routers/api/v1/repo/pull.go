@@ -28,6 +28,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
@@ -37,6 +38,7 @@ import (
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/gitdiff"
 	issue_service "code.gitea.io/gitea/services/issue"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -210,6 +212,74 @@ func GetPullRequest(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, convert.ToAPIPullRequest(ctx, pr, ctx.Doer))
 }
 
+// GetPullRequestStack returns the chain of stacked pull requests the pull request belongs to
+func GetPullRequestStack(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/stack repository repoGetPullRequestStack
+	// ---
+	// summary: Get the stack of pull requests the pull request belongs to, from the bottom to the top
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	stack, err := issues_model.GetPullRequestStack(ctx, pr)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiPrs, err := convert.ToAPIPullRequests(ctx, ctx.Repo.Repository, stack, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &apiPrs)
+}
+
+// getStackParent returns the pull request of the repository with the index a pull request is stacked on
+func getStackParent(ctx *context.APIContext, index int64) *issues_model.PullRequest {
+	parent, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, index)
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return parent
+}
+
 // GetPullRequest returns a single PR based on index
 func GetPullRequestByBaseHead(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/pulls/{base}/{head} repository repoGetPullRequestByBaseHead
@@ -514,6 +584,22 @@ func CreatePullRequest(ctx *context.APIContext) {
 		Type:       issues_model.PullRequestGitea,
 	}
 
+	if form.StackParent > 0 {
+		parent := getStackParent(ctx, form.StackParent)
+		if ctx.Written() {
+			return
+		}
+		if err := pull_service.CheckStackParent(ctx, pr, parent); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		pr.StackParentID = parent.ID
+	}
+
 	// Get all assignee IDs
 	assigneeIDs, err := issues_model.MakeIDsFromAPIAssigneesToAdd(ctx, form.Assignee, form.Assignees)
 	if err != nil {
@@ -777,6 +863,24 @@ func EditPullRequest(ctx *context.APIContext) {
 		notify_service.PullRequestChangeTargetBranch(ctx, ctx.Doer, pr, form.Base)
 	}
 
+	// stack the pull request on another one, or unstack it
+	if !pr.HasMerged && form.StackParent != nil {
+		var parent *issues_model.PullRequest
+		if *form.StackParent > 0 {
+			if parent = getStackParent(ctx, *form.StackParent); ctx.Written() {
+				return
+			}
+		}
+		if err := pull_service.SetStackParent(ctx, pr, parent); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+	}
+
 	// update allow edits
 	if form.AllowMaintainerEdit != nil {
 		if err := pull_service.SetAllowEdits(ctx, ctx.Doer, pr, *form.AllowMaintainerEdit); err != nil {
@@ -881,6 +985,8 @@ func MergePullRequest(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/empty"
+	//   "201":
+	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 	//   "405":
@@ -1005,6 +1111,28 @@ func MergePullRequest(ctx *context.APIContext) {
 		}
 	}
 
+	// the pull request will be merged by the merge queue, unless the admin forces merging it
+	if pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if pb != nil && pb.EnableMergeQueue && !form.ForceMerge {
+		if err := mergequeue.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, form.DeleteBranchAfterMerge); err != nil {
+			switch {
+			case pull_service.IsErrInvalidMergeStyle(err):
+				ctx.APIError(http.StatusMethodNotAllowed, fmt.Errorf("%s is not allowed an allowed merge style for this repository", repo_model.MergeStyle(form.Do)))
+			case errors.Is(err, mergequeue.ErrNoRequiredChecks):
+				ctx.APIError(http.StatusMethodNotAllowed, err)
+			case errors.Is(err, util.ErrAlreadyExist):
+				ctx.APIError(http.StatusConflict, err)
+			default:
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		ctx.Status(http.StatusCreated)
+		return
+	}
+
 	if err := pull_service.Merge(ctx, pr, ctx.Doer, ctx.Repo.GitRepo, repo_model.MergeStyle(form.Do), form.HeadCommitID, message, false); err != nil {
 		if pull_service.IsErrInvalidMergeStyle(err) {
 			ctx.APIError(http.StatusMethodNotAllowed, fmt.Errorf("%s is not allowed an allowed merge style for this repository", repo_model.MergeStyle(form.Do)))
@@ -1391,6 +1519,79 @@ func CancelScheduledAutoMerge(ctx *context.APIContext) {
 	}
 }
 
+// RemoveFromMergeQueue removes the given pull request from the merge queue
+func RemoveFromMergeQueue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/pulls/{index}/merge-queue repository repoRemovePullRequestFromMergeQueue
+	// ---
+	// summary: Remove the given pull request from the merge queue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	pull, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.APIErrorNotFound()
+			return
+		}
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	exist, entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pull.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if !exist {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	if allowed, err := mergequeue.CanRemoveFromMergeQueue(ctx, ctx.Doer, ctx.Repo.Permission, pull, entry); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if !allowed {
+		ctx.APIError(http.StatusForbidden, "user has no permission to remove the pull request from the merge queue")
+		return
+	}
+
+	if err := mergequeue.RemoveFromMergeQueue(ctx, ctx.Doer, pull, mergequeue.RemovedReasonCanceled); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+			return
+		}
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
 // GetPullRequestCommits gets all commits associated with a given PR
 func GetPullRequestCommits(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/commits repository repoGetPullRequestCommits
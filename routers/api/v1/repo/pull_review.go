@@ -15,12 +15,14 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/gitrepo"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	issue_service "code.gitea.io/gitea/services/issue"
 	pull_service "code.gitea.io/gitea/services/pull"
+	files_service "code.gitea.io/gitea/services/repository/files"
 )
 
 // ListPullReviews lists all reviews of a pull request
@@ -347,9 +349,21 @@ func CreatePullReview(ctx *context.APIContext) {
 
 	// create review comments
 	for _, c := range opts.Comments {
-		line := c.NewLineNum
+		line, startLine := c.NewLineNum, c.NewStartLineNum
 		if c.OldLineNum > 0 {
-			line = c.OldLineNum * -1
+			line, startLine = c.OldLineNum*-1, c.OldStartLineNum*-1
+		}
+		switch c.SubjectType {
+		case "", "line":
+			if line == 0 {
+				ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("comment on %q needs a position", c.Path))
+				return
+			}
+		case "file":
+			line, startLine = 0, 0
+		default:
+			ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("unknown subject type %q", c.SubjectType))
+			return
 		}
 
 		if _, err := pull_service.CreateCodeComment(ctx,
@@ -357,6 +371,7 @@ func CreatePullReview(ctx *context.APIContext) {
 			ctx.Repo.GitRepo,
 			pr.Issue,
 			line,
+			startLine,
 			c.Body,
 			c.Path,
 			true, // pending review
@@ -364,7 +379,11 @@ func CreatePullReview(ctx *context.APIContext) {
 			opts.CommitID,
 			nil,
 		); err != nil {
-			ctx.APIErrorInternal(err)
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
 			return
 		}
 	}
@@ -920,3 +939,74 @@ func dismissReview(ctx *context.APIContext, msg string, isDismiss, dismissPriors
 	}
 	ctx.JSON(http.StatusOK, apiReview)
 }
+
+// ApplyPullReviewSuggestions applies the suggested changes of review comments to the head branch of a pull request
+func ApplyPullReviewSuggestions(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/pulls/{index}/suggestions repository repoApplyPullReviewSuggestions
+	// ---
+	// summary: Apply the suggested changes of review comments to the head branch as one commit
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ApplyPullReviewSuggestionsOptions"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/FilesResponse"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opts := web.GetForm(ctx).(*api.ApplyPullReviewSuggestionsOptions)
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.APIErrorNotFound("GetPullRequestByIndex", err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	filesResponse, err := files_service.ApplySuggestions(ctx, ctx.Doer, pr, opts.CommentIDs, opts.Message)
+	if err != nil {
+		switch {
+		case files_service.IsErrSuggestionOutdated(err):
+			ctx.APIError(http.StatusConflict, err)
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.APIError(http.StatusForbidden, err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		default:
+			handleChangeRepoFilesError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, filesResponse)
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetPushRule gets the push rule of the repository
+func GetPushRule(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/push_rule repository getRepoPushRule
+	// ---
+	// summary: Get the repository's push rule
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetPushRule(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// UpdatePushRule creates or updates the push rule of the repository
+func UpdatePushRule(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/push_rule repository updateRepoPushRule
+	// ---
+	// summary: Create or update the repository's push rule
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UpdatePushRuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.UpdatePushRule(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// DeletePushRule deletes the push rule of the repository
+func DeletePushRule(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/push_rule repository deleteRepoPushRule
+	// ---
+	// summary: Delete the repository's push rule
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: push rule has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeletePushRule(ctx, 0, ctx.Repo.Repository.ID)
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListSecretScanAlerts lists the secret scanning alerts of a repository
+func ListSecretScanAlerts(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/secret-scanning/alerts repository repoListSecretScanAlerts
+	// ---
+	// summary: List the secret scanning alerts of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: filter the alerts by state
+	//   type: string
+	//   enum: [open, resolved]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanAlertList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	alerts, total, err := db.FindAndCount[git_model.SecretScanAlert](ctx, git_model.FindSecretScanAlertOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+		State:       git_model.SecretScanAlertState(ctx.FormString("state")),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiAlerts := make([]*api.SecretScanAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		apiAlert, err := convert.ToSecretScanAlert(ctx, alert, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiAlerts = append(apiAlerts, apiAlert)
+	}
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiAlerts)
+}
+
+func getSecretScanAlert(ctx *context.APIContext) *git_model.SecretScanAlert {
+	alert, err := git_model.GetSecretScanAlertByRepoAndID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return alert
+}
+
+// GetSecretScanAlert gets a secret scanning alert of a repository
+func GetSecretScanAlert(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/secret-scanning/alerts/{id} repository repoGetSecretScanAlert
+	// ---
+	// summary: Get a secret scanning alert of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the alert
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanAlert"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	alert := getSecretScanAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+	apiAlert, err := convert.ToSecretScanAlert(ctx, alert, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiAlert)
+}
+
+// EditSecretScanAlert resolves or reopens a secret scanning alert of a repository
+func EditSecretScanAlert(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/secret-scanning/alerts/{id} repository repoEditSecretScanAlert
+	// ---
+	// summary: Resolve or reopen a secret scanning alert of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the alert
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditSecretScanAlertOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanAlert"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	alert := getSecretScanAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.EditSecretScanAlertOption)
+	var resolution git_model.SecretScanAlertResolution
+	if form.State == string(git_model.SecretScanAlertStateResolved) {
+		resolution = git_model.SecretScanAlertResolution(form.Resolution)
+		if !resolution.IsValid() {
+			ctx.APIError(http.StatusUnprocessableEntity, "a valid resolution is required to resolve the alert")
+			return
+		}
+	}
+	if err := git_model.ResolveSecretScanAlert(ctx, alert, ctx.Doer, resolution, form.ResolutionComment); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiAlert, err := convert.ToSecretScanAlert(ctx, alert, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiAlert)
+}
@@ -0,0 +1,393 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	terraform_service "code.gitea.io/gitea/services/terraform"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// The lock methods used by the Terraform http backend
+// https://developer.hashicorp.com/terraform/language/backend/http
+const (
+	MethodLock   = "LOCK"
+	MethodUnlock = "UNLOCK"
+)
+
+func init() {
+	chi.RegisterMethod(MethodLock)
+	chi.RegisterMethod(MethodUnlock)
+}
+
+func getTerraformState(ctx *context.APIContext) *terraform_model.TerraformState {
+	s, err := terraform_model.GetStateByName(ctx, ctx.Repo.Repository.ID, ctx.PathParam("name"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return s
+}
+
+func writeTerraformState(ctx *context.APIContext, status int, content []byte) {
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Status(status)
+	_, _ = ctx.Write(content)
+}
+
+func handleTerraformStateError(ctx *context.APIContext, err error) {
+	var errLocked terraform_service.ErrStateLocked
+	switch {
+	case errors.As(err, &errLocked):
+		writeTerraformState(ctx, http.StatusLocked, []byte(errLocked.LockInfo))
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIErrorNotFound()
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusBadRequest, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// ListTerraformStates lists the Terraform states of a repository
+func ListTerraformStates(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/states repository repoListTerraformStates
+	// ---
+	// summary: List the Terraform states of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	states, err := terraform_model.GetStatesByRepo(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiStates := make([]*api.TerraformState, 0, len(states))
+	for _, s := range states {
+		apiStates = append(apiStates, convert.ToTerraformState(s))
+	}
+
+	ctx.JSON(http.StatusOK, apiStates)
+}
+
+// GetTerraformState returns the content of the latest version of a Terraform state
+func GetTerraformState(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/states/{name} repository repoGetTerraformState
+	// ---
+	// summary: Get the latest version of a Terraform state
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the state
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	content, err := terraform_service.ReadLatestState(ctx, ctx.Repo.Repository.ID, ctx.PathParam("name"))
+	if err != nil {
+		handleTerraformStateError(ctx, err)
+		return
+	}
+
+	writeTerraformState(ctx, http.StatusOK, content)
+}
+
+// UpdateTerraformState stores a new version of a Terraform state
+func UpdateTerraformState(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/terraform/states/{name} repository repoUpdateTerraformState
+	// ---
+	// summary: Store a new version of a Terraform state
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: ID
+	//   in: query
+	//   description: ID of the lock held by the client
+	//   type: string
+	// - name: body
+	//   in: body
+	//   schema:
+	//     type: object
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     description: the state is locked by another client
+
+	if !terraform_service.IsValidStateName(ctx.PathParam("name")) {
+		ctx.APIError(http.StatusBadRequest, terraform_service.ErrInvalidStateName)
+		return
+	}
+
+	content, err := io.ReadAll(io.LimitReader(ctx.Req.Body, setting.TerraformState.MaxSize*1024*1024+1))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if contentMD5 := ctx.Req.Header.Get("Content-MD5"); contentMD5 != "" {
+		hash := md5.Sum(content)
+		if expected, err := base64.StdEncoding.DecodeString(contentMD5); err != nil || !bytes.Equal(expected, hash[:]) {
+			ctx.APIError(http.StatusBadRequest, "Content-MD5 does not match the content")
+			return
+		}
+	}
+
+	if err := terraform_service.UpdateState(ctx, ctx.Repo.Repository.ID, ctx.Doer, ctx.PathParam("name"), ctx.FormString("ID"), content); err != nil {
+		handleTerraformStateError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// DeleteTerraformState deletes a Terraform state with all its versions
+func DeleteTerraformState(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/terraform/states/{name} repository repoDeleteTerraformState
+	// ---
+	// summary: Delete a Terraform state with all its versions
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: ID
+	//   in: query
+	//   description: ID of the lock held by the client
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     description: the state is locked by another client
+
+	if err := terraform_service.DeleteState(ctx, ctx.Repo.Repository.ID, ctx.PathParam("name"), ctx.FormString("ID")); err != nil {
+		handleTerraformStateError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// LockTerraformState acquires the lock of a Terraform state. The body contains the lock information as JSON.
+func LockTerraformState(ctx *context.APIContext) {
+	if !terraform_service.IsValidStateName(ctx.PathParam("name")) {
+		ctx.APIError(http.StatusBadRequest, terraform_service.ErrInvalidStateName)
+		return
+	}
+
+	info, err := io.ReadAll(io.LimitReader(ctx.Req.Body, 64*1024))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if err := terraform_service.LockState(ctx, ctx.Repo.Repository.ID, ctx.PathParam("name"), info); err != nil {
+		handleTerraformStateError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// UnlockTerraformState releases the lock of a Terraform state
+func UnlockTerraformState(ctx *context.APIContext) {
+	info, err := io.ReadAll(io.LimitReader(ctx.Req.Body, 64*1024))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if err := terraform_service.UnlockState(ctx, ctx.Repo.Repository.ID, ctx.PathParam("name"), info); err != nil {
+		handleTerraformStateError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// ListTerraformStateVersions lists the versions of a Terraform state
+func ListTerraformStateVersions(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/states/{name}/versions repository repoListTerraformStateVersions
+	// ---
+	// summary: List the versions of a Terraform state
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateVersionList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	versions, err := terraform_model.GetStateVersions(ctx, s.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiVersions := make([]*api.TerraformStateVersion, 0, len(versions))
+	for _, v := range versions {
+		apiVersions = append(apiVersions, convert.ToTerraformStateVersion(v))
+	}
+
+	ctx.JSON(http.StatusOK, apiVersions)
+}
+
+// GetTerraformStateVersion returns the content of a version of a Terraform state
+func GetTerraformStateVersion(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/states/{name}/versions/{version} repository repoGetTerraformStateVersion
+	// ---
+	// summary: Get a version of a Terraform state
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the state
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the state
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	v, err := terraform_model.GetStateVersion(ctx, s.ID, ctx.PathParamInt64("version"))
+	if err != nil {
+		handleTerraformStateError(ctx, err)
+		return
+	}
+
+	content, err := terraform_service.ReadStateVersion(v)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	writeTerraformState(ctx, http.StatusOK, content)
+}
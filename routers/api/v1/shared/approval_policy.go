@@ -0,0 +1,84 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+)
+
+func toAPIApprovalPolicy(policy *actions_model.ActionApprovalPolicy) *api.ActionApprovalPolicy {
+	return &api.ActionApprovalPolicy{
+		OwnerID:           policy.OwnerID,
+		RepoID:            policy.RepoID,
+		Mode:              policy.Mode.String(),
+		RequireForSecrets: policy.RequireForSecrets,
+		Environments:      policy.Environments,
+		ApproverTeamID:    policy.ApproverTeamID,
+	}
+}
+
+// GetApprovalPolicy responds the approval policy configured on the owner or the repo
+func GetApprovalPolicy(ctx *context.APIContext, ownerID, repoID int64) {
+	policy, err := actions_model.GetApprovalPolicy(ctx, ownerID, repoID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, toAPIApprovalPolicy(policy))
+}
+
+// UpdateApprovalPolicy creates or updates the approval policy of the owner or the repo,
+// the approver team must belong to the organization teamOrgID
+func UpdateApprovalPolicy(ctx *context.APIContext, ownerID, repoID, teamOrgID int64) {
+	opt := web.GetForm(ctx).(*api.UpdateActionApprovalPolicyOption)
+
+	mode, ok := actions_model.ParseApprovalMode(opt.Mode)
+	if !ok {
+		ctx.APIError(http.StatusBadRequest, util.NewInvalidArgumentErrorf("invalid approval mode %q", opt.Mode))
+		return
+	}
+
+	policy := &actions_model.ActionApprovalPolicy{
+		OwnerID:           ownerID,
+		RepoID:            repoID,
+		Mode:              mode,
+		RequireForSecrets: opt.RequireForSecrets,
+		Environments:      opt.Environments,
+		ApproverTeamID:    opt.ApproverTeamID,
+	}
+	if err := actions_service.UpsertApprovalPolicy(ctx, teamOrgID, policy); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, toAPIApprovalPolicy(policy))
+}
+
+// DeleteApprovalPolicy deletes the approval policy of the owner or the repo
+func DeleteApprovalPolicy(ctx *context.APIContext, ownerID, repoID int64) {
+	if err := actions_model.DeleteApprovalPolicy(ctx, ownerID, repoID); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
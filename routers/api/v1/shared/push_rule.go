@@ -0,0 +1,77 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	git_model "code.gitea.io/gitea/models/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+)
+
+func toAPIPushRule(rule *git_model.PushRule) *api.PushRule {
+	return &api.PushRule{
+		OwnerID:                    rule.OwnerID,
+		RepoID:                     rule.RepoID,
+		MaxFileSize:                rule.MaxFileSize,
+		ForbiddenPathPatterns:      rule.ForbiddenPathPatterns,
+		CommitMessagePattern:       rule.CommitMessagePattern,
+		RequireVerifiedAuthorEmail: rule.RequireVerifiedAuthorEmail,
+	}
+}
+
+// GetPushRule responds the push rule configured on the instance, the owner or the repo
+// ownerID == 0 and repoID == 0 means the instance level rule
+func GetPushRule(ctx *context.APIContext, ownerID, repoID int64) {
+	rule, err := git_model.GetPushRule(ctx, ownerID, repoID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, toAPIPushRule(rule))
+}
+
+// UpdatePushRule creates or updates the push rule of the instance, the owner or the repo
+func UpdatePushRule(ctx *context.APIContext, ownerID, repoID int64) {
+	opt := web.GetForm(ctx).(*api.UpdatePushRuleOption)
+
+	rule := &git_model.PushRule{
+		OwnerID:                    ownerID,
+		RepoID:                     repoID,
+		MaxFileSize:                opt.MaxFileSize,
+		ForbiddenPathPatterns:      opt.ForbiddenPathPatterns,
+		CommitMessagePattern:       opt.CommitMessagePattern,
+		RequireVerifiedAuthorEmail: opt.RequireVerifiedAuthorEmail,
+	}
+	if err := git_model.UpsertPushRule(ctx, rule); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, toAPIPushRule(rule))
+}
+
+// DeletePushRule deletes the push rule of the instance, the owner or the repo
+func DeletePushRule(ctx *context.APIContext, ownerID, repoID int64) {
+	if err := git_model.DeletePushRule(ctx, ownerID, repoID); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetQueueStats responds the number of queued and in progress jobs per label set,
+// ownerID == 0 and repoID == 0 means all jobs of the instance
+// Access rights are checked at the API route level
+func GetQueueStats(ctx *context.APIContext, ownerID, repoID int64) {
+	stats, err := actions_model.GetQueueStats(ctx, actions_model.FindQueueStatsOptions{
+		OwnerID: ownerID,
+		RepoID:  repoID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiStats := make([]*api.ActionQueueStat, len(stats))
+	for i, stat := range stats {
+		apiStats[i] = &api.ActionQueueStat{
+			Labels:     stat.Labels,
+			Queued:     stat.Queued,
+			InProgress: stat.InProgress,
+		}
+		if !stat.OldestQueued.IsZero() {
+			oldest := stat.OldestQueued.AsTime()
+			apiStats[i].OldestQueuedAt = &oldest
+		}
+	}
+	ctx.JSON(http.StatusOK, apiStats)
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func respondRulesetError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIErrorNotFound(err)
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusBadRequest, err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// ListRulesets lists the rulesets of the owner
+// ownerID == 0 means the instance level rulesets, it's the same for the other ruleset functions
+// Access rights are checked at the API route level
+func ListRulesets(ctx *context.APIContext, ownerID int64) {
+	rulesets, total, err := db.FindAndCount[git_model.Ruleset](ctx, git_model.FindRulesetOptions{
+		OwnerID:     ownerID,
+		ListOptions: utils.GetListOptions(ctx),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRulesets := make([]*api.Ruleset, len(rulesets))
+	for i, rs := range rulesets {
+		apiRulesets[i] = convert.ToRuleset(rs)
+	}
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiRulesets)
+}
+
+// CreateRuleset creates a ruleset for the owner
+func CreateRuleset(ctx *context.APIContext, ownerID int64) {
+	opt := web.GetForm(ctx).(*api.CreateRulesetOption)
+	rs := &git_model.Ruleset{
+		OwnerID:                 ownerID,
+		Name:                    opt.Name,
+		Enforcement:             git_model.RulesetEnforcement(opt.Enforcement),
+		RepoNamePatterns:        opt.RepoNamePatterns,
+		RepoTopics:              opt.RepoTopics,
+		RefPatterns:             opt.RefPatterns,
+		BlockCreation:           opt.BlockCreation,
+		BlockDeletion:           opt.BlockDeletion,
+		BlockForcePush:          opt.BlockForcePush,
+		RequirePullRequest:      opt.RequirePullRequest,
+		RequiredApprovals:       opt.RequiredApprovals,
+		BlockOnRejectedReviews:  opt.BlockOnRejectedReviews,
+		BlockOnOutdatedBranch:   opt.BlockOnOutdatedBranch,
+		RequireCodeOwnerReviews: opt.RequireCodeOwnerReviews,
+		RequireSignedCommits:    opt.RequireSignedCommits,
+		StatusCheckContexts:     opt.StatusCheckContexts,
+		BypassUserIDs:           opt.BypassUserIDs,
+		BypassTeamIDs:           opt.BypassTeamIDs,
+		BypassRepoAdmins:        opt.BypassRepoAdmins,
+	}
+	if rs.Enforcement == "" {
+		rs.Enforcement = git_model.RulesetEnforcementActive
+	}
+	if err := git_model.CreateRuleset(ctx, rs); err != nil {
+		respondRulesetError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToRuleset(rs))
+}
+
+// GetRuleset responds the ruleset of the owner
+func GetRuleset(ctx *context.APIContext, ownerID, rulesetID int64) {
+	rs, err := git_model.GetRulesetByOwnerAndID(ctx, ownerID, rulesetID)
+	if err != nil {
+		respondRulesetError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToRuleset(rs))
+}
+
+// EditRuleset edits the ruleset of the owner
+func EditRuleset(ctx *context.APIContext, ownerID, rulesetID int64) {
+	rs, err := git_model.GetRulesetByOwnerAndID(ctx, ownerID, rulesetID)
+	if err != nil {
+		respondRulesetError(ctx, err)
+		return
+	}
+
+	opt := web.GetForm(ctx).(*api.EditRulesetOption)
+	var cols []string
+	setCol := func(col string, changed bool) {
+		if changed {
+			cols = append(cols, col)
+		}
+	}
+	if opt.Name != nil {
+		rs.Name = *opt.Name
+		setCol("name", true)
+	}
+	if opt.Enforcement != nil {
+		rs.Enforcement = git_model.RulesetEnforcement(*opt.Enforcement)
+		setCol("enforcement", true)
+	}
+	setCol("repo_name_patterns", setIfNotNil(&rs.RepoNamePatterns, opt.RepoNamePatterns))
+	setCol("repo_topics", setIfNotNil(&rs.RepoTopics, opt.RepoTopics))
+	setCol("ref_patterns", setIfNotNil(&rs.RefPatterns, opt.RefPatterns))
+	setCol("block_creation", setIfNotNil(&rs.BlockCreation, opt.BlockCreation))
+	setCol("block_deletion", setIfNotNil(&rs.BlockDeletion, opt.BlockDeletion))
+	setCol("block_force_push", setIfNotNil(&rs.BlockForcePush, opt.BlockForcePush))
+	setCol("require_pull_request", setIfNotNil(&rs.RequirePullRequest, opt.RequirePullRequest))
+	setCol("required_approvals", setIfNotNil(&rs.RequiredApprovals, opt.RequiredApprovals))
+	setCol("block_on_rejected_reviews", setIfNotNil(&rs.BlockOnRejectedReviews, opt.BlockOnRejectedReviews))
+	setCol("block_on_outdated_branch", setIfNotNil(&rs.BlockOnOutdatedBranch, opt.BlockOnOutdatedBranch))
+	setCol("require_code_owner_reviews", setIfNotNil(&rs.RequireCodeOwnerReviews, opt.RequireCodeOwnerReviews))
+	setCol("require_signed_commits", setIfNotNil(&rs.RequireSignedCommits, opt.RequireSignedCommits))
+	setCol("status_check_contexts", setIfNotNil(&rs.StatusCheckContexts, opt.StatusCheckContexts))
+	setCol("bypass_user_ids", setIfNotNil(&rs.BypassUserIDs, opt.BypassUserIDs))
+	setCol("bypass_team_ids", setIfNotNil(&rs.BypassTeamIDs, opt.BypassTeamIDs))
+	setCol("bypass_repo_admins", setIfNotNil(&rs.BypassRepoAdmins, opt.BypassRepoAdmins))
+
+	if len(cols) > 0 {
+		if err := git_model.UpdateRuleset(ctx, rs, cols...); err != nil {
+			respondRulesetError(ctx, err)
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, convert.ToRuleset(rs))
+}
+
+// setIfNotNil sets the field to the value of the option if it's provided, it returns whether the field is set
+func setIfNotNil[T any](field *T, opt *T) bool {
+	if opt == nil {
+		return false
+	}
+	*field = *opt
+	return true
+}
+
+// DeleteRuleset deletes the ruleset of the owner
+func DeleteRuleset(ctx *context.APIContext, ownerID, rulesetID int64) {
+	rs, err := git_model.GetRulesetByOwnerAndID(ctx, ownerID, rulesetID)
+	if err != nil {
+		respondRulesetError(ctx, err)
+		return
+	}
+	if err := git_model.DeleteRulesetByID(ctx, rs.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
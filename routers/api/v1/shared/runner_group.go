@@ -0,0 +1,174 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func respondRunnerGroupError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIErrorNotFound(err)
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusBadRequest, err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// ListRunnerGroups lists the runner groups of the owner
+// ownerID == 0 means the global runner groups, it's the same for the other runner group functions
+// Access rights are checked at the API route level
+func ListRunnerGroups(ctx *context.APIContext, ownerID int64) {
+	groups, total, err := db.FindAndCount[actions_model.ActionRunnerGroup](ctx, actions_model.FindRunnerGroupOptions{
+		OwnerID:     ownerID,
+		ListOptions: utils.GetListOptions(ctx),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiGroups := make([]*api.ActionRunnerGroup, len(groups))
+	for i, group := range groups {
+		apiGroups[i] = convert.ToActionRunnerGroup(group)
+	}
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiGroups)
+}
+
+// CreateRunnerGroup creates a runner group for the owner
+func CreateRunnerGroup(ctx *context.APIContext, ownerID int64) {
+	opt := web.GetForm(ctx).(*api.CreateActionRunnerGroupOption)
+	group := &actions_model.ActionRunnerGroup{
+		OwnerID:       ownerID,
+		Name:          opt.Name,
+		Description:   opt.Description,
+		AllowAllRepos: opt.AllowAllRepos,
+		RepoIDs:       opt.RepoIDs,
+		Workflows:     opt.Workflows,
+	}
+	if err := actions_service.CreateRunnerGroup(ctx, group); err != nil {
+		respondRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToActionRunnerGroup(group))
+}
+
+// GetRunnerGroup responds the runner group of the owner
+func GetRunnerGroup(ctx *context.APIContext, ownerID, groupID int64) {
+	group, err := actions_model.GetRunnerGroupByOwnerAndID(ctx, ownerID, groupID)
+	if err != nil {
+		respondRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionRunnerGroup(group))
+}
+
+// EditRunnerGroup edits the runner group of the owner
+func EditRunnerGroup(ctx *context.APIContext, ownerID, groupID int64) {
+	group, err := actions_model.GetRunnerGroupByOwnerAndID(ctx, ownerID, groupID)
+	if err != nil {
+		respondRunnerGroupError(ctx, err)
+		return
+	}
+
+	opt := web.GetForm(ctx).(*api.EditActionRunnerGroupOption)
+	var cols []string
+	if opt.Name != nil {
+		group.Name = *opt.Name
+		cols = append(cols, "name")
+	}
+	if opt.Description != nil {
+		group.Description = *opt.Description
+		cols = append(cols, "description")
+	}
+	if opt.AllowAllRepos != nil {
+		group.AllowAllRepos = *opt.AllowAllRepos
+		cols = append(cols, "allow_all_repos")
+	}
+	if opt.RepoIDs != nil {
+		group.RepoIDs = *opt.RepoIDs
+		cols = append(cols, "repo_i_ds")
+	}
+	if opt.Workflows != nil {
+		group.Workflows = *opt.Workflows
+		cols = append(cols, "workflows")
+	}
+	if len(cols) > 0 {
+		if err := actions_service.UpdateRunnerGroup(ctx, group, cols...); err != nil {
+			respondRunnerGroupError(ctx, err)
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionRunnerGroup(group))
+}
+
+// DeleteRunnerGroup deletes the runner group of the owner, its runners are released from the group
+func DeleteRunnerGroup(ctx *context.APIContext, ownerID, groupID int64) {
+	group, err := actions_model.GetRunnerGroupByOwnerAndID(ctx, ownerID, groupID)
+	if err != nil {
+		respondRunnerGroupError(ctx, err)
+		return
+	}
+	if err := actions_model.DeleteRunnerGroup(ctx, group); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// AddRunnerToGroup moves a runner of the owner into the runner group
+func AddRunnerToGroup(ctx *context.APIContext, ownerID, groupID, runnerID int64) {
+	group, err := actions_model.GetRunnerGroupByOwnerAndID(ctx, ownerID, groupID)
+	if err != nil {
+		respondRunnerGroupError(ctx, err)
+		return
+	}
+	runner, ok := getRunnerByID(ctx, ownerID, 0, runnerID)
+	if !ok {
+		return
+	}
+	if err := actions_service.SetRunnerGroup(ctx, runner, group); err != nil {
+		respondRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveRunnerFromGroup removes a runner of the owner from the runner group
+func RemoveRunnerFromGroup(ctx *context.APIContext, ownerID, groupID, runnerID int64) {
+	group, err := actions_model.GetRunnerGroupByOwnerAndID(ctx, ownerID, groupID)
+	if err != nil {
+		respondRunnerGroupError(ctx, err)
+		return
+	}
+	runner, ok := getRunnerByID(ctx, ownerID, 0, runnerID)
+	if !ok {
+		return
+	}
+	if runner.GroupID != group.ID {
+		ctx.APIErrorNotFound("The runner is not in the runner group")
+		return
+	}
+	if err := actions_service.SetRunnerGroup(ctx, runner, nil); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListSecretScanPatterns lists the custom secret patterns of the owner
+// ownerID == 0 means the instance level patterns, it's the same for the other pattern functions
+func ListSecretScanPatterns(ctx *context.APIContext, ownerID int64) {
+	patterns, total, err := db.FindAndCount[git_model.SecretScanPattern](ctx, git_model.FindSecretScanPatternOptions{
+		OwnerID:     ownerID,
+		ListOptions: utils.GetListOptions(ctx),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiPatterns := make([]*api.SecretScanPattern, len(patterns))
+	for i, p := range patterns {
+		apiPatterns[i] = convert.ToSecretScanPattern(p)
+	}
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiPatterns)
+}
+
+// CreateSecretScanPattern creates a custom secret pattern for the owner
+func CreateSecretScanPattern(ctx *context.APIContext, ownerID int64) {
+	opt := web.GetForm(ctx).(*api.CreateSecretScanPatternOption)
+	p := &git_model.SecretScanPattern{
+		OwnerID: ownerID,
+		Name:    opt.Name,
+		Pattern: opt.Pattern,
+	}
+	if err := git_model.CreateSecretScanPattern(ctx, p); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusBadRequest, err)
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.APIError(http.StatusConflict, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToSecretScanPattern(p))
+}
+
+// DeleteSecretScanPattern deletes a custom secret pattern of the owner
+func DeleteSecretScanPattern(ctx *context.APIContext, ownerID, patternID int64) {
+	p, err := git_model.GetSecretScanPatternByOwnerAndID(ctx, ownerID, patternID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	if err := git_model.DeleteSecretScanPatternByID(ctx, p.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -46,3 +46,31 @@ type swaggerResponseActionWorkflowList struct {
 	// in:body
 	Body api.ActionWorkflowResponse `json:"body"`
 }
+
+// ActionApprovalPolicy
+// swagger:response ActionApprovalPolicy
+type swaggerResponseActionApprovalPolicy struct {
+	// in:body
+	Body api.ActionApprovalPolicy `json:"body"`
+}
+
+// ActionRunnerGroup
+// swagger:response ActionRunnerGroup
+type swaggerResponseActionRunnerGroup struct {
+	// in:body
+	Body api.ActionRunnerGroup `json:"body"`
+}
+
+// ActionRunnerGroupList
+// swagger:response ActionRunnerGroupList
+type swaggerResponseActionRunnerGroupList struct {
+	// in:body
+	Body []api.ActionRunnerGroup `json:"body"`
+}
+
+// ActionQueueStatList
+// swagger:response ActionQueueStatList
+type swaggerResponseActionQueueStatList struct {
+	// in:body
+	Body []api.ActionQueueStat `json:"body"`
+}
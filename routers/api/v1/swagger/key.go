@@ -48,3 +48,17 @@ type swaggerResponseDeployKeyList struct {
 	// in:body
 	Body []api.DeployKey `json:"body"`
 }
+
+// X509TrustedCA
+// swagger:response X509TrustedCA
+type swaggerResponseX509TrustedCA struct {
+	// in:body
+	Body api.X509TrustedCA `json:"body"`
+}
+
+// X509TrustedCAList
+// swagger:response X509TrustedCAList
+type swaggerResponseX509TrustedCAList struct {
+	// in:body
+	Body []api.X509TrustedCA `json:"body"`
+}
@@ -222,4 +222,37 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	LockIssueOption api.LockIssueOption
+
+	// in:body
+	UpdateActionApprovalPolicyOption api.UpdateActionApprovalPolicyOption
+
+	// in:body
+	CreateActionRunnerGroupOption api.CreateActionRunnerGroupOption
+
+	// in:body
+	EditActionRunnerGroupOption api.EditActionRunnerGroupOption
+
+	// in:body
+	CreateRulesetOption api.CreateRulesetOption
+
+	// in:body
+	EditRulesetOption api.EditRulesetOption
+
+	// in:body
+	UpdatePushRuleOption api.UpdatePushRuleOption
+
+	// in:body
+	EditSecretScanAlertOption api.EditSecretScanAlertOption
+
+	// in:body
+	CreateSecretScanPatternOption api.CreateSecretScanPatternOption
+
+	// in:body
+	CreateX509TrustedCAOption api.CreateX509TrustedCAOption
+
+	// in:body
+	ApplyPullReviewSuggestionsOptions api.ApplyPullReviewSuggestionsOptions
+
+	// in:body
+	PromotePackageVersionOption api.PromotePackageVersionOption
 }
@@ -27,3 +27,17 @@ type swaggerResponsePackageFileList struct {
 	// in:body
 	Body []api.PackageFile `json:"body"`
 }
+
+// PackageVulnerabilityList
+// swagger:response PackageVulnerabilityList
+type swaggerResponsePackageVulnerabilityList struct {
+	// in:body
+	Body []api.PackageVulnerability `json:"body"`
+}
+
+// PackageDownloadStatistics
+// swagger:response PackageDownloadStatistics
+type swaggerResponsePackageDownloadStatistics struct {
+	// in:body
+	Body api.PackageDownloadStatistics `json:"body"`
+}
@@ -49,6 +49,55 @@ type swaggerResponseBranchProtectionList struct {
 	Body []api.BranchProtection `json:"body"`
 }
 
+// Ruleset
+// swagger:response Ruleset
+type swaggerResponseRuleset struct {
+	// in:body
+	Body api.Ruleset `json:"body"`
+}
+
+// RulesetList
+// swagger:response RulesetList
+type swaggerResponseRulesetList struct {
+	// in:body
+	Body []api.Ruleset `json:"body"`
+}
+
+// PushRule
+// swagger:response PushRule
+type swaggerResponsePushRule struct {
+	// in:body
+	Body api.PushRule `json:"body"`
+}
+
+// SecretScanAlert
+// swagger:response SecretScanAlert
+type swaggerResponseSecretScanAlert struct {
+	// in:body
+	Body api.SecretScanAlert `json:"body"`
+}
+
+// SecretScanAlertList
+// swagger:response SecretScanAlertList
+type swaggerResponseSecretScanAlertList struct {
+	// in:body
+	Body []api.SecretScanAlert `json:"body"`
+}
+
+// SecretScanPattern
+// swagger:response SecretScanPattern
+type swaggerResponseSecretScanPattern struct {
+	// in:body
+	Body api.SecretScanPattern `json:"body"`
+}
+
+// SecretScanPatternList
+// swagger:response SecretScanPatternList
+type swaggerResponseSecretScanPatternList struct {
+	// in:body
+	Body []api.SecretScanPattern `json:"body"`
+}
+
 // TagList
 // swagger:response TagList
 type swaggerResponseTagList struct {
@@ -522,3 +571,17 @@ type swaggerMergeUpstreamResponse struct {
 	// in:body
 	Body api.MergeUpstreamResponse `json:"body"`
 }
+
+// TerraformStateList
+// swagger:response TerraformStateList
+type swaggerTerraformStateList struct {
+	// in:body
+	Body []api.TerraformState `json:"body"`
+}
+
+// TerraformStateVersionList
+// swagger:response TerraformStateVersionList
+type swaggerTerraformStateVersionList struct {
+	// in:body
+	Body []api.TerraformStateVersion `json:"body"`
+}
@@ -5,15 +5,21 @@ package common
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/context"
 )
 
+// followLogsInterval is the interval to check the new log lines of a running job
+var followLogsInterval = time.Second
+
 func DownloadActionsRunJobLogsWithIndex(ctx *context.Base, ctxRepo *repo_model.Repository, runID, jobIndex int64) error {
 	runJobs, err := actions_model.GetRunJobsByRunID(ctx, runID)
 	if err != nil {
@@ -69,3 +75,76 @@ func DownloadActionsRunJobLogs(ctx *context.Base, ctxRepo *repo_model.Repository
 	})
 	return nil
 }
+
+// FollowActionsRunJobLogs writes the log lines of the job from the line offset as plain text,
+// and keeps writing the new lines until the job is done or the client disconnects.
+// Errors can only be returned before the first byte is written.
+func FollowActionsRunJobLogs(ctx *context.Base, ctxRepo *repo_model.Repository, curJob *actions_model.ActionRunJob, offset int64) error {
+	if curJob.RepoID != ctxRepo.ID {
+		return util.NewNotExistErrorf("job not found")
+	}
+	if offset < 0 {
+		return util.NewInvalidArgumentErrorf("invalid offset %d", offset)
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	ctx.Resp.Header().Set("X-Accel-Buffering", "no")
+	ctx.Resp.WriteHeader(http.StatusOK)
+
+	taskID := curJob.TaskID
+	for {
+		job, err := actions_model.GetRunJobByID(ctx, curJob.ID)
+		if err != nil {
+			log.Error("GetRunJobByID: %v", err)
+			return nil
+		}
+		if taskID == 0 {
+			taskID = job.TaskID
+		} else if job.TaskID != taskID {
+			// the job has been rerun, the logs of the followed task won't change any more
+			return nil
+		}
+
+		if taskID > 0 {
+			task, err := actions_model.GetTaskByID(ctx, taskID)
+			if err != nil {
+				log.Error("GetTaskByID: %v", err)
+				return nil
+			}
+			if task.LogExpired {
+				return nil
+			}
+			if offset < task.LogLength && offset < int64(len(task.LogIndexes)) {
+				rows, err := actions.ReadLogs(ctx, task.LogInStorage, task.LogFilename, task.LogIndexes[offset], task.LogLength-offset)
+				if err != nil {
+					log.Error("ReadLogs: %v", err)
+					return nil
+				}
+				var sb strings.Builder
+				for _, row := range rows {
+					sb.WriteString(actions.FormatLog(row.Time.AsTime(), row.Content))
+					sb.WriteByte('\n')
+				}
+				if _, err := ctx.Resp.Write([]byte(sb.String())); err != nil {
+					return nil
+				}
+				ctx.Resp.Flush()
+				offset += int64(len(rows))
+			}
+			// the runner may upload the last lines after reporting the result, wait for them unless the runner has gone
+			if task.Status.IsDone() && offset >= task.LogLength &&
+				(task.LogInStorage || time.Since(task.Stopped.AsTime()) > time.Minute) {
+				return nil
+			}
+		} else if job.Status.IsDone() {
+			// the job has been cancelled or skipped before it starts
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(followLogsInterval):
+		}
+	}
+}
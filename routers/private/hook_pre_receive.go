@@ -16,10 +16,12 @@ import (
 	access_model "code.gitea.io/gitea/models/perm/access"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/private"
+	"code.gitea.io/gitea/modules/secretscan"
 	"code.gitea.io/gitea/modules/web"
 	gitea_context "code.gitea.io/gitea/services/context"
 	pull_service "code.gitea.io/gitea/services/pull"
@@ -43,6 +45,18 @@ type preReceiveContext struct {
 	protectedTags    []*git_model.ProtectedTag
 	gotProtectedTags bool
 
+	pushRules    []*git_model.PushRule
+	gotPushRules bool
+
+	secretPatterns []*secretscan.Pattern
+	scannedBlobs   container.Set[string]
+	// secretAlerts are created once all the refs of the request have passed the checks.
+	// The hook sends the refs in batches of 500, so the alerts of a batch are kept if a later batch is rejected.
+	secretAlerts []*git_model.SecretScanAlert
+
+	// warnings are sent back to the pusher when the push is accepted
+	warnings []string
+
 	env []string
 
 	opts *private.HookOptions
@@ -122,7 +136,7 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		case refFullName.IsBranch():
 			preReceiveBranch(ourCtx, oldCommitID, newCommitID, refFullName)
 		case refFullName.IsTag():
-			preReceiveTag(ourCtx, refFullName)
+			preReceiveTag(ourCtx, oldCommitID, newCommitID, refFullName)
 		case git.DefaultFeatures().SupportProcReceive && refFullName.IsFor():
 			preReceiveFor(ourCtx, refFullName)
 		default:
@@ -133,7 +147,17 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		}
 	}
 
-	ctx.PlainText(http.StatusOK, "ok")
+	if len(ourCtx.secretAlerts) > 0 {
+		if err := git_model.CreateSecretScanAlerts(ctx, ourCtx.secretAlerts); err != nil {
+			log.Error("Unable to create secret scanning alerts for %-v: %v", ctx.Repo.Repository, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, private.HookPreReceiveResult{Warnings: ourCtx.warnings})
 }
 
 func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
@@ -156,6 +180,18 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		return
 	}
 
+	if !preReceiveRulesets(ctx, oldCommitID, newCommitID, refFullName) {
+		return
+	}
+
+	if !preReceivePushRules(ctx, newCommitID, refFullName) {
+		return
+	}
+
+	if !preReceiveSecretScanning(ctx, newCommitID, refFullName) {
+		return
+	}
+
 	protectBranch, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, branchName)
 	if err != nil {
 		log.Error("Unable to get protected branch: %s in %-v Error: %v", branchName, repo, err)
@@ -188,14 +224,14 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 
 	// 2. Disallow force pushes to protected branches
 	if oldCommitID != objectFormat.EmptyObjectID().String() {
-		output, _, err := git.NewCommand("rev-list", "--max-count=1").AddDynamicArguments(oldCommitID, "^"+newCommitID).RunStdString(ctx, &git.RunOpts{Dir: repo.RepoPath(), Env: ctx.env})
+		forcePush, err := detectForcePush(ctx, oldCommitID, newCommitID)
 		if err != nil {
 			log.Error("Unable to detect force push between: %s and %s in %-v Error: %v", oldCommitID, newCommitID, repo, err)
 			ctx.JSON(http.StatusInternalServerError, private.Response{
 				Err: fmt.Sprintf("Fail to detect force push: %v", err),
 			})
 			return
-		} else if len(output) > 0 {
+		} else if forcePush {
 			if protectBranch.CanForcePush {
 				isForcePush = true
 			} else {
@@ -391,11 +427,23 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 	}
 }
 
-func preReceiveTag(ctx *preReceiveContext, refFullName git.RefName) {
+func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
 	if !ctx.AssertCanWriteCode() {
 		return
 	}
 
+	if !preReceiveRulesets(ctx, oldCommitID, newCommitID, refFullName) {
+		return
+	}
+
+	if !preReceivePushRules(ctx, newCommitID, refFullName) {
+		return
+	}
+
+	if !preReceiveSecretScanning(ctx, newCommitID, refFullName) {
+		return
+	}
+
 	tagName := refFullName.TagName()
 
 	if !ctx.gotProtectedTags {
@@ -484,6 +532,16 @@ func generateGitEnv(opts *private.HookOptions) (env []string) {
 	return env
 }
 
+// detectForcePush returns true if the old commit is not an ancestor of the new commit
+func detectForcePush(ctx *preReceiveContext, oldCommitID, newCommitID string) (bool, error) {
+	output, _, err := git.NewCommand("rev-list", "--max-count=1").AddDynamicArguments(oldCommitID, "^"+newCommitID).
+		RunStdString(ctx, &git.RunOpts{Dir: ctx.Repo.Repository.RepoPath(), Env: ctx.env})
+	if err != nil {
+		return false, err
+	}
+	return len(output) > 0, nil
+}
+
 // loadPusherAndPermission returns false if an error occurs, and it writes the error response
 func (ctx *preReceiveContext) loadPusherAndPermission() bool {
 	if ctx.loadedPusher {
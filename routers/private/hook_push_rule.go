@@ -0,0 +1,227 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/private"
+)
+
+// preReceivePushRules checks the content of the pushed commits against the push rules of the instance, the owner and the repository,
+// it returns false if the push is rejected or an error response has been written
+func preReceivePushRules(ctx *preReceiveContext, newCommitID string, refFullName git.RefName) bool {
+	// the pushes made by Gitea to merge or update a pull request only contain commits which have been pushed before
+	if ctx.opts.IsWiki || ctx.opts.PullRequestID != 0 || newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return true
+	}
+
+	repo := ctx.Repo.Repository
+	if !ctx.gotPushRules {
+		var err error
+		ctx.pushRules, err = git_model.GetPushRulesForRepo(ctx, repo)
+		if err != nil {
+			log.Error("Unable to get push rules for %-v: %v", repo, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return false
+		}
+		ctx.gotPushRules = true
+	}
+	if len(ctx.pushRules) == 0 {
+		return true
+	}
+	violation, err := checkPushRules(ctx, ctx.pushRules, newCommitID)
+	if err != nil {
+		log.Error("Unable to check push rules for %s in %-v: %v", refFullName, repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to check push rules: %v", err),
+		})
+		return false
+	} else if violation != "" {
+		log.Warn("Forbidden: Push rule rejects the push of user %d to %s in %-v: %s", ctx.opts.UserID, refFullName, repo, violation)
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: "push rule: " + violation,
+		})
+		return false
+	}
+	return true
+}
+
+// checkPushRules returns the first push rule violated by the objects received for the new commit, or an empty string
+func checkPushRules(ctx *preReceiveContext, rules []*git_model.PushRule, newCommitID string) (string, error) {
+	var maxFileSize int64
+	var checkPaths, checkMessage, checkAuthor bool
+	for _, rule := range rules {
+		if rule.MaxFileSize > 0 && (maxFileSize == 0 || rule.MaxFileSize < maxFileSize) {
+			maxFileSize = rule.MaxFileSize
+		}
+		checkPaths = checkPaths || len(rule.ForbiddenPathPatterns) > 0
+		checkMessage = checkMessage || rule.CommitMessagePattern != ""
+		checkAuthor = checkAuthor || rule.RequireVerifiedAuthorEmail
+	}
+
+	runOpts := &git.RunOpts{Dir: ctx.Repo.Repository.RepoPath(), Env: ctx.env}
+
+	if maxFileSize > 0 {
+		if violation, err := checkPushedFileSizes(ctx, runOpts, newCommitID, maxFileSize); err != nil || violation != "" {
+			return violation, err
+		}
+	}
+	if !checkPaths && !checkMessage && !checkAuthor {
+		return "", nil
+	}
+
+	// only the commits which are not in the repository yet are checked, the refs are not updated until the hook succeeds
+	stdout, _, runErr := git.NewCommand("rev-list").AddDynamicArguments(newCommitID).AddArguments("--not", "--all").RunStdString(ctx, runOpts)
+	if runErr != nil {
+		return "", runErr
+	}
+	commitIDs := strings.Fields(stdout)
+	if len(commitIDs) == 0 {
+		return "", nil
+	}
+
+	if checkPaths {
+		if violation, err := checkPushedPaths(ctx, runOpts, rules, commitIDs); err != nil || violation != "" {
+			return violation, err
+		}
+	}
+	if !checkMessage && !checkAuthor {
+		return "", nil
+	}
+
+	var authorEmails []string
+	authorCommits := make(map[string]string) // author email => short ID of the first commit
+
+	batch, err := git.NewBatchWithEnv(ctx, runOpts.Dir, ctx.env)
+	if err != nil {
+		return "", err
+	}
+	defer batch.Close()
+
+	for _, commitID := range commitIDs {
+		if _, err := batch.Writer.Write([]byte(commitID + "\n")); err != nil {
+			return "", err
+		}
+		_, typ, size, err := git.ReadBatchLine(batch.Reader)
+		if err != nil {
+			return "", err
+		}
+		if typ != "commit" {
+			return "", fmt.Errorf("unexpected object type %s of %s", typ, commitID)
+		}
+		commit, err := git.CommitFromReader(ctx.Repo.GitRepo, git.MustIDFromString(commitID), io.LimitReader(batch.Reader, size))
+		if err != nil {
+			return "", err
+		}
+		if _, err := batch.Reader.Discard(1); err != nil {
+			return "", err
+		}
+
+		shortID := base.ShortSha(commitID)
+		for _, rule := range rules {
+			if !rule.MatchCommitMessage(commit.CommitMessage) {
+				return fmt.Sprintf("the message of commit %s does not match the pattern %s", shortID, rule.CommitMessagePattern), nil
+			}
+		}
+		if _, ok := authorCommits[commit.Author.Email]; checkAuthor && !ok {
+			authorCommits[commit.Author.Email] = shortID
+			authorEmails = append(authorEmails, commit.Author.Email)
+		}
+	}
+
+	// the pushed commits may be authored by other users, e.g. after merging or cherry-picking their commits,
+	// so the author emails are looked up among the activated emails of all users
+	if len(authorEmails) == 0 {
+		return "", nil
+	}
+	emailUsers, err := user_model.GetUsersByEmails(ctx, authorEmails)
+	if err != nil {
+		return "", err
+	}
+	for _, email := range authorEmails {
+		if emailUsers.GetByEmail(email) == nil {
+			return fmt.Sprintf("the author email %s of commit %s is not a verified email address of any user", email, authorCommits[email]), nil
+		}
+	}
+	return "", nil
+}
+
+// checkPushedPaths returns a violation if any of the commits adds or changes a file matching a forbidden path pattern,
+// the changed files of all the commits are listed by one diff-tree process
+func checkPushedPaths(ctx *preReceiveContext, runOpts *git.RunOpts, rules []*git_model.PushRule, commitIDs []string) (string, error) {
+	stdout, _, err := git.NewCommand("diff-tree", "--stdin", "-r", "--root", "--name-only", "-z", "--diff-filter=d").RunStdString(ctx, &git.RunOpts{
+		Dir:   runOpts.Dir,
+		Env:   runOpts.Env,
+		Stdin: strings.NewReader(strings.Join(commitIDs, "\n") + "\n"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// the output is the ID of every commit changing files followed by the paths, all separated by NUL
+	commits := container.SetOf(commitIDs...)
+	var shortID string
+	for field := range strings.SplitSeq(strings.TrimSuffix(stdout, "\x00"), "\x00") {
+		if commits.Contains(field) {
+			shortID = base.ShortSha(field)
+			continue
+		}
+		if field == "" {
+			continue
+		}
+		for _, rule := range rules {
+			if pattern := rule.IsForbiddenPath(field); pattern != "" {
+				return fmt.Sprintf("commit %s changes %s, which matches the forbidden path pattern %s", shortID, field, pattern), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// checkPushedFileSizes returns a violation if any blob received for the new commit is larger than the max size
+func checkPushedFileSizes(ctx *preReceiveContext, runOpts *git.RunOpts, newCommitID string, maxFileSize int64) (string, error) {
+	objects, _, err := git.NewCommand("rev-list", "--objects").AddDynamicArguments(newCommitID).AddArguments("--not", "--all").RunStdString(ctx, runOpts)
+	if err != nil {
+		return "", err
+	}
+	if objects == "" {
+		return "", nil
+	}
+
+	stdout, _, err := git.NewCommand("cat-file", "--batch-check=%(objecttype) %(objectsize) %(rest)").RunStdString(ctx, &git.RunOpts{
+		Dir:   runOpts.Dir,
+		Env:   runOpts.Env,
+		Stdin: strings.NewReader(objects),
+	})
+	if err != nil {
+		return "", err
+	}
+	for line := range strings.SplitSeq(stdout, "\n") {
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) < 3 || fields[0] != "blob" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid object size in %q: %w", line, err)
+		}
+		if size > maxFileSize {
+			return fmt.Sprintf("file %s (%s) exceeds the maximum file size %s", fields[2], base.FileSize(size), base.FileSize(maxFileSize)), nil
+		}
+	}
+	return "", nil
+}
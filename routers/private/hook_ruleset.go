@@ -0,0 +1,132 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"fmt"
+	"net/http"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/private"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+// preReceiveRulesets enforces the org and instance level rulesets targeting the ref,
+// it returns false if the push is rejected or an error response has been written
+func preReceiveRulesets(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) bool {
+	repo := ctx.Repo.Repository
+	rulesets, err := git_model.GetRulesetsForRef(ctx, repo, refFullName.String())
+	if err != nil {
+		log.Error("Unable to get rulesets for %s in %-v: %v", refFullName, repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return false
+	}
+	if len(rulesets) == 0 {
+		return true
+	}
+
+	if !ctx.loadPusherAndPermission() {
+		return false
+	}
+	// a deploy key is never a bypass actor, even if its repository belongs to one
+	var doer *user_model.User
+	if ctx.opts.DeployKeyID == 0 {
+		doer = ctx.user
+	}
+
+	for _, rs := range rulesets {
+		canBypass, err := rs.CanBypass(ctx, doer, ctx.userPerm.IsAdmin())
+		if err != nil {
+			log.Error("Unable to check if user %d can bypass ruleset %d: %v", ctx.opts.UserID, rs.ID, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return false
+		} else if canBypass {
+			continue
+		}
+
+		violation, err := checkPushRuleset(ctx, rs, doer, oldCommitID, newCommitID, refFullName)
+		if err != nil {
+			log.Error("Unable to check ruleset %d for %s in %-v: %v", rs.ID, refFullName, repo, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: fmt.Sprintf("Unable to check ruleset %s: %v", rs.Name, err),
+			})
+			return false
+		} else if violation == "" {
+			continue
+		}
+
+		if rs.IsEvaluateOnly() {
+			log.Info("Ruleset %q (evaluate only) would reject the push of user %d to %s in %-v: %s", rs.Name, ctx.opts.UserID, refFullName, repo, violation)
+			continue
+		}
+		log.Warn("Forbidden: Ruleset %q rejects the push of user %d to %s in %-v: %s", rs.Name, ctx.opts.UserID, refFullName, repo, violation)
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: fmt.Sprintf("ruleset %s: %s", rs.Name, violation),
+		})
+		return false
+	}
+	return true
+}
+
+// checkPushRuleset returns the first rule of the ruleset the push violates, or an empty string
+func checkPushRuleset(ctx *preReceiveContext, rs *git_model.Ruleset, doer *user_model.User, oldCommitID, newCommitID string, refFullName git.RefName) (string, error) {
+	emptyObjectID := ctx.Repo.GetObjectFormat().EmptyObjectID().String()
+	isCreation, isDeletion := oldCommitID == emptyObjectID, newCommitID == emptyObjectID
+
+	if isDeletion {
+		if rs.BlockDeletion {
+			return fmt.Sprintf("%s is protected from deletion", refFullName.ShortName()), nil
+		}
+		return "", nil
+	}
+	if isCreation && rs.BlockCreation {
+		return fmt.Sprintf("%s is protected from creation", refFullName.ShortName()), nil
+	}
+
+	if !isCreation && rs.BlockForcePush {
+		forcePush, err := detectForcePush(ctx, oldCommitID, newCommitID)
+		if err != nil {
+			return "", err
+		} else if forcePush {
+			return fmt.Sprintf("%s is protected from force push", refFullName.ShortName()), nil
+		}
+	}
+
+	if refFullName.IsBranch() {
+		if ctx.opts.PullRequestID == 0 {
+			if rs.RequirePullRequest {
+				return fmt.Sprintf("changes to %s must be made through a pull request", refFullName.ShortName()), nil
+			}
+		} else {
+			// the push is a merge or an update of a pull request made by Gitea itself
+			pr, err := issues_model.GetPullRequestByID(ctx, ctx.opts.PullRequestID)
+			if err != nil {
+				return "", err
+			}
+			if pr.BaseRepoID == ctx.Repo.Repository.ID && pr.BaseBranch == refFullName.BranchName() {
+				if violation, err := pull_service.CheckPullRuleset(ctx, rs, pr, doer); err != nil || violation != "" {
+					return violation, err
+				}
+			}
+		}
+	}
+
+	if rs.RequireSignedCommits {
+		if err := verifyCommits(oldCommitID, newCommitID, ctx.Repo.GitRepo, ctx.env); err != nil {
+			if !isErrUnverifiedCommit(err) {
+				return "", err
+			}
+			return fmt.Sprintf("%s is protected from unverified commit %s", refFullName.ShortName(), err.(*errUnverifiedCommit).sha), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/private"
+	"code.gitea.io/gitea/modules/secretscan"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// maxReportedSecrets limits the number of secrets listed in the message sent back to the pusher
+const maxReportedSecrets = 10
+
+// secretFinding is a secret found in a blob of a pushed commit
+type secretFinding struct {
+	secretscan.Finding
+	CommitID string
+	Path     string
+}
+
+// preReceiveSecretScanning scans the files received for the new commit for secrets,
+// it returns false if the push is rejected or an error response has been written
+func preReceiveSecretScanning(ctx *preReceiveContext, newCommitID string, refFullName git.RefName) bool {
+	// the pushes made by Gitea to merge or update a pull request only contain files which have been pushed before
+	if !setting.SecretScanning.Enabled || ctx.opts.IsWiki || ctx.opts.PullRequestID != 0 ||
+		newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return true
+	}
+
+	repo := ctx.Repo.Repository
+	if ctx.secretPatterns == nil {
+		customPatterns, err := git_model.GetSecretScanPatternsForRepo(ctx, repo)
+		if err != nil {
+			log.Error("Unable to get secret scanning patterns for %-v: %v", repo, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return false
+		}
+		ctx.secretPatterns = secretscan.BuiltinPatterns(setting.SecretScanning.EntropyThreshold)
+		for _, p := range customPatterns {
+			pattern, err := p.ToPattern()
+			if err != nil {
+				log.Warn("Invalid secret scanning pattern %d: %v", p.ID, err)
+				continue
+			}
+			ctx.secretPatterns = append(ctx.secretPatterns, pattern)
+		}
+		ctx.scannedBlobs = make(container.Set[string])
+	}
+
+	findings, err := scanPushedFiles(ctx, newCommitID)
+	if err != nil {
+		log.Error("Unable to scan %s in %-v for secrets: %v", refFullName, repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to scan for secrets: %v", err),
+		})
+		return false
+	}
+	if len(findings) == 0 {
+		return true
+	}
+
+	// the secrets whose alerts have been resolved are not reported again
+	hashes := make([]string, 0, len(findings))
+	for _, f := range findings {
+		hashes = append(hashes, f.Hash())
+	}
+	alerts, err := git_model.GetSecretScanAlertsByHashes(ctx, repo.ID, hashes)
+	if err != nil {
+		log.Error("Unable to get secret scanning alerts of %-v: %v", repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return false
+	}
+	findings = filterResolvedSecrets(findings, alerts)
+	if len(findings) == 0 {
+		return true
+	}
+
+	bypassReason := strings.TrimSpace(ctx.opts.GitPushOptions[private.GitPushOptionSecretScanningBypass])
+	if setting.SecretScanning.Mode == setting.SecretScanningModeBlock && bypassReason == "" {
+		log.Warn("Forbidden: Secret scanning rejects the push of user %d to %s in %-v: %d secrets found", ctx.opts.UserID, refFullName, repo, len(findings))
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: formatSecretFindings(findings) +
+				fmt.Sprintf("\nRemove the secrets from the commits, or push again with -o %s=\"<reason>\" if they are not sensitive.", private.GitPushOptionSecretScanningBypass),
+		})
+		return false
+	}
+
+	// the alerts are only created when all the refs of the batch are accepted
+	for _, f := range findings {
+		ctx.secretAlerts = append(ctx.secretAlerts, &git_model.SecretScanAlert{
+			RepoID:         repo.ID,
+			SecretHash:     f.Hash(),
+			PatternID:      f.PatternID,
+			PatternName:    f.PatternName,
+			RedactedSecret: f.Redacted(),
+			RefName:        refFullName.String(),
+			CommitID:       f.CommitID,
+			Path:           f.Path,
+			Line:           f.Line,
+			PusherID:       ctx.opts.UserID,
+			BypassReason:   bypassReason,
+		})
+	}
+	ctx.warnings = append(ctx.warnings, formatSecretFindings(findings)+"\nAlerts have been opened for the repository administrators.")
+	return true
+}
+
+// filterResolvedSecrets removes the findings whose secrets have resolved alerts in the repository
+func filterResolvedSecrets(findings []*secretFinding, alerts map[string]*git_model.SecretScanAlert) []*secretFinding {
+	filtered := findings[:0]
+	for _, f := range findings {
+		if alert, ok := alerts[f.Hash()]; ok && alert.State == git_model.SecretScanAlertStateResolved {
+			continue
+		}
+		filtered = append(filtered, f)
+	}
+	return filtered
+}
+
+func formatSecretFindings(findings []*secretFinding) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Secret scanning found %d secret(s) in the pushed commits:\n", len(findings))
+	for i, f := range findings {
+		if i == maxReportedSecrets {
+			fmt.Fprintf(&sb, "  ... and %d more\n", len(findings)-maxReportedSecrets)
+			break
+		}
+		fmt.Fprintf(&sb, "  - %s (%s) in %s:%d of commit %s\n", f.PatternName, f.Redacted(), f.Path, f.Line, base.ShortSha(f.CommitID))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// scanPushedFiles scans the files of the objects which are not in the repository yet,
+// including the files only changed by merge commits. The blobs are attributed to the pushed commit
+// because the commit introducing them isn't known.
+func scanPushedFiles(ctx *preReceiveContext, newCommitID string) ([]*secretFinding, error) {
+	runOpts := &git.RunOpts{Dir: ctx.Repo.Repository.RepoPath(), Env: ctx.env}
+
+	stdout, _, runErr := git.NewCommand("rev-list", "--objects").AddDynamicArguments(newCommitID).AddArguments("--not", "--all").RunStdString(ctx, runOpts)
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	// the output lists the commits as "<id>" and the trees and blobs as "<id> <path>", the submodules aren't listed
+	type pushedObject struct{ id, path string }
+	var objects []pushedObject
+	for line := range strings.SplitSeq(stdout, "\n") {
+		id, path, ok := strings.Cut(line, " ")
+		if !ok || path == "" || ctx.scannedBlobs.Contains(id) {
+			continue
+		}
+		ctx.scannedBlobs.Add(id)
+		objects = append(objects, pushedObject{id: id, path: path})
+	}
+	if len(objects) == 0 {
+		return nil, nil
+	}
+
+	batch, err := git.NewBatchWithEnv(ctx, runOpts.Dir, ctx.env)
+	if err != nil {
+		return nil, err
+	}
+	defer batch.Close()
+
+	var findings []*secretFinding
+	for _, object := range objects {
+		if _, err := batch.Writer.Write([]byte(object.id + "\n")); err != nil {
+			return nil, err
+		}
+		_, typ, size, err := git.ReadBatchLine(batch.Reader)
+		if err != nil {
+			return nil, err
+		}
+		if typ != "blob" || (setting.SecretScanning.MaxBlobSize >= 0 && size > setting.SecretScanning.MaxBlobSize) {
+			if err := git.DiscardFull(batch.Reader, size+1); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		content, err := io.ReadAll(io.LimitReader(batch.Reader, size))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := batch.Reader.Discard(1); err != nil {
+			return nil, err
+		}
+		for _, f := range secretscan.Scan(content, ctx.secretPatterns) {
+			findings = append(findings, &secretFinding{Finding: f, CommitID: newCommitID, Path: object.path})
+		}
+	}
+	return findings, nil
+}
@@ -111,6 +111,19 @@ func prepareMockDataBadgeCommitSign(ctx *context.Context) {
 			Commit: &git.Commit{ID: git.Sha1ObjectFormat.EmptyObjectID()},
 		},
 	})
+	commits = append(commits, &asymkey.SignCommit{
+		Verification: &asymkey.CommitVerification{
+			Verified:    true,
+			Reason:      "name / fingerprint",
+			SigningUser: mockUser,
+			SigningX509: &asymkey.X509Certificate{Fingerprint: "0123456789abcdef"},
+			TrustStatus: "trusted",
+		},
+		UserCommit: &user_model.UserCommit{
+			User:   mockUser,
+			Commit: &git.Commit{ID: git.Sha1ObjectFormat.EmptyObjectID()},
+		},
+	})
 	commits = append(commits, &asymkey.SignCommit{
 		Verification: &asymkey.CommitVerification{
 			Warning:      true,
@@ -19,24 +19,23 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/renderhelper"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup/markdown"
 	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/templates"
-	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/common"
 	actions_service "code.gitea.io/gitea/services/actions"
 	context_module "code.gitea.io/gitea/services/context"
-	notify_service "code.gitea.io/gitea/services/notify"
 
 	"github.com/nektos/act/pkg/model"
-	"xorm.io/builder"
 )
 
 func getRunIndex(ctx *context_module.Context) int64 {
@@ -131,9 +130,11 @@ type ViewResponse struct {
 			Commit            ViewCommit    `json:"commit"`
 		} `json:"run"`
 		CurrentJob struct {
-			Title  string         `json:"title"`
-			Detail string         `json:"detail"`
-			Steps  []*ViewJobStep `json:"steps"`
+			Title       string            `json:"title"`
+			Detail      string            `json:"detail"`
+			Steps       []*ViewJobStep    `json:"steps"`
+			Summary     template.HTML     `json:"summary"` // rendered from the markdown the steps write to $GITHUB_STEP_SUMMARY
+			Annotations []*ViewAnnotation `json:"annotations"`
 		} `json:"currentJob"`
 	} `json:"state"`
 	Logs struct {
@@ -173,6 +174,15 @@ type ViewJobStep struct {
 	Status   string `json:"status"`
 }
 
+type ViewAnnotation struct {
+	Level   string `json:"level"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Link    string `json:"link"` // the link to the line in the file at the commit of the run
+}
+
 type ViewStepLog struct {
 	Step    int                `json:"step"`
 	Cursor  int64              `json:"cursor"`
@@ -236,6 +246,12 @@ func ViewPost(ctx *context_module.Context) {
 	resp.State.Run.Link = run.Link()
 	resp.State.Run.CanCancel = !run.Status.IsDone() && ctx.Repo.CanWrite(unit.TypeActions)
 	resp.State.Run.CanApprove = run.NeedApproval && ctx.Repo.CanWrite(unit.TypeActions)
+	if resp.State.Run.CanApprove {
+		if resp.State.Run.CanApprove, err = actions_service.CanApproveRun(ctx, run, ctx.Doer); err != nil {
+			ctx.ServerError("CanApproveRun", err)
+			return
+		}
+	}
 	resp.State.Run.CanRerun = run.Status.IsDone() && ctx.Repo.CanWrite(unit.TypeActions)
 	resp.State.Run.CanDeleteArtifact = run.Status.IsDone() && ctx.Repo.CanWrite(unit.TypeActions)
 	resp.State.Run.Done = run.Status.IsDone()
@@ -301,6 +317,7 @@ func ViewPost(ctx *context_module.Context) {
 	}
 	resp.State.CurrentJob.Steps = make([]*ViewJobStep, 0) // marshal to '[]' instead fo 'null' in json
 	resp.Logs.StepsLog = make([]*ViewStepLog, 0)          // marshal to '[]' instead fo 'null' in json
+	resp.State.CurrentJob.Annotations = make([]*ViewAnnotation, 0)
 	if task != nil {
 		steps, logs, err := convertToViewModel(ctx, req.LogCursors, task)
 		if err != nil {
@@ -309,11 +326,54 @@ func ViewPost(ctx *context_module.Context) {
 		}
 		resp.State.CurrentJob.Steps = append(resp.State.CurrentJob.Steps, steps...)
 		resp.Logs.StepsLog = append(resp.Logs.StepsLog, logs...)
+
+		resp.State.CurrentJob.Summary, resp.State.CurrentJob.Annotations, err = getViewSummaryAndAnnotations(ctx, task)
+		if err != nil {
+			ctx.ServerError("getViewSummaryAndAnnotations", err)
+			return
+		}
 	}
 
 	ctx.JSON(http.StatusOK, resp)
 }
 
+func getViewSummaryAndAnnotations(ctx *context_module.Context, task *actions_model.ActionTask) (template.HTML, []*ViewAnnotation, error) {
+	var summary template.HTML
+	content, err := actions_model.GetTaskSummary(ctx, task.ID)
+	if err != nil {
+		return "", nil, err
+	}
+	if content != "" {
+		rctx := renderhelper.NewRenderContextRepoComment(ctx, ctx.Repo.Repository)
+		if summary, err = markdown.RenderString(rctx, content); err != nil {
+			return "", nil, err
+		}
+	}
+
+	annotations, err := db.Find[actions_model.ActionTaskAnnotation](ctx, actions_model.FindTaskAnnotationsOptions{TaskID: task.ID})
+	if err != nil {
+		return "", nil, err
+	}
+	viewAnnotations := make([]*ViewAnnotation, 0, len(annotations))
+	for _, a := range annotations {
+		v := &ViewAnnotation{
+			Level:   a.Level.String(),
+			Title:   a.Title,
+			Message: a.Message,
+			Path:    a.Path,
+			Line:    a.Line,
+		}
+		if a.Path != "" {
+			v.Link = ctx.Repo.RepoLink + "/src/commit/" + url.PathEscape(a.CommitSHA) + "/" + util.PathEscapeSegments(a.Path)
+			if a.Line > 0 {
+				v.Link += "#L" + strconv.Itoa(a.Line)
+			}
+		}
+		viewAnnotations = append(viewAnnotations, v)
+	}
+	return summary, viewAnnotations, nil
+}
+
 func convertToViewModel(ctx *context_module.Context, cursors []LogCursor, task *actions_model.ActionTask) ([]*ViewJobStep, []*ViewStepLog, error) {
 	var viewJobs []*ViewJobStep
 	var logs []*ViewStepLog
@@ -406,96 +466,29 @@ func Rerun(ctx *context_module.Context) {
 		jobIndex, _ = strconv.ParseInt(jobIndexStr, 10, 64)
 	}
 
-	run, err := actions_model.GetRunByIndex(ctx, ctx.Repo.Repository.ID, runIndex)
-	if err != nil {
-		ctx.ServerError("GetRunByIndex", err)
-		return
-	}
-
-	// can not rerun job when workflow is disabled
-	cfgUnit := ctx.Repo.Repository.MustGetUnit(ctx, unit.TypeActions)
-	cfg := cfgUnit.ActionsConfig()
-	if cfg.IsWorkflowDisabled(run.WorkflowID) {
-		ctx.JSONError(ctx.Locale.Tr("actions.workflow.disabled"))
-		return
-	}
-
-	// reset run's start and stop time when it is done
-	if run.Status.IsDone() {
-		run.PreviousDuration = run.Duration()
-		run.Started = 0
-		run.Stopped = 0
-		if err := actions_model.UpdateRun(ctx, run, "started", "stopped", "previous_duration"); err != nil {
-			ctx.ServerError("UpdateRun", err)
-			return
-		}
-
-		if err := run.LoadAttributes(ctx); err != nil {
-			ctx.ServerError("run.LoadAttributes", err)
-			return
-		}
-		notify_service.WorkflowRunStatusUpdate(ctx, run.Repo, run.TriggerUser, run)
-	}
-
 	job, jobs := getRunJobs(ctx, runIndex, jobIndex)
 	if ctx.Written() {
 		return
 	}
 
+	var err error
 	if jobIndexStr == "" { // rerun all jobs
-		for _, j := range jobs {
-			// if the job has needs, it should be set to "blocked" status to wait for other jobs
-			shouldBlock := len(j.Needs) > 0
-			if err := rerunJob(ctx, j, shouldBlock); err != nil {
-				ctx.ServerError("RerunJob", err)
-				return
-			}
-		}
-		ctx.JSONOK()
-		return
+		err = actions_service.RerunRun(ctx, job.Run, jobs)
+	} else {
+		err = actions_service.RerunJob(ctx, job.Run, job, jobs)
 	}
-
-	rerunJobs := actions_service.GetAllRerunJobs(job, jobs)
-
-	for _, j := range rerunJobs {
-		// jobs other than the specified one should be set to "blocked" status
-		shouldBlock := j.JobID != job.JobID
-		if err := rerunJob(ctx, j, shouldBlock); err != nil {
-			ctx.ServerError("RerunJob", err)
+	if err != nil {
+		if errors.Is(err, actions_service.ErrWorkflowDisabled) {
+			ctx.JSONError(ctx.Locale.Tr("actions.workflow.disabled"))
 			return
 		}
+		ctx.ServerError("Rerun", err)
+		return
 	}
 
 	ctx.JSONOK()
 }
 
-func rerunJob(ctx *context_module.Context, job *actions_model.ActionRunJob, shouldBlock bool) error {
-	status := job.Status
-	if !status.IsDone() || !job.Run.Status.IsDone() {
-		return nil
-	}
-
-	job.TaskID = 0
-	job.Status = actions_model.StatusWaiting
-	if shouldBlock {
-		job.Status = actions_model.StatusBlocked
-	}
-	job.Started = 0
-	job.Stopped = 0
-
-	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		_, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": status}, "task_id", "status", "started", "stopped")
-		return err
-	}); err != nil {
-		return err
-	}
-
-	actions_service.CreateCommitStatus(ctx, job)
-	notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
-
-	return nil
-}
-
 func Logs(ctx *context_module.Context) {
 	runIndex := getRunIndex(ctx)
 	jobIndex := ctx.PathParamInt64("job")
@@ -523,49 +516,10 @@ func Cancel(ctx *context_module.Context) {
 		return
 	}
 
-	var updatedjobs []*actions_model.ActionRunJob
-
-	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		for _, job := range jobs {
-			status := job.Status
-			if status.IsDone() {
-				continue
-			}
-			if job.TaskID == 0 {
-				job.Status = actions_model.StatusCancelled
-				job.Stopped = timeutil.TimeStampNow()
-				n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"task_id": 0}, "status", "stopped")
-				if err != nil {
-					return err
-				}
-				if n == 0 {
-					return errors.New("job has changed, try again")
-				}
-				if n > 0 {
-					updatedjobs = append(updatedjobs, job)
-				}
-				continue
-			}
-			if err := actions_model.StopTask(ctx, job.TaskID, actions_model.StatusCancelled); err != nil {
-				return err
-			}
-		}
-		return nil
-	}); err != nil {
-		ctx.ServerError("StopTask", err)
+	if err := actions_service.CancelJobs(ctx, jobs); err != nil {
+		ctx.ServerError("CancelJobs", err)
 		return
 	}
-
-	actions_service.CreateCommitStatus(ctx, jobs...)
-
-	for _, job := range updatedjobs {
-		_ = job.LoadAttributes(ctx)
-		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
-	}
-	if len(updatedjobs) > 0 {
-		job := updatedjobs[0]
-		actions_service.NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
-	}
 	ctx.JSONOK()
 }
 
@@ -576,47 +530,16 @@ func Approve(ctx *context_module.Context) {
 	if ctx.Written() {
 		return
 	}
-	run := current.Run
-	doer := ctx.Doer
 
-	var updatedjobs []*actions_model.ActionRunJob
-
-	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		run.NeedApproval = false
-		run.ApprovedBy = doer.ID
-		if err := actions_model.UpdateRun(ctx, run, "need_approval", "approved_by"); err != nil {
-			return err
-		}
-		for _, job := range jobs {
-			if len(job.Needs) == 0 && job.Status.IsBlocked() {
-				job.Status = actions_model.StatusWaiting
-				n, err := actions_model.UpdateRunJob(ctx, job, nil, "status")
-				if err != nil {
-					return err
-				}
-				if n > 0 {
-					updatedjobs = append(updatedjobs, job)
-				}
-			}
+	if err := actions_service.ApproveRun(ctx, current.Run, jobs, ctx.Doer); err != nil {
+		if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.JSONError(ctx.Tr("actions.runs.approve_not_allowed"))
+			return
 		}
-		return nil
-	}); err != nil {
-		ctx.ServerError("UpdateRunJob", err)
+		ctx.ServerError("ApproveRun", err)
 		return
 	}
 
-	actions_service.CreateCommitStatus(ctx, jobs...)
-
-	if len(updatedjobs) > 0 {
-		job := updatedjobs[0]
-		actions_service.NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
-	}
-
-	for _, job := range updatedjobs {
-		_ = job.LoadAttributes(ctx)
-		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
-	}
-
 	ctx.JSONOK()
 }
 
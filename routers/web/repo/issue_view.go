@@ -387,6 +387,7 @@ func ViewIssue(ctx *context.Context) {
 		prepareIssueViewSidebarTimeTracker,
 		prepareIssueViewSidebarDependency,
 		prepareIssueViewSidebarPin,
+		prepareIssueViewSidebarPullStack,
 		func(ctx *context.Context, issue *issues_model.Issue) { preparePullViewPullInfo(ctx, issue) },
 		preparePullViewReviewAndMerge,
 	}
@@ -577,6 +578,34 @@ func preparePullViewDeleteBranch(ctx *context.Context, issue *issues_model.Issue
 	ctx.Data["IsPullBranchDeletable"] = isPullBranchDeletable
 }
 
+func prepareIssueViewSidebarPullStack(ctx *context.Context, issue *issues_model.Issue) {
+	if !issue.IsPull {
+		return
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		ctx.ServerError("LoadPullRequest", err)
+		return
+	}
+	stack, err := issues_model.GetPullRequestStack(ctx, issue.PullRequest)
+	if err != nil {
+		ctx.ServerError("GetPullRequestStack", err)
+		return
+	}
+	if len(stack) < 2 {
+		return
+	}
+	if err := stack.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	for _, pr := range stack {
+		// all the pull requests of a stack have the same base repository
+		pr.Issue.Repo = issue.Repo
+		pr.Issue.PullRequest = pr
+	}
+	ctx.Data["PullRequestStack"] = stack
+}
+
 func prepareIssueViewSidebarPin(ctx *context.Context, issue *issues_model.Issue) {
 	var pinAllowed bool
 	if err := issue.LoadPinOrder(ctx); err != nil {
@@ -949,6 +978,13 @@ func preparePullViewReviewAndMerge(ctx *context.Context, issue *issues_model.Iss
 		ctx.Data["IsBlockedByRejection"] = issues_model.MergeBlockedByRejectedReview(ctx, pb, pull)
 		ctx.Data["IsBlockedByOfficialReviewRequests"] = issues_model.MergeBlockedByOfficialReviewRequests(ctx, pb, pull)
 		ctx.Data["IsBlockedByOutdatedBranch"] = issues_model.MergeBlockedByOutdatedBranch(pb, pull)
+		outstandingOwners, outstandingTeams, err := pull_service.GetOutstandingCodeOwners(ctx, pb, pull)
+		if err != nil {
+			log.Error("GetOutstandingCodeOwners %-v: %v", pull, err)
+		}
+		ctx.Data["IsBlockedByCodeOwners"] = len(outstandingOwners) > 0 || len(outstandingTeams) > 0
+		ctx.Data["OutstandingCodeOwnerUsers"] = outstandingOwners
+		ctx.Data["OutstandingCodeOwnerTeams"] = outstandingTeams
 		ctx.Data["GrantedApprovals"] = issues_model.GetGrantedApprovalsCount(ctx, pb, pull)
 		ctx.Data["RequireSigned"] = pb.RequireSignedCommits
 		ctx.Data["ChangedProtectedFiles"] = pull.ChangedProtectedFiles
@@ -989,6 +1025,21 @@ func preparePullViewReviewAndMerge(ctx *context.Context, issue *issues_model.Iss
 		ctx.ServerError("GetScheduledMergeByPullID", err)
 		return
 	}
+
+	// Check if the pr is in the merge queue
+	exists, mergeQueueEntry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pull.ID)
+	if err != nil {
+		ctx.ServerError("GetMergeQueueEntryByPullID", err)
+		return
+	}
+	if exists {
+		ctx.Data["MergeQueueEntry"] = mergeQueueEntry
+		ctx.Data["MergeQueuePosition"], err = pull_model.GetMergeQueuePosition(ctx, mergeQueueEntry)
+		if err != nil {
+			ctx.ServerError("GetMergeQueuePosition", err)
+			return
+		}
+	}
 }
 
 func prepareIssueViewContent(ctx *context.Context, issue *issues_model.Issue) {
@@ -42,6 +42,7 @@ import (
 	"code.gitea.io/gitea/services/context/upload"
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/gitdiff"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -778,6 +779,13 @@ func viewPullFiles(ctx *context.Context, beforeCommitID, afterCommitID string) {
 		return
 	}
 
+	if ctx.Repo.CanRead(unit.TypeActions) {
+		if err = diff.LoadActionsAnnotations(ctx, ctx.Repo.Repository.ID, afterCommitID); err != nil {
+			ctx.ServerError("LoadActionsAnnotations", err)
+			return
+		}
+	}
+
 	allComments := issues_model.CommentList{}
 	for _, file := range diff.Files {
 		for _, section := range file.Sections {
@@ -1111,6 +1119,29 @@ func MergePullRequest(ctx *context.Context) {
 		}
 	}
 
+	// the pull request will be merged by the merge queue, unless the admin forces merging it
+	if pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch); err != nil {
+		ctx.ServerError("GetFirstMatchProtectedBranchRule", err)
+		return
+	} else if pb != nil && pb.EnableMergeQueue && !form.ForceMerge {
+		if err := mergequeue.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, form.DeleteBranchAfterMerge); err != nil {
+			switch {
+			case pull_service.IsErrInvalidMergeStyle(err):
+				ctx.JSONError(ctx.Tr("repo.pulls.invalid_merge_option"))
+			case errors.Is(err, mergequeue.ErrNoRequiredChecks):
+				ctx.JSONError(ctx.Tr("repo.pulls.merge_queue.no_checks"))
+			case errors.Is(err, util.ErrAlreadyExist):
+				ctx.JSONError(ctx.Tr("repo.pulls.merge_queue.already_queued"))
+			default:
+				ctx.ServerError("AddToMergeQueue", err)
+			}
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue.added"))
+		ctx.JSONRedirect(issue.Link())
+		return
+	}
+
 	if err := pull_service.Merge(ctx, pr, ctx.Doer, ctx.Repo.GitRepo, repo_model.MergeStyle(form.Do), form.HeadCommitID, message, false); err != nil {
 		if pull_service.IsErrInvalidMergeStyle(err) {
 			ctx.JSONError(ctx.Tr("repo.pulls.invalid_merge_option"))
@@ -1236,6 +1267,43 @@ func CancelAutoMergePullRequest(ctx *context.Context) {
 	ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
 }
 
+// CancelMergeQueuePullRequest removes a pr from the merge queue
+func CancelMergeQueuePullRequest(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+
+	exist, entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, issue.PullRequest.ID)
+	if err != nil {
+		ctx.ServerError("GetMergeQueueEntryByPullID", err)
+		return
+	} else if !exist {
+		ctx.Flash.Error(ctx.Tr("repo.pulls.merge_queue.not_queued"))
+		ctx.Redirect(issue.Link())
+		return
+	}
+	if allowed, err := mergequeue.CanRemoveFromMergeQueue(ctx, ctx.Doer, ctx.Repo.Permission, issue.PullRequest, entry); err != nil {
+		ctx.ServerError("CanRemoveFromMergeQueue", err)
+		return
+	} else if !allowed {
+		ctx.HTTPError(http.StatusForbidden)
+		return
+	}
+
+	if err := mergequeue.RemoveFromMergeQueue(ctx, ctx.Doer, issue.PullRequest, mergequeue.RemovedReasonCanceled); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.Flash.Error(ctx.Tr("repo.pulls.merge_queue.not_queued"))
+			ctx.Redirect(issue.Link())
+			return
+		}
+		ctx.ServerError("RemoveFromMergeQueue", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue.removed"))
+	ctx.Redirect(issue.Link())
+}
+
 func stopTimerIfAvailable(ctx *context.Context, user *user_model.User, issue *issues_model.Issue) error {
 	_, err := issues_model.FinishIssueStopwatch(ctx, user, issue)
 	return err
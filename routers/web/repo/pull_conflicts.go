@@ -0,0 +1,160 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/utils"
+	"code.gitea.io/gitea/services/context"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+const tplPullConflicts templates.TplName = "repo/pulls/conflicts"
+
+// getPullForConflictResolution loads the pull request and checks the doer may update its head branch
+func getPullForConflictResolution(ctx *context.Context) *issues_model.Issue {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return nil
+	}
+	if issue.IsClosed || issue.PullRequest.HasMerged {
+		ctx.NotFound(nil)
+		return nil
+	}
+	if err := issue.PullRequest.LoadHeadRepo(ctx); err != nil {
+		ctx.ServerError("LoadHeadRepo", err)
+		return nil
+	}
+	if issue.PullRequest.HeadRepo == nil {
+		ctx.NotFound(nil)
+		return nil
+	}
+
+	allowedUpdateByMerge, _, err := pull_service.IsUserAllowedToUpdate(ctx, issue.PullRequest, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("IsUserAllowedToUpdate", err)
+		return nil
+	}
+	if !allowedUpdateByMerge {
+		ctx.Flash.Error(ctx.Tr("repo.pulls.update_not_allowed"))
+		ctx.Redirect(issue.Link())
+		return nil
+	}
+	return issue
+}
+
+// PullConflicts renders the editor to resolve the conflicts of a pull request
+func PullConflicts(ctx *context.Context) {
+	issue := getPullForConflictResolution(ctx)
+	if issue == nil {
+		return
+	}
+
+	conflicts, err := pull_service.GetConflicts(ctx, issue.PullRequest, ctx.Doer)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(issue.Link())
+			return
+		}
+		ctx.ServerError("GetConflicts", err)
+		return
+	}
+	if len(conflicts.Files) == 0 {
+		ctx.Flash.Info(ctx.Tr("repo.pulls.conflicts.none"))
+		ctx.Redirect(issue.Link())
+		return
+	}
+
+	ctx.Data["Title"] = ctx.Tr("repo.pulls.conflicts.title", issue.Title)
+	ctx.Data["PageIsPullList"] = true
+	ctx.Data["Issue"] = issue
+	ctx.Data["Conflicts"] = conflicts
+	ctx.Data["DefaultMessage"] = fmt.Sprintf("Merge branch '%s' into %s", issue.PullRequest.BaseBranch, issue.PullRequest.HeadBranch)
+	ctx.HTML(http.StatusOK, tplPullConflicts)
+}
+
+// ResolvePullConflicts commits the resolution of the conflicts of a pull request to its head branch
+func ResolvePullConflicts(ctx *context.Context) {
+	issue := getPullForConflictResolution(ctx)
+	if issue == nil {
+		return
+	}
+	conflictsLink := issue.Link() + "/conflicts"
+
+	opts := &pull_service.ResolveConflictsOptions{
+		HeadCommitID: ctx.FormString("head_commit_id"),
+		BaseCommitID: ctx.FormString("base_commit_id"),
+		Choices:      map[string][]pull_service.ConflictChoice{},
+		Message:      ctx.FormTrim("message"),
+	}
+	if opts.Message == "" {
+		opts.Message = fmt.Sprintf("Merge branch '%s' into %s", issue.PullRequest.BaseBranch, issue.PullRequest.HeadBranch)
+	}
+	for i := 0; ; i++ {
+		path := ctx.FormString("path-" + strconv.Itoa(i))
+		if path == "" {
+			break
+		}
+		var choices []pull_service.ConflictChoice
+		for j := 0; ; j++ {
+			choice := ctx.FormString(fmt.Sprintf("choice-%d-%d", i, j))
+			if choice == "" {
+				break
+			}
+			choices = append(choices, pull_service.ConflictChoice(choice))
+		}
+		opts.Choices[path] = choices
+	}
+
+	// The update process should not be cancelled by the user
+	// so we set the context to be a background context
+	if err := pull_service.ResolveConflicts(graceful.GetManager().ShutdownContext(), issue.PullRequest, ctx.Doer, opts); err != nil {
+		switch {
+		case errors.Is(err, pull_service.ErrConflictsOutdated):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.outdated"))
+			ctx.Redirect(conflictsLink)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(conflictsLink)
+		case git.IsErrPushRejected(err):
+			log.Debug("ResolvePullConflicts push rejected: %v", err)
+			pushrejErr := err.(*git.ErrPushRejected)
+			if len(pushrejErr.Message) == 0 {
+				ctx.Flash.Error(ctx.Tr("repo.pulls.push_rejected_no_message"))
+			} else {
+				flashError, err := ctx.RenderToHTML(tplAlertDetails, map[string]any{
+					"Message": ctx.Tr("repo.pulls.push_rejected"),
+					"Summary": ctx.Tr("repo.pulls.push_rejected_summary"),
+					"Details": utils.SanitizeFlashErrorString(pushrejErr.Message),
+				})
+				if err != nil {
+					ctx.ServerError("ResolvePullConflicts.HTMLString", err)
+					return
+				}
+				ctx.Flash.Error(flashError)
+			}
+			ctx.Redirect(issue.Link())
+		case git.IsErrPushOutOfDate(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.outdated"))
+			ctx.Redirect(conflictsLink)
+		default:
+			ctx.ServerError("ResolveConflicts", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.pulls.conflicts.resolved"))
+	ctx.Redirect(issue.Link())
+}
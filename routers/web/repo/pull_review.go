@@ -7,21 +7,25 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
 	pull_model "code.gitea.io/gitea/models/pull"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/context/upload"
 	"code.gitea.io/gitea/services/forms"
 	issue_service "code.gitea.io/gitea/services/issue"
 	pull_service "code.gitea.io/gitea/services/pull"
+	files_service "code.gitea.io/gitea/services/repository/files"
 	user_service "code.gitea.io/gitea/services/user"
 )
 
@@ -77,9 +81,10 @@ func CreateCodeComment(ctx *context.Context) {
 		return
 	}
 
-	signedLine := form.Line
+	signedLine, signedStartLine := form.Line, form.StartLine
 	if form.Side == "previous" {
 		signedLine *= -1
+		signedStartLine *= -1
 	}
 
 	var attachments []string
@@ -92,6 +97,7 @@ func CreateCodeComment(ctx *context.Context) {
 		ctx.Repo.GitRepo,
 		issue,
 		signedLine,
+		signedStartLine,
 		form.Content,
 		form.TreePath,
 		!form.SingleReview,
@@ -99,7 +105,10 @@ func CreateCodeComment(ctx *context.Context) {
 		form.LatestCommitID,
 		attachments,
 	)
-	if err != nil {
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.HTTPError(http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
 		ctx.ServerError("CreateCodeComment", err)
 		return
 	}
@@ -450,3 +459,37 @@ func UpdatePullReviewRequest(ctx *context.Context) {
 
 	ctx.JSONOK()
 }
+
+// ApplySuggestions commits the suggested changes of review comments to the head branch of the pull request
+func ApplySuggestions(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	redirectTo := ctx.FormString("redirect_to")
+
+	commentIDs, err := base.StringsToInt64s(strings.Split(ctx.FormString("comment_ids"), ","))
+	if err != nil {
+		ctx.HTTPError(http.StatusBadRequest)
+		return
+	}
+
+	if _, err := files_service.ApplySuggestions(ctx, ctx.Doer, issue.PullRequest, commentIDs, ctx.FormString("message")); err != nil {
+		switch {
+		case files_service.IsErrSuggestionOutdated(err), files_service.IsErrCommitIDDoesNotMatch(err), pull_service.IsErrSHADoesNotMatch(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.suggestion.outdated"))
+		case errors.Is(err, util.ErrPermissionDenied), files_service.IsErrUserCannotCommit(err), pull_service.IsErrFilePathProtected(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.update_not_allowed"))
+		case errors.Is(err, util.ErrInvalidArgument), errors.Is(err, util.ErrNotExist):
+			ctx.Flash.Error(err.Error())
+		default:
+			ctx.ServerError("ApplySuggestions", err)
+			return
+		}
+		ctx.RedirectToCurrentSite(redirectTo, issue.Link())
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.pulls.suggestion.applied"))
+	ctx.RedirectToCurrentSite(redirectTo, issue.Link())
+}
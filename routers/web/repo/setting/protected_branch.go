@@ -163,6 +163,16 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		ctx.Redirect(fmt.Sprintf("%s/settings/branches/edit?rule_name=%s", ctx.Repo.RepoLink, f.RuleName))
 		return
 	}
+	if f.EnableMergeQueue && f.MergeQueueBatchSize < 1 {
+		ctx.Flash.Error(ctx.Tr("repo.settings.protected_branch_merge_queue_batch_size_min"))
+		ctx.Redirect(fmt.Sprintf("%s/settings/branches/edit?rule_name=%s", ctx.Repo.RepoLink, f.RuleName))
+		return
+	}
+	if f.EnableMergeQueue && !f.EnableStatusCheck {
+		ctx.Flash.Error(ctx.Tr("repo.settings.protected_branch_merge_queue_status_check"))
+		ctx.Redirect(fmt.Sprintf("%s/settings/branches/edit?rule_name=%s", ctx.Repo.RepoLink, f.RuleName))
+		return
+	}
 
 	switch f.EnablePush {
 	case "all":
@@ -257,11 +267,16 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 	protectBranch.BlockOnOfficialReviewRequests = f.BlockOnOfficialReviewRequests
 	protectBranch.DismissStaleApprovals = f.DismissStaleApprovals
 	protectBranch.IgnoreStaleApprovals = f.IgnoreStaleApprovals
+	protectBranch.RequireCodeOwnerReviews = f.RequireCodeOwnerReviews
 	protectBranch.RequireSignedCommits = f.RequireSignedCommits
 	protectBranch.ProtectedFilePatterns = f.ProtectedFilePatterns
 	protectBranch.UnprotectedFilePatterns = f.UnprotectedFilePatterns
 	protectBranch.BlockOnOutdatedBranch = f.BlockOnOutdatedBranch
 	protectBranch.BlockAdminMergeOverride = f.BlockAdminMergeOverride
+	protectBranch.EnableMergeQueue = f.EnableMergeQueue
+	if f.EnableMergeQueue {
+		protectBranch.MergeQueueBatchSize = f.MergeQueueBatchSize
+	}
 
 	if err = pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
 		UserIDs:          whitelistUsers,
@@ -4,8 +4,11 @@
 package packages
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	packages_model "code.gitea.io/gitea/models/packages"
@@ -16,6 +19,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	packages_service "code.gitea.io/gitea/services/packages"
 	cargo_service "code.gitea.io/gitea/services/packages/cargo"
 	container_service "code.gitea.io/gitea/services/packages/container"
 )
@@ -28,6 +32,39 @@ func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
 	}
 
 	ctx.Data["CleanupRules"] = pcrs
+
+	prs, err := packages_model.GetRemotesByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetRemotesByOwner", err)
+		return
+	}
+
+	ctx.Data["Remotes"] = prs
+
+	pvs, err := packages_model.GetVirtualsByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetVirtualsByOwner", err)
+		return
+	}
+
+	memberNames := make(map[int64][]string, len(pvs))
+	for _, pv := range pvs {
+		if memberNames[pv.ID], err = getVirtualMemberNames(ctx, pv); err != nil {
+			ctx.ServerError("getVirtualMemberNames", err)
+			return
+		}
+	}
+
+	ctx.Data["Virtuals"] = pvs
+	ctx.Data["VirtualMemberNames"] = memberNames
+
+	immutable, err := packages_service.IsImmutableVersionsEnabled(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("IsImmutableVersionsEnabled", err)
+		return
+	}
+
+	ctx.Data["ImmutableVersions"] = immutable
 }
 
 func SetRuleAddContext(ctx *context.Context) {
@@ -92,6 +129,7 @@ func performRuleEditPost(ctx *context.Context, owner *user_model.User, pcr *pack
 	pcr.KeepCount = form.KeepCount
 	pcr.KeepPattern = form.KeepPattern
 	pcr.RemoveDays = form.RemoveDays
+	pcr.RemoveNotDownloadedDays = form.RemoveNotDownloadedDays
 	pcr.RemovePattern = form.RemovePattern
 	pcr.MatchFullName = form.MatchFullName
 
@@ -144,6 +182,7 @@ func SetRulePreviewContext(ctx *context.Context, owner *user_model.User) {
 	}
 
 	olderThan := time.Now().AddDate(0, 0, -pcr.RemoveDays)
+	notDownloadedSince := time.Now().AddDate(0, 0, -pcr.RemoveNotDownloadedDays)
 
 	packages, err := packages_model.GetPackagesByType(ctx, pcr.OwnerID, pcr.Type)
 	if err != nil {
@@ -188,6 +227,9 @@ func SetRulePreviewContext(ctx *context.Context, owner *user_model.User) {
 			if pv.CreatedUnix.AsLocalTime().After(olderThan) {
 				continue
 			}
+			if pv.LastUsedUnix().AsLocalTime().After(notDownloadedSince) {
+				continue
+			}
 			if pcr.RemovePatternMatcher != nil && !pcr.RemovePatternMatcher.MatchString(toMatch) {
 				continue
 			}
@@ -230,6 +272,311 @@ func getCleanupRuleByContext(ctx *context.Context, owner *user_model.User) *pack
 	return nil
 }
 
+func SetRemoteAddContext(ctx *context.Context) {
+	setRemoteEditContext(ctx, nil)
+}
+
+func SetRemoteEditContext(ctx *context.Context, owner *user_model.User) {
+	pr := getRemoteByContext(ctx, owner)
+	if pr == nil {
+		return
+	}
+
+	setRemoteEditContext(ctx, pr)
+}
+
+func setRemoteEditContext(ctx *context.Context, pr *packages_model.PackageRemote) {
+	ctx.Data["IsEditRemote"] = pr != nil
+
+	if pr == nil {
+		pr = &packages_model.PackageRemote{Enabled: true}
+	}
+	ctx.Data["Remote"] = pr
+	ctx.Data["AvailableTypes"] = packages_model.RemoteTypeList
+}
+
+func PerformRemoteAddPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	performRemoteEditPost(ctx, owner, nil, redirectURL, template)
+}
+
+func PerformRemoteEditPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	pr := getRemoteByContext(ctx, owner)
+	if pr == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageRemoteForm)
+
+	if form.Action == "remove" {
+		if err := packages_model.DeleteRemoteByID(ctx, pr.ID); err != nil {
+			ctx.ServerError("DeleteRemoteByID", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.remotes.success.delete"))
+		ctx.Redirect(redirectURL)
+	} else {
+		performRemoteEditPost(ctx, owner, pr, redirectURL, template)
+	}
+}
+
+func performRemoteEditPost(ctx *context.Context, owner *user_model.User, pr *packages_model.PackageRemote, redirectURL string, template templates.TplName) {
+	isEditRemote := pr != nil
+
+	if pr == nil {
+		pr = &packages_model.PackageRemote{}
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageRemoteForm)
+
+	pr.Enabled = form.Enabled
+	pr.OwnerID = owner.ID
+	pr.URL = strings.TrimSuffix(form.URL, "/")
+	pr.Username = form.Username
+	pr.MetadataTTL = form.MetadataTTL
+
+	ctx.Data["IsEditRemote"] = isEditRemote
+	ctx.Data["Remote"] = pr
+	ctx.Data["AvailableTypes"] = packages_model.RemoteTypeList
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+
+	// an empty password keeps the stored one unless the credentials are removed
+	if form.Password != "" || form.Username == "" {
+		if err := pr.SetPassword(form.Password); err != nil {
+			ctx.ServerError("SetPassword", err)
+			return
+		}
+	}
+
+	if isEditRemote {
+		if err := packages_model.UpdateRemote(ctx, pr); err != nil {
+			ctx.ServerError("UpdateRemote", err)
+			return
+		}
+	} else {
+		pr.Type = packages_model.Type(form.Type)
+
+		if has, err := packages_model.HasOwnerRemoteForPackageType(ctx, owner.ID, pr.Type); err != nil {
+			ctx.ServerError("HasOwnerRemoteForPackageType", err)
+			return
+		} else if has {
+			ctx.Data["Err_Type"] = true
+			ctx.HTML(http.StatusOK, template)
+			return
+		}
+
+		var err error
+		if pr, err = packages_model.InsertRemote(ctx, pr); err != nil {
+			ctx.ServerError("InsertRemote", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.remotes.success.update"))
+	ctx.Redirect(fmt.Sprintf("%s/remotes/%d", redirectURL, pr.ID))
+}
+
+func getRemoteByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageRemote {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.PathParamInt64("id")
+	}
+
+	pr, err := packages_model.GetRemoteByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetRemoteByID", err)
+		}
+		return nil
+	}
+
+	if pr.OwnerID == owner.ID {
+		return pr
+	}
+
+	ctx.NotFound(fmt.Errorf("PackageRemote[%v] not associated to owner %v", id, owner))
+
+	return nil
+}
+
+func SetVirtualAddContext(ctx *context.Context) {
+	setVirtualEditContext(ctx, nil, nil)
+}
+
+func SetVirtualEditContext(ctx *context.Context, owner *user_model.User) {
+	pv := getVirtualByContext(ctx, owner)
+	if pv == nil {
+		return
+	}
+
+	memberNames, err := getVirtualMemberNames(ctx, pv)
+	if err != nil {
+		ctx.ServerError("getVirtualMemberNames", err)
+		return
+	}
+
+	setVirtualEditContext(ctx, pv, memberNames)
+}
+
+func setVirtualEditContext(ctx *context.Context, pv *packages_model.PackageVirtual, memberNames []string) {
+	ctx.Data["IsEditVirtual"] = pv != nil
+
+	if pv == nil {
+		pv = &packages_model.PackageVirtual{Enabled: true}
+	}
+	ctx.Data["Virtual"] = pv
+	ctx.Data["Members"] = strings.Join(memberNames, "\n")
+	ctx.Data["AvailableTypes"] = packages_model.VirtualTypeList
+}
+
+func PerformVirtualAddPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	performVirtualEditPost(ctx, owner, nil, redirectURL, template)
+}
+
+func PerformVirtualEditPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	pv := getVirtualByContext(ctx, owner)
+	if pv == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageVirtualForm)
+
+	if form.Action == "remove" {
+		if err := packages_model.DeleteVirtualByID(ctx, pv.ID); err != nil {
+			ctx.ServerError("DeleteVirtualByID", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.virtuals.success.delete"))
+		ctx.Redirect(redirectURL)
+	} else {
+		performVirtualEditPost(ctx, owner, pv, redirectURL, template)
+	}
+}
+
+func performVirtualEditPost(ctx *context.Context, owner *user_model.User, pv *packages_model.PackageVirtual, redirectURL string, template templates.TplName) {
+	isEditVirtual := pv != nil
+
+	if pv == nil {
+		pv = &packages_model.PackageVirtual{}
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageVirtualForm)
+
+	pv.Enabled = form.Enabled
+	pv.OwnerID = owner.ID
+
+	ctx.Data["IsEditVirtual"] = isEditVirtual
+	ctx.Data["Virtual"] = pv
+	ctx.Data["Members"] = form.Members
+	ctx.Data["AvailableTypes"] = packages_model.VirtualTypeList
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+
+	// members are listed by name in the order of their priority
+	pv.MemberIDs = make([]int64, 0, 5)
+	for _, name := range strings.FieldsFunc(form.Members, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r' || r == ' '
+	}) {
+		member, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Data["Err_Members"] = true
+				ctx.RenderWithErr(ctx.Tr("packages.owner.settings.virtuals.members.not_exist", name), template, form)
+				return
+			}
+			ctx.ServerError("GetUserByName", err)
+			return
+		}
+		if member.ID == owner.ID || slices.Contains(pv.MemberIDs, member.ID) {
+			continue
+		}
+		pv.MemberIDs = append(pv.MemberIDs, member.ID)
+	}
+
+	if isEditVirtual {
+		if err := packages_model.UpdateVirtual(ctx, pv); err != nil {
+			ctx.ServerError("UpdateVirtual", err)
+			return
+		}
+	} else {
+		pv.Type = packages_model.Type(form.Type)
+
+		if has, err := packages_model.HasOwnerVirtualForPackageType(ctx, owner.ID, pv.Type); err != nil {
+			ctx.ServerError("HasOwnerVirtualForPackageType", err)
+			return
+		} else if has {
+			ctx.Data["Err_Type"] = true
+			ctx.HTML(http.StatusOK, template)
+			return
+		}
+
+		var err error
+		if pv, err = packages_model.InsertVirtual(ctx, pv); err != nil {
+			ctx.ServerError("InsertVirtual", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.virtuals.success.update"))
+	ctx.Redirect(fmt.Sprintf("%s/virtuals/%d", redirectURL, pv.ID))
+}
+
+func getVirtualByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageVirtual {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.PathParamInt64("id")
+	}
+
+	pv, err := packages_model.GetVirtualByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageVirtualNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetVirtualByID", err)
+		}
+		return nil
+	}
+
+	if pv.OwnerID == owner.ID {
+		return pv
+	}
+
+	ctx.NotFound(fmt.Errorf("PackageVirtual[%v] not associated to owner %v", id, owner))
+
+	return nil
+}
+
+// getVirtualMemberNames returns the names of the existing members of the virtual repository in the order of their priority
+func getVirtualMemberNames(ctx *context.Context, pv *packages_model.PackageVirtual) ([]string, error) {
+	members, err := user_model.GetUsersByIDs(ctx, pv.MemberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(members))
+	for _, member := range members {
+		names[member.ID] = member.Name
+	}
+
+	memberNames := make([]string, 0, len(pv.MemberIDs))
+	for _, id := range pv.MemberIDs {
+		if name, ok := names[id]; ok {
+			memberNames = append(memberNames, name)
+		}
+	}
+	return memberNames, nil
+}
+
 func InitializeCargoIndex(ctx *context.Context, owner *user_model.User) {
 	err := cargo_service.InitializeIndexRepository(ctx, owner, owner)
 	if err != nil {
@@ -249,3 +596,11 @@ func RebuildCargoIndex(ctx *context.Context, owner *user_model.User) {
 		ctx.Flash.Success(ctx.Tr("packages.owner.settings.cargo.rebuild.success"))
 	}
 }
+
+func SetImmutableVersions(ctx *context.Context, owner *user_model.User) {
+	if err := packages_service.SetImmutableVersionsEnabled(ctx, owner.ID, ctx.FormBool("immutable")); err != nil {
+		ctx.ServerError("SetImmutableVersionsEnabled", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.immutable.success"))
+}
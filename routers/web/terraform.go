@@ -0,0 +1,26 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package web
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/context"
+)
+
+type terraformServicesType struct {
+	ModulesV1   string `json:"modules.v1"`
+	ProvidersV1 string `json:"providers.v1"`
+}
+
+// terraformServiceDiscovery announces the package registry endpoints to Terraform
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+func terraformServiceDiscovery(ctx *context.Context) {
+	url := setting.AppURL + "api/packages/-/terraform/"
+	ctx.JSON(http.StatusOK, terraformServicesType{
+		ModulesV1:   url + "modules/v1/",
+		ProvidersV1: url + "providers/v1/",
+	})
+}
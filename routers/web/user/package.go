@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"code.gitea.io/gitea/models/db"
 	org_model "code.gitea.io/gitea/models/organization"
@@ -16,6 +17,7 @@ import (
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/httplib"
 	"code.gitea.io/gitea/modules/log"
@@ -24,6 +26,7 @@ import (
 	arch_module "code.gitea.io/gitea/modules/packages/arch"
 	container_module "code.gitea.io/gitea/modules/packages/container"
 	debian_module "code.gitea.io/gitea/modules/packages/debian"
+	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
 	rpm_module "code.gitea.io/gitea/modules/packages/rpm"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
@@ -32,9 +35,12 @@ import (
 	packages_helper "code.gitea.io/gitea/routers/api/packages/helper"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
 	"code.gitea.io/gitea/services/forms"
 	packages_service "code.gitea.io/gitea/services/packages"
 	container_service "code.gitea.io/gitea/services/packages/container"
+	packages_promote_service "code.gitea.io/gitea/services/packages/promote"
+	packages_vulnerability_service "code.gitea.io/gitea/services/packages/vulnerability"
 )
 
 const (
@@ -42,6 +48,8 @@ const (
 	tplPackagesView       templates.TplName = "package/view"
 	tplPackageVersionList templates.TplName = "user/overview/package_versions"
 	tplPackagesSettings   templates.TplName = "package/settings"
+
+	packageDownloadStatisticsDays = 90
 )
 
 // ListPackages displays a list of all packages of the context user
@@ -293,7 +301,39 @@ func ViewPackageVersion(ctx *context.Context) {
 			}
 		}
 		ctx.Data["ContainerImageMetadata"] = imageMetadata
+
+		subject := versionSub
+		if subject == "" {
+			for _, pfd := range pd.Files {
+				if pfd.File.IsLead && pfd.File.LowerName == container_module.ManifestFilename {
+					subject = pfd.Properties.GetByName(container_module.PropertyDigest)
+				}
+			}
+		}
+		if subject != "" {
+			referrers, err := container_service.GetReferrers(ctx, pd.Owner.ID, pd.Package.LowerName, subject, "")
+			if err != nil {
+				ctx.ServerError("GetReferrers", err)
+				return
+			}
+			ctx.Data["ContainerReferrers"] = referrers
+		}
 	}
+	if packages_vulnerability_service.IsSupportedType(pd.Package.Type) {
+		findings, err := packages_model.GetVulnerabilityFindingsByVersionID(ctx, pd.Version.ID)
+		if err != nil {
+			ctx.ServerError("GetVulnerabilityFindingsByVersionID", err)
+			return
+		}
+		ctx.Data["PackageVulnerabilities"] = findings
+	}
+	downloads, err := packages_service.GetDailyDownloads(ctx, pd.Version, packageDownloadStatisticsDays)
+	if err != nil {
+		ctx.ServerError("GetDailyDownloads", err)
+		return
+	}
+	ctx.Data["PackageDownloadDays"] = packageDownloadStatisticsDays
+	ctx.Data["PackageDownloadStatistics"] = convert.ToPackageDownloadStatistics(pd.Version, downloads)
 	var pvs []*packages_model.PackageVersion
 	var pvsTotal int64
 	if pd.Package.Type == packages_model.TypeContainer {
@@ -438,6 +478,13 @@ func PackageSettings(ctx *context.Context) {
 	})
 	ctx.Data["Repos"] = repos
 	ctx.Data["CanWritePackages"] = ctx.Package.AccessMode >= perm.AccessModeWrite || ctx.IsUserSiteAdmin()
+	ctx.Data["CanPromote"] = packages_promote_service.IsPromotionSupported(pd)
+
+	for _, pp := range pd.VersionProperties {
+		if pp.Name == pypi_module.PropertyYanked {
+			ctx.Data["IsYanked"] = true
+		}
+	}
 
 	ctx.HTML(http.StatusOK, tplPackagesSettings)
 }
@@ -479,11 +526,36 @@ func PackageSettingsPost(ctx *context.Context) {
 			ctx.Flash.Error(ctx.Tr("packages.settings.link.error"))
 		}
 
+		ctx.Redirect(ctx.Link)
+		return
+	case "promote":
+		promotePackageVersion(ctx, form.Owner)
+		return
+	case "yank", "unyank":
+		if pd.Package.Type != packages_model.TypePyPI {
+			ctx.NotFound(nil)
+			return
+		}
+
+		yanked := form.Action == "yank"
+		if err := setPackageVersionYanked(ctx, pd.Version, yanked, form.Reason); err != nil {
+			log.Error("Error updating yanked state of package version: %v", err)
+			ctx.Flash.Error(ctx.Tr("packages.settings.yank.error"))
+		} else if yanked {
+			ctx.Flash.Success(ctx.Tr("packages.settings.yank.success"))
+		} else {
+			ctx.Flash.Success(ctx.Tr("packages.settings.yank.undo.success"))
+		}
+
 		ctx.Redirect(ctx.Link)
 		return
 	case "delete":
 		err := packages_service.RemovePackageVersion(ctx, ctx.Doer, ctx.Package.Descriptor.Version)
-		if err != nil {
+		if errors.Is(err, packages_service.ErrImmutablePackageVersion) {
+			ctx.Flash.Error(ctx.Tr("packages.settings.delete.immutable"))
+			ctx.Redirect(ctx.Link)
+			return
+		} else if err != nil {
 			log.Error("Error deleting package: %v", err)
 			ctx.Flash.Error(ctx.Tr("packages.settings.delete.error"))
 		} else {
@@ -501,6 +573,66 @@ func PackageSettingsPost(ctx *context.Context) {
 	}
 }
 
+func promotePackageVersion(ctx *context.Context, ownerName string) {
+	target, err := user_model.GetUserByName(ctx, ownerName)
+	if err != nil {
+		if !user_model.IsErrUserNotExist(err) {
+			ctx.ServerError("GetUserByName", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("packages.settings.promote.owner_not_exist", ownerName))
+		ctx.Redirect(ctx.Link)
+		return
+	}
+
+	accessMode, err := context.DeterminePackageAccessMode(ctx, target, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("DeterminePackageAccessMode", err)
+		return
+	}
+	if accessMode < perm.AccessModeWrite {
+		ctx.Flash.Error(ctx.Tr("packages.settings.promote.no_access", target.Name))
+		ctx.Redirect(ctx.Link)
+		return
+	}
+
+	pv, err := packages_promote_service.PromotePackageVersion(ctx, ctx.Doer, ctx.Package.Descriptor, target)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrDuplicatePackageVersion) {
+			ctx.Flash.Error(ctx.Tr("packages.settings.promote.exists", target.Name))
+		} else {
+			log.Error("Error promoting package version: %v", err)
+			ctx.Flash.Error(ctx.Tr("packages.settings.promote.error", err))
+		}
+		ctx.Redirect(ctx.Link)
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		ctx.ServerError("GetPackageDescriptor", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.settings.promote.success", target.Name))
+	ctx.Redirect(pd.VersionWebLink())
+}
+
+// setPackageVersionYanked marks a PyPI package version as yanked
+// https://peps.python.org/pep-0592/
+func setPackageVersionYanked(ctx *context.Context, pv *packages_model.PackageVersion, yanked bool, reason string) error {
+	return db.WithTx(ctx, func(ctx gocontext.Context) error {
+		if err := packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, pypi_module.PropertyYanked); err != nil {
+			return err
+		}
+		if !yanked {
+			return nil
+		}
+		_, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, pypi_module.PropertyYanked, strings.TrimSpace(reason))
+		return err
+	})
+}
+
 // DownloadPackageFile serves the content of a package file
 func DownloadPackageFile(ctx *context.Context) {
 	pf, err := packages_model.GetFileForVersionByID(ctx, ctx.Package.Descriptor.Version.ID, ctx.PathParamInt64("fileid"))
@@ -488,6 +488,7 @@ func registerWebRoutes(m *web.Router) {
 			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		})
 		m.Get("/passkey-endpoints", passkeyEndpoints)
+		m.Get("/terraform.json", packagesEnabled, terraformServiceDiscovery)
 		m.Methods("GET, HEAD", "/*", public.FileHandlerFunc())
 	}, optionsCorsHandler())
 
@@ -666,10 +667,31 @@ func registerWebRoutes(m *web.Router) {
 					m.Get("/preview", user_setting.PackagesRulePreview)
 				})
 			})
+			m.Group("/remotes", func() {
+				m.Group("/add", func() {
+					m.Get("", user_setting.PackagesRemoteAdd)
+					m.Post("", web.Bind(forms.PackageRemoteForm{}), user_setting.PackagesRemoteAddPost)
+				})
+				m.Group("/{id}", func() {
+					m.Get("", user_setting.PackagesRemoteEdit)
+					m.Post("", web.Bind(forms.PackageRemoteForm{}), user_setting.PackagesRemoteEditPost)
+				})
+			})
+			m.Group("/virtuals", func() {
+				m.Group("/add", func() {
+					m.Get("", user_setting.PackagesVirtualAdd)
+					m.Post("", web.Bind(forms.PackageVirtualForm{}), user_setting.PackagesVirtualAddPost)
+				})
+				m.Group("/{id}", func() {
+					m.Get("", user_setting.PackagesVirtualEdit)
+					m.Post("", web.Bind(forms.PackageVirtualForm{}), user_setting.PackagesVirtualEditPost)
+				})
+			})
 			m.Group("/cargo", func() {
 				m.Post("/initialize", user_setting.InitializeCargoIndex)
 				m.Post("/rebuild", user_setting.RebuildCargoIndex)
 			})
+			m.Post("/immutable", user_setting.SetImmutableVersions)
 			m.Post("/chef/regenerate_keypair", user_setting.RegenerateChefKeyPair)
 		}, packagesEnabled)
 
@@ -998,10 +1020,31 @@ func registerWebRoutes(m *web.Router) {
 							m.Get("/preview", org.PackagesRulePreview)
 						})
 					})
+					m.Group("/remotes", func() {
+						m.Group("/add", func() {
+							m.Get("", org.PackagesRemoteAdd)
+							m.Post("", web.Bind(forms.PackageRemoteForm{}), org.PackagesRemoteAddPost)
+						})
+						m.Group("/{id}", func() {
+							m.Get("", org.PackagesRemoteEdit)
+							m.Post("", web.Bind(forms.PackageRemoteForm{}), org.PackagesRemoteEditPost)
+						})
+					})
+					m.Group("/virtuals", func() {
+						m.Group("/add", func() {
+							m.Get("", org.PackagesVirtualAdd)
+							m.Post("", web.Bind(forms.PackageVirtualForm{}), org.PackagesVirtualAddPost)
+						})
+						m.Group("/{id}", func() {
+							m.Get("", org.PackagesVirtualEdit)
+							m.Post("", web.Bind(forms.PackageVirtualForm{}), org.PackagesVirtualEditPost)
+						})
+					})
 					m.Group("/cargo", func() {
 						m.Post("/initialize", org.InitializeCargoIndex)
 						m.Post("/rebuild", org.RebuildCargoIndex)
 					})
+					m.Post("/immutable", org.SetImmutableVersions)
 				}, packagesEnabled)
 
 				m.Group("/blocked_users", func() {
@@ -1548,7 +1591,10 @@ func registerWebRoutes(m *web.Router) {
 			})
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
+			m.Post("/cancel_merge_queue", reqSignIn, context.RepoMustNotBeArchived(), repo.CancelMergeQueuePullRequest)
 			m.Post("/update", repo.UpdatePullRequest)
+			m.Post("/suggestions", reqSignIn, context.RepoMustNotBeArchived(), repo.ApplySuggestions)
+			m.Combo("/conflicts", reqSignIn, context.RepoMustNotBeArchived()).Get(repo.PullConflicts).Post(repo.ResolvePullConflicts)
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), repo.CleanUpPullRequest)
 			m.Group("/files", func() {
@@ -0,0 +1,61 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	actions_module "code.gitea.io/gitea/modules/actions"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+)
+
+// SaveTaskAnnotations parses the annotation workflow commands in the log rows uploaded by the runner and saves them.
+// index is the log index of the first row.
+func SaveTaskAnnotations(ctx context.Context, task *actions_model.ActionTask, index int64, rows []*runnerv1.LogRow) error {
+	var annotations []*actions_model.ActionTaskAnnotation
+	for i, row := range rows {
+		a := actions_module.ParseAnnotation(row.Content)
+		if a == nil {
+			continue
+		}
+		level, _ := actions_model.ParseAnnotationLevel(a.Level)
+		annotations = append(annotations, &actions_model.ActionTaskAnnotation{
+			LogIndex: index + int64(i),
+			Level:    level,
+			Path:     a.File,
+			Line:     a.Line,
+			EndLine:  a.EndLine,
+			Title:    a.Title,
+			Message:  a.Message,
+		})
+	}
+	return actions_model.InsertTaskAnnotations(ctx, task, annotations)
+}
+
+// annotationCountsDescription returns the description of the annotation counts like "2 errors, 1 warning",
+// it's empty if the task has no error or warning
+func annotationCountsDescription(ctx context.Context, taskID int64) (string, error) {
+	if taskID == 0 {
+		return "", nil
+	}
+	counts, err := actions_model.CountTaskAnnotations(ctx, taskID)
+	if err != nil {
+		return "", err
+	}
+	var parts []string
+	for _, level := range []actions_model.AnnotationLevel{actions_model.AnnotationLevelError, actions_model.AnnotationLevelWarning} {
+		switch n := counts[level]; n {
+		case 0:
+		case 1:
+			parts = append(parts, fmt.Sprintf("1 %s", level))
+		default:
+			parts = append(parts, fmt.Sprintf("%d %ss", n, level))
+		}
+	}
+	return strings.Join(parts, ", "), nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveTaskAnnotations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	task := &actions_model.ActionTask{ID: 1000, RepoID: 4, CommitSHA: "c2d72f548424103f01ee1dc02889c1e2bff816b0"}
+	rows := []*runnerv1.LogRow{
+		{Content: "Run lint"},
+		{Content: "::error file=main.go,line=3::undefined: foo"},
+		{Content: "::warning file=main.go,line=5::unused variable"},
+		{Content: "::warning::deprecated input"},
+		{Content: "::group::Details"},
+	}
+	require.NoError(t, SaveTaskAnnotations(db.DefaultContext, task, 10, rows))
+
+	annotations, err := db.Find[actions_model.ActionTaskAnnotation](db.DefaultContext, actions_model.FindTaskAnnotationsOptions{TaskID: task.ID})
+	require.NoError(t, err)
+	require.Len(t, annotations, 3)
+	assert.EqualValues(t, 11, annotations[0].LogIndex)
+	assert.Equal(t, actions_model.AnnotationLevelError, annotations[0].Level)
+	assert.Equal(t, "main.go", annotations[0].Path)
+	assert.Equal(t, 3, annotations[0].Line)
+	assert.Equal(t, task.CommitSHA, annotations[0].CommitSHA)
+
+	description, err := annotationCountsDescription(db.DefaultContext, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "1 error, 2 warnings", description)
+
+	description, err = annotationCountsDescription(db.DefaultContext, 0)
+	require.NoError(t, err)
+	assert.Empty(t, description)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// UpsertApprovalPolicy validates the approver team and saves the approval policy.
+// orgID is the organization which the approver team should belong to.
+func UpsertApprovalPolicy(ctx context.Context, orgID int64, policy *actions_model.ActionApprovalPolicy) error {
+	if policy.ApproverTeamID != 0 {
+		team, err := organization.GetTeamByID(ctx, policy.ApproverTeamID)
+		if err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				return util.NewInvalidArgumentErrorf("approver team %d does not exist", policy.ApproverTeamID)
+			}
+			return err
+		}
+		if team.OrgID != orgID {
+			return util.NewInvalidArgumentErrorf("approver team %d does not belong to the owner", policy.ApproverTeamID)
+		}
+	}
+	return actions_model.UpsertApprovalPolicy(ctx, policy)
+}
+
+// CanApproveRun returns whether the user can approve the run.
+// The caller should have checked that the user can write actions of the repository.
+func CanApproveRun(ctx context.Context, run *actions_model.ActionRun, doer *user_model.User) (bool, error) {
+	if run.ApproverTeamID == 0 {
+		return true, nil
+	}
+	return organization.IsTeamMember(ctx, run.OwnerID, run.ApproverTeamID, doer.ID)
+}
+
+// ApproveRun approves the run which needs approval and unblocks its jobs
+func ApproveRun(ctx context.Context, run *actions_model.ActionRun, jobs []*actions_model.ActionRunJob, doer *user_model.User) error {
+	if ok, err := CanApproveRun(ctx, run, doer); err != nil {
+		return fmt.Errorf("CanApproveRun: %w", err)
+	} else if !ok {
+		return util.NewPermissionDeniedErrorf("only members of the approver team can approve the run")
+	}
+
+	var updatedJobs []*actions_model.ActionRunJob
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		run.NeedApproval = false
+		run.ApprovedBy = doer.ID
+		if err := actions_model.UpdateRun(ctx, run, "need_approval", "approved_by"); err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			if len(job.Needs) == 0 && job.Status.IsBlocked() {
+				job.Status = actions_model.StatusWaiting
+				n, err := actions_model.UpdateRunJob(ctx, job, nil, "status")
+				if err != nil {
+					return err
+				}
+				if n > 0 {
+					updatedJobs = append(updatedJobs, job)
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	CreateCommitStatus(ctx, jobs...)
+
+	if len(updatedJobs) > 0 {
+		job := updatedJobs[0]
+		NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
+	}
+
+	for _, job := range updatedJobs {
+		_ = job.LoadAttributes(ctx)
+		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
+	}
+
+	return nil
+}
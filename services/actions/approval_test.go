@@ -0,0 +1,90 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIfNeedApproval(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	outsider := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	plainWorkflow := []byte("on: pull_request\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make test\n")
+	secretWorkflow := []byte("on: push\njobs:\n  deploy:\n    runs-on: ubuntu-latest\n    steps:\n      - run: deploy ${{ secrets.DEPLOY_KEY }}\n")
+	envWorkflow := []byte("on: push\njobs:\n  deploy:\n    runs-on: ubuntu-latest\n    environment: production\n")
+
+	forkRun := func(sha string) *actions_model.ActionRun {
+		return &actions_model.ActionRun{RepoID: repo.ID, OwnerID: repo.OwnerID, CommitSHA: sha, IsForkPullRequest: true, TriggerEvent: "pull_request"}
+	}
+	needApproval := func(run *actions_model.ActionRun, user *user_model.User, content []byte) bool {
+		need, err := ifNeedApproval(db.DefaultContext, run, repo, user, content)
+		require.NoError(t, err)
+		return need
+	}
+
+	// the default policy: only the first run of an outsider needs approval
+	assert.False(t, needApproval(forkRun("aaa"), owner, plainWorkflow))
+	assert.True(t, needApproval(forkRun("aaa"), outsider, plainWorkflow))
+	require.NoError(t, db.Insert(db.DefaultContext, &actions_model.ActionRun{
+		RepoID: repo.ID, OwnerID: repo.OwnerID, Index: 1000, TriggerUserID: outsider.ID, CommitSHA: "aaa", ApprovedBy: owner.ID,
+	}))
+	assert.False(t, needApproval(forkRun("bbb"), outsider, plainWorkflow))
+
+	// every push needs approval
+	require.NoError(t, actions_model.UpsertApprovalPolicy(db.DefaultContext, &actions_model.ActionApprovalPolicy{OwnerID: repo.OwnerID, Mode: actions_model.ApprovalModeEveryPush}))
+	run := forkRun("bbb")
+	assert.True(t, needApproval(run, outsider, plainWorkflow))
+	assert.Equal(t, actions_model.ApprovalModeEveryPush, run.ApprovalMode)
+	assert.NotZero(t, run.ApprovalPolicyID)
+	assert.False(t, needApproval(forkRun("aaa"), outsider, plainWorkflow))
+	assert.False(t, needApproval(forkRun("bbb"), owner, plainWorkflow))
+
+	// every run needs approval
+	require.NoError(t, actions_model.UpsertApprovalPolicy(db.DefaultContext, &actions_model.ActionApprovalPolicy{RepoID: repo.ID, Mode: actions_model.ApprovalModeAlways}))
+	assert.True(t, needApproval(forkRun("aaa"), outsider, plainWorkflow))
+	assert.False(t, needApproval(forkRun("aaa"), owner, plainWorkflow))
+
+	// sensitive workflows need approval even if they are not triggered by fork pull requests,
+	// but users who can write to the repository are trusted
+	require.NoError(t, actions_model.UpsertApprovalPolicy(db.DefaultContext, &actions_model.ActionApprovalPolicy{RepoID: repo.ID, RequireForSecrets: true, Environments: []string{"production"}}))
+	pushRun := &actions_model.ActionRun{RepoID: repo.ID, OwnerID: repo.OwnerID, CommitSHA: "ccc", TriggerEvent: "push"}
+	assert.False(t, needApproval(pushRun, outsider, plainWorkflow))
+	assert.True(t, needApproval(pushRun, outsider, secretWorkflow))
+	assert.True(t, needApproval(pushRun, outsider, envWorkflow))
+	assert.False(t, needApproval(pushRun, owner, secretWorkflow))
+	assert.False(t, needApproval(pushRun, owner, envWorkflow))
+}
+
+func TestCanApproveRun(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	run := &actions_model.ActionRun{OwnerID: 3}
+	ok, err := CanApproveRun(db.DefaultContext, run, user5)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	run.ApproverTeamID = 2
+	ok, err = CanApproveRun(db.DefaultContext, run, user4)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = CanApproveRun(db.DefaultContext, run, user5)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/kballard/go-shellquote"
+)
+
+// ScaleRequest asks the scaler to start ephemeral runners for the jobs waiting on the labels
+type ScaleRequest struct {
+	Labels     []string `json:"labels"`
+	Queued     int64    `json:"queued"`
+	InProgress int64    `json:"in_progress"`
+	// Stale is the number of jobs which have been waiting longer than the queue time threshold
+	Stale          int64     `json:"stale"`
+	OldestQueuedAt time.Time `json:"oldest_queued_at"`
+}
+
+// Scaler starts ephemeral runners on demand, e.g. pods of Kubernetes or Firecracker microVMs
+type Scaler interface {
+	ScaleUp(ctx context.Context, req *ScaleRequest) error
+}
+
+var scalerFactories = map[string]func() Scaler{
+	"command": func() Scaler {
+		return &commandScaler{command: setting.ActionsAutoscaler.Command, timeout: setting.ActionsAutoscaler.Timeout}
+	},
+	"http": func() Scaler {
+		return &httpScaler{
+			url:           setting.ActionsAutoscaler.URL,
+			authorization: setting.ActionsAutoscaler.Authorization,
+			client:        httpScalerClient(),
+		}
+	},
+}
+
+// httpScalerClient is shared by the scale ups, so the connections to the endpoint are reused
+var httpScalerClient = sync.OnceValue(func() *http.Client {
+	return &http.Client{Timeout: setting.ActionsAutoscaler.Timeout, Transport: &http.Transport{Proxy: proxy.Proxy()}}
+})
+
+// RegisterScaler registers a scaler type which can be used by [actions.autoscaler] TYPE
+func RegisterScaler(typ string, newScaler func() Scaler) {
+	scalerFactories[typ] = newScaler
+}
+
+// commandScaler runs a local command and writes the scale request to its stdin as JSON
+type commandScaler struct {
+	command string
+	timeout time.Duration
+}
+
+func (s *commandScaler) ScaleUp(ctx context.Context, req *ScaleRequest) error {
+	args, err := shellquote.Split(s.command)
+	if err != nil {
+		return fmt.Errorf("invalid command %q: %w", s.command, err)
+	} else if len(args) == 0 {
+		return fmt.Errorf("invalid command %q: empty", s.command)
+	}
+	input, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, _, finished := process.GetManager().AddContextTimeout(ctx, s.timeout, fmt.Sprintf("Actions autoscaler: %s", args[0]))
+	defer finished()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run %q: %w, stderr: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// httpScaler posts the scale request to an HTTP endpoint as JSON
+type httpScaler struct {
+	url           string
+	authorization string
+	client        *http.Client
+}
+
+func (s *httpScaler) ScaleUp(ctx context.Context, req *ScaleRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+	if s.authorization != "" {
+		httpReq.Header.Set("Authorization", s.authorization)
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// autoscaler remembers when the runners for each label set were last requested
+type autoscaler struct {
+	mu          sync.Mutex
+	lastScaleUp map[string]time.Time
+}
+
+var globalAutoscaler = &autoscaler{lastScaleUp: map[string]time.Time{}}
+
+// Autoscale asks the configured scaler for runners for the label sets which have jobs waiting longer than the threshold.
+// The same label set is not requested again before the cooldown ends.
+func Autoscale(ctx context.Context) error {
+	newScaler, ok := scalerFactories[setting.ActionsAutoscaler.Type]
+	if !ok {
+		return fmt.Errorf("unsupported scaler type %q", setting.ActionsAutoscaler.Type)
+	}
+	return globalAutoscaler.autoscale(ctx, newScaler(), setting.ActionsAutoscaler.QueueTimeThreshold, setting.ActionsAutoscaler.Cooldown)
+}
+
+func (a *autoscaler) autoscale(ctx context.Context, scaler Scaler, threshold, cooldown time.Duration) error {
+	now := time.Now()
+	stats, err := actions_model.GetQueueStats(ctx, actions_model.FindQueueStatsOptions{
+		StaleBefore: timeutil.TimeStamp(now.Add(-threshold).Unix()),
+	})
+	if err != nil {
+		return fmt.Errorf("GetQueueStats: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// forget the label sets whose cooldown has ended, so the label sets which are no longer used are not kept forever
+	for key, last := range a.lastScaleUp {
+		if now.Sub(last) >= cooldown {
+			delete(a.lastScaleUp, key)
+		}
+	}
+
+	for _, stat := range stats {
+		if stat.Stale == 0 {
+			continue
+		}
+		key := strings.Join(stat.Labels, ",")
+		if last, ok := a.lastScaleUp[key]; ok && now.Sub(last) < cooldown {
+			continue
+		}
+		req := &ScaleRequest{
+			Labels:         stat.Labels,
+			Queued:         stat.Queued,
+			InProgress:     stat.InProgress,
+			Stale:          stat.Stale,
+			OldestQueuedAt: stat.OldestQueued.AsTime(),
+		}
+		if err := scaler.ScaleUp(ctx, req); err != nil {
+			// don't stop the other label sets
+			log.Error("Actions autoscaler: scale up for labels %v: %v", stat.Labels, err)
+			continue
+		}
+		a.lastScaleUp[key] = now
+	}
+	return nil
+}
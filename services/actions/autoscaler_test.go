@@ -0,0 +1,101 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingScaler struct {
+	requests []*ScaleRequest
+}
+
+func (s *recordingScaler) ScaleUp(_ context.Context, req *ScaleRequest) error {
+	s.requests = append(s.requests, req)
+	return nil
+}
+
+func (s *recordingScaler) requestsFor(labels ...string) (ret []*ScaleRequest) {
+	for _, req := range s.requests {
+		if slices.Equal(req.Labels, labels) {
+			ret = append(ret, req)
+		}
+	}
+	return ret
+}
+
+func TestAutoscale(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	job := &actions_model.ActionRunJob{RepoID: 4, RunID: 793, RunsOn: []string{"gpu"}, Status: actions_model.StatusWaiting}
+	require.NoError(t, db.Insert(db.DefaultContext, job))
+
+	a := &autoscaler{lastScaleUp: map[string]time.Time{}}
+	scaler := &recordingScaler{}
+
+	// the job has just been queued
+	require.NoError(t, a.autoscale(db.DefaultContext, scaler, time.Minute, time.Hour))
+	assert.Empty(t, scaler.requestsFor("gpu"))
+
+	_, err := db.GetEngine(db.DefaultContext).Exec("UPDATE action_run_job SET updated = updated - 120 WHERE id = ?", job.ID)
+	require.NoError(t, err)
+	require.NoError(t, a.autoscale(db.DefaultContext, scaler, time.Minute, time.Hour))
+	if reqs := scaler.requestsFor("gpu"); assert.Len(t, reqs, 1) {
+		assert.EqualValues(t, 1, reqs[0].Queued)
+		assert.EqualValues(t, 1, reqs[0].Stale)
+	}
+
+	// in the cooldown
+	require.NoError(t, a.autoscale(db.DefaultContext, scaler, time.Minute, time.Hour))
+	assert.Len(t, scaler.requestsFor("gpu"), 1)
+
+	require.NoError(t, a.autoscale(db.DefaultContext, scaler, time.Minute, 0))
+	assert.Len(t, scaler.requestsFor("gpu"), 2)
+
+	// the label sets whose cooldown has ended are forgotten
+	a.lastScaleUp["removed"] = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, a.autoscale(db.DefaultContext, scaler, time.Minute, time.Hour))
+	assert.NotContains(t, a.lastScaleUp, "removed")
+	assert.Contains(t, a.lastScaleUp, "gpu")
+}
+
+func TestHTTPScalerClient(t *testing.T) {
+	assert.Same(t, httpScalerClient(), scalerFactories["http"]().(*httpScaler).client)
+}
+
+func TestHTTPScaler(t *testing.T) {
+	var received ScaleRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &received))
+		if len(received.Labels) == 0 {
+			http.Error(w, "no labels", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	scaler := &httpScaler{url: server.URL, authorization: "Bearer secret", client: server.Client()}
+	require.NoError(t, scaler.ScaleUp(t.Context(), &ScaleRequest{Labels: []string{"ubuntu"}, Queued: 2, Stale: 1}))
+	assert.Equal(t, []string{"ubuntu"}, received.Labels)
+	assert.EqualValues(t, 2, received.Queued)
+
+	err := scaler.ScaleUp(t.Context(), &ScaleRequest{})
+	assert.ErrorContains(t, err, "no labels")
+}
@@ -80,6 +80,10 @@ func createCommitStatus(ctx context.Context, job *actions_model.ActionRunJob) er
 	case webhook_module.HookEventRelease:
 		event = string(run.Event)
 		sha = run.CommitSHA
+	case webhook_module.HookEventMergeGroup:
+		// the checks of the combined commit of the merge group decide whether the group can be merged
+		event = actions_module.GithubEventMergeGroup
+		sha = run.CommitSHA
 	default:
 		return nil
 	}
@@ -124,6 +128,15 @@ func createCommitStatus(ctx context.Context, job *actions_model.ActionRunJob) er
 	case actions_model.StatusBlocked:
 		description = "Blocked by required conditions"
 	}
+	if job.Status.IsDone() {
+		counts, err := annotationCountsDescription(ctx, job.TaskID)
+		if err != nil {
+			return fmt.Errorf("annotationCountsDescription: %w", err)
+		}
+		if counts != "" {
+			description += " - " + counts
+		}
+	}
 
 	index, err := getIndexOfJob(ctx, job)
 	if err != nil {
@@ -37,4 +37,12 @@ type API interface {
 	ListWorkflowJobs(*context.APIContext)
 	// ListWorkflowRuns list runs
 	ListWorkflowRuns(*context.APIContext)
+	// GetApprovalPolicy get the approval policy
+	GetApprovalPolicy(*context.APIContext)
+	// UpdateApprovalPolicy create or update the approval policy
+	UpdateApprovalPolicy(*context.APIContext)
+	// DeleteApprovalPolicy delete the approval policy
+	DeleteApprovalPolicy(*context.APIContext)
+	// GetQueueStats get the queue depth per label set
+	GetQueueStats(*context.APIContext)
 }
@@ -12,6 +12,7 @@ import (
 	packages_model "code.gitea.io/gitea/models/packages"
 	perm_model "code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
+	pull_model "code.gitea.io/gitea/models/pull"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -794,3 +795,34 @@ func (n *actionsNotifier) WorkflowRunStatusUpdate(ctx context.Context, repo *rep
 		Sender:       convert.ToUser(ctx, sender, nil),
 	}).Notify(ctx)
 }
+
+// MergeGroupChecksRequested triggers the merge_group workflows for the combined commit of the merge group
+func (n *actionsNotifier) MergeGroupChecksRequested(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, group *pull_model.MergeGroup) {
+	ctx = withMethod(ctx, "MergeGroupChecksRequested")
+
+	indexes := make([]int64, 0, len(group.Entries))
+	for _, entry := range group.Entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			log.Error("GetPullRequestByID[%d]: %v", entry.PullID, err)
+			return
+		}
+		indexes = append(indexes, pr.Index)
+	}
+
+	newNotifyInput(repo, doer, webhook_module.HookEventMergeGroup).
+		WithRef(group.Ref).
+		WithPayload(&api.MergeGroupPayload{
+			Action: api.HookMergeGroupChecksRequested,
+			MergeGroup: &api.MergeGroup{
+				HeadSHA:      group.HeadCommitID,
+				HeadRef:      group.Ref,
+				BaseSHA:      group.BaseCommitID,
+				BaseRef:      git.RefNameFromBranch(group.BaseBranch).String(),
+				PullRequests: indexes,
+			},
+			Repo:   convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm_model.AccessModeOwner}),
+			Sender: convert.ToUser(ctx, doer, nil),
+		}).
+		Notify(ctx)
+}
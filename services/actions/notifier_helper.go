@@ -338,7 +338,7 @@ func handleWorkflows(
 			Status:            actions_model.StatusWaiting,
 		}
 
-		need, err := ifNeedApproval(ctx, run, input.Repo, input.Doer)
+		need, err := ifNeedApproval(ctx, run, input.Repo, input.Doer, dwf.Content)
 		if err != nil {
 			log.Error("check if need approval for repo %d with user %d: %v", input.Repo.ID, input.Doer.ID, err)
 			continue
@@ -456,7 +456,30 @@ func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_mo
 		Notify(ctx)
 }
 
-func ifNeedApproval(ctx context.Context, run *actions_model.ActionRun, repo *repo_model.Repository, user *user_model.User) (bool, error) {
+func ifNeedApproval(ctx context.Context, run *actions_model.ActionRun, repo *repo_model.Repository, user *user_model.User, content []byte) (bool, error) {
+	policy, err := actions_model.GetEffectiveApprovalPolicy(ctx, repo.OwnerID, repo.ID)
+	if err != nil {
+		return false, fmt.Errorf("GetEffectiveApprovalPolicy: %w", err)
+	}
+	if policy != nil {
+		run.ApprovalPolicyID = policy.ID
+		run.ApprovalMode = policy.Mode
+		run.ApproverTeamID = policy.ApproverTeamID
+
+		// workflows touching secrets or protected environments need approval unless the user can write,
+		// no matter if they are triggered by a fork pull request or not
+		if need, err := isSensitiveWorkflow(policy, content); err != nil {
+			return false, err
+		} else if need {
+			if canWrite, err := canWriteActions(ctx, repo, user); err != nil {
+				return false, err
+			} else if user.IsRestricted || !canWrite {
+				log.Trace("need approval because workflow %s uses secrets or protected environments", run.WorkflowID)
+				return true, nil
+			}
+		}
+	}
+
 	// 1. don't need approval if it's not a fork PR
 	// 2. don't need approval if the event is `pull_request_target` since the workflow will run in the context of base branch
 	// 		see https://docs.github.com/en/actions/managing-workflow-runs/approving-workflow-runs-from-public-forks#about-workflow-runs-from-public-forks
@@ -471,19 +494,29 @@ func ifNeedApproval(ctx context.Context, run *actions_model.ActionRun, repo *rep
 	}
 
 	// don't need approval if the user can write
-	if perm, err := access_model.GetUserRepoPermission(ctx, repo, user); err != nil {
-		return false, fmt.Errorf("GetUserRepoPermission: %w", err)
-	} else if perm.CanWrite(unit_model.TypeActions) {
+	if canWrite, err := canWriteActions(ctx, repo, user); err != nil {
+		return false, err
+	} else if canWrite {
 		log.Trace("do not need approval because user %d can write", user.ID)
 		return false, nil
 	}
 
-	// don't need approval if the user has been approved before
-	if count, err := db.Count[actions_model.ActionRun](ctx, actions_model.FindRunOptions{
+	opts := actions_model.FindRunOptions{
 		RepoID:        repo.ID,
 		TriggerUserID: user.ID,
 		Approved:      true,
-	}); err != nil {
+	}
+	switch run.ApprovalMode {
+	case actions_model.ApprovalModeAlways:
+		log.Trace("need approval because every run of user %d needs approval", user.ID)
+		return true, nil
+	case actions_model.ApprovalModeEveryPush:
+		// runs of the same commit, e.g. triggered by other events or other workflows, share the approval
+		opts.CommitSHA = run.CommitSHA
+	}
+
+	// don't need approval if the user has been approved before
+	if count, err := db.Count[actions_model.ActionRun](ctx, opts); err != nil {
 		return false, fmt.Errorf("CountRuns: %w", err)
 	} else if count > 0 {
 		log.Trace("do not need approval because user %d has been approved before", user.ID)
@@ -495,6 +528,32 @@ func ifNeedApproval(ctx context.Context, run *actions_model.ActionRun, repo *rep
 	return true, nil
 }
 
+func canWriteActions(ctx context.Context, repo *repo_model.Repository, user *user_model.User) (bool, error) {
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, user)
+	if err != nil {
+		return false, fmt.Errorf("GetUserRepoPermission: %w", err)
+	}
+	return perm.CanWrite(unit_model.TypeActions), nil
+}
+
+func isSensitiveWorkflow(policy *actions_model.ActionApprovalPolicy, content []byte) (bool, error) {
+	if policy.RequireForSecrets {
+		if uses, err := actions_module.UsesSecrets(content); err != nil {
+			return false, fmt.Errorf("UsesSecrets: %w", err)
+		} else if uses {
+			return true, nil
+		}
+	}
+	if len(policy.Environments) > 0 {
+		envs, err := actions_module.GetEnvironmentsFromContent(content)
+		if err != nil {
+			return false, fmt.Errorf("GetEnvironmentsFromContent: %w", err)
+		}
+		return policy.MatchEnvironments(envs), nil
+	}
+	return false, nil
+}
+
 func handleSchedules(
 	ctx context.Context,
 	detectedWorkflows []*actions_module.DetectedWorkflow,
@@ -4,10 +4,23 @@
 package actions
 
 import (
+	"context"
+	"errors"
+
 	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+
+	"xorm.io/builder"
 )
 
+// ErrWorkflowDisabled is returned when rerunning the jobs of a disabled workflow
+var ErrWorkflowDisabled = util.NewInvalidArgumentErrorf("workflow is disabled")
+
 // GetAllRerunJobs get all jobs that need to be rerun when job should be rerun
 func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.ActionRunJob) []*actions_model.ActionRunJob {
 	rerunJobs := []*actions_model.ActionRunJob{job}
@@ -36,3 +49,154 @@ func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.A
 
 	return rerunJobs
 }
+
+// RerunRun reruns all jobs of the done run
+func RerunRun(ctx context.Context, run *actions_model.ActionRun, jobs []*actions_model.ActionRunJob) error {
+	return rerunJobs(ctx, run, jobs)
+}
+
+// RerunJob reruns the job of the done run and the jobs depending on it
+func RerunJob(ctx context.Context, run *actions_model.ActionRun, job *actions_model.ActionRunJob, jobs []*actions_model.ActionRunJob) error {
+	return rerunJobs(ctx, run, GetAllRerunJobs(job, jobs))
+}
+
+// RerunFailedJobs reruns the failed or cancelled jobs of the done run and the jobs depending on them
+func RerunFailedJobs(ctx context.Context, run *actions_model.ActionRun, jobs []*actions_model.ActionRunJob) error {
+	var toRerun []*actions_model.ActionRunJob
+	jobIDs := make(container.Set[string])
+	for _, job := range jobs {
+		if job.Status != actions_model.StatusFailure && job.Status != actions_model.StatusCancelled {
+			continue
+		}
+		for _, j := range GetAllRerunJobs(job, jobs) {
+			if jobIDs.Add(j.JobID) {
+				toRerun = append(toRerun, j)
+			}
+		}
+	}
+	if len(toRerun) == 0 {
+		return nil
+	}
+	return rerunJobs(ctx, run, toRerun)
+}
+
+func rerunJobs(ctx context.Context, run *actions_model.ActionRun, jobs []*actions_model.ActionRunJob) error {
+	// can not rerun job when workflow is disabled
+	if err := run.LoadRepo(ctx); err != nil {
+		return err
+	}
+	cfgUnit, err := run.Repo.GetUnit(ctx, unit.TypeActions)
+	if err != nil {
+		return err
+	}
+	if cfgUnit.ActionsConfig().IsWorkflowDisabled(run.WorkflowID) {
+		return ErrWorkflowDisabled
+	}
+	if !run.Status.IsDone() {
+		return nil
+	}
+
+	// reset run's start and stop time when it is done
+	run.PreviousDuration = run.Duration()
+	run.Started = 0
+	run.Stopped = 0
+	if err := actions_model.UpdateRun(ctx, run, "started", "stopped", "previous_duration"); err != nil {
+		return err
+	}
+	if err := run.LoadAttributes(ctx); err != nil {
+		return err
+	}
+	notify_service.WorkflowRunStatusUpdate(ctx, run.Repo, run.TriggerUser, run)
+
+	jobIDs := make(container.Set[string], len(jobs))
+	for _, j := range jobs {
+		j.Run = run
+		jobIDs.Add(j.JobID)
+	}
+	for _, j := range jobs {
+		// if the job needs other rerun jobs, it should be set to "blocked" status to wait for them
+		shouldBlock := false
+		for _, need := range j.Needs {
+			if jobIDs.Contains(need) {
+				shouldBlock = true
+				break
+			}
+		}
+		if err := rerunJob(ctx, j, shouldBlock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rerunJob(ctx context.Context, job *actions_model.ActionRunJob, shouldBlock bool) error {
+	status := job.Status
+	if !status.IsDone() {
+		return nil
+	}
+
+	job.TaskID = 0
+	job.Status = actions_model.StatusWaiting
+	if shouldBlock {
+		job.Status = actions_model.StatusBlocked
+	}
+	job.Started = 0
+	job.Stopped = 0
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		_, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": status}, "task_id", "status", "started", "stopped")
+		return err
+	}); err != nil {
+		return err
+	}
+
+	CreateCommitStatus(ctx, job)
+	notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
+
+	return nil
+}
+
+// CancelJobs cancels the jobs which are not done, the running tasks of them are stopped
+func CancelJobs(ctx context.Context, jobs []*actions_model.ActionRunJob) error {
+	var updatedJobs []*actions_model.ActionRunJob
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		for _, job := range jobs {
+			status := job.Status
+			if status.IsDone() {
+				continue
+			}
+			if job.TaskID == 0 {
+				job.Status = actions_model.StatusCancelled
+				job.Stopped = timeutil.TimeStampNow()
+				n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"task_id": 0}, "status", "stopped")
+				if err != nil {
+					return err
+				}
+				if n == 0 {
+					return errors.New("job has changed, try again")
+				}
+				updatedJobs = append(updatedJobs, job)
+				continue
+			}
+			if err := actions_model.StopTask(ctx, job.TaskID, actions_model.StatusCancelled); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	CreateCommitStatus(ctx, jobs...)
+
+	for _, job := range updatedJobs {
+		_ = job.LoadAttributes(ctx)
+		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
+	}
+	if len(updatedJobs) > 0 {
+		job := updatedJobs[0]
+		NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
+	}
+	return nil
+}
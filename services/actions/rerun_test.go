@@ -7,8 +7,13 @@ import (
 	"testing"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetAllRerunJobs(t *testing.T) {
@@ -46,3 +51,26 @@ func TestGetAllRerunJobs(t *testing.T) {
 		assert.ElementsMatch(t, tc.rerunJobs, rerunJobs)
 	}
 }
+
+func TestRerunFailedJobs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	run := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{ID: 795})
+	require.NoError(t, db.Insert(db.DefaultContext, &repo_model.RepoUnit{
+		RepoID: run.RepoID,
+		Type:   unit.TypeActions,
+		Config: &repo_model.ActionsConfig{},
+	}))
+	jobs, err := actions_model.GetRunJobsByRunID(db.DefaultContext, run.ID)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	require.NoError(t, RerunFailedJobs(db.DefaultContext, run, jobs))
+
+	// only the failed job is rerun
+	job1 := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: 198})
+	assert.Equal(t, actions_model.StatusSuccess, job1.Status)
+	job2 := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: 199})
+	assert.Equal(t, actions_model.StatusWaiting, job2.Status)
+	assert.Zero(t, job2.TaskID)
+}
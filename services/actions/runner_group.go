@@ -0,0 +1,72 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"path"
+	"slices"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// validateRunnerGroupAccess checks that the allowed repositories exist and belong to the owner of the group,
+// and normalizes the allowed workflows to file names
+func validateRunnerGroupAccess(ctx context.Context, group *actions_model.ActionRunnerGroup) error {
+	slices.Sort(group.RepoIDs)
+	group.RepoIDs = slices.Compact(group.RepoIDs)
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, group.RepoIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range group.RepoIDs {
+		repo, ok := repos[id]
+		if !ok {
+			return util.NewInvalidArgumentErrorf("repository %d does not exist", id)
+		}
+		if group.OwnerID != 0 && repo.OwnerID != group.OwnerID {
+			return util.NewInvalidArgumentErrorf("repository %d does not belong to the owner of the runner group", id)
+		}
+	}
+
+	workflows := make([]string, 0, len(group.Workflows))
+	for _, wf := range group.Workflows {
+		// the run only records the file name of its workflow, like "deploy.yml"
+		if wf = path.Base(wf); wf != "." && wf != "/" && !slices.Contains(workflows, wf) {
+			workflows = append(workflows, wf)
+		}
+	}
+	group.Workflows = workflows
+	return nil
+}
+
+// CreateRunnerGroup validates and creates the runner group
+func CreateRunnerGroup(ctx context.Context, group *actions_model.ActionRunnerGroup) error {
+	if err := validateRunnerGroupAccess(ctx, group); err != nil {
+		return err
+	}
+	return actions_model.CreateRunnerGroup(ctx, group)
+}
+
+// UpdateRunnerGroup validates and updates the columns of the runner group
+func UpdateRunnerGroup(ctx context.Context, group *actions_model.ActionRunnerGroup, cols ...string) error {
+	if err := validateRunnerGroupAccess(ctx, group); err != nil {
+		return err
+	}
+	return actions_model.UpdateRunnerGroup(ctx, group, cols...)
+}
+
+// SetRunnerGroup moves the runner into the group, nil group means removing the runner from its group.
+// Only the runners of the owner of the group can be added, repository level runners can't be grouped.
+func SetRunnerGroup(ctx context.Context, runner *actions_model.ActionRunner, group *actions_model.ActionRunnerGroup) error {
+	if group == nil {
+		return actions_model.SetRunnerGroup(ctx, runner, 0)
+	}
+	if runner.RepoID != 0 || runner.OwnerID != group.OwnerID {
+		return util.NewInvalidArgumentErrorf("runner %d doesn't have the same scope as the runner group", runner.ID)
+	}
+	return actions_model.SetRunnerGroup(ctx, runner, group.ID)
+}
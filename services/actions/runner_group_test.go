@@ -0,0 +1,41 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerGroupAccess(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo 1 belongs to user2, not org3
+	err := CreateRunnerGroup(db.DefaultContext, &actions_model.ActionRunnerGroup{OwnerID: 3, Name: "group", RepoIDs: []int64{3, 1}})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = CreateRunnerGroup(db.DefaultContext, &actions_model.ActionRunnerGroup{OwnerID: 3, Name: "group", RepoIDs: []int64{3, 1000}})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	group := &actions_model.ActionRunnerGroup{OwnerID: 3, Name: "group", RepoIDs: []int64{3, 3}, Workflows: []string{".gitea/workflows/deploy.yml", "deploy.yml"}}
+	require.NoError(t, CreateRunnerGroup(db.DefaultContext, group))
+	assert.Equal(t, []int64{3}, group.RepoIDs)
+	assert.Equal(t, []string{"deploy.yml"}, group.Workflows)
+
+	// global groups can allow the repositories of any owner
+	require.NoError(t, CreateRunnerGroup(db.DefaultContext, &actions_model.ActionRunnerGroup{Name: "group", RepoIDs: []int64{1, 3}}))
+
+	orgRunner := &actions_model.ActionRunner{ID: 1, OwnerID: 3}
+	repoRunner := &actions_model.ActionRunner{ID: 2, RepoID: 3}
+	assert.ErrorIs(t, SetRunnerGroup(db.DefaultContext, repoRunner, group), util.ErrInvalidArgument)
+	assert.ErrorIs(t, SetRunnerGroup(db.DefaultContext, &actions_model.ActionRunner{ID: 3, OwnerID: 2}, group), util.ErrInvalidArgument)
+	require.NoError(t, SetRunnerGroup(db.DefaultContext, orgRunner, group))
+	assert.Equal(t, group.ID, orgRunner.GroupID)
+}
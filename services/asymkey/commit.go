@@ -35,7 +35,18 @@ func ParseCommitWithSignature(ctx context.Context, c *git.Commit) *asymkey_model
 	return ParseCommitWithSignatureCommitter(ctx, c, committer)
 }
 
-// ParseCommitWithSignatureCommitter parses a commit's GPG or SSH signature.
+// ParseTagWithSignature check if the signature of an annotated tag is good against keystore, the tagger takes the role of the committer.
+func ParseTagWithSignature(ctx context.Context, t *git.Tag) *asymkey_model.CommitVerification {
+	return ParseCommitWithSignature(ctx, &git.Commit{
+		ID:            t.ID,
+		Author:        t.Tagger,
+		Committer:     t.Tagger,
+		CommitMessage: t.Message,
+		Signature:     t.Signature,
+	})
+}
+
+// ParseCommitWithSignatureCommitter parses a commit's GPG, SSH or X.509 signature.
 // The caller guarantees that the committer user is related to the commit by checking its activated email addresses or no-reply address.
 // If the commit is singed by an instance key, then committer can be nil.
 // If the signature exists, even if committer is nil, the returned CommittingUser will be a non-nil fake user (e.g.: instance key)
@@ -58,6 +69,9 @@ func ParseCommitWithSignatureCommitter(ctx context.Context, c *git.Commit, commi
 	if strings.HasPrefix(c.Signature.Signature, "-----BEGIN SSH SIGNATURE-----") {
 		return parseCommitWithSSHSignature(ctx, c, committer)
 	}
+	if isX509Signature(c.Signature.Signature) {
+		return parseCommitWithX509Signature(ctx, c, committer)
+	}
 	return parseCommitWithGPGSignature(ctx, c, committer)
 }
 
@@ -97,3 +97,134 @@ Initial commit with signed file
 		}
 	})
 }
+
+// x509TestCA issued the certificates of the X.509 signatures below, which are only valid during 2025
+const x509TestCA = `-----BEGIN CERTIFICATE-----
+MIIBYTCCAQegAwIBAgIBATAKBggqhkjOPQQDAjAYMRYwFAYDVQQDEw1HaXRlYSBU
+ZXN0IENBMB4XDTI1MDEwMTAwMDAwMFoXDTQ1MDEwMTAwMDAwMFowGDEWMBQGA1UE
+AxMNR2l0ZWEgVGVzdCBDQTBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABEU4RDR2
+l40XbiNqq4U82xtlMKsJf9ylWrJzNnG2E7zBBih7Tf7mnoisFbScggxswhMzgJfd
+wk7QdxP2oongMs6jQjBAMA4GA1UdDwEB/wQEAwICBDAPBgNVHRMBAf8EBTADAQH/
+MB0GA1UdDgQWBBS9ss843R2Fr3+BnoqW1stNylRlODAKBggqhkjOPQQDAgNIADBF
+AiEAjUWDF/NKl3ZNGgBUgWTVcxoZgJqyKR4ggkLmtDpE90ICIHCmLObaAM6AUHZT
+HllyCvgNdDhJ74RXCVZfo330HkRt
+-----END CERTIFICATE-----
+`
+
+// newX509SignedCommit returns a commit of user2 signed at 2025-06-06 with a certificate issued by x509TestCA
+func newX509SignedCommit(t *testing.T, message, signature string) *git.Commit {
+	gpgsig := strings.ReplaceAll(strings.TrimSpace(signature), "\n", "\n ")
+	commit, err := git.CommitFromReader(nil, git.Sha1ObjectFormat.EmptyObjectID(), strings.NewReader(`tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+author User Two <user2@example.com> 1749230009 +0000
+committer User Two <user2@example.com> 1749230009 +0000
+gpgsig `+gpgsig+`
+
+`+message+`
+`))
+	require.NoError(t, err)
+	return commit
+}
+
+func TestParseCommitWithX509Signature(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	emailProtectionCommit := newX509SignedCommit(t, "signed with email protection certificate", `-----BEGIN SIGNED MESSAGE-----
+MIICrwYJKoZIhvcNAQcCoIICoDCCApwCAQExDTALBglghkgBZQMEAgEwCwYJKoZI
+hvcNAQcBoIIBhDCCAYAwggEmoAMCAQICAQIwCgYIKoZIzj0EAwIwGDEWMBQGA1UE
+AxMNR2l0ZWEgVGVzdCBDQTAeFw0yNTAxMDEwMDAwMDBaFw0yNjAxMDEwMDAwMDBa
+MBMxETAPBgNVBAMTCFVzZXIgVHdvMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE
+WtYU9LDeA9cNQXg4386PdCXDSnf2RKpHXF3Fn67ovplQTwGAUD+giJD2wqu0kSQE
+QZcUgRE4AfoXGDqa9pWaQaNmMGQwDgYDVR0PAQH/BAQDAgeAMBMGA1UdJQQMMAoG
+CCsGAQUFBwMEMB8GA1UdIwQYMBaAFL2yzzjdHYWvf4GeipbWy03KVGU4MBwGA1Ud
+EQQVMBOBEXVzZXIyQGV4YW1wbGUuY29tMAoGCCqGSM49BAMCA0gAMEUCIAqG0Rj6
+KZi+T5QuWbgN0olXIZGhg/96NdC2sCqHtMZwAiEA2oQwFV+qeHQsM+wxv1OTRbMk
+6BXb6rs7mjF6sR03jvkxgfIwge8CAQEwHTAYMRYwFAYDVQQDEw1HaXRlYSBUZXN0
+IENBAgECMAsGCWCGSAFlAwQCAaBpMBgGCSqGSIb3DQEJAzELBgkqhkiG9w0BBwEw
+HAYJKoZIhvcNAQkFMQ8XDTI1MDYwNjE3MTMyOVowLwYJKoZIhvcNAQkEMSIEIAiR
+rMBAc2liQaFtIP1AlRktVOH4hEC1xRnvEdgK9mw9MAoGCCqGSM49BAMCBEcwRQIg
+cPxnf1LJzNfVQhNsf/hmQTkS6ezXRg9wtrsqbAGdP3cCIQDOHFIbkf3Z2++q8gdb
+7ufAkFOIRa6bmK7XroUIgT+iOg==
+-----END SIGNED MESSAGE-----
+`)
+	codeSigningCommit := newX509SignedCommit(t, "signed with code signing certificate", `-----BEGIN SIGNED MESSAGE-----
+MIICrwYJKoZIhvcNAQcCoIICoDCCApwCAQExDTALBglghkgBZQMEAgEwCwYJKoZI
+hvcNAQcBoIIBhDCCAYAwggEmoAMCAQICAQMwCgYIKoZIzj0EAwIwGDEWMBQGA1UE
+AxMNR2l0ZWEgVGVzdCBDQTAeFw0yNTAxMDEwMDAwMDBaFw0yNjAxMDEwMDAwMDBa
+MBMxETAPBgNVBAMTCFVzZXIgVHdvMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE
+THeVpA/WgyvwjChEjnBPpyjjNHePx9NGx67aEsLXZbYhqUVZWOycnevQoCDpk3+p
+e5W2a6/09CCyZZFcEE0m2qNmMGQwDgYDVR0PAQH/BAQDAgeAMBMGA1UdJQQMMAoG
+CCsGAQUFBwMDMB8GA1UdIwQYMBaAFL2yzzjdHYWvf4GeipbWy03KVGU4MBwGA1Ud
+EQQVMBOBEXVzZXIyQGV4YW1wbGUuY29tMAoGCCqGSM49BAMCA0gAMEUCIBxl1iER
+Zc9leKfXkmzWU1a1aDRANN6IDfDyWhAuVV6VAiEAzL35vi2EzfiVcnNtOlUf22wC
+FE3vpKRPaN9+DJfXcZMxgfIwge8CAQEwHTAYMRYwFAYDVQQDEw1HaXRlYSBUZXN0
+IENBAgEDMAsGCWCGSAFlAwQCAaBpMBgGCSqGSIb3DQEJAzELBgkqhkiG9w0BBwEw
+HAYJKoZIhvcNAQkFMQ8XDTI1MDYwNjE3MTMyOVowLwYJKoZIhvcNAQkEMSIEIIoR
+JqdJU0sHq9VQwAq1qdf3RD5ZUiR79/kAmkGJ/N1KMAoGCCqGSM49BAMCBEcwRQIg
+Sb7ASc442PCEizuDmWvcB+EdxgfaiQT4qEA09qsdRFgCIQDjP+puokhU3Lsf3HVB
+66fCEigt74AYZYvoo6Ru5EU70w==
+-----END SIGNED MESSAGE-----
+`)
+	serverAuthCommit := newX509SignedCommit(t, "signed with server auth certificate", `-----BEGIN SIGNED MESSAGE-----
+MIICsAYJKoZIhvcNAQcCoIICoTCCAp0CAQExDTALBglghkgBZQMEAgEwCwYJKoZI
+hvcNAQcBoIIBhDCCAYAwggEmoAMCAQICAQQwCgYIKoZIzj0EAwIwGDEWMBQGA1UE
+AxMNR2l0ZWEgVGVzdCBDQTAeFw0yNTAxMDEwMDAwMDBaFw0yNjAxMDEwMDAwMDBa
+MBMxETAPBgNVBAMTCFVzZXIgVHdvMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE
+2wDtOxUwpCyljz2XQMOBzhvOIJLM2jTlLVTmHM0IaSa0EO0t/sclnqPcA0aWJnet
+r6Dtcs79FW1qC/fY0Ox7pKNmMGQwDgYDVR0PAQH/BAQDAgeAMBMGA1UdJQQMMAoG
+CCsGAQUFBwMBMB8GA1UdIwQYMBaAFL2yzzjdHYWvf4GeipbWy03KVGU4MBwGA1Ud
+EQQVMBOBEXVzZXIyQGV4YW1wbGUuY29tMAoGCCqGSM49BAMCA0gAMEUCIA7A7ZFA
+V6B8R+2TW2e8Is0ktCA9ZU4OIh3A3VYR4OFfAiEA+rV5LV1SYqqV6AepnDhj/oY4
+EoLAM2dcChtLhfwZlKcxgfMwgfACAQEwHTAYMRYwFAYDVQQDEw1HaXRlYSBUZXN0
+IENBAgEEMAsGCWCGSAFlAwQCAaBpMBgGCSqGSIb3DQEJAzELBgkqhkiG9w0BBwEw
+HAYJKoZIhvcNAQkFMQ8XDTI1MDYwNjE3MTMyOVowLwYJKoZIhvcNAQkEMSIEIPbX
+G4G2nI4kVaO+hqGeGjzyGspdUqoJ5+3Pzl5gRpu0MAoGCCqGSM49BAMCBEgwRgIh
+AO8ij3IJ5rL4Crkzsf93tfPnBrVlKlggDhDXcjHbsPIJAiEAx3CpMO2GkXq8jSOC
+AbaldCvqCXwds5z/wHh3EHs++Yg=
+-----END SIGNED MESSAGE-----
+`)
+
+	t.Run("NoTrustedCA", func(t *testing.T) {
+		ret := parseCommitWithX509Signature(t.Context(), emailProtectionCommit, user2)
+		assert.False(t, ret.Verified)
+		assert.Equal(t, "gpg.error.no_trusted_x509_ca", ret.Reason)
+	})
+
+	require.NoError(t, asymkey_model.CreateX509TrustedCA(t.Context(), &asymkey_model.X509TrustedCA{Name: "test", Content: x509TestCA}))
+
+	t.Run("EmailProtection", func(t *testing.T) {
+		// the certificate has expired since, but it was valid when the commit was signed
+		ret := parseCommitWithX509Signature(t.Context(), emailProtectionCommit, user2)
+		assert.True(t, ret.Verified)
+		assert.False(t, ret.Warning)
+		assert.Equal(t, user2.ID, ret.SigningUser.ID)
+		assert.Equal(t, "user2@example.com", ret.SigningEmail)
+		assert.Equal(t, user2.Name+" / "+ret.SigningX509.Fingerprint, ret.Reason)
+	})
+
+	t.Run("CodeSigning", func(t *testing.T) {
+		ret := parseCommitWithX509Signature(t.Context(), codeSigningCommit, user2)
+		assert.True(t, ret.Verified)
+		assert.Equal(t, user2.ID, ret.SigningUser.ID)
+	})
+
+	t.Run("ServerAuth", func(t *testing.T) {
+		// a certificate which isn't issued for signing emails or code is not accepted
+		ret := parseCommitWithX509Signature(t.Context(), serverAuthCommit, user2)
+		assert.False(t, ret.Verified)
+		assert.Equal(t, "gpg.error.x509_untrusted_certificate", ret.Reason)
+	})
+
+	t.Run("ModifiedCommit", func(t *testing.T) {
+		commit := *emailProtectionCommit
+		commit.Signature = &git.CommitSignature{
+			Signature: emailProtectionCommit.Signature.Signature,
+			Payload:   emailProtectionCommit.Signature.Payload + "modified\n",
+		}
+		ret := parseCommitWithX509Signature(t.Context(), &commit, user2)
+		assert.False(t, ret.Verified)
+		assert.True(t, ret.Warning)
+		assert.Equal(t, asymkey_model.BadSignature, ret.Reason)
+	})
+}
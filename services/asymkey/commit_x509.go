@@ -0,0 +1,135 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/cachegroup"
+	"code.gitea.io/gitea/modules/cms"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+)
+
+// x509SigningTimeSkew is the tolerated clock difference for signing times which lie in the future
+const x509SigningTimeSkew = 5 * time.Minute
+
+func isX509Signature(signature string) bool {
+	return strings.HasPrefix(signature, "-----BEGIN SIGNED MESSAGE-----") || strings.HasPrefix(signature, "-----BEGIN PKCS7-----")
+}
+
+// parseCommitWithX509Signature verifies an X.509 (S/MIME) signature against the trusted CA bundles
+// and associates it with the user owning the certificate email address.
+func parseCommitWithX509Signature(ctx context.Context, c *git.Commit, committer *user_model.User) *asymkey_model.CommitVerification {
+	sig, err := cms.ParseSignature([]byte(c.Signature.Signature))
+	if err != nil {
+		log.Error("ParseSignature: %v", err)
+		return &asymkey_model.CommitVerification{
+			CommittingUser: committer,
+			Verified:       false,
+			Reason:         "gpg.error.extract_sign",
+		}
+	}
+
+	cert, err := sig.Verify([]byte(c.Signature.Payload))
+	if errors.Is(err, cms.ErrInvalidSignature) {
+		return &asymkey_model.CommitVerification{
+			CommittingUser: committer,
+			Verified:       false,
+			Warning:        true,
+			Reason:         asymkey_model.BadSignature,
+		}
+	} else if err != nil {
+		log.Debug("Unable to verify X.509 signature of %s: %v", c.ID, err)
+		return &asymkey_model.CommitVerification{
+			CommittingUser: committer,
+			Verified:       false,
+			Reason:         "gpg.error.extract_sign",
+		}
+	}
+	signingCert := asymkey_model.NewX509Certificate(cert)
+
+	pool, err := cache.GetWithContextCache(ctx, cachegroup.X509TrustedCerts, "", func(ctx context.Context, _ string) (*x509.CertPool, error) {
+		return asymkey_model.GetX509TrustedCertPool(ctx)
+	})
+	if err != nil {
+		log.Error("GetX509TrustedCertPool: %v", err)
+		return &asymkey_model.CommitVerification{
+			CommittingUser: committer,
+			Verified:       false,
+			Reason:         "gpg.error.failed_retrieval_gpg_keys",
+		}
+	} else if pool == nil {
+		return &asymkey_model.CommitVerification{
+			CommittingUser: committer,
+			Verified:       false,
+			Reason:         "gpg.error.no_trusted_x509_ca",
+			SigningX509:    signingCert,
+		}
+	}
+
+	// the certificate must have been valid when the signature was made, not necessarily now
+	signingTime := sig.SigningTime
+	if signingTime.IsZero() {
+		signingTime = c.Committer.When
+	}
+	if signingTime.After(time.Now().Add(x509SigningTimeSkew)) {
+		return &asymkey_model.CommitVerification{
+			CommittingUser: committer,
+			Verified:       false,
+			Warning:        true,
+			Reason:         "gpg.error.x509_signing_time_in_future",
+			SigningX509:    signingCert,
+		}
+	}
+	// the certificate must be issued for signing emails or code, the chain is valid if it allows any of them
+	if _, err := sig.VerifyCertificate(cert, x509.VerifyOptions{
+		Roots:       pool,
+		CurrentTime: signingTime,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection, x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		log.Debug("Untrusted X.509 certificate %q for %s: %v", signingCert.Subject, c.ID, err)
+		return &asymkey_model.CommitVerification{
+			CommittingUser: committer,
+			Verified:       false,
+			Reason:         "gpg.error.x509_untrusted_certificate",
+			SigningX509:    signingCert,
+		}
+	}
+
+	for _, email := range asymkey_model.X509CertificateEmails(cert) {
+		signer := committer
+		if committer.ID == 0 || !strings.EqualFold(email, c.Committer.Email) {
+			if signer, err = user_model.GetUserByEmail(ctx, email); err != nil {
+				if !user_model.IsErrUserNotExist(err) {
+					log.Error("GetUserByEmail: %v", err)
+				}
+				continue
+			}
+		}
+		return &asymkey_model.CommitVerification{ // Everything is ok
+			CommittingUser: committer,
+			Verified:       true,
+			Reason:         fmt.Sprintf("%s / %s", signer.Name, signingCert.Fingerprint),
+			SigningUser:    signer,
+			SigningX509:    signingCert,
+			SigningEmail:   email,
+		}
+	}
+
+	return &asymkey_model.CommitVerification{
+		CommittingUser: committer,
+		Verified:       false,
+		Reason:         "gpg.error.x509_no_matching_email",
+		SigningX509:    signingCert,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	pull_model "code.gitea.io/gitea/models/pull"
@@ -23,6 +24,7 @@ import (
 	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/services/automergequeue"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -268,6 +270,23 @@ func handlePullRequestAutoMerge(pullID int64, sha string) {
 		return
 	}
 
+	// hand the pull request over to the merge queue if the branch requires it
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		log.Error("GetFirstMatchProtectedBranchRule: %v", err)
+		return
+	}
+	if pb != nil && pb.EnableMergeQueue {
+		if err := pull_model.DeleteScheduledAutoMerge(ctx, pr.ID); err != nil {
+			log.Error("DeleteScheduledAutoMerge %-v: %v", pr, err)
+			return
+		}
+		if err := mergequeue.AddToMergeQueue(ctx, doer, pr, scheduledPRM.MergeStyle, scheduledPRM.Message, scheduledPRM.DeleteBranchAfterMerge); err != nil {
+			log.Error("AddToMergeQueue %-v: %v", pr, err)
+		}
+		return
+	}
+
 	if err := pull_service.Merge(ctx, pr, doer, baseGitRepo, scheduledPRM.MergeStyle, "", scheduledPRM.Message, true); err != nil {
 		log.Error("pull_service.Merge: %v", err)
 		// FIXME: if merge failed, we should display some error message to the pull request page.
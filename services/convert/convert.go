@@ -192,10 +192,13 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		BlockOnOutdatedBranch:         bp.BlockOnOutdatedBranch,
 		DismissStaleApprovals:         bp.DismissStaleApprovals,
 		IgnoreStaleApprovals:          bp.IgnoreStaleApprovals,
+		RequireCodeOwnerReviews:       bp.RequireCodeOwnerReviews,
 		RequireSignedCommits:          bp.RequireSignedCommits,
 		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
 		BlockAdminMergeOverride:       bp.BlockAdminMergeOverride,
+		EnableMergeQueue:              bp.EnableMergeQueue,
+		MergeQueueBatchSize:           bp.MergeQueueBatchSize,
 		Created:                       bp.CreatedUnix.AsTime(),
 		Updated:                       bp.UpdatedUnix.AsTime(),
 	}
@@ -526,19 +529,136 @@ func ToActionRunner(ctx context.Context, runner *actions_model.ActionRunner) *ap
 		Busy:      status == runnerv1.RunnerStatus_RUNNER_STATUS_ACTIVE,
 		Ephemeral: runner.Ephemeral,
 		Labels:    labels,
+		GroupID:   runner.GroupID,
+	}
+}
+
+// ToActionRunnerGroup convert actions_model.ActionRunnerGroup to api.ActionRunnerGroup
+func ToActionRunnerGroup(group *actions_model.ActionRunnerGroup) *api.ActionRunnerGroup {
+	return &api.ActionRunnerGroup{
+		ID:            group.ID,
+		Name:          group.Name,
+		Description:   group.Description,
+		AllowAllRepos: group.AllowAllRepos,
+		RepoIDs:       group.RepoIDs,
+		Workflows:     group.Workflows,
+		Created:       group.Created.AsTime(),
+		Updated:       group.Updated.AsTime(),
+	}
+}
+
+// ToRuleset convert git_model.Ruleset to api.Ruleset
+func ToRuleset(rs *git_model.Ruleset) *api.Ruleset {
+	return &api.Ruleset{
+		ID:                      rs.ID,
+		Name:                    rs.Name,
+		Enforcement:             string(rs.Enforcement),
+		RepoNamePatterns:        rs.RepoNamePatterns,
+		RepoTopics:              rs.RepoTopics,
+		RefPatterns:             rs.RefPatterns,
+		BlockCreation:           rs.BlockCreation,
+		BlockDeletion:           rs.BlockDeletion,
+		BlockForcePush:          rs.BlockForcePush,
+		RequirePullRequest:      rs.RequirePullRequest,
+		RequiredApprovals:       rs.RequiredApprovals,
+		BlockOnRejectedReviews:  rs.BlockOnRejectedReviews,
+		BlockOnOutdatedBranch:   rs.BlockOnOutdatedBranch,
+		RequireCodeOwnerReviews: rs.RequireCodeOwnerReviews,
+		RequireSignedCommits:    rs.RequireSignedCommits,
+		StatusCheckContexts:     rs.StatusCheckContexts,
+		BypassUserIDs:           rs.BypassUserIDs,
+		BypassTeamIDs:           rs.BypassTeamIDs,
+		BypassRepoAdmins:        rs.BypassRepoAdmins,
+		Created:                 rs.Created.AsTime(),
+		Updated:                 rs.Updated.AsTime(),
+	}
+}
+
+// ToSecretScanAlert convert git_model.SecretScanAlert to api.SecretScanAlert
+func ToSecretScanAlert(ctx context.Context, alert *git_model.SecretScanAlert, doer *user_model.User) (*api.SecretScanAlert, error) {
+	if err := alert.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	apiAlert := &api.SecretScanAlert{
+		ID:                alert.ID,
+		PatternID:         alert.PatternID,
+		PatternName:       alert.PatternName,
+		RedactedSecret:    alert.RedactedSecret,
+		RefName:           alert.RefName,
+		CommitID:          alert.CommitID,
+		Path:              alert.Path,
+		Line:              alert.Line,
+		Pusher:            ToUser(ctx, alert.Pusher, doer),
+		BypassReason:      alert.BypassReason,
+		State:             string(alert.State),
+		Resolution:        string(alert.Resolution),
+		ResolutionComment: alert.ResolutionComment,
+		Created:           alert.CreatedUnix.AsTime(),
+		Updated:           alert.UpdatedUnix.AsTime(),
+	}
+	if alert.ResolvedBy != nil {
+		apiAlert.ResolvedBy = ToUser(ctx, alert.ResolvedBy, doer)
+		resolved := alert.ResolvedUnix.AsTime()
+		apiAlert.Resolved = &resolved
+	}
+	return apiAlert, nil
+}
+
+// ToSecretScanPattern convert git_model.SecretScanPattern to api.SecretScanPattern
+func ToSecretScanPattern(p *git_model.SecretScanPattern) *api.SecretScanPattern {
+	return &api.SecretScanPattern{
+		ID:      p.ID,
+		Name:    p.Name,
+		Pattern: p.Pattern,
+		Created: p.CreatedUnix.AsTime(),
+	}
+}
+
+// ToX509TrustedCA convert asymkey_model.X509TrustedCA to api.X509TrustedCA
+func ToX509TrustedCA(ca *asymkey_model.X509TrustedCA) (*api.X509TrustedCA, error) {
+	certs, err := ca.Certificates()
+	if err != nil {
+		return nil, err
+	}
+	apiCerts := make([]*api.X509Certificate, len(certs))
+	for i, cert := range certs {
+		info := asymkey_model.NewX509Certificate(cert)
+		apiCerts[i] = &api.X509Certificate{
+			Subject:      info.Subject,
+			Issuer:       info.Issuer,
+			SerialNumber: info.SerialNumber,
+			Fingerprint:  info.Fingerprint,
+			NotBefore:    cert.NotBefore,
+			NotAfter:     cert.NotAfter,
+		}
 	}
+	return &api.X509TrustedCA{
+		ID:           ca.ID,
+		Name:         ca.Name,
+		Content:      ca.Content,
+		Certificates: apiCerts,
+		Created:      ca.CreatedUnix.AsTime(),
+	}, nil
 }
 
 // ToVerification convert a git.Commit.Signature to an api.PayloadCommitVerification
 func ToVerification(ctx context.Context, c *git.Commit) *api.PayloadCommitVerification {
-	verif := asymkey_service.ParseCommitWithSignature(ctx, c)
+	return toPayloadCommitVerification(asymkey_service.ParseCommitWithSignature(ctx, c), c.Signature)
+}
+
+// ToTagVerification convert a git.Tag.Signature to an api.PayloadCommitVerification
+func ToTagVerification(ctx context.Context, t *git.Tag) *api.PayloadCommitVerification {
+	return toPayloadCommitVerification(asymkey_service.ParseTagWithSignature(ctx, t), t.Signature)
+}
+
+func toPayloadCommitVerification(verif *asymkey_model.CommitVerification, signature *git.CommitSignature) *api.PayloadCommitVerification {
 	commitVerification := &api.PayloadCommitVerification{
 		Verified: verif.Verified,
 		Reason:   verif.Reason,
 	}
-	if c.Signature != nil {
-		commitVerification.Signature = c.Signature.Signature
-		commitVerification.Payload = c.Signature.Payload
+	if signature != nil {
+		commitVerification.Signature = signature.Signature
+		commitVerification.Payload = signature.Payload
 	}
 	if verif.SigningUser != nil {
 		commitVerification.Signer = &api.PayloadUser{
@@ -705,7 +825,7 @@ func ToAnnotatedTag(ctx context.Context, repo *repo_model.Repository, t *git.Tag
 		Message:      t.Message,
 		URL:          util.URLJoin(repo.APIURL(), "git/tags", t.ID.String()),
 		Tagger:       ToCommitUser(t.Tagger),
-		Verification: ToVerification(ctx, c),
+		Verification: ToTagVerification(ctx, t),
 	}
 }
 
@@ -5,6 +5,7 @@ package convert
 
 import (
 	"context"
+	"time"
 
 	"code.gitea.io/gitea/models/packages"
 	access_model "code.gitea.io/gitea/models/perm/access"
@@ -51,3 +52,36 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageVulnerability converts a vulnerability finding to an api.PackageVulnerability
+func ToPackageVulnerability(f *packages.PackageVulnerabilityFinding) *api.PackageVulnerability {
+	return &api.PackageVulnerability{
+		ID:                f.Vulnerability.AdvisoryID,
+		Summary:           f.Vulnerability.Summary,
+		Details:           f.Vulnerability.Details,
+		Aliases:           f.Vulnerability.Aliases,
+		Severity:          f.Vulnerability.Severity,
+		DependencyName:    f.DependencyName,
+		DependencyVersion: f.DependencyVersion,
+		PublishedAt:       f.Vulnerability.PublishedUnix.AsTime(),
+		ModifiedAt:        f.Vulnerability.ModifiedUnix.AsTime(),
+	}
+}
+
+// ToPackageDownloadStatistics converts the daily downloads of a package version to api.PackageDownloadStatistics
+func ToPackageDownloadStatistics(pv *packages.PackageVersion, downloads []*packages.PackageVersionDownload) *api.PackageDownloadStatistics {
+	stats := &api.PackageDownloadStatistics{
+		TotalCount: pv.DownloadCount,
+		Days:       make([]*api.PackageDownloadDay, 0, len(downloads)),
+	}
+	if pv.LastDownloadUnix != 0 {
+		stats.LastDownloadedAt = pv.LastDownloadUnix.AsTimePtr()
+	}
+	for _, d := range downloads {
+		stats.Days = append(stats.Days, &api.PackageDownloadDay{
+			Date:  d.DayUnix.AsTimeInLocation(time.UTC).Format(time.DateOnly),
+			Count: d.DownloadCount,
+		})
+	}
+	return stats
+}
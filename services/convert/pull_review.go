@@ -108,10 +108,21 @@ func ToPullReviewCommentList(ctx context.Context, review *issues_model.Review, d
 					HTMLPullURL:  review.Issue.HTMLURL(),
 				}
 
-				if comment.Line < 0 {
+				switch {
+				case comment.IsFileComment():
+					apiComment.SubjectType = "file"
+				case comment.Line < 0:
+					apiComment.SubjectType = "line"
 					apiComment.OldLineNum = comment.UnsignedLine()
-				} else {
+					if comment.IsMultiLine() {
+						apiComment.OldStartLineNum = comment.UnsignedStartLine()
+					}
+				default:
+					apiComment.SubjectType = "line"
 					apiComment.LineNum = comment.UnsignedLine()
+					if comment.IsMultiLine() {
+						apiComment.StartLineNum = comment.UnsignedStartLine()
+					}
 				}
 				apiComments = append(apiComments, apiComment)
 			}
@@ -0,0 +1,36 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToTerraformState converts a TerraformState to API format
+func ToTerraformState(s *terraform_model.TerraformState) *api.TerraformState {
+	result := &api.TerraformState{
+		Name:      s.Name,
+		Version:   s.LatestVersion,
+		Locked:    s.IsLocked(),
+		LockInfo:  s.LockInfo,
+		CreatedAt: s.CreatedUnix.AsTime(),
+		UpdatedAt: s.UpdatedUnix.AsTime(),
+	}
+	if s.IsLocked() {
+		lockedAt := s.LockedUnix.AsTime()
+		result.LockedAt = &lockedAt
+	}
+	return result
+}
+
+// ToTerraformStateVersion converts a TerraformStateVersion to API format
+func ToTerraformStateVersion(v *terraform_model.TerraformStateVersion) *api.TerraformStateVersion {
+	return &api.TerraformStateVersion{
+		Version:   v.Version,
+		Size:      v.Size,
+		SHA256:    v.HashSHA256,
+		CreatedAt: v.CreatedUnix.AsTime(),
+	}
+}
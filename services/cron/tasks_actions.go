@@ -20,6 +20,9 @@ func initActionsTasks() {
 	registerCancelAbandonedJobs()
 	registerScheduleTasks()
 	registerActionsCleanup()
+	if setting.ActionsAutoscaler.Enabled {
+		registerActionsAutoscale()
+	}
 }
 
 func registerStopZombieTasks() {
@@ -74,3 +77,13 @@ func registerActionsCleanup() {
 		return actions_service.Cleanup(ctx)
 	})
 }
+
+func registerActionsAutoscale() {
+	RegisterTaskFatal("actions_autoscale", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 30s",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.Autoscale(ctx)
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	packages_vulnerability_service "code.gitea.io/gitea/services/packages/vulnerability"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
 )
@@ -156,6 +157,16 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerScanPackageVulnerabilities() {
+	RegisterTaskFatal("scan_package_vulnerabilities", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return packages_vulnerability_service.ScanTask(ctx)
+	})
+}
+
 func registerSyncRepoLicenses() {
 	RegisterTaskFatal("sync_repo_licenses", &BaseConfig{
 		Enabled:    false,
@@ -181,6 +192,7 @@ func initBasicTasks() {
 	registerCleanupHookTaskTable()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
+		registerScanPackageVulnerabilities()
 	}
 	registerSyncRepoLicenses()
 }
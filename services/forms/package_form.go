@@ -13,18 +13,48 @@ import (
 )
 
 type PackageCleanupRuleForm struct {
-	ID            int64
-	Enabled       bool
-	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,vagrant)"`
-	KeepCount     int    `binding:"In(0,1,5,10,25,50,100)"`
-	KeepPattern   string `binding:"RegexPattern"`
-	RemoveDays    int    `binding:"In(0,7,14,30,60,90,180)"`
-	RemovePattern string `binding:"RegexPattern"`
-	MatchFullName bool
-	Action        string `binding:"Required;In(save,remove)"`
+	ID                      int64
+	Enabled                 bool
+	Type                    string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,hex,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,terraform,vagrant)"`
+	KeepCount               int    `binding:"In(0,1,5,10,25,50,100)"`
+	KeepPattern             string `binding:"RegexPattern"`
+	RemoveDays              int    `binding:"In(0,7,14,30,60,90,180)"`
+	RemoveNotDownloadedDays int    `binding:"In(0,7,14,30,60,90,180)"`
+	RemovePattern           string `binding:"RegexPattern"`
+	MatchFullName           bool
+	Action                  string `binding:"Required;In(save,remove)"`
 }
 
 func (f *PackageCleanupRuleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// PackageRemoteForm form for creating or editing a remote package registry
+type PackageRemoteForm struct {
+	Enabled     bool
+	Type        string `binding:"Required;In(container,go,maven,npm,pypi)"`
+	URL         string `binding:"Required;ValidUrl;MaxSize(2048)"`
+	Username    string `binding:"MaxSize(255)"`
+	Password    string `binding:"MaxSize(255)"`
+	MetadataTTL int64  `binding:"In(0,60,300,1800,3600,21600,86400)"`
+	Action      string `binding:"Required;In(save,remove)"`
+}
+
+func (f *PackageRemoteForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// PackageVirtualForm form for creating or editing a virtual package repository
+type PackageVirtualForm struct {
+	Enabled bool
+	Type    string `binding:"Required;In(maven,npm,pypi)"`
+	Members string `binding:"MaxSize(4096)"`
+	Action  string `binding:"Required;In(save,remove)"`
+}
+
+func (f *PackageVirtualForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -417,7 +417,9 @@ func (f *WebauthnDeleteForm) Validate(req *http.Request, errs binding.Errors) bi
 // PackageSettingForm form for package settings
 type PackageSettingForm struct {
 	Action string
-	RepoID int64 `form:"repo_id"`
+	RepoID int64  `form:"repo_id"`
+	Owner  string `form:"owner"`
+	Reason string `form:"reason"`
 }
 
 // Validate validates the fields
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -24,6 +25,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/analyze"
 	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/attribute"
 	"code.gitea.io/gitea/modules/highlight"
@@ -82,7 +84,9 @@ type DiffLine struct {
 	Match       int // the diff matched index. -1: no match. 0: plain and no need to match. >0: for add/del, "Lines" slice index of the other side
 	Type        DiffLineType
 	Content     string
-	Comments    issues_model.CommentList // related PR code comments
+	Comments    issues_model.CommentList              // related PR code comments
+	InComment   bool                                  // the line is one of the lines of a multi-line PR code comment
+	Annotations []*actions_model.ActionTaskAnnotation // annotations reported by the actions tasks of the after commit
 	SectionInfo *DiffLineSectionInfo
 }
 
@@ -337,6 +341,7 @@ type DiffFile struct {
 
 	// will be filled by route handler
 	IsProtected bool
+	Comments    issues_model.CommentList // PR code comments on the whole file
 
 	// will be filled by SyncUserSpecificDiff
 	IsViewed                  bool // User specific
@@ -454,7 +459,7 @@ type Diff struct {
 	NumViewedFiles int // user-specific
 }
 
-// LoadComments loads comments into each line
+// LoadComments loads comments into each line, and the comments on a whole file into the file
 func (diff *Diff) LoadComments(ctx context.Context, issue *issues_model.Issue, currentUser *user_model.User, showOutdatedComments bool) error {
 	allComments, err := issues_model.FetchCodeComments(ctx, issue, currentUser, showOutdatedComments)
 	if err != nil {
@@ -462,14 +467,42 @@ func (diff *Diff) LoadComments(ctx context.Context, issue *issues_model.Issue, c
 	}
 	for _, file := range diff.Files {
 		if lineCommits, ok := allComments[file.Name]; ok {
+			file.Comments = lineCommits[0]
+
+			// the signed lines which are covered by a multi-line comment
+			var ranges [][2]int64
+			for line, comments := range lineCommits {
+				for _, comment := range comments {
+					if comment.IsMultiLine() {
+						ranges = append(ranges, [2]int64{comment.StartLine, line})
+					}
+				}
+			}
+			inRange := func(line int64) bool {
+				for _, r := range ranges {
+					if line != 0 && min(r[0], r[1]) <= line && line <= max(r[0], r[1]) {
+						return true
+					}
+				}
+				return false
+			}
+
 			for _, section := range file.Sections {
 				for _, line := range section.Lines {
-					if comments, ok := lineCommits[int64(line.LeftIdx*-1)]; ok {
-						line.Comments = append(line.Comments, comments...)
+					if line.Type == DiffLineSection {
+						continue
+					}
+					if line.LeftIdx > 0 {
+						if comments, ok := lineCommits[int64(line.LeftIdx*-1)]; ok {
+							line.Comments = append(line.Comments, comments...)
+						}
 					}
-					if comments, ok := lineCommits[int64(line.RightIdx)]; ok {
-						line.Comments = append(line.Comments, comments...)
+					if line.RightIdx > 0 {
+						if comments, ok := lineCommits[int64(line.RightIdx)]; ok {
+							line.Comments = append(line.Comments, comments...)
+						}
 					}
+					line.InComment = inRange(int64(line.LeftIdx*-1)) || inRange(int64(line.RightIdx))
 					sort.SliceStable(line.Comments, func(i, j int) bool {
 						return line.Comments[i].CreatedUnix < line.Comments[j].CreatedUnix
 					})
@@ -480,6 +513,55 @@ func (diff *Diff) LoadComments(ctx context.Context, issue *issues_model.Issue, c
 	return nil
 }
 
+// LoadActionsAnnotations attaches the annotations reported by the actions tasks of the after commit to the added or unchanged lines
+func (diff *Diff) LoadActionsAnnotations(ctx context.Context, repoID int64, afterCommitID string) error {
+	annotations, err := db.Find[actions_model.ActionTaskAnnotation](ctx, actions_model.FindTaskAnnotationsOptions{
+		RepoID:    repoID,
+		CommitSHA: afterCommitID,
+		HasPath:   true,
+	})
+	if err != nil {
+		return err
+	}
+
+	type annotationKey struct {
+		level   actions_model.AnnotationLevel
+		line    int
+		message string
+	}
+	// the same annotations are reported again when a job is rerun
+	fileAnnotations := make(map[string]map[int][]*actions_model.ActionTaskAnnotation)
+	seen := make(map[string]container.Set[annotationKey])
+	for _, a := range annotations {
+		if a.Line <= 0 {
+			continue
+		}
+		if seen[a.Path] == nil {
+			seen[a.Path] = make(container.Set[annotationKey])
+			fileAnnotations[a.Path] = make(map[int][]*actions_model.ActionTaskAnnotation)
+		}
+		if !seen[a.Path].Add(annotationKey{a.Level, a.Line, a.Message}) {
+			continue
+		}
+		fileAnnotations[a.Path][a.Line] = append(fileAnnotations[a.Path][a.Line], a)
+	}
+
+	for _, file := range diff.Files {
+		lineAnnotations, ok := fileAnnotations[file.Name]
+		if !ok {
+			continue
+		}
+		for _, section := range file.Sections {
+			for _, line := range section.Lines {
+				if line.RightIdx > 0 && line.Type != DiffLineSection {
+					line.Annotations = lineAnnotations[line.RightIdx]
+				}
+			}
+		}
+	}
+	return nil
+}
+
 const cmdDiffHead = "diff --git "
 
 // ParsePatch builds a Diff object from a io.Reader and some parameters.
@@ -1374,12 +1456,24 @@ func CommentAsDiff(ctx context.Context, c *issues_model.Comment) (*Diff, error)
 	if len(secs) == 0 {
 		return nil, fmt.Errorf("no sections found for comment ID: %d", c.ID)
 	}
+	if c.IsMultiLine() {
+		start, end := int(c.UnsignedStartLine()), int(c.UnsignedLine())
+		for _, sec := range secs {
+			for _, line := range sec.Lines {
+				idx := line.RightIdx
+				if c.Line < 0 {
+					idx = line.LeftIdx
+				}
+				line.InComment = line.Type != DiffLineSection && start <= idx && idx <= end
+			}
+		}
+	}
 	return diff, nil
 }
 
 // CommentMustAsDiff executes AsDiff and logs the error instead of returning
 func CommentMustAsDiff(ctx context.Context, c *issues_model.Comment) *Diff {
-	if c == nil {
+	if c == nil || c.IsFileComment() {
 		return nil
 	}
 	defer func() {
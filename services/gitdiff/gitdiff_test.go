@@ -585,6 +585,37 @@ func TestDiff_LoadCommentsWithOutdated(t *testing.T) {
 	assert.Len(t, diff.Files[0].Sections[0].Lines[0].Comments, 3)
 }
 
+func TestDiff_LoadCommentsRangeAndFile(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	for _, c := range []*issues_model.Comment{
+		{Line: 0, Content: "this file shouldn't be committed"},
+		{Line: 7, StartLine: 5, Content: "extract these lines"},
+	} {
+		c.Type, c.PosterID, c.IssueID, c.TreePath = issues_model.CommentTypeCode, user.ID, issue.ID, "README.md"
+		assert.NoError(t, db.Insert(db.DefaultContext, c))
+	}
+
+	diff := setupDefaultDiff()
+	lines := diff.Files[0].Sections[0].Lines
+	for idx := 5; idx <= 8; idx++ {
+		lines = append(lines, &DiffLine{RightIdx: idx, Type: DiffLineAdd})
+	}
+	diff.Files[0].Sections[0].Lines = lines
+	assert.NoError(t, diff.LoadComments(db.DefaultContext, issue, user, false))
+
+	if assert.Len(t, diff.Files[0].Comments, 1) {
+		assert.True(t, diff.Files[0].Comments[0].IsFileComment())
+	}
+	assert.Len(t, lines[0].Comments, 2, "a comment on the file is not a comment on the added lines")
+	for i, inComment := range []bool{false, true, true, true, false} {
+		assert.Equal(t, inComment, lines[i].InComment, "line %d", lines[i].RightIdx)
+	}
+	assert.Len(t, lines[3].Comments, 1, "a multi-line comment is shown at its last line")
+}
+
 func TestDiffLine_CanComment(t *testing.T) {
 	assert.False(t, (&DiffLine{Type: DiffLineSection}).CanComment())
 	assert.False(t, (&DiffLine{Type: DiffLineAdd, Comments: []*issues_model.Comment{{Content: "bla"}}}).CanComment())
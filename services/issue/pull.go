@@ -4,14 +4,17 @@
 package issue
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"time"
 
 	issues_model "code.gitea.io/gitea/models/issues"
 	org_model "code.gitea.io/gitea/models/organization"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/cache"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/log"
@@ -78,52 +81,11 @@ func PullRequestCodeOwnersReview(ctx context.Context, pr *issues_model.PullReque
 		return nil, err
 	}
 
-	var data string
-	for _, file := range codeOwnerFiles {
-		if blob, err := commit.GetBlobByPath(file); err == nil {
-			data, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize)
-			if err == nil {
-				break
-			}
-		}
-	}
-	if data == "" {
-		return nil, nil
-	}
-
-	rules, _ := issues_model.GetCodeOwnersFromContent(ctx, data)
-	if len(rules) == 0 {
-		return nil, nil
-	}
-
-	// get the mergebase
-	mergeBase, err := getMergeBase(repo, pr, git.BranchPrefix+pr.BaseBranch, pr.GetGitHeadRefName())
-	if err != nil {
+	uniqUsers, uniqTeams, err := getCodeOwnersOfChangedFiles(ctx, repo, pr, commit)
+	if err != nil || (len(uniqUsers) == 0 && len(uniqTeams) == 0) {
 		return nil, err
 	}
 
-	// https://github.com/go-gitea/gitea/issues/29763, we need to get the files changed
-	// between the merge base and the head commit but not the base branch and the head commit
-	changedFiles, err := repo.GetFilesChangedBetween(mergeBase, pr.GetGitHeadRefName())
-	if err != nil {
-		return nil, err
-	}
-
-	uniqUsers := make(map[int64]*user_model.User)
-	uniqTeams := make(map[string]*org_model.Team)
-	for _, rule := range rules {
-		for _, f := range changedFiles {
-			if (rule.Rule.MatchString(f) && !rule.Negative) || (!rule.Rule.MatchString(f) && rule.Negative) {
-				for _, u := range rule.Users {
-					uniqUsers[u.ID] = u
-				}
-				for _, t := range rule.Teams {
-					uniqTeams[fmt.Sprintf("%d/%d", t.OrgID, t.ID)] = t
-				}
-			}
-		}
-	}
-
 	notifiers := make([]*ReviewRequestNotifier, 0, len(uniqUsers)+len(uniqTeams))
 
 	if err := issue.LoadPoster(ctx); err != nil {
@@ -181,3 +143,138 @@ func PullRequestCodeOwnersReview(ctx context.Context, pr *issues_model.PullReque
 
 	return notifiers, nil
 }
+
+// getCodeOwnersOfChangedFiles returns the users and teams owning the files changed by the pull request,
+// the rules are read from the CODEOWNERS file in the commit
+func getCodeOwnersOfChangedFiles(ctx context.Context, repo *git.Repository, pr *issues_model.PullRequest, commit *git.Commit) (map[int64]*user_model.User, map[string]*org_model.Team, error) {
+	var data string
+	for _, file := range codeOwnerFiles {
+		if blob, err := commit.GetBlobByPath(file); err == nil {
+			data, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize)
+			if err == nil {
+				break
+			}
+		}
+	}
+	if data == "" {
+		return nil, nil, nil
+	}
+
+	rules, _ := issues_model.GetCodeOwnersFromContent(ctx, data)
+	if len(rules) == 0 {
+		return nil, nil, nil
+	}
+
+	// get the mergebase
+	mergeBase, err := getMergeBase(repo, pr, git.BranchPrefix+pr.BaseBranch, pr.GetGitHeadRefName())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// https://github.com/go-gitea/gitea/issues/29763, we need to get the files changed
+	// between the merge base and the head commit but not the base branch and the head commit
+	changedFiles, err := repo.GetFilesChangedBetween(mergeBase, pr.GetGitHeadRefName())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uniqUsers := make(map[int64]*user_model.User)
+	uniqTeams := make(map[string]*org_model.Team)
+	for _, rule := range rules {
+		for _, f := range changedFiles {
+			if (rule.Rule.MatchString(f) && !rule.Negative) || (!rule.Rule.MatchString(f) && rule.Negative) {
+				for _, u := range rule.Users {
+					uniqUsers[u.ID] = u
+				}
+				for _, t := range rule.Teams {
+					uniqTeams[fmt.Sprintf("%d/%d", t.OrgID, t.ID)] = t
+				}
+			}
+		}
+	}
+	return uniqUsers, uniqTeams, nil
+}
+
+// codeOwnersCacheTTL is the lifetime of the cached code owners of a pull request, the cache key contains the commits
+// they are computed from, so the entries never need to be invalidated
+const codeOwnersCacheTTL = 24 * 60 * 60
+
+// cachedCodeOwners are the IDs of the code owners of a pull request stored in the cache
+type cachedCodeOwners struct {
+	UserIDs []int64
+	TeamIDs []int64
+}
+
+func getCodeOwnersCacheKey(pr *issues_model.PullRequest, baseCommitID, headCommitID string) string {
+	return fmt.Sprintf("pull_code_owners:%d:%s:%s", pr.ID, baseCommitID, headCommitID)
+}
+
+// GetPullRequestCodeOwners returns the users and teams owning the files changed by the pull request,
+// according to the CODEOWNERS file of its base branch. The result is cached for the base and head commits.
+func GetPullRequestCodeOwners(ctx context.Context, pr *issues_model.PullRequest) ([]*user_model.User, []*org_model.Team, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer repo.Close()
+
+	commit, err := repo.GetBranchCommit(pr.BaseBranch)
+	if err != nil {
+		return nil, nil, err
+	}
+	headCommitID, err := repo.GetRefCommitID(pr.GetGitHeadRefName())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := getCodeOwnersCacheKey(pr, commit.ID.String(), headCommitID)
+	cc := cache.GetCache()
+	if cc != nil {
+		cached := &cachedCodeOwners{}
+		if exist, err := cc.GetJSON(key, cached); exist && err == nil {
+			return loadCodeOwners(ctx, cached)
+		}
+	}
+
+	uniqUsers, uniqTeams, err := getCodeOwnersOfChangedFiles(ctx, repo, pr, commit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := slices.SortedFunc(maps.Values(uniqUsers), func(a, b *user_model.User) int { return cmp.Compare(a.ID, b.ID) })
+	teams := slices.SortedFunc(maps.Values(uniqTeams), func(a, b *org_model.Team) int { return cmp.Compare(a.ID, b.ID) })
+
+	if cc != nil {
+		cached := &cachedCodeOwners{UserIDs: make([]int64, 0, len(users)), TeamIDs: make([]int64, 0, len(teams))}
+		for _, u := range users {
+			cached.UserIDs = append(cached.UserIDs, u.ID)
+		}
+		for _, t := range teams {
+			cached.TeamIDs = append(cached.TeamIDs, t.ID)
+		}
+		if err := cc.PutJSON(key, cached, codeOwnersCacheTTL); err != nil {
+			log.Warn("Unable to cache the code owners of %-v: %v", pr, err)
+		}
+	}
+	return users, teams, nil
+}
+
+// loadCodeOwners loads the cached code owners, the deleted users and teams are skipped
+func loadCodeOwners(ctx context.Context, cached *cachedCodeOwners) ([]*user_model.User, []*org_model.Team, error) {
+	users, err := user_model.GetUsersByIDs(ctx, cached.UserIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	slices.SortFunc(users, func(a, b *user_model.User) int { return cmp.Compare(a.ID, b.ID) })
+
+	teamsByID, err := org_model.GetTeamsByIDs(ctx, cached.TeamIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	teams := slices.SortedFunc(maps.Values(teamsByID), func(a, b *org_model.Team) int { return cmp.Compare(a.ID, b.ID) })
+	return users, teams, nil
+}
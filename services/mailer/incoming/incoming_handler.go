@@ -122,6 +122,7 @@ func (h *ReplyHandler) Handle(ctx context.Context, content *MailContent, doer *u
 				nil,
 				issue,
 				comment.Line,
+				comment.StartLine,
 				content.Content,
 				comment.TreePath,
 				false, // not pending review but a single review
@@ -0,0 +1,524 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+	pull_service "code.gitea.io/gitea/services/pull"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// The reasons why a pull request is removed from the merge queue, they are stored in the content of the comment
+const (
+	RemovedReasonCanceled     = "canceled"
+	RemovedReasonHeadUpdated  = "head_updated"
+	RemovedReasonBaseChanged  = "base_changed"
+	RemovedReasonClosed       = "closed"
+	RemovedReasonConflicts    = "conflicts"
+	RemovedReasonChecksFailed = "checks_failed"
+	RemovedReasonMergeFailed  = "merge_failed"
+	RemovedReasonDisabled     = "disabled"
+	RemovedReasonNoChecks     = "no_checks"
+)
+
+// maxMergeGroups is the maximum number of merge groups tested at the same time,
+// every merge group is tested speculatively on top of the combined commit of the one ahead of it
+const maxMergeGroups = 3
+
+// ErrNoRequiredChecks represents an error if the base branch of a pull request added to the merge queue requires no status checks
+var ErrNoRequiredChecks = util.NewInvalidArgumentErrorf("the merge queue requires status checks on the branch")
+
+// hasRequiredChecks returns true if the merge groups of the branch protected by the rule have to pass status checks
+func hasRequiredChecks(pb *git_model.ProtectedBranch) bool {
+	return pb != nil && pb.EnableStatusCheck
+}
+
+var mergeQueue *queue.WorkerPoolQueue[string]
+
+// Init runs the task queue that handles merge queues
+func Init() error {
+	notify_service.RegisterNotifier(NewNotifier())
+
+	mergeQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "pr_merge_queue", handler)
+	if mergeQueue == nil {
+		return errors.New("unable to create pr_merge_queue queue")
+	}
+	go graceful.GetManager().RunWithCancel(mergeQueue)
+	return nil
+}
+
+// handle passed repo ID and branch pairs and process their merge queues
+func handler(items ...string) []string {
+	for _, s := range items {
+		id, branch, _ := strings.Cut(s, "_")
+		repoID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || branch == "" {
+			log.Error("could not parse data from pr_merge_queue queue (%v): %v", s, err)
+			continue
+		}
+		handleMergeQueue(repoID, branch)
+	}
+	return nil
+}
+
+func addToQueue(repoID int64, branch string) {
+	log.Trace("Adding merge queue of branch %s in repo %d to the pr_merge_queue queue", branch, repoID)
+	if err := mergeQueue.Push(fmt.Sprintf("%d_%s", repoID, branch)); err != nil {
+		log.Error("Error adding merge queue of branch %s in repo %d to the pr_merge_queue queue: %v", branch, repoID, err)
+	}
+}
+
+// AddToMergeQueue appends the pull request to the merge queue of its base branch, it will be merged when
+// the checks of the merge group it's tested in succeed
+func AddToMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, style repo_model.MergeStyle, message string, deleteBranchAfterMerge bool) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	prUnit, err := pr.BaseRepo.GetUnit(ctx, unit.TypePullRequests)
+	if err != nil {
+		return err
+	}
+	if !prUnit.PullRequestsConfig().IsMergeStyleAllowed(style) {
+		return pull_service.ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: style}
+	}
+	// without required checks nothing would be tested before merging
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return err
+	} else if !hasRequiredChecks(pb) {
+		return ErrNoRequiredChecks
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+	if err != nil {
+		return err
+	}
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.AddToMergeQueue(ctx, &pull_model.MergeQueueEntry{
+			RepoID:                 pr.BaseRepoID,
+			BaseBranch:             pr.BaseBranch,
+			PullID:                 pr.ID,
+			DoerID:                 doer.ID,
+			MergeStyle:             style,
+			Message:                message,
+			DeleteBranchAfterMerge: deleteBranchAfterMerge,
+			HeadCommitID:           headCommitID,
+		}); err != nil {
+			return err
+		}
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRAddedToMergeQueue, pr, doer, "")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Trace("Pull request [%d] added to the merge queue with style [%s] and message [%s]", pr.ID, style, message)
+	addToQueue(pr.BaseRepoID, pr.BaseBranch)
+	return nil
+}
+
+// CanRemoveFromMergeQueue returns true if the user is allowed to remove the pull request from the merge queue,
+// which is the user who added it or a user allowed to merge it
+func CanRemoveFromMergeQueue(ctx context.Context, doer *user_model.User, perm access_model.Permission, pr *issues_model.PullRequest, entry *pull_model.MergeQueueEntry) (bool, error) {
+	if doer.ID == entry.DoerID {
+		return true, nil
+	}
+	return pull_service.IsUserAllowedToMerge(ctx, pr, perm, doer)
+}
+
+// RemoveFromMergeQueue removes the pull request from the merge queue,
+// the other pull requests of the merge group it's being tested in will be tested again without it
+func RemoveFromMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, reason string) error {
+	exists, entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		return err
+	} else if !exists {
+		return util.NewNotExistErrorf("pull request %d is not in the merge queue", pr.ID)
+	}
+
+	// the merge group of the entry and the ones behind it contain its commits, so they have to be tested again
+	var groups [][]*pull_model.MergeQueueEntry
+	if entry.IsTesting() {
+		entries, err := pull_model.GetMergeQueue(ctx, entry.RepoID, entry.BaseBranch)
+		if err != nil {
+			return err
+		}
+		groups = pull_model.GetTestingMergeGroups(entries)
+		for len(groups) > 0 && groups[0][0].GroupHeadCommitID != entry.GroupHeadCommitID {
+			groups = groups[1:]
+		}
+	}
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		if deleted, err := pull_model.DeleteMergeQueueEntry(ctx, pr.ID); err != nil {
+			return err
+		} else if !deleted {
+			// it has been merged or removed by others
+			return nil
+		}
+
+		for _, group := range groups {
+			if err := pull_model.ResetMergeGroup(ctx, group); err != nil {
+				return err
+			}
+		}
+
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRRemovedFromMergeQueue, pr, doer, reason)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(groups) > 0 {
+		if err := pr.LoadBaseRepo(ctx); err != nil {
+			return err
+		}
+		for _, group := range groups {
+			removeMergeGroupRef(ctx, pr.BaseRepo, group[0].GroupRefName())
+		}
+		addToQueue(entry.RepoID, entry.BaseBranch)
+	}
+	return nil
+}
+
+func removeMergeGroupRef(ctx context.Context, repo *repo_model.Repository, ref string) {
+	if repo == nil {
+		return
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		log.Error("OpenRepository: %v", err)
+		return
+	}
+	defer gitRepo.Close()
+	if err := gitRepo.RemoveReference(ref); err != nil {
+		log.Error("RemoveReference %s in %-v: %v", ref, repo, err)
+	}
+}
+
+// evict removes the entry from the merge queue with a comment by the user who added it
+func evict(ctx context.Context, entry *pull_model.MergeQueueEntry, reason string) error {
+	pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+	if err != nil {
+		return err
+	}
+	if err := entry.LoadDoer(ctx); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := pull_model.DeleteMergeQueueEntry(ctx, entry.PullID); err != nil {
+			return err
+		}
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRRemovedFromMergeQueue, pr, entry.Doer, reason)
+		return err
+	})
+}
+
+// handleMergeQueue processes the merge queue of the branch until it needs to wait for the checks of a merge group
+func handleMergeQueue(repoID int64, branch string) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(),
+		fmt.Sprintf("Handle merge queue of branch %s in repo %d", branch, repoID))
+	defer finished()
+
+	releaser, err := globallock.Lock(ctx, fmt.Sprintf("merge_queue_%d_%s", repoID, branch))
+	if err != nil {
+		log.Error("lock.Lock(): %v", err)
+		return
+	}
+	defer releaser()
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		log.Error("GetRepositoryByID[%d]: %v", repoID, err)
+		return
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		log.Error("OpenRepository: %v", err)
+		return
+	}
+	defer gitRepo.Close()
+
+	for {
+		again, err := processMergeQueue(ctx, repo, gitRepo, branch)
+		if err != nil {
+			log.Error("Merge queue of branch %s in %-v: %v", branch, repo, err)
+			return
+		}
+		if !again {
+			return
+		}
+	}
+}
+
+// processMergeQueue finishes the merge group at the head of the queue or starts a new one,
+// it returns true if the queue should be processed again
+func processMergeQueue(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, branch string) (bool, error) {
+	entries, err := pull_model.GetMergeQueue(ctx, repo.ID, branch)
+	if err != nil || len(entries) == 0 {
+		return false, err
+	}
+	groups := pull_model.GetTestingMergeGroups(entries)
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, branch)
+	if err != nil {
+		return false, err
+	}
+	if pb == nil || !pb.EnableMergeQueue || !hasRequiredChecks(pb) {
+		reason := RemovedReasonDisabled
+		if pb != nil && pb.EnableMergeQueue {
+			reason = RemovedReasonNoChecks
+		}
+		for _, entry := range entries {
+			if err := evict(ctx, entry, reason); err != nil {
+				return false, err
+			}
+		}
+		for _, group := range groups {
+			removeMergeGroupRef(ctx, repo, group[0].GroupRefName())
+		}
+		return false, nil
+	}
+
+	if len(groups) == 0 {
+		return startMergeGroup(ctx, repo, gitRepo, branch, entries, int(pb.MergeQueueBatchSize), nil)
+	}
+	group := groups[0]
+
+	baseCommitID, err := gitRepo.GetBranchCommitID(branch)
+	if err != nil {
+		return false, err
+	}
+	if baseCommitID != group[0].GroupBaseCommitID {
+		// the branch has been updated outside of the queue, so the tested commits are out of date
+		return true, resetMergeGroups(ctx, repo, groups)
+	}
+
+	statuses, err := git_model.GetLatestCommitStatus(ctx, repo.ID, group[0].GroupHeadCommitID, db.ListOptionsAll)
+	if err != nil {
+		return false, err
+	}
+	state := pull_service.MergeRequiredContextsCommitStatus(statuses, pb.StatusCheckContexts)
+
+	switch {
+	case state.IsPending():
+		// test the next queued pull requests on top of the groups being tested, assuming they will succeed
+		testing := 0
+		for _, g := range groups {
+			testing += len(g)
+		}
+		if len(groups) >= maxMergeGroups || testing == len(entries) {
+			return false, nil
+		}
+		return startMergeGroup(ctx, repo, gitRepo, branch, entries[testing:], int(pb.MergeQueueBatchSize), groups[len(groups)-1])
+	case state.IsSuccess():
+		defer removeMergeGroupRef(ctx, repo, group[0].GroupRefName())
+		return true, landMergeGroup(ctx, repo, groups)
+	}
+
+	// the groups behind the failed one contain its commits, so they have to be tested again
+	if err := resetMergeGroups(ctx, repo, groups[1:]); err != nil {
+		return false, err
+	}
+	defer removeMergeGroupRef(ctx, repo, group[0].GroupRefName())
+	if len(group) == 1 {
+		return true, evict(ctx, group[0], RemovedReasonChecksFailed)
+	}
+	// split the failed group to find out the failing pull request
+	if err := pull_model.ResetMergeGroup(ctx, group); err != nil {
+		return false, err
+	}
+	return startMergeGroup(ctx, repo, gitRepo, branch, entries, len(group)/2, nil)
+}
+
+// resetMergeGroups puts the entries of the merge groups back to the queue
+func resetMergeGroups(ctx context.Context, repo *repo_model.Repository, groups [][]*pull_model.MergeQueueEntry) error {
+	for _, group := range groups {
+		if err := pull_model.ResetMergeGroup(ctx, group); err != nil {
+			return err
+		}
+		removeMergeGroupRef(ctx, repo, group[0].GroupRefName())
+	}
+	return nil
+}
+
+// startMergeGroup creates the combined commit of the first entries and requests the checks for it,
+// the commit is based on the combined commit of the parent group if given, otherwise on the branch.
+// It returns true if an entry conflicts and was removed, or the checks of the group have finished already
+func startMergeGroup(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, branch string, entries []*pull_model.MergeQueueEntry, size int, parent []*pull_model.MergeQueueEntry) (bool, error) {
+	size = max(min(size, len(entries)), 1)
+	entries = entries[:size]
+
+	pulls := make([]*pull_service.MergeGroupPull, 0, size)
+	for _, entry := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			return false, err
+		}
+		message := entry.Message
+		if message == "" && entry.MergeStyle != repo_model.MergeStyleFastForwardOnly {
+			title, body, err := pull_service.GetDefaultMergeMessage(ctx, gitRepo, pr, entry.MergeStyle)
+			if err != nil {
+				return false, err
+			}
+			message = strings.TrimSpace(title + "\n\n" + body)
+		}
+		pulls = append(pulls, &pull_service.MergeGroupPull{PR: pr, MergeStyle: entry.MergeStyle, Message: message})
+	}
+
+	if err := entries[0].LoadDoer(ctx); err != nil {
+		return false, err
+	}
+	group := &pull_model.MergeGroup{
+		RepoID:     repo.ID,
+		BaseBranch: branch,
+		Ref:        entries[0].GroupRefName(),
+		Entries:    entries,
+	}
+
+	baseRef := ""
+	if len(parent) > 0 {
+		baseRef = parent[0].GroupRefName()
+	}
+	var err error
+	group.BaseCommitID, err = pull_service.CreateMergeGroup(ctx, entries[0].Doer, pulls, baseRef, group.Ref)
+	if err != nil {
+		var conflictsErr pull_service.ErrMergeGroupConflicts
+		if errors.As(err, &conflictsErr) {
+			return true, evict(ctx, entries[conflictsErr.Index], RemovedReasonConflicts)
+		}
+		return false, err
+	}
+	if len(parent) > 0 && group.BaseCommitID != parent[0].GroupHeadCommitID {
+		// the parent group has been changed meanwhile
+		removeMergeGroupRef(ctx, repo, group.Ref)
+		return true, nil
+	}
+	for i, entry := range entries {
+		entry.GroupCommitID = pulls[i].CommitID
+	}
+	group.HeadCommitID = pulls[len(pulls)-1].CommitID
+
+	if err := pull_model.StartMergeGroup(ctx, group); err != nil {
+		return false, err
+	}
+	notify_service.MergeGroupChecksRequested(ctx, entries[0].Doer, repo, group)
+
+	// the checks could have finished already, e.g. the same commit has been tested before
+	return isMergeGroupChecked(ctx, repo.ID, group)
+}
+
+func isMergeGroupChecked(ctx context.Context, repoID int64, group *pull_model.MergeGroup) (bool, error) {
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repoID, group.BaseBranch)
+	if err != nil || !hasRequiredChecks(pb) {
+		// the entries will be removed from the queue
+		return err == nil, err
+	}
+	statuses, err := git_model.GetLatestCommitStatus(ctx, repoID, group.HeadCommitID, db.ListOptionsAll)
+	if err != nil {
+		return false, err
+	}
+	return !pull_service.MergeRequiredContextsCommitStatus(statuses, pb.StatusCheckContexts).IsPending(), nil
+}
+
+// landMergeGroup merges the pull requests of the first merge group whose checks succeeded by fast-forwarding the branch
+// to their tested commits one after another, the following groups are reset if it can't be landed completely
+func landMergeGroup(ctx context.Context, repo *repo_model.Repository, groups [][]*pull_model.MergeQueueEntry) error {
+	group := groups[0]
+	baseCommitID := group[0].GroupBaseCommitID
+	for i, entry := range group {
+		if exists, current, err := pull_model.GetMergeQueueEntryByPullID(ctx, entry.PullID); err != nil {
+			return err
+		} else if !exists || !current.IsTesting() || current.GroupHeadCommitID != entry.GroupHeadCommitID {
+			// an entry has been removed while merging the ones before it, the rest have been reset
+			return nil
+		}
+
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			return err
+		}
+		if err := entry.LoadDoer(ctx); err != nil {
+			return err
+		}
+
+		err = pull_service.LandMergeGroupPull(ctx, pr, entry.Doer, entry.HeadCommitID, baseCommitID, entry.GroupCommitID)
+		if err != nil {
+			log.Warn("Unable to merge %-v of the merge queue: %v", pr, err)
+			rest := group[i+1:]
+			if git.IsErrPushOutOfDate(err) {
+				// the branch has been updated outside of the queue, the rest have to be tested again
+				rest = group[i:]
+			} else {
+				reason := RemovedReasonMergeFailed
+				if pull_service.IsErrSHADoesNotMatch(err) {
+					reason = RemovedReasonHeadUpdated
+				}
+				if err := evict(ctx, entry, reason); err != nil {
+					return err
+				}
+			}
+			if err := pull_model.ResetMergeGroup(ctx, rest); err != nil {
+				return err
+			}
+			return resetMergeGroups(ctx, repo, groups[1:])
+		}
+		// the post receive hook has removed the entry when marking the pull request merged
+		baseCommitID = entry.GroupCommitID
+		deleteHeadBranch(ctx, pr, entry)
+	}
+	return nil
+}
+
+func deleteHeadBranch(ctx context.Context, pr *issues_model.PullRequest, entry *pull_model.MergeQueueEntry) {
+	if pr.Flow != issues_model.PullRequestFlowGithub || !entry.DeleteBranchAfterMerge {
+		return
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		log.Error("%-v LoadHeadRepo: %v", pr, err)
+		return
+	} else if pr.HeadRepo == nil {
+		return
+	}
+	headGitRepo, err := gitrepo.OpenRepository(ctx, pr.HeadRepo)
+	if err != nil {
+		log.Error("OpenRepository %-v: %v", pr.HeadRepo, err)
+		return
+	}
+	defer headGitRepo.Close()
+	if err := repo_service.DeleteBranch(ctx, entry.Doer, pr.HeadRepo, headGitRepo, pr.HeadBranch, pr); err != nil {
+		log.Error("DeletePullRequestHeadBranch: %v", err)
+	}
+}
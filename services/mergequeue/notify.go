@@ -0,0 +1,78 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+type mergeQueueNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &mergeQueueNotifier{}
+
+// NewNotifier create a new mergeQueueNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &mergeQueueNotifier{}
+}
+
+func (n *mergeQueueNotifier) CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus) {
+	entries, err := pull_model.GetMergeQueueEntriesByGroupHead(ctx, repo.ID, commit.Sha1)
+	if err != nil {
+		log.Error("GetMergeQueueEntriesByGroupHead[repo_id: %d, sha: %s]: %v", repo.ID, commit.Sha1, err)
+		return
+	}
+	if len(entries) > 0 {
+		addToQueue(repo.ID, entries[0].BaseBranch)
+	}
+}
+
+func (n *mergeQueueNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	// the branch has been updated outside of the queue, the merge group being tested is out of date
+	entries, err := pull_model.GetMergeQueue(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		log.Error("GetMergeQueue[repo_id: %d, branch: %s]: %v", pr.BaseRepoID, pr.BaseBranch, err)
+		return
+	}
+	if len(entries) > 0 {
+		addToQueue(pr.BaseRepoID, pr.BaseBranch)
+	}
+}
+
+func (n *mergeQueueNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	removeFromMergeQueue(ctx, doer, pr, RemovedReasonHeadUpdated)
+}
+
+func (n *mergeQueueNotifier) PullRequestChangeTargetBranch(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, oldBranch string) {
+	removeFromMergeQueue(ctx, doer, pr, RemovedReasonBaseChanged)
+}
+
+func (n *mergeQueueNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, isClosed bool) {
+	if !issue.IsPull || !isClosed {
+		return
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		log.Error("LoadPullRequest: %v", err)
+		return
+	}
+	removeFromMergeQueue(ctx, doer, issue.PullRequest, RemovedReasonClosed)
+}
+
+func removeFromMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, reason string) {
+	if err := RemoveFromMergeQueue(ctx, doer, pr, reason); err != nil && !errors.Is(err, util.ErrNotExist) {
+		log.Error("RemoveFromMergeQueue %-v: %v", pr, err)
+	}
+}
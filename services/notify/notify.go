@@ -10,6 +10,7 @@ import (
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	packages_model "code.gitea.io/gitea/models/packages"
+	pull_model "code.gitea.io/gitea/models/pull"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -169,6 +170,13 @@ func PullReviewDismiss(ctx context.Context, doer *user_model.User, review *issue
 	}
 }
 
+// MergeGroupChecksRequested notifies when the combined commit of a merge group needs to be checked
+func MergeGroupChecksRequested(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, group *pull_model.MergeGroup) {
+	for _, notifier := range notifiers {
+		notifier.MergeGroupChecksRequested(ctx, doer, repo, group)
+	}
+}
+
 // UpdateComment notifies update comment to notifiers
 func UpdateComment(ctx context.Context, doer *user_model.User, c *issues_model.Comment, oldContent string) {
 	if !shouldSendCommentChangeNotification(ctx, c) {
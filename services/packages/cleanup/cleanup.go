@@ -34,6 +34,7 @@ func CleanupTask(ctx context.Context, olderThan time.Duration) error {
 
 func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package) (versionDeleted bool, err error) {
 	olderThan := time.Now().AddDate(0, 0, -pcr.RemoveDays)
+	notDownloadedSince := time.Now().AddDate(0, 0, -pcr.RemoveNotDownloadedDays)
 	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
 		PackageID:  p.ID,
 		IsInternal: optional.Some(false),
@@ -70,6 +71,10 @@ func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.Packa
 			log.Debug("Rule[%d]: keep '%s/%s' (remove days) %v", pcr.ID, p.Name, pv.Version, pv.CreatedUnix.FormatDate())
 			continue
 		}
+		if pv.LastUsedUnix().AsLocalTime().After(notDownloadedSince) {
+			log.Debug("Rule[%d]: keep '%s/%s' (remove not downloaded days) %v", pcr.ID, p.Name, pv.Version, pv.LastUsedUnix().FormatDate())
+			continue
+		}
 		if pcr.RemovePatternMatcher != nil && !pcr.RemovePatternMatcher.MatchString(toMatch) {
 			log.Debug("Rule[%d]: keep '%s/%s' (remove pattern)", pcr.ID, p.Name, pv.Version)
 			continue
@@ -89,6 +94,13 @@ func executeCleanupOneRule(ctx context.Context, pcr *packages_model.PackageClean
 		return fmt.Errorf("CleanupRule [%d]: CompilePattern failed: %w", pcr.ID, err)
 	}
 
+	if immutable, err := packages_service.IsImmutableVersionsEnabled(ctx, pcr.OwnerID); err != nil {
+		return fmt.Errorf("CleanupRule [%d]: IsImmutableVersionsEnabled failed: %w", pcr.ID, err)
+	} else if immutable {
+		log.Debug("CleanupRule [%d]: skipped because the package versions of the owner are immutable", pcr.ID)
+		return nil
+	}
+
 	packages, err := packages_model.GetPackagesByType(ctx, pcr.OwnerID, pcr.Type)
 	if err != nil {
 		return fmt.Errorf("CleanupRule [%d]: GetPackagesByType failed: %w", pcr.ID, err)
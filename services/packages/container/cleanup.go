@@ -102,6 +102,26 @@ func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule
 		if has {
 			return true, nil
 		}
+
+		// Skip it if the version is an artifact (signature, SBOM, ...) attached to an existing manifest
+		subjects, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject)
+		if err != nil {
+			return false, err
+		}
+		for _, subject := range subjects {
+			pvs, err := container_model.GetManifestVersions(ctx, &container_model.BlobSearchOptions{
+				OwnerID:    p.OwnerID,
+				Image:      p.LowerName,
+				Digest:     subject.Value,
+				IsManifest: true,
+			})
+			if err != nil {
+				return false, err
+			}
+			if len(pvs) > 0 {
+				return true, nil
+			}
+		}
 	}
 
 	return false, nil
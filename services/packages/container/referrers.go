@@ -0,0 +1,66 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/json"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// GetReferrers gets the descriptors of all manifests of the image which refer to the subject digest.
+// If artifactType is not empty, only manifests with this artifact type are returned.
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func GetReferrers(ctx context.Context, ownerID int64, image, subject, artifactType string) ([]oci.Descriptor, error) {
+	pfds, err := container_model.GetContainerBlobs(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    ownerID,
+		Image:      image,
+		Subject:    subject,
+		IsManifest: true,
+		OnlyLead:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	descriptors := make([]oci.Descriptor, 0, len(pfds))
+	seen := make(map[string]bool, len(pfds))
+	for _, pfd := range pfds {
+		manifestDigest := pfd.Properties.GetByName(container_module.PropertyDigest)
+		// a manifest pushed by tag and by digest is stored in multiple versions
+		if seen[manifestDigest] {
+			continue
+		}
+		seen[manifestDigest] = true
+
+		pv, err := packages_model.GetVersionByID(ctx, pfd.File.VersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		var metadata container_module.Metadata
+		if err := json.Unmarshal([]byte(pv.MetadataJSON), &metadata); err != nil {
+			return nil, err
+		}
+
+		if artifactType != "" && metadata.ArtifactType != artifactType {
+			continue
+		}
+
+		descriptors = append(descriptors, oci.Descriptor{
+			MediaType:    pfd.Properties.GetByName(container_module.PropertyMediaType),
+			Digest:       digest.Digest(manifestDigest),
+			Size:         pfd.Blob.Size,
+			ArtifactType: metadata.ArtifactType,
+			Annotations:  metadata.Annotations,
+		})
+	}
+	return descriptors, nil
+}
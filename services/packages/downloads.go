@@ -0,0 +1,38 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+)
+
+// GetDailyDownloads returns the downloads of the version for each of the last days, oldest first.
+// Days without downloads are included with a count of zero.
+func GetDailyDownloads(ctx context.Context, pv *packages_model.PackageVersion, days int) ([]*packages_model.PackageVersionDownload, error) {
+	today := packages_model.StartOfDay(time.Now())
+	since := today.AddDuration(-time.Duration(days-1) * 24 * time.Hour)
+
+	downloads, err := packages_model.GetDailyDownloadsByVersionID(ctx, pv.ID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int64, len(downloads))
+	for _, d := range downloads {
+		counts[int64(d.DayUnix)] = d.DownloadCount
+	}
+
+	result := make([]*packages_model.PackageVersionDownload, 0, days)
+	for day := since; day <= today; day = day.AddDuration(24 * time.Hour) {
+		result = append(result, &packages_model.PackageVersionDownload{
+			VersionID:     pv.ID,
+			DayUnix:       day,
+			DownloadCount: counts[int64(day)],
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	stateKey = "packages_hex_signing_key"
+	lockKey  = "packages_hex_signing_key"
+)
+
+// signingKey is the instance wide RSA key pair used to sign the registry resources
+type signingKey struct {
+	PrivateKey string `json:"private_key"`
+	PublicKey  string `json:"public_key"`
+}
+
+func getSigningKey(ctx context.Context) (*signingKey, error) {
+	content, err := system_model.GetAppStateContent(ctx, stateKey)
+	if err != nil || content == "" {
+		return nil, err
+	}
+	key := &signingKey{}
+	return key, json.Unmarshal([]byte(content), key)
+}
+
+func getOrCreateSigningKey(ctx context.Context) (*signingKey, error) {
+	key, err := getSigningKey(ctx)
+	if err != nil || key != nil {
+		return key, err
+	}
+
+	releaser, err := globallock.Lock(ctx, lockKey)
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	// another request may have created the key while waiting for the lock
+	if key, err = getSigningKey(ctx); err != nil || key != nil {
+		return key, err
+	}
+
+	priv, pub, err := util.GenerateKeyPair(4096)
+	if err != nil {
+		return nil, err
+	}
+
+	key = &signingKey{
+		PrivateKey: priv,
+		PublicKey:  pub,
+	}
+	content, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := system_model.SaveAppStateContent(ctx, stateKey, string(content)); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// GetOrCreatePublicKey gets the PEM encoded public key which clients use to verify the registry resources.
+// The key pair is created on first use.
+func GetOrCreatePublicKey(ctx context.Context) (string, error) {
+	key, err := getOrCreateSigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	return key.PublicKey, nil
+}
+
+// GetOrCreatePrivateKey gets the private key used to sign the registry resources.
+// The key pair is created on first use.
+func GetOrCreatePrivateKey(ctx context.Context) (*rsa.PrivateKey, error) {
+	key, err := getOrCreateSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, errors.New("failed to decode private key pem")
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strconv"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// SettingKeyImmutableVersions is the user setting which enables the immutable versions policy of an owner
+const SettingKeyImmutableVersions = "packages.immutable_versions"
+
+var ErrImmutablePackageVersion = util.NewPermissionDeniedErrorf("package versions of this owner are immutable and can't be overwritten or deleted")
+
+// IsImmutableVersionsEnabled checks if the owner enabled the immutable versions policy
+func IsImmutableVersionsEnabled(ctx context.Context, ownerID int64) (bool, error) {
+	value, err := user_model.GetUserSetting(ctx, ownerID, SettingKeyImmutableVersions, "false")
+	if err != nil {
+		return false, err
+	}
+	enabled, _ := strconv.ParseBool(value)
+	return enabled, nil
+}
+
+// SetImmutableVersionsEnabled enables or disables the immutable versions policy of the owner
+func SetImmutableVersionsEnabled(ctx context.Context, ownerID int64, enabled bool) error {
+	if !enabled {
+		return user_model.DeleteUserSetting(ctx, ownerID, SettingKeyImmutableVersions)
+	}
+	return user_model.SetUserSetting(ctx, ownerID, SettingKeyImmutableVersions, "true")
+}
+
+// CheckPackageVersionMutable returns ErrImmutablePackageVersion if the owner enabled the immutable versions policy
+// The check is skipped if the doer is an admin.
+func CheckPackageVersionMutable(ctx context.Context, doer *user_model.User, ownerID int64) error {
+	if doer != nil && doer.IsAdmin {
+		return nil
+	}
+
+	enabled, err := IsImmutableVersionsEnabled(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		return ErrImmutablePackageVersion
+	}
+	return nil
+}
+
+// checkFileOverwrite returns ErrImmutablePackageVersion if the file would replace an existing file with different content
+// and the owner enabled the immutable versions policy
+func checkFileOverwrite(ctx context.Context, pv *packages_model.PackageVersion, pvi *PackageInfo, pfci *PackageFileCreationInfo) error {
+	err := CheckPackageVersionMutable(ctx, pfci.Creator, pvi.Owner.ID)
+	if !errors.Is(err, ErrImmutablePackageVersion) {
+		return err
+	}
+
+	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, pfci.Filename, pfci.CompositeKey)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+	if err != nil {
+		return err
+	}
+
+	_, _, hashSHA256, _ := pfci.Data.Sums()
+	if pb.HashSHA256 == hex.EncodeToString(hashSHA256) {
+		return nil
+	}
+	return ErrImmutablePackageVersion
+}
+
+func checkPackageFileMutable(ctx context.Context, doer *user_model.User, pf *packages_model.PackageFile) error {
+	pv, err := packages_model.GetVersionByID(ctx, pf.VersionID)
+	if err != nil {
+		return err
+	}
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		return err
+	}
+	return CheckPackageVersionMutable(ctx, doer, p.OwnerID)
+}
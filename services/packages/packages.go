@@ -255,6 +255,12 @@ func addFileToPackageVersion(ctx context.Context, pv *packages_model.PackageVers
 		return nil, nil, false, err
 	}
 
+	if pfci.OverwriteExisting {
+		if err := checkFileOverwrite(ctx, pv, pvi, pfci); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
 	return addFileToPackageVersionUnchecked(ctx, pv, pfci)
 }
 
@@ -378,6 +384,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
 		typeSpecificSize = setting.Packages.LimitSizeHelm
+	case packages_model.TypeHex:
+		typeSpecificSize = setting.Packages.LimitSizeHex
 	case packages_model.TypeMaven:
 		typeSpecificSize = setting.Packages.LimitSizeMaven
 	case packages_model.TypeNpm:
@@ -394,6 +402,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeRubyGems
 	case packages_model.TypeSwift:
 		typeSpecificSize = setting.Packages.LimitSizeSwift
+	case packages_model.TypeTerraform:
+		typeSpecificSize = setting.Packages.LimitSizeTerraform
 	case packages_model.TypeVagrant:
 		typeSpecificSize = setting.Packages.LimitSizeVagrant
 	}
@@ -474,6 +484,10 @@ func RemovePackageVersion(ctx context.Context, doer *user_model.User, pv *packag
 		return err
 	}
 
+	if err := CheckPackageVersionMutable(ctx, doer, pd.Owner.ID); err != nil {
+		return err
+	}
+
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		log.Trace("Deleting package: %v", pv.ID)
 		return DeletePackageVersionAndReferences(ctx, pv)
@@ -491,6 +505,10 @@ func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_m
 	var pd *packages_model.PackageDescriptor
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkPackageFileMutable(ctx, doer, pf); err != nil {
+			return err
+		}
+
 		if err := DeletePackageFile(ctx, pf); err != nil {
 			return err
 		}
@@ -533,6 +551,14 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 		return err
 	}
 
+	if err := packages_model.DeleteVulnerabilityFindingsByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
+	if err := packages_model.DeleteDailyDownloadsByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
 	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
 	if err != nil {
 		return err
@@ -0,0 +1,242 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package promote
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+	packages_service "code.gitea.io/gitea/services/packages"
+	alpine_service "code.gitea.io/gitea/services/packages/alpine"
+	arch_service "code.gitea.io/gitea/services/packages/arch"
+	cargo_service "code.gitea.io/gitea/services/packages/cargo"
+	debian_service "code.gitea.io/gitea/services/packages/debian"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+	rpm_service "code.gitea.io/gitea/services/packages/rpm"
+)
+
+var (
+	ErrPackagePromotionNotSupported = util.NewInvalidArgumentErrorf("package versions cached from a remote registry can't be promoted")
+	ErrPackagePromotionSameOwner    = util.NewInvalidArgumentErrorf("package version can't be promoted to its own owner")
+)
+
+// IsPromotionSupported checks if the package version can be promoted to another owner.
+// The packages cached from a remote registry are managed by the remote configuration of their owner.
+func IsPromotionSupported(pd *packages_model.PackageDescriptor) bool {
+	return pd.PackageProperties.GetByName(remote_service.PropertyRemote) == ""
+}
+
+// PromotePackageVersion copies the package version with all its files and properties to another owner.
+// The copied files reference the existing blobs, so the content is not stored again.
+// The manifests referenced by a container image index are copied too, and the repository indexes of the target owner are rebuilt.
+func PromotePackageVersion(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor, target *user_model.User) (*packages_model.PackageVersion, error) {
+	if !IsPromotionSupported(pd) {
+		return nil, ErrPackagePromotionNotSupported
+	}
+	if pd.Owner.ID == target.ID {
+		return nil, ErrPackagePromotionSameOwner
+	}
+
+	log.Trace("Promoting package version: %v, %v, %v", pd.Version.ID, target.ID, doer.ID)
+
+	pv, err := db.WithTx2(ctx, func(ctx context.Context) (*packages_model.PackageVersion, error) {
+		if pd.Package.Type == packages_model.TypeContainer {
+			if err := copyReferencedManifests(ctx, doer, pd, target); err != nil {
+				return nil, err
+			}
+		}
+
+		return copyPackageVersion(ctx, doer, pd, target, &packages_model.PackageVersion{
+			Version:      pd.Version.Version,
+			LowerVersion: pd.Version.LowerVersion,
+			MetadataJSON: pd.Version.MetadataJSON,
+		}, pd.VersionProperties)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := buildRepositoryFiles(ctx, doer, target, pd.Package.Type, pv.PackageID); err != nil {
+		return nil, err
+	}
+
+	promoted, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		return nil, err
+	}
+
+	notify_service.PackageCreate(ctx, doer, promoted)
+
+	return pv, nil
+}
+
+// copyPackageVersion creates the version for the target owner and adds the files of the package descriptor to it
+func copyPackageVersion(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor, target *user_model.User, version *packages_model.PackageVersion, versionProperties packages_model.PackagePropertyList) (*packages_model.PackageVersion, error) {
+	packageCreated := true
+	p := &packages_model.Package{
+		OwnerID:          target.ID,
+		Type:             pd.Package.Type,
+		Name:             pd.Package.Name,
+		LowerName:        pd.Package.LowerName,
+		SemverCompatible: pd.Package.SemverCompatible,
+	}
+	p, err := packages_model.TryInsertPackage(ctx, p)
+	if err != nil {
+		if !errors.Is(err, packages_model.ErrDuplicatePackage) {
+			return nil, err
+		}
+		packageCreated = false
+	}
+
+	if packageCreated {
+		for _, pp := range pd.PackageProperties {
+			value := pp.Value
+			if pp.Name == container_module.PropertyRepository {
+				// the images are pulled by the name of the owner
+				value = strings.ToLower(target.LowerName + "/" + pd.Package.LowerName)
+			}
+			if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypePackage, p.ID, pp.Name, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	version.PackageID = p.ID
+	version.CreatorID = doer.ID
+	pv, err := packages_model.GetOrInsertVersion(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := packages_service.CheckCountQuotaExceeded(ctx, doer, target); err != nil {
+		return nil, err
+	}
+	if err := packages_service.CheckSizeQuotaExceeded(ctx, doer, target, pd.Package.Type, pd.CalculateBlobSize()); err != nil {
+		return nil, err
+	}
+
+	for _, pp := range versionProperties {
+		if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, pp.Name, pp.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, pfd := range pd.Files {
+		pf, err := packages_model.TryInsertFile(ctx, &packages_model.PackageFile{
+			VersionID:    pv.ID,
+			BlobID:       pfd.Blob.ID,
+			Name:         pfd.File.Name,
+			LowerName:    pfd.File.LowerName,
+			CompositeKey: pfd.File.CompositeKey,
+			IsLead:       pfd.File.IsLead,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pp := range pfd.Properties {
+			if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypeFile, pf.ID, pp.Name, pp.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return pv, nil
+}
+
+// copyReferencedManifests copies the manifests referenced by a container image index which the target owner doesn't have yet.
+// They are copied as untagged versions named by their digest, like a client pushing the index would upload them.
+func copyReferencedManifests(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor, target *user_model.User) error {
+	for _, pp := range pd.VersionProperties {
+		if pp.Name != container_module.PropertyManifestReference {
+			continue
+		}
+
+		_, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+			OwnerID:    target.ID,
+			Image:      pd.Package.LowerName,
+			Digest:     pp.Value,
+			IsManifest: true,
+		})
+		if err == nil {
+			continue
+		} else if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+			return err
+		}
+
+		pfd, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+			OwnerID:    pd.Owner.ID,
+			Image:      pd.Package.LowerName,
+			Digest:     pp.Value,
+			IsManifest: true,
+		})
+		if err != nil {
+			return err
+		}
+		pv, err := packages_model.GetVersionByID(ctx, pfd.File.VersionID)
+		if err != nil {
+			return err
+		}
+		manifest, err := packages_model.GetPackageDescriptor(ctx, pv)
+		if err != nil {
+			return err
+		}
+
+		metadata := manifest.Metadata.(*container_module.Metadata)
+		metadata.IsTagged = false
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+
+		versionProperties := make(packages_model.PackagePropertyList, 0, len(manifest.VersionProperties))
+		for _, vp := range manifest.VersionProperties {
+			if vp.Name != container_module.PropertyManifestTagged {
+				versionProperties = append(versionProperties, vp)
+			}
+		}
+
+		if _, err := copyPackageVersion(ctx, doer, manifest, target, &packages_model.PackageVersion{
+			Version:      strings.ToLower(pp.Value),
+			LowerVersion: strings.ToLower(pp.Value),
+			MetadataJSON: string(metadataJSON),
+		}, versionProperties); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildRepositoryFiles rebuilds the indexes of the target owner which list the files of the package types
+func buildRepositoryFiles(ctx context.Context, doer, target *user_model.User, packageType packages_model.Type, packageID int64) error {
+	switch packageType {
+	case packages_model.TypeAlpine:
+		return alpine_service.BuildAllRepositoryFiles(ctx, target.ID)
+	case packages_model.TypeArch:
+		release, err := arch_service.AquireRegistryLock(ctx, target.ID)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return arch_service.BuildAllRepositoryFiles(ctx, target.ID)
+	case packages_model.TypeCargo:
+		return cargo_service.UpdatePackageIndexIfExists(ctx, doer, target, packageID)
+	case packages_model.TypeDebian:
+		return debian_service.BuildAllRepositoryFiles(ctx, target.ID)
+	case packages_model.TypeRpm:
+		return rpm_service.BuildAllRepositoryFiles(ctx, target.ID)
+	}
+	return nil
+}
@@ -0,0 +1,394 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/hostmatcher"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// PropertyRemote marks a package as cached from a remote registry, the value is the URL of the remote
+const PropertyRemote = "remote.url"
+
+// ErrRemoteRequestFailed indicates the remote registry returned an unexpected response
+var ErrRemoteRequestFailed = errors.New("request to remote registry failed")
+
+var (
+	httpClient     *http.Client
+	httpClientOnce sync.Once
+)
+
+func getHTTPClient() *http.Client {
+	httpClientOnce.Do(func() {
+		allowedHostListValue := setting.Packages.RemoteAllowedHostList
+		if allowedHostListValue == "" {
+			allowedHostListValue = hostmatcher.MatchBuiltinExternal
+		}
+		allowedHostMatcher := hostmatcher.ParseHostMatchList("packages.REMOTE_ALLOWED_HOST_LIST", allowedHostListValue)
+
+		httpClient = &http.Client{
+			Timeout: setting.Packages.RemoteTimeout,
+			Transport: &http.Transport{
+				Proxy:       proxy.Proxy(),
+				DialContext: hostmatcher.NewDialContext("package remote", allowedHostMatcher, nil, nil),
+			},
+		}
+	})
+	return httpClient
+}
+
+// Client requests content from a remote registry
+type Client struct {
+	Remote   *packages_model.PackageRemote
+	baseURL  *url.URL
+	password string
+}
+
+// GetClient returns a client for the enabled remote of the owner for the package type.
+// ErrPackageRemoteNotExist is returned if there is none.
+func GetClient(ctx context.Context, ownerID int64, packageType packages_model.Type) (*Client, error) {
+	pr, err := packages_model.GetEnabledRemoteByOwnerAndType(ctx, ownerID, packageType)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(pr)
+}
+
+// NewClient creates a client for the remote
+func NewClient(pr *packages_model.PackageRemote) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(pr.URL, "/") + "/")
+	if err != nil {
+		return nil, err
+	}
+	password, err := pr.Password()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Remote:   pr,
+		baseURL:  baseURL,
+		password: password,
+	}, nil
+}
+
+// URL resolves the path relative to the URL of the remote. Absolute URLs are returned unchanged.
+func (c *Client) URL(p string) string {
+	if strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://") {
+		return p
+	}
+	return c.baseURL.JoinPath(p).String()
+}
+
+// EscapedURL resolves an already escaped path relative to the URL of the remote
+func (c *Client) EscapedURL(p string) string {
+	return c.baseURL.String() + strings.TrimPrefix(p, "/")
+}
+
+func (c *Client) isRemoteHost(u *url.URL) bool {
+	return strings.EqualFold(u.Host, c.baseURL.Host)
+}
+
+// Get requests the URL. A response with a 404 status results in an error wrapping util.ErrNotExist,
+// other unsuccessful responses in an error wrapping ErrRemoteRequestFailed.
+func (c *Client) Get(ctx context.Context, method, rawURL string, header http.Header) (*http.Response, error) {
+	resp, err := c.do(ctx, method, rawURL, header, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// registries like the Docker Hub require a token which is announced by the authentication challenge
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			return nil, fmt.Errorf("%w: %s returned status %d", ErrRemoteRequestFailed, rawURL, http.StatusUnauthorized)
+		}
+		token, err := c.requestBearerToken(ctx, challenge)
+		if err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(ctx, method, rawURL, header, token); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, util.NewNotExistErrorf("%s does not exist on the remote registry", rawURL)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s returned status %d", ErrRemoteRequestFailed, rawURL, resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func (c *Client) do(ctx context.Context, method, rawURL string, header http.Header, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if c.Remote.Username != "" && c.isRemoteHost(req.URL) {
+		req.SetBasicAuth(c.Remote.Username, c.password)
+	}
+
+	resp, err := getHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRemoteRequestFailed, err)
+	}
+	return resp, nil
+}
+
+// parseAuthChallenge parses the parameters of a WWW-Authenticate header
+func parseAuthChallenge(challenge string) map[string]string {
+	params := make(map[string]string)
+	_, rest, _ := strings.Cut(challenge, " ")
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key != "" {
+			params[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+	}
+	return params
+}
+
+func (c *Client) requestBearerToken(ctx context.Context, challenge string) (string, error) {
+	params := parseAuthChallenge(challenge)
+	realm, err := url.Parse(params["realm"])
+	if err != nil || (realm.Scheme != "https" && realm.Scheme != "http") {
+		return "", fmt.Errorf("%w: invalid authentication realm %q", ErrRemoteRequestFailed, params["realm"])
+	}
+	q := realm.Query()
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	if params["scope"] != "" {
+		q.Set("scope", params["scope"])
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.Remote.Username != "" {
+		req.SetBasicAuth(c.Remote.Username, c.password)
+	}
+	resp, err := getHTTPClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRemoteRequestFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: token request returned status %d", ErrRemoteRequestFailed, resp.StatusCode)
+	}
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("%w: invalid token response: %w", ErrRemoteRequestFailed, err)
+	}
+	return util.IfZero(tokenResponse.Token, tokenResponse.AccessToken), nil
+}
+
+// Metadata is a document fetched from the remote registry
+type Metadata struct {
+	ContentType string
+	Content     []byte
+}
+
+func (c *Client) metadataCacheKey(rawURL string, header http.Header) string {
+	return fmt.Sprintf("package_remote:%d:%s:%s", c.Remote.ID, header.Get("Accept"), rawURL)
+}
+
+// GetMetadata fetches a document which may change over time. The document is cached for the metadata TTL of the remote.
+func (c *Client) GetMetadata(ctx context.Context, rawURL string, header http.Header) (*Metadata, error) {
+	key := c.metadataCacheKey(rawURL, header)
+
+	cc := cache.GetCache()
+	if cc != nil {
+		m := &Metadata{}
+		if exist, err := cc.GetJSON(key, m); exist && err == nil {
+			return m, nil
+		}
+	}
+
+	resp, err := c.Get(ctx, http.MethodGet, rawURL, header)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, setting.UI.MaxDisplayFileSize*4))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRemoteRequestFailed, err)
+	}
+	m := &Metadata{
+		ContentType: resp.Header.Get("Content-Type"),
+		Content:     content,
+	}
+
+	if cc != nil {
+		if err := cc.PutJSON(key, m, int64(c.Remote.MetadataTTLDuration().Seconds())); err != nil {
+			log.Warn("Unable to cache metadata of %s: %v", rawURL, err)
+		}
+	}
+	return m, nil
+}
+
+// CacheFileOptions describe a file to fetch from the remote registry and to store locally
+type CacheFileOptions struct {
+	URL    string
+	Header http.Header
+	// Verify is called with the fetched content before it is stored. It may complete the creation info from the content.
+	Verify          func(buf *packages_module.HashedBuffer) error
+	PackageCreation *packages_service.PackageCreationInfo
+	FileCreation    *packages_service.PackageFileCreationInfo
+}
+
+func cacheFileLockKey(pi *packages_service.PackageInfo, filename string) string {
+	return fmt.Sprintf("package_remote_%d_%s_%s_%s_%s", pi.Owner.ID, pi.PackageType, pi.Name, pi.Version, filename)
+}
+
+// Creator returns the user who is recorded as creator of cached packages
+func Creator(doer *user_model.User) *user_model.User {
+	if doer == nil {
+		return user_model.NewGhostUser()
+	}
+	return doer
+}
+
+// CacheFile fetches a file from the remote registry and stores it locally with
+// packages_service.CreatePackageOrAddFileToExisting. A file which got cached concurrently is not an error.
+func (c *Client) CacheFile(ctx context.Context, opts *CacheFileOptions) error {
+	pvci := opts.PackageCreation
+	pfci := opts.FileCreation
+
+	releaser, err := globallock.Lock(ctx, cacheFileLockKey(&pvci.PackageInfo, pfci.Filename))
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	if pv, err := packages_model.GetVersionByNameAndVersion(ctx, pvci.Owner.ID, pvci.PackageType, pvci.Name, pvci.Version); err == nil {
+		if _, err := packages_model.GetFileForVersionByName(ctx, pv.ID, pfci.Filename, pfci.CompositeKey); err == nil {
+			return nil
+		}
+	}
+
+	resp, err := c.Get(ctx, http.MethodGet, opts.URL, opts.Header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRemoteRequestFailed, err)
+	}
+	defer buf.Close()
+
+	if opts.Verify != nil {
+		if err := opts.Verify(buf); err != nil {
+			return err
+		}
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	if pvci.PackageProperties == nil {
+		pvci.PackageProperties = map[string]string{}
+	}
+	pvci.PackageProperties[PropertyRemote] = c.Remote.URL
+	pfci.Data = buf
+
+	_, _, err = packages_service.CreatePackageOrAddFileToExisting(ctx, pvci, pfci)
+	if errors.Is(err, packages_model.ErrDuplicatePackageFile) {
+		return nil
+	}
+	return err
+}
+
+// IsCachedPackage checks if the package was created by caching files of a remote registry
+func IsCachedPackage(ctx context.Context, packageID int64) (bool, error) {
+	pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypePackage, packageID, PropertyRemote)
+	if err != nil {
+		return false, err
+	}
+	return len(pps) > 0, nil
+}
+
+// IsCachedOrMissing checks if the versions are empty or all belong to packages cached from a remote registry.
+// The versions of a virtual repository can belong to multiple packages, local versions must not be hidden by the remote.
+func IsCachedOrMissing(ctx context.Context, pvs []*packages_model.PackageVersion) (bool, error) {
+	checked := make(container.Set[int64])
+	for _, pv := range pvs {
+		if !checked.Add(pv.PackageID) {
+			continue
+		}
+		cached, err := IsCachedPackage(ctx, pv.PackageID)
+		if err != nil || !cached {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// VerifyChecksum compares the hex encoded checksum of the content with the expected value
+func VerifyChecksum(buf *packages_module.HashedBuffer, algorithm, expected string) error {
+	hashMD5, hashSHA1, hashSHA256, hashSHA512 := buf.Sums()
+	var actual []byte
+	switch strings.ToLower(algorithm) {
+	case "md5":
+		actual = hashMD5
+	case "sha1":
+		actual = hashSHA1
+	case "sha256":
+		actual = hashSHA256
+	case "sha512":
+		actual = hashSHA512
+	default:
+		return fmt.Errorf("%w: unsupported checksum algorithm %q", ErrRemoteRequestFailed, algorithm)
+	}
+	if !strings.EqualFold(hex.EncodeToString(actual), expected) {
+		return fmt.Errorf("%w: %s checksum mismatch", ErrRemoteRequestFailed, algorithm)
+	}
+	return nil
+}
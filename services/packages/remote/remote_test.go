@@ -0,0 +1,115 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuthChallenge(t *testing.T) {
+	params := parseAuthChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:library/alpine:pull"`)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:library/alpine:pull",
+	}, params)
+
+	params = parseAuthChallenge(`Bearer realm=https://auth.example.com/token, service=registry`)
+	assert.Equal(t, "https://auth.example.com/token", params["realm"])
+	assert.Equal(t, "registry", params["service"])
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	setting.AppDataPath = t.TempDir()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(strings.NewReader("content"))
+	require.NoError(t, err)
+	defer buf.Close()
+
+	assert.NoError(t, VerifyChecksum(buf, "sha256", "ED7002B439E9AC845F22357D822BAC1444730FBDB6016D3EC9432297B9EC9F73"))
+	assert.NoError(t, VerifyChecksum(buf, "sha1", "040f06fd774092478d450774f5ba30c5da78acc8"))
+	assert.ErrorIs(t, VerifyChecksum(buf, "sha256", "0000"), ErrRemoteRequestFailed)
+	assert.ErrorIs(t, VerifyChecksum(buf, "crc32", "0000"), ErrRemoteRequestFailed)
+}
+
+func TestClient(t *testing.T) {
+	defer test.MockVariableValue(&setting.Packages.RemoteAllowedHostList, "loopback")()
+	defer test.MockVariableValue(&setting.SecretKey, "secret")()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if r.URL.Query().Get("scope") != "repository:test:pull" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = io.WriteString(w, `{"token":"abc"}`)
+		case "/registry/protected":
+			if r.Header.Get("Authorization") != "Bearer abc" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",scope="repository:test:pull"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = io.WriteString(w, "protected content")
+		case "/registry/basic":
+			if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = io.WriteString(w, "basic content")
+		case "/registry/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	pr := &packages_model.PackageRemote{URL: srv.URL + "/registry/", Username: "user"}
+	require.NoError(t, pr.SetPassword("pass"))
+	c, err := NewClient(pr)
+	require.NoError(t, err)
+
+	assert.Equal(t, srv.URL+"/registry/a/b", c.URL("a/b"))
+	assert.Equal(t, "https://example.com/file", c.URL("https://example.com/file"))
+	assert.Equal(t, srv.URL+"/registry/%40scope%2fname", c.EscapedURL("%40scope%2fname"))
+
+	t.Run("Bearer", func(t *testing.T) {
+		m, err := c.GetMetadata(t.Context(), c.URL("protected"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", m.ContentType)
+		assert.Equal(t, "protected content", string(m.Content))
+	})
+
+	t.Run("Basic", func(t *testing.T) {
+		m, err := c.GetMetadata(t.Context(), c.URL("basic"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "basic content", string(m.Content))
+	})
+
+	t.Run("NotExist", func(t *testing.T) {
+		_, err := c.GetMetadata(t.Context(), c.URL("missing"), nil)
+		assert.ErrorIs(t, err, util.ErrNotExist)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := c.GetMetadata(t.Context(), c.URL("error"), nil)
+		assert.ErrorIs(t, err, ErrRemoteRequestFailed)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+var ErrInvalidSignature = util.NewInvalidArgumentErrorf("signature can't be verified with a GPG key of the user")
+
+// VerifySignature verifies the detached signature of the content with the GPG keys of the signer.
+// The signature may be binary or ASCII armored. It returns the ID of the primary key which created the signature.
+func VerifySignature(ctx context.Context, signer *user_model.User, content, signature []byte) (string, error) {
+	keys, err := db.Find[asymkey_model.GPGKey](ctx, asymkey_model.FindGPGKeyOptions{
+		OwnerID: signer.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	isArmored := bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN"))
+
+	for _, key := range keys {
+		keyring, err := getKeyRing(ctx, key.KeyID)
+		if err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				continue
+			}
+			return "", err
+		}
+
+		if isArmored {
+			_, err = openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(content), bytes.NewReader(signature), nil)
+		} else {
+			_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(content), bytes.NewReader(signature), nil)
+		}
+		if err == nil {
+			return key.KeyID, nil
+		}
+	}
+
+	return "", ErrInvalidSignature
+}
+
+// GetArmoredPublicKey returns the ASCII armored public key with the ID
+func GetArmoredPublicKey(ctx context.Context, keyID string) (string, error) {
+	imp, err := asymkey_model.GetGPGImportByKeyID(ctx, keyID)
+	if err != nil {
+		return "", err
+	}
+	return imp.Content, nil
+}
+
+func getKeyRing(ctx context.Context, keyID string) (openpgp.EntityList, error) {
+	armored, err := GetArmoredPublicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+}
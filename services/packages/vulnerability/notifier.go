@@ -0,0 +1,43 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vulnerability
+
+import (
+	"context"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+func init() {
+	notify_service.RegisterNotifier(&vulnerabilityNotifier{})
+}
+
+type vulnerabilityNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &vulnerabilityNotifier{}
+
+// PackageCreate scans new package versions so that findings are available without waiting for the next database import
+func (n *vulnerabilityNotifier) PackageCreate(ctx context.Context, _ *user_model.User, pd *packages_model.PackageDescriptor) {
+	if !IsSupportedType(pd.Package.Type) {
+		return
+	}
+
+	count, err := packages_model.CountVulnerabilities(ctx)
+	if err != nil {
+		log.Error("CountVulnerabilities: %v", err)
+		return
+	}
+	if count == 0 {
+		return
+	}
+
+	if err := ScanPackageVersion(ctx, pd.Package.Type, pd.Version); err != nil {
+		log.Error("ScanPackageVersion[%d]: %v", pd.Version.ID, err)
+	}
+}
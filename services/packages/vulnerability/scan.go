@@ -0,0 +1,209 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vulnerability
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	cargo_module "code.gitea.io/gitea/modules/packages/cargo"
+	composer_module "code.gitea.io/gitea/modules/packages/composer"
+	maven_module "code.gitea.io/gitea/modules/packages/maven"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/packages/osv"
+	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// dependency is a dependency of a package version with its version constraint
+type dependency struct {
+	Name    string
+	Version string
+}
+
+var ecosystems = map[packages_model.Type]string{
+	packages_model.TypeCargo:    osv.EcosystemCargo,
+	packages_model.TypeComposer: osv.EcosystemComposer,
+	packages_model.TypeMaven:    osv.EcosystemMaven,
+	packages_model.TypeNpm:      osv.EcosystemNpm,
+	packages_model.TypePyPI:     osv.EcosystemPyPI,
+}
+
+// IsSupportedType checks if package versions of the type are scanned for vulnerabilities
+func IsSupportedType(pt packages_model.Type) bool {
+	_, ok := ecosystems[pt]
+	return ok
+}
+
+// https://packaging.python.org/en/latest/specifications/dependency-specifiers/
+var requiresDistPattern = regexp.MustCompile(`\A\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*\(?([^;)]*)\)?`)
+
+// getDependencies extracts the dependencies from the metadata of a package version
+func getDependencies(pt packages_model.Type, metadataJSON string) ([]*dependency, error) {
+	var deps []*dependency
+	addAll := func(m map[string]string) {
+		for name, constraint := range m {
+			deps = append(deps, &dependency{Name: name, Version: constraint})
+		}
+	}
+
+	switch pt {
+	case packages_model.TypeCargo:
+		var metadata cargo_module.Metadata
+		if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+			return nil, err
+		}
+		for _, d := range metadata.Dependencies {
+			if d.Kind == "dev" {
+				continue
+			}
+			name := d.Name
+			if d.Package != nil && *d.Package != "" {
+				name = *d.Package
+			}
+			deps = append(deps, &dependency{Name: name, Version: d.Req})
+		}
+	case packages_model.TypeComposer:
+		var metadata composer_module.Metadata
+		if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+			return nil, err
+		}
+		for name, constraint := range metadata.Require {
+			// skip platform requirements like "php" and "ext-json"
+			if !strings.Contains(name, "/") {
+				continue
+			}
+			deps = append(deps, &dependency{Name: name, Version: constraint})
+		}
+	case packages_model.TypeMaven:
+		var metadata maven_module.Metadata
+		if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+			return nil, err
+		}
+		for _, d := range metadata.Dependencies {
+			deps = append(deps, &dependency{Name: d.GroupID + ":" + d.ArtifactID, Version: d.Version})
+		}
+	case packages_model.TypeNpm:
+		var metadata npm_module.Metadata
+		if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+			return nil, err
+		}
+		addAll(metadata.Dependencies)
+		addAll(metadata.OptionalDependencies)
+	case packages_model.TypePyPI:
+		var metadata pypi_module.Metadata
+		if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+			return nil, err
+		}
+		for _, requirement := range metadata.RequiresDist {
+			if m := requiresDistPattern.FindStringSubmatch(requirement); m != nil {
+				deps = append(deps, &dependency{Name: m[1], Version: strings.TrimSpace(m[2])})
+			}
+		}
+	}
+	return deps, nil
+}
+
+// ScanPackageVersion matches the dependencies of the package version against the vulnerability database
+// and replaces the stored findings. The lowest version allowed by the version constraint of a dependency is checked.
+func ScanPackageVersion(ctx context.Context, pt packages_model.Type, pv *packages_model.PackageVersion) error {
+	ecosystem, ok := ecosystems[pt]
+	if !ok {
+		return nil
+	}
+
+	deps, err := getDependencies(pt, pv.MetadataJSON)
+	if err != nil {
+		return err
+	}
+
+	findings := make([]*packages_model.PackageVulnerabilityFinding, 0, 2)
+	for _, dep := range deps {
+		v := osv.MinimumVersion(dep.Version)
+		if v == "" {
+			continue
+		}
+
+		affected, err := packages_model.GetVulnerabilityAffectedByName(ctx, ecosystem, osv.NormalizeName(ecosystem, dep.Name))
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[int64]bool, len(affected))
+		for _, a := range affected {
+			if seen[a.VulnerabilityID] || a.Affected == nil || !a.Affected.IsVersionAffected(v) {
+				continue
+			}
+			seen[a.VulnerabilityID] = true
+
+			findings = append(findings, &packages_model.PackageVulnerabilityFinding{
+				VulnerabilityID:   a.VulnerabilityID,
+				DependencyName:    dep.Name,
+				DependencyVersion: dep.Version,
+			})
+		}
+	}
+
+	return packages_model.ReplaceVulnerabilityFindings(ctx, pv.ID, findings)
+}
+
+// ScanAll scans all package versions of the supported package types
+func ScanAll(ctx context.Context) error {
+	for pt := range ecosystems {
+		for page := 1; ; page++ {
+			pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+				Type:       pt,
+				IsInternal: optional.Some(false),
+				Paginator:  &db.ListOptions{Page: page, PageSize: 200},
+				Sort:       packages_model.SortCreatedAsc,
+			})
+			if err != nil {
+				return err
+			}
+			if len(pvs) == 0 {
+				break
+			}
+
+			for _, pv := range pvs {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				default:
+				}
+
+				if err := ScanPackageVersion(ctx, pt, pv); err != nil {
+					return fmt.Errorf("ScanPackageVersion[%d]: %w", pv.ID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ScanTask rescans all package versions if the vulnerability database was imported since the last scan
+func ScanTask(ctx context.Context) error {
+	state, err := getState(ctx)
+	if err != nil {
+		return err
+	}
+	if state.ImportedUnix == 0 || state.ScannedUnix > state.ImportedUnix {
+		return nil
+	}
+
+	started := timeutil.TimeStampNow()
+	if err := ScanAll(ctx); err != nil {
+		return err
+	}
+	log.Info("Scanned all packages for vulnerabilities")
+
+	state.ScannedUnix = started
+	return saveState(ctx, state)
+}
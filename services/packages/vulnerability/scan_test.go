@@ -0,0 +1,36 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vulnerability
+
+import (
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDependencies(t *testing.T) {
+	t.Run("PyPI", func(t *testing.T) {
+		deps, err := getDependencies(packages_model.TypePyPI, `{"requires_dist":["requests (>=2.0)","urllib3[socks]>=1.26,<2 ; python_version >= \"3.7\"","six"]}`)
+		assert.NoError(t, err)
+		assert.Equal(t, []*dependency{
+			{Name: "requests", Version: ">=2.0"},
+			{Name: "urllib3", Version: ">=1.26,<2"},
+			{Name: "six", Version: ""},
+		}, deps)
+	})
+
+	t.Run("Composer", func(t *testing.T) {
+		deps, err := getDependencies(packages_model.TypeComposer, `{"require":{"php":">=8.1","ext-json":"*","guzzlehttp/guzzle":"^7.0"}}`)
+		assert.NoError(t, err)
+		assert.Equal(t, []*dependency{{Name: "guzzlehttp/guzzle", Version: "^7.0"}}, deps)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		deps, err := getDependencies(packages_model.TypeGeneric, `{}`)
+		assert.NoError(t, err)
+		assert.Empty(t, deps)
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vulnerability
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/packages/osv"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+const stateKey = "package_vulnerability_database"
+
+// databaseState tracks when the vulnerability database was imported and when the packages were scanned the last time
+type databaseState struct {
+	ImportedUnix timeutil.TimeStamp `json:"imported_unix"`
+	ScannedUnix  timeutil.TimeStamp `json:"scanned_unix"`
+}
+
+func getState(ctx context.Context) (*databaseState, error) {
+	state := &databaseState{}
+	content, err := system_model.GetAppStateContent(ctx, stateKey)
+	if err != nil || content == "" {
+		return state, err
+	}
+	return state, json.Unmarshal([]byte(content), state)
+}
+
+func saveState(ctx context.Context, state *databaseState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return system_model.SaveAppStateContent(ctx, stateKey, string(content))
+}
+
+var supportedEcosystems = container.SetOf(osv.EcosystemCargo, osv.EcosystemComposer, osv.EcosystemMaven, osv.EcosystemNpm, osv.EcosystemPyPI)
+
+// ImportDatabase imports a snapshot of an OSV database. The snapshot is a zip archive containing the vulnerabilities
+// as JSON files, like the "all.zip" archives provided by https://osv.dev. Only vulnerabilities affecting ecosystems
+// of supported package types are imported and vulnerabilities not contained in the snapshot are removed.
+// The package versions are rescanned by the cron task afterwards.
+func ImportDatabase(ctx context.Context, r io.ReaderAt, size int64) (int, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return 0, err
+	}
+
+	imported := make(container.Set[int64])
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".json") {
+			continue
+		}
+
+		entry, err := readEntry(f)
+		if err != nil {
+			log.Warn("Skipping invalid vulnerability entry %s: %v", f.Name, err)
+			continue
+		}
+		if entry.Withdrawn != nil {
+			continue
+		}
+
+		affected := make([]*packages_model.PackageVulnerabilityAffected, 0, len(entry.Affected))
+		for _, a := range entry.Affected {
+			if !supportedEcosystems.Contains(a.Package.Ecosystem) {
+				continue
+			}
+			affected = append(affected, &packages_model.PackageVulnerabilityAffected{
+				Ecosystem: a.Package.Ecosystem,
+				LowerName: osv.NormalizeName(a.Package.Ecosystem, a.Package.Name),
+				Affected:  a,
+			})
+		}
+		if len(affected) == 0 {
+			continue
+		}
+
+		v := &packages_model.PackageVulnerability{
+			AdvisoryID:    entry.ID,
+			Summary:       entry.Summary,
+			Details:       entry.Details,
+			Aliases:       entry.Aliases,
+			Severity:      entry.Severity(),
+			PublishedUnix: timeutil.TimeStamp(entry.Published.Unix()),
+			ModifiedUnix:  timeutil.TimeStamp(entry.Modified.Unix()),
+		}
+		if err := packages_model.InsertOrUpdateVulnerability(ctx, v, affected); err != nil {
+			return 0, err
+		}
+		imported.Add(v.ID)
+	}
+
+	ids, err := packages_model.GetVulnerabilityIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		if imported.Contains(id) {
+			continue
+		}
+		if err := packages_model.DeleteVulnerabilityByID(ctx, id); err != nil {
+			return 0, err
+		}
+	}
+
+	state, err := getState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	state.ImportedUnix = timeutil.TimeStampNow()
+	return len(imported), saveState(ctx, state)
+}
+
+func readEntry(f *zip.File) (*osv.Entry, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return osv.ParseEntry(rc)
+}
@@ -4,9 +4,11 @@
 package pull
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +16,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	"code.gitea.io/gitea/models/pull"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -30,6 +33,7 @@ import (
 	"code.gitea.io/gitea/modules/timeutil"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	"code.gitea.io/gitea/services/automergequeue"
+	issue_service "code.gitea.io/gitea/services/issue"
 	notify_service "code.gitea.io/gitea/services/notify"
 )
 
@@ -206,6 +210,14 @@ func CheckPullMergeable(stdCtx context.Context, doer *user_model.User, perm *acc
 			}
 		}
 
+		// the rulesets can't be overridden by "Force Merge", only their bypass actors are not restricted by them
+		if err := CheckPullRulesets(ctx, pr, doer, perm.IsAdmin()); err != nil {
+			// when doing Auto Merge, the rulesets will be checked again when the pull request is merged
+			if !errors.Is(err, ErrNotReadyToMerge) || mergeCheckType != MergeCheckTypeAuto {
+				return err
+			}
+		}
+
 		if _, err := isSignedIfRequired(ctx, pr, doer); err != nil {
 			return err
 		}
@@ -236,6 +248,70 @@ func isSignedIfRequired(ctx context.Context, pr *issues_model.PullRequest, doer
 	return sign, err
 }
 
+// GetOutstandingCodeOwners returns the code owners of the files changed by the pull request who have not approved it yet,
+// a team is satisfied when any of its members has approved. The poster can't approve their own pull request,
+// so when they own some files, the approval of another code owner is required instead.
+// Nothing is returned if the rule doesn't require code owner reviews.
+func GetOutstandingCodeOwners(ctx context.Context, pb *git_model.ProtectedBranch, pr *issues_model.PullRequest) ([]*user_model.User, []*organization.Team, error) {
+	if pb == nil || !pb.RequireCodeOwnerReviews {
+		return nil, nil, nil
+	}
+
+	owners, teams, err := issue_service.GetPullRequestCodeOwners(ctx, pr)
+	if err != nil || (len(owners) == 0 && len(teams) == 0) {
+		return nil, nil, err
+	}
+
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	approverIDs, err := issues_model.GetGrantedApprovalReviewerIDs(ctx, pb, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the approvals of the poster never count, even if they are granted by a team
+	approverIDs = slices.DeleteFunc(approverIDs, func(id int64) bool { return id == pr.Issue.PosterID })
+
+	var outstandingUsers []*user_model.User
+	var poster *user_model.User
+	ownerApproved := false
+	for _, u := range owners {
+		switch {
+		case u.ID == pr.Issue.PosterID:
+			poster = u
+		case slices.Contains(approverIDs, u.ID):
+			ownerApproved = true
+		default:
+			outstandingUsers = append(outstandingUsers, u)
+		}
+	}
+
+	var outstandingTeams []*organization.Team
+	for _, t := range teams {
+		approved := false
+		for _, approverID := range approverIDs {
+			if approved, err = organization.IsTeamMember(ctx, t.OrgID, t.ID, approverID); err != nil {
+				return nil, nil, err
+			} else if approved {
+				break
+			}
+		}
+		if approved {
+			ownerApproved = true
+		} else {
+			outstandingTeams = append(outstandingTeams, t)
+		}
+	}
+
+	if poster != nil && !ownerApproved {
+		outstandingUsers = append(outstandingUsers, poster)
+		slices.SortFunc(outstandingUsers, func(a, b *user_model.User) int { return cmp.Compare(a.ID, b.ID) })
+	}
+	return outstandingUsers, outstandingTeams, nil
+}
+
 // markPullRequestAsMergeable checks if pull request is possible to leaving checking status,
 // and set to be either conflict or mergeable.
 func markPullRequestAsMergeable(ctx context.Context, pr *issues_model.PullRequest) {
@@ -0,0 +1,503 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ConflictChoice is the side picked to resolve a conflicting chunk
+type ConflictChoice string
+
+const (
+	// ConflictChoiceOurs keeps the content of the head branch of the pull request
+	ConflictChoiceOurs ConflictChoice = "ours"
+	// ConflictChoiceTheirs keeps the content of the base branch of the pull request
+	ConflictChoiceTheirs ConflictChoice = "theirs"
+	// ConflictChoiceBoth keeps the content of the head branch followed by the content of the base branch
+	ConflictChoiceBoth ConflictChoice = "both"
+)
+
+// IsValid checks if the choice is known
+func (c ConflictChoice) IsValid() bool {
+	return c == ConflictChoiceOurs || c == ConflictChoiceTheirs || c == ConflictChoiceBoth
+}
+
+// ErrConflictsOutdated is returned when the branches of a pull request moved since the conflicts were loaded
+var ErrConflictsOutdated = errors.New("the branches of the pull request have changed since the conflicts were loaded")
+
+// conflictMarkerSize is larger than the git default so that content containing default markers can be parsed
+const conflictMarkerSize = 20
+
+// ConflictChunk is a part of a conflicting text file. Chunks which are not in conflict only have Lines.
+type ConflictChunk struct {
+	IsConflict bool
+	// Index is the position of the conflict among the conflicts of the file
+	Index  int
+	Lines  []string
+	Ours   []string
+	Base   []string
+	Theirs []string
+}
+
+// Resolve returns the content of the chunk for the given choice
+func (c *ConflictChunk) Resolve(choice ConflictChoice) []string {
+	if !c.IsConflict {
+		return c.Lines
+	}
+	switch choice {
+	case ConflictChoiceOurs:
+		return c.Ours
+	case ConflictChoiceTheirs:
+		return c.Theirs
+	default:
+		return append(append([]string{}, c.Ours...), c.Theirs...)
+	}
+}
+
+// ConflictFile is a file which could not be merged automatically.
+// Files which are not text (binary, symlinks, submodules, deleted on one side...) can only be resolved as a whole.
+type ConflictFile struct {
+	Path          string
+	IsText        bool
+	OursDeleted   bool
+	TheirsDeleted bool
+	Chunks        []*ConflictChunk
+
+	// the stages are only needed to resolve the file, they are not kept in the cache
+	ours   *lsFileLine
+	theirs *lsFileLine
+}
+
+// NumConflicts returns the number of choices needed to resolve the file
+func (f *ConflictFile) NumConflicts() int {
+	if !f.IsText {
+		return 1
+	}
+	n := 0
+	for _, chunk := range f.Chunks {
+		if chunk.IsConflict {
+			n++
+		}
+	}
+	return n
+}
+
+// Conflicts are the conflicting files of merging the base branch into the head branch of a pull request
+type Conflicts struct {
+	HeadCommitID string
+	BaseCommitID string
+	Files        []*ConflictFile
+}
+
+// ResolveConflictsOptions holds the choices made to resolve the conflicts of a pull request
+type ResolveConflictsOptions struct {
+	HeadCommitID string
+	BaseCommitID string
+	// Choices contains a choice per conflicting chunk for each file, or a single choice for files which are not text
+	Choices map[string][]ConflictChoice
+	Message string
+}
+
+// loadConflictRepos loads the repositories of a pull request whose conflicts can be resolved
+func loadConflictRepos(ctx context.Context, pr *issues_model.PullRequest) error {
+	if pr.Flow == issues_model.PullRequestFlowAGit {
+		return util.NewInvalidArgumentErrorf("resolving conflicts of agit flow pull requests is unsupported")
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return fmt.Errorf("LoadBaseRepo: %w", err)
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return fmt.Errorf("LoadHeadRepo: %w", err)
+	}
+	if pr.HeadRepo == nil {
+		return repo_model.ErrRepoNotExist{ID: pr.HeadRepoID}
+	}
+	return nil
+}
+
+// prepareConflictMerge merges the base branch into the head branch in a temporary repository leaving the conflicts in
+// place. The caller must call cancel once done with the returned context.
+func prepareConflictMerge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User) (mergeCtx *mergeContext, cancel context.CancelFunc, conflicts *Conflicts, err error) {
+	if err := loadConflictRepos(ctx, pr); err != nil {
+		return nil, nil, nil, err
+	}
+
+	mergeCtx, cancel, err = createTemporaryRepoForMerge(ctx, reversePullRequest(pr), doer, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	conflicts = &Conflicts{}
+	if conflicts.HeadCommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, baseBranch); err != nil {
+		cancel()
+		return nil, nil, nil, fmt.Errorf("GetFullCommitID(%s): %w", baseBranch, err)
+	}
+	if conflicts.BaseCommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, trackingBranch); err != nil {
+		cancel()
+		return nil, nil, nil, fmt.Errorf("GetFullCommitID(%s): %w", trackingBranch, err)
+	}
+
+	cmd := git.NewCommand("merge", "--no-ff", "--no-commit").AddDynamicArguments(trackingBranch)
+	if err := runMergeCommand(mergeCtx, repo_model.MergeStyleMerge, cmd); err != nil {
+		if !IsErrMergeConflicts(err) {
+			cancel()
+			return nil, nil, nil, err
+		}
+	} else {
+		// the branches merge cleanly
+		return mergeCtx, cancel, conflicts, nil
+	}
+
+	unmerged := make(chan *unmergedFile)
+	go unmergedFiles(ctx, mergeCtx.tmpBasePath, unmerged)
+	defer func() {
+		for range unmerged {
+			// empty the channel
+		}
+	}()
+
+	for file := range unmerged {
+		if file.err != nil {
+			cancel()
+			return nil, nil, nil, file.err
+		}
+		conflictFile, err := loadConflictFile(mergeCtx, file)
+		if err != nil {
+			cancel()
+			return nil, nil, nil, err
+		}
+		conflicts.Files = append(conflicts.Files, conflictFile)
+	}
+	return mergeCtx, cancel, conflicts, nil
+}
+
+func isTextMode(line *lsFileLine) bool {
+	return line != nil && (line.mode == "100644" || line.mode == "100755")
+}
+
+func readBlob(ctx *mergeContext, sha string) ([]byte, bool, error) {
+	size, _, err := git.NewCommand("cat-file", "-s").AddDynamicArguments(sha).RunStdString(ctx, &git.RunOpts{Dir: ctx.tmpBasePath})
+	if err != nil {
+		return nil, false, fmt.Errorf("git cat-file -s %s: %w", sha, err)
+	}
+	if n, err := strconv.ParseInt(strings.TrimSpace(size), 10, 64); err != nil || n > setting.UI.MaxDisplayFileSize {
+		return nil, false, nil
+	}
+	content, _, err := git.NewCommand("cat-file", "blob").AddDynamicArguments(sha).RunStdBytes(ctx, &git.RunOpts{Dir: ctx.tmpBasePath})
+	if err != nil {
+		return nil, false, fmt.Errorf("git cat-file blob %s: %w", sha, err)
+	}
+	return content, bytes.IndexByte(content, 0) == -1, nil
+}
+
+// loadConflictFile reads the stages of an unmerged file and splits text files into chunks
+func loadConflictFile(ctx *mergeContext, file *unmergedFile) (*ConflictFile, error) {
+	conflictFile := &ConflictFile{
+		ours:          file.stage2,
+		theirs:        file.stage3,
+		OursDeleted:   file.stage2 == nil,
+		TheirsDeleted: file.stage3 == nil,
+	}
+	for _, stage := range []*lsFileLine{file.stage1, file.stage2, file.stage3} {
+		if stage != nil {
+			conflictFile.Path = stage.path
+		}
+	}
+
+	if !isTextMode(file.stage2) || !isTextMode(file.stage3) || file.stage2.mode != file.stage3.mode ||
+		(file.stage1 != nil && !isTextMode(file.stage1)) {
+		return conflictFile, nil
+	}
+
+	tmpDir, err := os.MkdirTemp(filepath.Join(ctx.tmpBasePath, ".git"), "conflict-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temporary directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	names := []string{"ours", "base", "theirs"}
+	for i, stage := range []*lsFileLine{file.stage2, file.stage1, file.stage3} {
+		var content []byte
+		if stage != nil {
+			var isText bool
+			content, isText, err = readBlob(ctx, stage.sha)
+			if err != nil {
+				return nil, err
+			}
+			if !isText {
+				return conflictFile, nil
+			}
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, names[i]), content, 0o600); err != nil {
+			return nil, fmt.Errorf("unable to write %s: %w", names[i], err)
+		}
+	}
+
+	cmd := git.NewCommand("merge-file", "-p", "--diff3").
+		AddOptionFormat("--marker-size=%d", conflictMarkerSize).
+		AddArguments("-L", "ours", "-L", "base", "-L", "theirs").
+		AddDynamicArguments(names...)
+	stdout := &bytes.Buffer{}
+	stderr := &strings.Builder{}
+	if err := cmd.Run(ctx, &git.RunOpts{Dir: tmpDir, Stdout: stdout, Stderr: stderr}); err != nil {
+		// the exit code is the number of conflicts, and negative on error
+		var exitErr interface{ ExitCode() int }
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() <= 0 || exitErr.ExitCode() >= 128 {
+			return nil, fmt.Errorf("git merge-file %s: %w", conflictFile.Path, git.ConcatenateError(err, stderr.String()))
+		}
+	}
+
+	conflictFile.Chunks, err = parseConflictChunks(stdout.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse conflicts of %s: %w", conflictFile.Path, err)
+	}
+	conflictFile.IsText = true
+	return conflictFile, nil
+}
+
+// parseConflictChunks splits the diff3 output of git merge-file into chunks
+func parseConflictChunks(content string) ([]*ConflictChunk, error) {
+	oursMarker := strings.Repeat("<", conflictMarkerSize) + " "
+	baseMarker := strings.Repeat("|", conflictMarkerSize) + " "
+	separator := strings.Repeat("=", conflictMarkerSize)
+	theirsMarker := strings.Repeat(">", conflictMarkerSize) + " "
+
+	const (
+		stateCommon = iota
+		stateOurs
+		stateBase
+		stateTheirs
+	)
+
+	var chunks []*ConflictChunk
+	var current *ConflictChunk
+	numConflicts := 0
+	state := stateCommon
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if line == "" {
+			continue
+		}
+		trimmed := strings.TrimRight(line, "\r\n")
+		switch {
+		case state == stateCommon && strings.HasPrefix(trimmed, oursMarker):
+			current = &ConflictChunk{IsConflict: true, Index: numConflicts}
+			chunks = append(chunks, current)
+			numConflicts++
+			state = stateOurs
+		case state == stateOurs && strings.HasPrefix(trimmed, baseMarker):
+			state = stateBase
+		case (state == stateOurs || state == stateBase) && trimmed == separator:
+			state = stateTheirs
+		case state == stateTheirs && strings.HasPrefix(trimmed, theirsMarker):
+			current = nil
+			state = stateCommon
+		case state == stateCommon:
+			if current == nil {
+				current = &ConflictChunk{}
+				chunks = append(chunks, current)
+			}
+			current.Lines = append(current.Lines, line)
+		case state == stateOurs:
+			current.Ours = append(current.Ours, line)
+		case state == stateBase:
+			current.Base = append(current.Base, line)
+		case state == stateTheirs:
+			current.Theirs = append(current.Theirs, line)
+		}
+	}
+	if state != stateCommon {
+		return nil, errors.New("unterminated conflict")
+	}
+	return chunks, nil
+}
+
+// conflictsCacheTTL is the lifetime of the cached conflicts of a pull request, the cache key contains the commits
+// they are computed from, so the entries never need to be invalidated
+const conflictsCacheTTL = 60 * 60
+
+// GetConflicts returns the files which conflict when merging the base branch into the head branch of the pull request.
+// Building the merge is expensive, so the result is cached for the current commits of the branches.
+func GetConflicts(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User) (*Conflicts, error) {
+	if err := loadConflictRepos(ctx, pr); err != nil {
+		return nil, err
+	}
+	headCommitID, err := gitrepo.GetBranchCommitID(ctx, pr.HeadRepo, pr.HeadBranch)
+	if err != nil {
+		return nil, fmt.Errorf("GetBranchCommitID(%s): %w", pr.HeadBranch, err)
+	}
+	baseCommitID, err := gitrepo.GetBranchCommitID(ctx, pr.BaseRepo, pr.BaseBranch)
+	if err != nil {
+		return nil, fmt.Errorf("GetBranchCommitID(%s): %w", pr.BaseBranch, err)
+	}
+
+	key := fmt.Sprintf("pull_conflicts:%d:%s:%s", pr.ID, headCommitID, baseCommitID)
+	cc := cache.GetCache()
+	if cc != nil {
+		cached := &Conflicts{}
+		if exist, err := cc.GetJSON(key, cached); exist && err == nil {
+			return cached, nil
+		}
+	}
+
+	_, cancel, conflicts, err := prepareConflictMerge(ctx, pr, doer)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	// the branches may have moved while the merge was prepared
+	if cc != nil && conflicts.HeadCommitID == headCommitID && conflicts.BaseCommitID == baseCommitID {
+		if err := cc.PutJSON(key, conflicts, conflictsCacheTTL); err != nil {
+			log.Warn("Unable to cache the conflicts of %-v: %v", pr, err)
+		}
+	}
+	return conflicts, nil
+}
+
+// resolveConflictFile writes the resolution of a file into the temporary repository and stages it
+func resolveConflictFile(ctx *mergeContext, file *ConflictFile, choices []ConflictChoice) error {
+	if len(choices) != file.NumConflicts() {
+		return util.NewInvalidArgumentErrorf("expected %d choices for %s but got %d", file.NumConflicts(), file.Path, len(choices))
+	}
+	for _, choice := range choices {
+		if !choice.IsValid() {
+			return util.NewInvalidArgumentErrorf("invalid choice %q for %s", choice, file.Path)
+		}
+	}
+
+	if !file.IsText {
+		var side *lsFileLine
+		switch choices[0] {
+		case ConflictChoiceOurs:
+			side = file.ours
+		case ConflictChoiceTheirs:
+			side = file.theirs
+		default:
+			return util.NewInvalidArgumentErrorf("%s can only be resolved by picking one side", file.Path)
+		}
+		if side == nil {
+			return stageConflictResolution(ctx, file.Path, "", "")
+		}
+		return stageConflictResolution(ctx, file.Path, side.mode, side.sha)
+	}
+
+	var content strings.Builder
+	for _, chunk := range file.Chunks {
+		var choice ConflictChoice
+		if chunk.IsConflict {
+			choice = choices[chunk.Index]
+		}
+		for _, line := range chunk.Resolve(choice) {
+			content.WriteString(line)
+		}
+	}
+
+	blobID, _, err := git.NewCommand("hash-object", "-w", "--stdin").RunStdString(ctx, &git.RunOpts{
+		Dir:   ctx.tmpBasePath,
+		Stdin: strings.NewReader(content.String()),
+	})
+	if err != nil {
+		return fmt.Errorf("git hash-object %s: %w", file.Path, err)
+	}
+	return stageConflictResolution(ctx, file.Path, file.ours.mode, strings.TrimSpace(blobID))
+}
+
+// stageConflictResolution replaces the unmerged stages of a path in the index with the given blob,
+// or removes the path if sha is empty. The sparse working tree is left untouched.
+func stageConflictResolution(ctx *mergeContext, path, mode, sha string) error {
+	info := fmt.Sprintf("0 %s\t%s\n", git.ObjectFormatFromName(ctx.pr.BaseRepo.ObjectFormatName).EmptyObjectID().String(), path)
+	if sha != "" {
+		info += fmt.Sprintf("%s %s 0\t%s\n", mode, sha, path)
+	}
+	if err := git.NewCommand("update-index", "--index-info").Run(ctx, &git.RunOpts{
+		Dir:   ctx.tmpBasePath,
+		Env:   ctx.env,
+		Stdin: strings.NewReader(info),
+	}); err != nil {
+		return fmt.Errorf("git update-index %s: %w", path, err)
+	}
+	return nil
+}
+
+// ResolveConflicts merges the base branch into the head branch of the pull request using the given choices
+// for the conflicting files and pushes the merge commit to the head branch
+func ResolveConflicts(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, opts *ResolveConflictsOptions) error {
+	if pr.HasMerged || pr.Issue != nil && pr.Issue.IsClosed {
+		return util.NewInvalidArgumentErrorf("pull request is not open")
+	}
+
+	releaser, err := globallock.Lock(ctx, getPullWorkingLockKey(pr.ID))
+	if err != nil {
+		log.Error("lock.Lock(): %v", err)
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+
+	mergeCtx, cancel, conflicts, err := prepareConflictMerge(ctx, pr, doer)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if conflicts.HeadCommitID != opts.HeadCommitID || conflicts.BaseCommitID != opts.BaseCommitID {
+		return ErrConflictsOutdated
+	}
+	if len(conflicts.Files) == 0 {
+		return util.NewInvalidArgumentErrorf("pull request has no conflicts")
+	}
+	if len(opts.Choices) != len(conflicts.Files) {
+		return util.NewInvalidArgumentErrorf("all conflicting files must be resolved")
+	}
+
+	for _, file := range conflicts.Files {
+		choices, ok := opts.Choices[file.Path]
+		if !ok {
+			return util.NewInvalidArgumentErrorf("no resolution for %s", file.Path)
+		}
+		if err := resolveConflictFile(mergeCtx, file, choices); err != nil {
+			return err
+		}
+	}
+
+	if err := commitAndSignNoAuthor(mergeCtx, opts.Message); err != nil {
+		return err
+	}
+
+	defer func() {
+		go AddTestPullRequestTask(TestPullRequestOptions{
+			RepoID:      pr.BaseRepo.ID,
+			Doer:        doer,
+			Branch:      pr.BaseBranch,
+			IsSync:      false,
+			IsForcePush: false,
+			OldCommitID: "",
+			NewCommitID: "",
+		})
+	}()
+
+	_, err = pushMergeResult(ctx, mergeCtx, reversePullRequest(pr), doer, repository.PushTriggerPRUpdateWithBase)
+	return err
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConflictChunks(t *testing.T) {
+	ours := strings.Repeat("<", conflictMarkerSize) + " ours\n"
+	base := strings.Repeat("|", conflictMarkerSize) + " base\n"
+	sep := strings.Repeat("=", conflictMarkerSize) + "\n"
+	theirs := strings.Repeat(">", conflictMarkerSize) + " theirs\n"
+
+	content := "a\n" + ours + "b1\n" + base + "b\n" + sep + "b2\nb3\n" + theirs + "c\n<<<<<<< not a marker\n" + ours + sep + "d\n" + theirs
+	chunks, err := parseConflictChunks(content)
+	require.NoError(t, err)
+	require.Len(t, chunks, 4)
+
+	assert.False(t, chunks[0].IsConflict)
+	assert.Equal(t, []string{"a\n"}, chunks[0].Lines)
+
+	assert.True(t, chunks[1].IsConflict)
+	assert.Equal(t, []string{"b1\n"}, chunks[1].Ours)
+	assert.Equal(t, []string{"b\n"}, chunks[1].Base)
+	assert.Equal(t, []string{"b2\n", "b3\n"}, chunks[1].Theirs)
+	assert.Equal(t, []string{"b1\n"}, chunks[1].Resolve(ConflictChoiceOurs))
+	assert.Equal(t, []string{"b2\n", "b3\n"}, chunks[1].Resolve(ConflictChoiceTheirs))
+	assert.Equal(t, []string{"b1\n", "b2\n", "b3\n"}, chunks[1].Resolve(ConflictChoiceBoth))
+
+	assert.Equal(t, []string{"c\n", "<<<<<<< not a marker\n"}, chunks[2].Lines)
+
+	assert.True(t, chunks[3].IsConflict)
+	assert.Equal(t, 1, chunks[3].Index)
+	assert.Empty(t, chunks[3].Ours)
+	assert.Equal(t, []string{"d\n"}, chunks[3].Theirs)
+
+	file := &ConflictFile{IsText: true, Chunks: chunks}
+	assert.Equal(t, 2, file.NumConflicts())
+
+	_, err = parseConflictChunks("a\n" + ours + "b\n")
+	assert.Error(t, err)
+}
@@ -264,8 +264,13 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 		return err
 	}
 
+	return afterMergePushed(ctx, pr.ID, doer, wasAutoMerged)
+}
+
+// afterMergePushed notifies the merge of the pull request which has been pushed to the base branch
+func afterMergePushed(ctx context.Context, prID int64, doer *user_model.User, wasAutoMerged bool) error {
 	// reload pull request because it has been updated by post receive hook
-	pr, err = issues_model.GetPullRequestByID(ctx, pr.ID)
+	pr, err := issues_model.GetPullRequestByID(ctx, prID)
 	if err != nil {
 		return err
 	}
@@ -290,6 +295,12 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 	// Reset cached commit count
 	cache.Remove(pr.Issue.Repo.GetCommitsCountCacheKey(pr.BaseBranch, true))
 
+	if setting.Repository.PullRequest.RetargetChildrenOnMerge {
+		if err := retargetStackedPulls(ctx, doer, pr); err != nil {
+			log.Error("retargetStackedPulls %-v: %v", pr, err)
+		}
+	}
+
 	return handleCloseCrossReferences(ctx, pr, doer)
 }
 
@@ -355,6 +366,12 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 		return "", ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: mergeStyle}
 	}
 
+	return pushMergeResult(ctx, mergeCtx, pr, doer, pushTrigger)
+}
+
+// pushMergeResult pushes the commits prepared on the base branch of the temporary merge repository back to the
+// base branch of the pull request, returning the id of the new head commit
+func pushMergeResult(ctx context.Context, mergeCtx *mergeContext, pr *issues_model.PullRequest, doer *user_model.User, pushTrigger repo_module.PushTrigger) (string, error) {
 	// OK we should cache our current head and origin/headbranch
 	mergeHeadSHA, err := git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, "HEAD")
 	if err != nil {
@@ -587,6 +604,11 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 	if issues_model.MergeBlockedByOfficialReviewRequests(ctx, pb, pr) {
 		return util.ErrorWrap(ErrNotReadyToMerge, "There are official review requests")
 	}
+	if owners, teams, err := GetOutstandingCodeOwners(ctx, pb, pr); err != nil {
+		return err
+	} else if len(owners) > 0 || len(teams) > 0 {
+		return util.ErrorWrap(ErrNotReadyToMerge, "Missing approvals from code owners")
+	}
 
 	if issues_model.MergeBlockedByOutdatedBranch(pb, pr) {
 		return util.ErrorWrap(ErrNotReadyToMerge, "The head branch is behind the base branch")
@@ -706,6 +728,11 @@ func SetMerged(ctx context.Context, pr *issues_model.PullRequest, mergedCommitID
 			return false, fmt.Errorf("DeleteScheduledAutoMerge[%d]: %v", pr.ID, err)
 		}
 
+		// Removing it from the merge queue and ignore if not exist
+		if _, err := pull_model.DeleteMergeQueueEntry(ctx, pr.ID); err != nil {
+			return false, fmt.Errorf("DeleteMergeQueueEntry[%d]: %v", pr.ID, err)
+		}
+
 		// Set issue as closed
 		if _, err := issues_model.SetIssueAsClosed(ctx, pr.Issue, pr.Merger, true); err != nil {
 			return false, fmt.Errorf("ChangeIssueStatus: %w", err)
@@ -0,0 +1,211 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// ErrMergeGroupConflicts represents an error if a pull request of a merge group conflicts with the ones before it
+type ErrMergeGroupConflicts struct {
+	// Index is the index of the conflicting pull request in the merge group
+	Index int
+	Err   error
+}
+
+// IsErrMergeGroupConflicts checks if an error is a ErrMergeGroupConflicts.
+func IsErrMergeGroupConflicts(err error) bool {
+	var e ErrMergeGroupConflicts
+	return errors.As(err, &e)
+}
+
+func (err ErrMergeGroupConflicts) Error() string {
+	return fmt.Sprintf("pull request %d of the merge group conflicts: %v", err.Index, err.Err)
+}
+
+func (err ErrMergeGroupConflicts) Unwrap() error {
+	return err.Err
+}
+
+// MergeGroupPull is a pull request of a merge group and how it is merged
+type MergeGroupPull struct {
+	PR         *issues_model.PullRequest
+	MergeStyle repo_model.MergeStyle
+	Message    string
+	// CommitID is the commit of the merge group after merging the pull request, it's set by CreateMergeGroup
+	CommitID string
+}
+
+func isMergeGroupConflictsErr(err error) bool {
+	return IsErrMergeConflicts(err) || IsErrRebaseConflicts(err) || IsErrMergeUnrelatedHistories(err) || IsErrMergeDivergingFastForwardOnly(err)
+}
+
+// CreateMergeGroup merges the pull requests one after another with their merge styles in a temporary repository,
+// and pushes the combined commit to the given ref of the base repository, so the checks could run against it.
+// The pull requests are merged onto the commit of baseRef if it's given, otherwise onto their base branch.
+// All the pull requests must have the same base repository and branch.
+func CreateMergeGroup(ctx context.Context, doer *user_model.User, pulls []*MergeGroupPull, baseRef, ref string) (baseCommitID string, err error) {
+	if len(pulls) == 0 {
+		return "", errors.New("no pull requests for the merge group")
+	}
+
+	mergeCtx, cancel, err := createTemporaryRepoForMerge(ctx, pulls[0].PR, doer, "")
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+
+	if baseRef != "" {
+		// build the merge group on top of the one being tested ahead of it
+		if err := git.NewCommand("fetch", "--no-tags", "origin").AddDynamicArguments(baseRef).Run(ctx, mergeCtx.RunOpts()); err != nil {
+			return "", fmt.Errorf("unable to fetch %s: %w\n%s\n%s", baseRef, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+		}
+		if err := git.NewCommand("update-ref").AddDynamicArguments(git.BranchPrefix+baseBranch, "FETCH_HEAD").Run(ctx, mergeCtx.RunOpts()); err != nil {
+			return "", fmt.Errorf("unable to update base to %s: %w\n%s\n%s", baseRef, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+		}
+		mergeCtx.outbuf.Reset()
+		mergeCtx.errbuf.Reset()
+	}
+
+	baseCommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, baseBranch)
+	if err != nil {
+		return "", fmt.Errorf("failed to get full commit id for the base of the merge group: %w", err)
+	}
+
+	for i, pull := range pulls {
+		mergeCtx.pr = pull.PR
+		if i > 0 {
+			// the head refs of all pull requests exist in the base repository
+			if err := git.NewCommand("fetch", "--no-tags", "origin").AddDynamicArguments("+"+pull.PR.GetGitHeadRefName()+":"+trackingBranch).
+				Run(ctx, mergeCtx.RunOpts()); err != nil {
+				return "", fmt.Errorf("unable to fetch head of %v: %w\n%s\n%s", pull.PR, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+			}
+			mergeCtx.outbuf.Reset()
+			mergeCtx.errbuf.Reset()
+		}
+		if i > 0 || baseRef != "" {
+			// the files to check out depend on the base and the head
+			if err := prepareTemporaryRepoForMerge(mergeCtx); err != nil {
+				return "", err
+			}
+		}
+
+		switch pull.MergeStyle {
+		case repo_model.MergeStyleMerge:
+			err = doMergeStyleMerge(mergeCtx, pull.Message)
+		case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
+			if err = doMergeStyleRebase(mergeCtx, pull.MergeStyle, pull.Message); err == nil {
+				err = git.NewCommand("branch", "-D").AddDynamicArguments(stagingBranch).Run(ctx, mergeCtx.RunOpts())
+			}
+		case repo_model.MergeStyleSquash:
+			err = doMergeStyleSquash(mergeCtx, pull.Message)
+		case repo_model.MergeStyleFastForwardOnly:
+			err = doMergeStyleFastForwardOnly(mergeCtx)
+		default:
+			err = ErrInvalidMergeStyle{ID: pull.PR.BaseRepoID, Style: pull.MergeStyle}
+		}
+		if err != nil {
+			if isMergeGroupConflictsErr(err) {
+				return "", ErrMergeGroupConflicts{Index: i, Err: err}
+			}
+			return "", err
+		}
+		mergeCtx.outbuf.Reset()
+		mergeCtx.errbuf.Reset()
+
+		if pull.CommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, baseBranch); err != nil {
+			return "", fmt.Errorf("failed to get full commit id of the merge group after merging %v: %w", pull.PR, err)
+		}
+	}
+
+	// the ref is not a branch, so there is nothing for the hooks to do
+	mergeCtx.env = repo_module.InternalPushingEnvironment(doer, pulls[0].PR.BaseRepo)
+	if err := git.NewCommand("push", "--force", "origin").AddDynamicArguments(baseBranch+":"+ref).
+		Run(ctx, mergeCtx.RunOpts()); err != nil {
+		log.Error("Unable to push merge group of %-v to %s: %v\n%s", pulls[0].PR, ref, err, mergeCtx.errbuf.String())
+		return "", fmt.Errorf("git push: %s", strings.TrimSpace(mergeCtx.errbuf.String()))
+	}
+
+	return baseCommitID, nil
+}
+
+// LandMergeGroupPull merges a pull request of a merge group whose checks succeeded by fast-forwarding the base branch
+// to the tested commit of the merge group, so exactly the tested commit lands. The base branch must be at baseCommitID,
+// which is the commit of the merge group before the pull request, otherwise git.ErrPushOutOfDate is returned.
+func LandMergeGroupPull(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID, baseCommitID, commitID string) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return fmt.Errorf("unable to load base repo: %w", err)
+	} else if err := pr.LoadHeadRepo(ctx); err != nil {
+		return fmt.Errorf("unable to load head repo: %w", err)
+	}
+
+	releaser, err := globallock.Lock(ctx, getPullWorkingLockKey(pr.ID))
+	if err != nil {
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+	defer func() {
+		go AddTestPullRequestTask(TestPullRequestOptions{
+			RepoID: pr.BaseRepo.ID,
+			Doer:   doer,
+			Branch: pr.BaseBranch,
+		})
+	}()
+
+	baseGitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return err
+	}
+	defer baseGitRepo.Close()
+	if headCommitID, err := baseGitRepo.GetRefCommitID(pr.GetGitHeadRefName()); err != nil {
+		return err
+	} else if headCommitID != expectedHeadCommitID {
+		return ErrSHADoesNotMatch{GivenSHA: expectedHeadCommitID, CurrentSHA: headCommitID}
+	}
+	if branchCommitID, err := baseGitRepo.GetBranchCommitID(pr.BaseBranch); err != nil {
+		return err
+	} else if branchCommitID != baseCommitID {
+		return &git.ErrPushOutOfDate{Err: fmt.Errorf("branch %s is at %s instead of %s", pr.BaseBranch, branchCommitID, baseCommitID)}
+	}
+
+	if setting.LFS.StartServer {
+		if err := LFSPush(ctx, pr.BaseRepo.RepoPath(), commitID, baseCommitID, pr); err != nil {
+			return err
+		}
+	}
+
+	headUser := doer
+	if err := pr.HeadRepo.LoadOwner(ctx); err == nil {
+		headUser = pr.HeadRepo.Owner
+	} else if !user_model.IsErrUserNotExist(err) {
+		return err
+	}
+
+	// the post receive hook marks the pull request merged
+	env := repo_module.FullPushingEnvironment(headUser, doer, pr.BaseRepo, pr.BaseRepo.Name, pr.ID)
+	env = append(env, repo_module.EnvPushTrigger+"="+string(repo_module.PushTriggerPRMergeToBase))
+	if err := git.Push(ctx, pr.BaseRepo.RepoPath(), git.PushOptions{
+		Remote: pr.BaseRepo.RepoPath(),
+		Branch: commitID + ":" + git.BranchPrefix + pr.BaseBranch,
+		Env:    env,
+	}); err != nil {
+		return err
+	}
+	releaser()
+
+	return afterMergePushed(ctx, pr.ID, doer, true)
+}
@@ -331,7 +331,10 @@ func ChangeTargetBranch(ctx context.Context, pr *issues_model.PullRequest, doer
 	pr.CommitsAhead = divergence.Ahead
 	pr.CommitsBehind = divergence.Behind
 
-	if err := pr.UpdateColsIfNotMerged(ctx, "merge_base", "status", "conflicted_files", "changed_protected_files", "base_branch", "commits_ahead", "commits_behind"); err != nil {
+	// the pull request isn't stacked on its parent anymore, the stack is restored when it is retargeted after the parent was merged
+	pr.StackParentID = 0
+
+	if err := pr.UpdateColsIfNotMerged(ctx, "merge_base", "status", "conflicted_files", "changed_protected_files", "base_branch", "commits_ahead", "commits_behind", "stack_parent_id"); err != nil {
 		return err
 	}
 
@@ -655,9 +658,10 @@ func UpdateRef(ctx context.Context, pr *issues_model.PullRequest) (err error) {
 	return err
 }
 
-// retargetBranchPulls change target branch for all pull requests whose base branch is the branch
-// Both branch and targetBranch must be in the same repo (for security reasons)
-func retargetBranchPulls(ctx context.Context, doer *user_model.User, repoID int64, branch, targetBranch string) error {
+// retargetBranchPulls change target branch for all pull requests whose base branch is the branch,
+// the pull requests stacked on a merged pull request are retargeted to the base branch of their parent instead.
+// Both branch and defaultTargetBranch must be in the same repo (for security reasons)
+func retargetBranchPulls(ctx context.Context, doer *user_model.User, repoID int64, branch, defaultTargetBranch string) error {
 	prs, err := issues_model.GetUnmergedPullRequestsByBaseInfo(ctx, repoID, branch)
 	if err != nil {
 		return err
@@ -669,12 +673,28 @@ func retargetBranchPulls(ctx context.Context, doer *user_model.User, repoID int6
 
 	var errs []error
 	for _, pr := range prs {
+		targetBranch, stackParentID := defaultTargetBranch, int64(0)
+		if pr.StackParentID > 0 {
+			parent, err := issues_model.GetPullRequestByID(ctx, pr.StackParentID)
+			if err != nil && !issues_model.IsErrPullRequestNotExist(err) {
+				errs = append(errs, err)
+				continue
+			}
+			if parent != nil && parent.HasMerged && parent.BaseRepoID == repoID {
+				targetBranch, stackParentID = parent.BaseBranch, parent.ID
+			}
+		}
+
 		if err = pr.Issue.LoadRepo(ctx); err != nil {
 			errs = append(errs, err)
-		} else if err = ChangeTargetBranch(ctx, pr, doer, targetBranch); err != nil &&
-			!issues_model.IsErrIssueIsClosed(err) && !IsErrPullRequestHasMerged(err) &&
-			!issues_model.IsErrPullRequestAlreadyExists(err) {
-			errs = append(errs, err)
+		} else if err = ChangeTargetBranch(ctx, pr, doer, targetBranch); err != nil {
+			if !issues_model.IsErrIssueIsClosed(err) && !IsErrPullRequestHasMerged(err) && !issues_model.IsErrPullRequestAlreadyExists(err) {
+				errs = append(errs, err)
+			}
+		} else if stackParentID > 0 {
+			if err = pr.UpdateStackParent(ctx, stackParentID); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	}
 	return errors.Join(errs...)
@@ -682,7 +702,7 @@ func retargetBranchPulls(ctx context.Context, doer *user_model.User, repoID int6
 
 // AdjustPullsCausedByBranchDeleted close all the pull requests who's head branch is the branch
 // Or Close all the plls who's base branch is the branch if setting.Repository.PullRequest.RetargetChildrenOnMerge is false.
-// If it's true, Retarget all these pulls to the default branch.
+// If it's true, Retarget all these pulls to the default branch, or to the base branch of the merged pull request they are stacked on.
 func AdjustPullsCausedByBranchDeleted(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, branch string) error {
 	// branch as head branch
 	prs, err := issues_model.GetUnmergedPullRequestsByHeadInfo(ctx, repo.ID, branch)
@@ -47,12 +47,25 @@ func (err ErrDismissRequestOnClosedPR) Unwrap() error {
 // ErrSubmitReviewOnClosedPR represents an error when an user tries to submit an approve or reject review associated to a closed or merged PR.
 var ErrSubmitReviewOnClosedPR = errors.New("can't submit review for a closed or merged PR")
 
-// checkInvalidation checks if the line of code comment got changed by another commit.
-// If the line got changed the comment is going to be invalidated.
+// blameCodeComment returns the latest commit of the revision which changed the commented lines,
+// or the commented file if it is a comment on the whole file
+func blameCodeComment(repo *git.Repository, revision string, c *issues_model.Comment) (*git.Commit, error) {
+	if c.IsFileComment() {
+		commit, err := repo.GetCommit(revision)
+		if err != nil {
+			return nil, err
+		}
+		return commit.GetCommitByPath(c.TreePath)
+	}
+	return repo.LineRangeBlame(revision, repo.Path, c.TreePath, uint(c.UnsignedStartLine()), uint(c.UnsignedLine()))
+}
+
+// checkInvalidation checks if the lines (or the file) of code comment got changed by another commit.
+// If they got changed the comment is going to be invalidated.
 func checkInvalidation(ctx context.Context, c *issues_model.Comment, repo *git.Repository, branch string) error {
 	// FIXME differentiate between previous and proposed line
-	commit, err := repo.LineBlame(branch, repo.Path, c.TreePath, uint(c.UnsignedLine()))
-	if err != nil && (strings.Contains(err.Error(), "fatal: no such path") || notEnoughLines.MatchString(err.Error())) {
+	commit, err := blameCodeComment(repo, branch, c)
+	if err != nil && (strings.Contains(err.Error(), "fatal: no such path") || notEnoughLines.MatchString(err.Error()) || git.IsErrNotExist(err)) {
 		c.Invalidated = true
 		return issues_model.UpdateCommentInvalidate(ctx, c)
 	}
@@ -90,13 +103,21 @@ func InvalidateCodeComments(ctx context.Context, prs issues_model.PullRequestLis
 	return nil
 }
 
-// CreateCodeComment creates a comment on the code line
-func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, issue *issues_model.Issue, line int64, content, treePath string, pendingReview bool, replyReviewID int64, latestCommitID string, attachments []string) (*issues_model.Comment, error) {
+// CreateCodeComment creates a comment on the code line, on the lines from startLine to line if startLine is not 0,
+// or on the whole file if line is 0
+func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, issue *issues_model.Issue, line, startLine int64, content, treePath string, pendingReview bool, replyReviewID int64, latestCommitID string, attachments []string) (*issues_model.Comment, error) {
 	var (
 		existsReview bool
 		err          error
 	)
 
+	if startLine == line {
+		startLine = 0
+	}
+	if startLine != 0 && (line == 0 || (startLine < 0) != (line < 0) || max(startLine, -startLine) > max(line, -line)) {
+		return nil, util.NewInvalidArgumentErrorf("start line %d must be before line %d on the same side", startLine, line)
+	}
+
 	// CreateCodeComment() is used for:
 	// - Single comments
 	// - Comments that are part of a review
@@ -123,6 +144,7 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 			content,
 			treePath,
 			line,
+			startLine,
 			replyReviewID,
 			attachments,
 		)
@@ -164,6 +186,7 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 		content,
 		treePath,
 		line,
+		startLine,
 		review.ID,
 		attachments,
 	)
@@ -183,8 +206,8 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 	return comment, nil
 }
 
-// createCodeComment creates a plain code comment at the specified line / path
-func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content, treePath string, line, reviewID int64, attachments []string) (*issues_model.Comment, error) {
+// createCodeComment creates a plain code comment at the specified line(s) / path
+func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content, treePath string, line, startLine, reviewID int64, attachments []string) (*issues_model.Comment, error) {
 	var commitID, patch string
 	if err := issue.LoadPullRequest(ctx); err != nil {
 		return nil, fmt.Errorf("LoadPullRequest: %w", err)
@@ -201,13 +224,14 @@ func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_mo
 
 	invalidated := false
 	head := pr.GetGitHeadRefName()
-	if line > 0 {
+	if line >= 0 {
 		if reviewID != 0 {
 			first, err := issues_model.FindComments(ctx, &issues_model.FindCommentsOptions{
-				ReviewID: reviewID,
-				Line:     line,
-				TreePath: treePath,
-				Type:     issues_model.CommentTypeCode,
+				ReviewID:  reviewID,
+				Line:      line,
+				FileLevel: line == 0,
+				TreePath:  treePath,
+				Type:      issues_model.CommentTypeCode,
 				ListOptions: db.ListOptions{
 					PageSize: 1,
 					Page:     1,
@@ -217,6 +241,8 @@ func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_mo
 				commitID = first[0].CommitSHA
 				invalidated = first[0].Invalidated
 				patch = first[0].Patch
+				// a reply belongs to the conversation, so it comments the same lines
+				startLine = first[0].StartLine
 			} else if err != nil && !issues_model.IsErrCommentNotExist(err) {
 				return nil, fmt.Errorf("Find first comment for %d line %d path %s. Error: %w", reviewID, line, treePath, err)
 			} else {
@@ -231,19 +257,19 @@ func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_mo
 
 		if len(commitID) == 0 {
 			// FIXME validate treePath
-			// Get latest commit referencing the commented line
+			// Get latest commit referencing the commented lines
 			// No need for get commit for base branch changes
-			commit, err := gitRepo.LineBlame(head, gitRepo.Path, treePath, uint(line))
+			commit, err := blameCodeComment(gitRepo, head, &issues_model.Comment{Type: issues_model.CommentTypeCode, Line: line, StartLine: startLine, TreePath: treePath})
 			if err == nil {
 				commitID = commit.ID.String()
-			} else if !(strings.Contains(err.Error(), "exit status 128 - fatal: no such path") || notEnoughLines.MatchString(err.Error())) {
-				return nil, fmt.Errorf("LineBlame[%s, %s, %s, %d]: %w", pr.GetGitHeadRefName(), gitRepo.Path, treePath, line, err)
+			} else if !(strings.Contains(err.Error(), "exit status 128 - fatal: no such path") || notEnoughLines.MatchString(err.Error()) || git.IsErrNotExist(err)) {
+				return nil, fmt.Errorf("blame[%s, %s, %s, %d-%d]: %w", pr.GetGitHeadRefName(), gitRepo.Path, treePath, startLine, line, err)
 			}
 		}
 	}
 
-	// Only fetch diff if comment is review comment
-	if len(patch) == 0 && reviewID != 0 {
+	// Only fetch diff if comment is review comment, a comment on the whole file has no hunk
+	if len(patch) == 0 && reviewID != 0 && line != 0 {
 		headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitHeadRefName())
 		if err != nil {
 			return nil, fmt.Errorf("GetRefCommitID[%s]: %w", pr.GetGitHeadRefName(), err)
@@ -264,25 +290,29 @@ func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_mo
 			_ = writer.Close()
 		}()
 
-		patch, err = git.CutDiffAroundLine(reader, int64((&issues_model.Comment{Line: line}).UnsignedLine()), line < 0, setting.UI.CodeCommentLines)
+		// the hunk has to contain all the commented lines
+		commented := &issues_model.Comment{Line: line, StartLine: startLine}
+		numberOfLines := max(setting.UI.CodeCommentLines, int(commented.UnsignedLine()-commented.UnsignedStartLine())+1)
+		patch, err = git.CutDiffAroundLine(reader, int64(commented.UnsignedLine()), line < 0, numberOfLines)
 		if err != nil {
 			log.Error("Error whilst generating patch: %v", err)
 			return nil, err
 		}
 	}
 	return issues_model.CreateComment(ctx, &issues_model.CreateCommentOptions{
-		Type:        issues_model.CommentTypeCode,
-		Doer:        doer,
-		Repo:        repo,
-		Issue:       issue,
-		Content:     content,
-		LineNum:     line,
-		TreePath:    treePath,
-		CommitSHA:   commitID,
-		ReviewID:    reviewID,
-		Patch:       patch,
-		Invalidated: invalidated,
-		Attachments: attachments,
+		Type:         issues_model.CommentTypeCode,
+		Doer:         doer,
+		Repo:         repo,
+		Issue:        issue,
+		Content:      content,
+		LineNum:      line,
+		StartLineNum: startLine,
+		TreePath:     treePath,
+		CommitSHA:    commitID,
+		ReviewID:     reviewID,
+		Patch:        patch,
+		Invalidated:  invalidated,
+		Attachments:  attachments,
 	})
 }
 
@@ -4,12 +4,14 @@
 package pull_test
 
 import (
+	"fmt"
 	"testing"
 
 	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
 	pull_service "code.gitea.io/gitea/services/pull"
 
 	"github.com/stretchr/testify/assert"
@@ -46,3 +48,17 @@ func TestDismissReview(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, pull_service.IsErrDismissRequestOnClosedPR(err))
 }
+
+func TestCreateCodeCommentInvalidRange(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	pull := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{})
+	assert.NoError(t, pull.LoadIssue(db.DefaultContext))
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	for _, lines := range [][2]int64{{5, 3}, {-2, 3}, {2, -3}, {1, 0}} {
+		t.Run(fmt.Sprintf("%d-%d", lines[0], lines[1]), func(t *testing.T) {
+			_, err := pull_service.CreateCodeComment(db.DefaultContext, doer, nil, pull.Issue, lines[1], lines[0], "content", "README.md", true, 0, "", nil)
+			assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	asymkey_service "code.gitea.io/gitea/services/asymkey"
+)
+
+// CheckPullRulesets checks the merge rules of the org and instance level rulesets targeting the base branch of the pull request.
+// The rulesets the doer can bypass are skipped, and the violations of evaluate-only rulesets are logged without blocking.
+// They are checked in addition to the protected branch of the repository, so the stricter rule wins.
+func CheckPullRulesets(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, isRepoAdmin bool) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+
+	rulesets, err := git_model.GetRulesetsForRef(ctx, pr.BaseRepo, git.RefNameFromBranch(pr.BaseBranch).String())
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range rulesets {
+		if canBypass, err := rs.CanBypass(ctx, doer, isRepoAdmin); err != nil {
+			return err
+		} else if canBypass {
+			continue
+		}
+
+		violation, err := CheckPullRuleset(ctx, rs, pr, doer)
+		if err != nil {
+			return err
+		} else if violation == "" {
+			continue
+		}
+
+		if rs.IsEvaluateOnly() {
+			log.Info("Ruleset %q (evaluate only) would block merging %-v by %-v: %s", rs.Name, pr, doer, violation)
+			continue
+		}
+		return util.ErrorWrap(ErrNotReadyToMerge, "Ruleset %q: %s", rs.Name, violation)
+	}
+	return nil
+}
+
+// CheckPullRuleset returns the first merge rule of the ruleset the pull request violates, or an empty string
+func CheckPullRuleset(ctx context.Context, rs *git_model.Ruleset, pr *issues_model.PullRequest, doer *user_model.User) (string, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return "", err
+	}
+	pb := rs.ToProtectedBranch(pr.BaseRepo)
+
+	if pb.EnableStatusCheck {
+		commitStatuses, err := getPullRequestHeadCommitStatuses(ctx, pr)
+		if err != nil {
+			return "", err
+		}
+		if !MergeRequiredContextsCommitStatus(commitStatuses, pb.StatusCheckContexts).IsSuccess() {
+			return "Not all required status checks successful", nil
+		}
+	}
+
+	if !issues_model.HasEnoughApprovals(ctx, pb, pr) {
+		return "Does not have enough approvals", nil
+	}
+	if issues_model.MergeBlockedByRejectedReview(ctx, pb, pr) {
+		return "There are requested changes", nil
+	}
+	if owners, teams, err := GetOutstandingCodeOwners(ctx, pb, pr); err != nil {
+		return "", err
+	} else if len(owners) > 0 || len(teams) > 0 {
+		return "Missing approvals from code owners", nil
+	}
+	if issues_model.MergeBlockedByOutdatedBranch(pb, pr) {
+		return "The head branch is behind the base branch", nil
+	}
+
+	if pb.RequireSignedCommits && doer != nil {
+		sign, _, _, err := asymkey_service.SignMerge(ctx, pr, doer, pr.BaseRepo.RepoPath(), pr.BaseBranch, pr.GetGitHeadRefName())
+		if err != nil && !asymkey_service.IsErrWontSign(err) {
+			return "", err
+		}
+		if !sign {
+			return "The merge commit would not be signed", nil
+		}
+	}
+	return "", nil
+}
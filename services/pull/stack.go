@@ -0,0 +1,171 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const stackParentBranch = "stack_parent" // name of the branch holding the head of the merged parent in the temporary repository
+
+// CheckStackParent checks whether the pull request can be stacked on the parent pull request,
+// the parent must be an open pull request of the same repository whose head branch is the base branch of the pull request
+func CheckStackParent(ctx context.Context, pr, parent *issues_model.PullRequest) error {
+	if pr.Flow != issues_model.PullRequestFlowGithub || parent.Flow != issues_model.PullRequestFlowGithub {
+		return util.NewInvalidArgumentErrorf("AGit pull requests can't be stacked")
+	}
+	if parent.ID == pr.ID || parent.BaseRepoID != pr.BaseRepoID || parent.HeadRepoID != pr.BaseRepoID || parent.HeadBranch != pr.BaseBranch {
+		return util.NewInvalidArgumentErrorf("the pull request can only be stacked on a pull request of the same repository whose head branch is its base branch")
+	}
+	if err := parent.LoadIssue(ctx); err != nil {
+		return err
+	}
+	if parent.HasMerged || parent.Issue.IsClosed {
+		return util.NewInvalidArgumentErrorf("the pull request can't be stacked on a closed pull request")
+	}
+
+	if pr.ID > 0 {
+		stack, err := issues_model.GetPullRequestStack(ctx, parent)
+		if err != nil {
+			return err
+		}
+		for _, p := range stack {
+			if p.ID == parent.ID {
+				break
+			}
+			if p.ID == pr.ID {
+				return util.NewInvalidArgumentErrorf("the pull request can't be stacked on a pull request stacked on it")
+			}
+		}
+	}
+	return nil
+}
+
+// SetStackParent stacks the pull request on the parent pull request, or unstacks it if the parent is nil.
+// Only the stacked pull requests are retargeted and rebased when their parent is merged.
+func SetStackParent(ctx context.Context, pr, parent *issues_model.PullRequest) error {
+	if parent == nil {
+		return pr.UpdateStackParent(ctx, 0)
+	}
+	if err := CheckStackParent(ctx, pr, parent); err != nil {
+		return err
+	}
+	return pr.UpdateStackParent(ctx, parent.ID)
+}
+
+// retargetStackedPulls retargets the pull requests stacked on the merged pull request to its base branch,
+// and rebases them onto the merge result so they don't contain the commits of the merged pull request anymore.
+// The other pull requests targeting the head branch of the merged pull request are left untouched.
+func retargetStackedPulls(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	if pr.HeadRepoID != pr.BaseRepoID || pr.Flow != issues_model.PullRequestFlowGithub {
+		return nil
+	}
+
+	prs, err := issues_model.GetStackChildren(ctx, pr.ID)
+	if err != nil {
+		return err
+	}
+	if err := prs.LoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, child := range prs {
+		if child.HasMerged || child.Issue.IsClosed || child.BaseRepoID != pr.BaseRepoID || child.BaseBranch != pr.HeadBranch {
+			continue
+		}
+		if err := child.Issue.LoadRepo(ctx); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := ChangeTargetBranch(ctx, child, doer, pr.BaseBranch); err != nil {
+			if !issues_model.IsErrIssueIsClosed(err) && !IsErrPullRequestHasMerged(err) && !issues_model.IsErrPullRequestAlreadyExists(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		// keep the merged pull request in the stack, so the chain stays navigable
+		if err := child.UpdateStackParent(ctx, pr.ID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := rebaseStackedPull(ctx, doer, child, pr); err != nil {
+			// the author of the stacked pull request has to update its head branch
+			if _, err := issues_model.CreateStackRebaseFailedComment(ctx, child, doer, pr); err != nil {
+				errs = append(errs, err)
+			}
+			if !errors.Is(err, errStackRebaseNotAllowed) {
+				errs = append(errs, fmt.Errorf("rebase %v onto the merge of %v: %w", child, pr, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// errStackRebaseNotAllowed is returned when the doer isn't allowed to update the head branch of a stacked pull request by rebase
+var errStackRebaseNotAllowed = errors.New("not allowed to rebase the head branch")
+
+// rebaseStackedPull rebases the commits of the pull request which are not part of its merged parent onto its base branch.
+func rebaseStackedPull(ctx context.Context, doer *user_model.User, pr, parent *issues_model.PullRequest) error {
+	if pr.Flow != issues_model.PullRequestFlowGithub {
+		return nil
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return err
+	} else if pr.HeadRepo == nil {
+		return nil
+	}
+	if _, rebaseAllowed, err := IsUserAllowedToUpdate(ctx, pr, doer); err != nil {
+		return err
+	} else if !rebaseAllowed {
+		return errStackRebaseNotAllowed
+	}
+
+	releaser, err := globallock.Lock(ctx, getPullWorkingLockKey(pr.ID))
+	if err != nil {
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+
+	mergeCtx, cancel, err := createTemporaryRepoForMerge(ctx, pr, doer, "")
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	// the head ref of the merged parent still exists in the base repository
+	if err := git.NewCommand("fetch", "--no-tags", "origin").AddDynamicArguments("+"+parent.GetGitHeadRefName()+":"+stackParentBranch).
+		Run(ctx, mergeCtx.RunOpts()); err != nil {
+		return fmt.Errorf("unable to fetch head of %v: %w\n%s\n%s", parent, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+	}
+
+	oldMergeBase, _, _ := git.NewCommand("merge-base").AddDashesAndList(baseBranch, trackingBranch).RunStdString(ctx, &git.RunOpts{Dir: mergeCtx.tmpBasePath})
+	oldMergeBase = strings.TrimSpace(oldMergeBase)
+
+	if err := git.NewCommand("checkout", "-b").AddDynamicArguments(stagingBranch, trackingBranch).
+		Run(ctx, mergeCtx.RunOpts()); err != nil {
+		return fmt.Errorf("unable to git checkout tracking as staging in temp repo for %v: %w\n%s\n%s", pr, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+	}
+	mergeCtx.outbuf.Reset()
+	mergeCtx.errbuf.Reset()
+
+	// drop the commits of the parent, they have been merged into the base branch already
+	if err := git.NewCommand("rebase", "--onto").AddDynamicArguments(baseBranch, stackParentBranch).
+		Run(ctx, mergeCtx.RunOpts()); err != nil {
+		return fmt.Errorf("unable to git rebase staging on to base in temp repo for %v: %w\n%s\n%s", pr, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+	}
+	mergeCtx.outbuf.Reset()
+	mergeCtx.errbuf.Reset()
+
+	return pushRebasedHead(ctx, mergeCtx, pr, doer, oldMergeBase)
+}
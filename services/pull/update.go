@@ -77,7 +77,14 @@ func Update(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.
 	// TODO: FakePR: it is somewhat hacky, but it is the only way to "merge" at the moment
 	// ideally in the future the "merge" functions should be refactored to decouple from the PullRequest
 	// now use a fake reverse PR to switch head&base repos/branches
-	reversePR := &issues_model.PullRequest{
+	_, err = doMergeAndPush(ctx, reversePullRequest(pr), doer, repo_model.MergeStyleMerge, "", message, repository.PushTriggerPRUpdateWithBase)
+	return err
+}
+
+// reversePullRequest returns a fake pull request with head and base switched so that the merge functions
+// update the head branch with the base branch
+func reversePullRequest(pr *issues_model.PullRequest) *issues_model.PullRequest {
+	return &issues_model.PullRequest{
 		ID: pr.ID,
 
 		HeadRepoID: pr.BaseRepoID,
@@ -88,9 +95,6 @@ func Update(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.
 		BaseRepo:   pr.HeadRepo,
 		BaseBranch: pr.HeadBranch,
 	}
-
-	_, err = doMergeAndPush(ctx, reversePR, doer, repo_model.MergeStyleMerge, "", message, repository.PushTriggerPRUpdateWithBase)
-	return err
 }
 
 // IsUserAllowedToUpdate check if user is allowed to update PR with given permissions and branch protections
@@ -35,6 +35,11 @@ func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullReques
 		return err
 	}
 
+	return pushRebasedHead(ctx, mergeCtx, pr, doer, oldMergeBase)
+}
+
+// pushRebasedHead pushes the staging branch of the temporary repository back to the head branch of the pull request
+func pushRebasedHead(ctx context.Context, mergeCtx *mergeContext, pr *issues_model.PullRequest, doer *user_model.User, oldMergeBase string) error {
 	if setting.LFS.StartServer {
 		// Now we need to ensure that the head repository contains any LFS objects between the new base and the old mergebase
 		// It's questionable about where this should go - either after or before the push
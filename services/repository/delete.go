@@ -20,6 +20,7 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	secret_model "code.gitea.io/gitea/models/secret"
 	system_model "code.gitea.io/gitea/models/system"
+	terraform_model "code.gitea.io/gitea/models/terraform"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/models/webhook"
 	actions_module "code.gitea.io/gitea/modules/actions"
@@ -30,6 +31,7 @@ import (
 	actions_service "code.gitea.io/gitea/services/actions"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	issue_service "code.gitea.io/gitea/services/issue"
+	terraform_service "code.gitea.io/gitea/services/terraform"
 
 	"xorm.io/builder"
 )
@@ -81,6 +83,12 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return fmt.Errorf("list actions artifacts of repo %v: %w", repoID, err)
 	}
 
+	// Query the terraform state versions of this repo, they will be needed after they have been deleted to remove the files in ObjectStorage
+	terraformStateVersions, err := terraform_model.GetStateVersionsByRepo(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("list terraform state versions of repo %v: %w", repoID, err)
+	}
+
 	// In case owner is a organization, we have to change repo specific teams
 	// if ignoreOrgTeams is not true
 	var org *user_model.User
@@ -143,6 +151,10 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return fmt.Errorf("cleanupEphemeralRunners: %w", err)
 	}
 
+	if err := actions_model.RemoveRepoFromRunnerGroups(ctx, repo.OwnerID, repoID); err != nil {
+		return fmt.Errorf("RemoveRepoFromRunnerGroups: %w", err)
+	}
+
 	if err := db.DeleteBeans(ctx,
 		&access_model.Access{RepoID: repo.ID},
 		&activities_model.Action{RepoID: repo.ID},
@@ -177,7 +189,14 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&actions_model.ActionSchedule{RepoID: repoID},
 		&actions_model.ActionArtifact{RepoID: repoID},
 		&actions_model.ActionRunnerToken{RepoID: repoID},
+		&actions_model.ActionApprovalPolicy{RepoID: repoID},
+		&git_model.PushRule{RepoID: repoID},
+		&git_model.SecretScanAlert{RepoID: repoID},
+		&actions_model.ActionTaskSummary{RepoID: repoID},
+		&actions_model.ActionTaskAnnotation{RepoID: repoID},
 		&issues_model.IssuePin{RepoID: repoID},
+		&terraform_model.TerraformStateVersion{RepoID: repoID},
+		&terraform_model.TerraformState{RepoID: repoID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
 	}
@@ -369,6 +388,9 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		}
 	}
 
+	// delete terraform states in ObjectStorage after the repo have already been deleted
+	terraform_service.RemoveStateVersionFiles(terraformStateVersions)
+
 	return nil
 }
 
@@ -0,0 +1,216 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package files
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+// ErrSuggestionOutdated represents an error that the lines of a suggestion have changed since the suggestion was made
+type ErrSuggestionOutdated struct {
+	CommentID int64
+}
+
+// IsErrSuggestionOutdated checks if an error is a ErrSuggestionOutdated.
+func IsErrSuggestionOutdated(err error) bool {
+	_, ok := err.(ErrSuggestionOutdated)
+	return ok
+}
+
+func (err ErrSuggestionOutdated) Error() string {
+	return fmt.Sprintf("the lines of the suggestion have changed since it was made [comment_id: %d]", err.CommentID)
+}
+
+type suggestionEdit struct {
+	start, end int // 1-based, inclusive
+	lines      []string
+}
+
+type suggestionFile struct {
+	entryID string
+	lines   []string
+	edits   []*suggestionEdit
+}
+
+func readBlobLines(commit *git.Commit, treePath string) (entryID string, lines []string, err error) {
+	entry, err := commit.GetTreeEntryByPath(treePath)
+	if err != nil {
+		return "", nil, err
+	}
+	content, err := entry.Blob().GetBlobContent(entry.Blob().Size())
+	if err != nil {
+		return "", nil, err
+	}
+	return entry.ID.String(), strings.Split(content, "\n"), nil
+}
+
+// ApplySuggestions commits the suggested changes of the given code comments to the head branch of the pull request
+// as a single commit, the authors of the suggestions are added as co-authors.
+// It refuses to apply a suggestion if the commented lines have been changed since the comment was made.
+func ApplySuggestions(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, commentIDs []int64, message string) (*api.FilesResponse, error) {
+	commentIDs = container.SetOf(commentIDs...).Values()
+	if len(commentIDs) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("no suggestion to apply")
+	}
+	slices.Sort(commentIDs)
+
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+	if pr.HasMerged || pr.Issue.IsClosed {
+		return nil, util.NewInvalidArgumentErrorf("pull request is closed")
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return nil, err
+	}
+	if pr.HeadRepo == nil {
+		return nil, util.NewInvalidArgumentErrorf("head repository of the pull request has been deleted")
+	}
+	if allowed, _, err := pull_service.IsUserAllowedToUpdate(ctx, pr, doer); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, util.NewPermissionDeniedErrorf("not allowed to push to the head branch of the pull request")
+	}
+
+	comments := make([]*issues_model.Comment, 0, len(commentIDs))
+	for _, id := range commentIDs {
+		c, err := issues_model.GetCommentByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if c.IssueID != pr.IssueID || c.Type != issues_model.CommentTypeCode {
+			return nil, issues_model.ErrCommentNotExist{ID: id}
+		}
+		if err := c.LoadReview(ctx); err != nil {
+			return nil, err
+		}
+		if c.Review != nil && c.Review.IsPending() {
+			return nil, issues_model.ErrCommentNotExist{ID: id}
+		}
+		if len(c.Suggestions()) != 1 {
+			return nil, util.NewInvalidArgumentErrorf("comment %d does not contain exactly one suggestion", id)
+		}
+		if c.Invalidated || c.CommitSHA == "" {
+			return nil, ErrSuggestionOutdated{CommentID: id}
+		}
+		if err := c.LoadPoster(ctx); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.HeadRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	headCommit, err := gitRepo.GetBranchCommit(pr.HeadBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	changedFiles := make(map[string]*suggestionFile)
+	var treePaths []string
+	for _, c := range comments {
+		file, ok := changedFiles[c.TreePath]
+		if !ok {
+			entryID, lines, err := readBlobLines(headCommit, c.TreePath)
+			if git.IsErrNotExist(err) {
+				return nil, ErrSuggestionOutdated{CommentID: c.ID}
+			} else if err != nil {
+				return nil, err
+			}
+			file = &suggestionFile{entryID: entryID, lines: lines}
+			changedFiles[c.TreePath] = file
+			treePaths = append(treePaths, c.TreePath)
+		}
+
+		// the commented lines must be the same as in the commit which has been reviewed
+		start, end := c.SuggestionLineRange()
+		if int(end) > len(file.lines) {
+			return nil, ErrSuggestionOutdated{CommentID: c.ID}
+		}
+		if c.CommitSHA != headCommit.ID.String() {
+			reviewedCommit, err := gitRepo.GetCommit(c.CommitSHA)
+			if err != nil {
+				return nil, ErrSuggestionOutdated{CommentID: c.ID}
+			}
+			_, reviewedLines, err := readBlobLines(reviewedCommit, c.TreePath)
+			if err != nil || int(end) > len(reviewedLines) || !slices.Equal(reviewedLines[start-1:end], file.lines[start-1:end]) {
+				return nil, ErrSuggestionOutdated{CommentID: c.ID}
+			}
+		}
+
+		replacement := slices.Clone(c.Suggestions()[0].Lines)
+		if strings.HasSuffix(file.lines[end-1], "\r") {
+			for i := range replacement {
+				replacement[i] += "\r"
+			}
+		}
+		file.edits = append(file.edits, &suggestionEdit{start: int(start), end: int(end), lines: replacement})
+	}
+
+	opts := &ChangeRepoFilesOptions{
+		LastCommitID: headCommit.ID.String(),
+		OldBranch:    pr.HeadBranch,
+		NewBranch:    pr.HeadBranch,
+		Message:      message,
+	}
+	for _, treePath := range treePaths {
+		file := changedFiles[treePath]
+		slices.SortFunc(file.edits, func(a, b *suggestionEdit) int { return b.start - a.start })
+		for i := 1; i < len(file.edits); i++ {
+			if file.edits[i].end >= file.edits[i-1].start {
+				return nil, util.NewInvalidArgumentErrorf("suggestions for %q overlap", treePath)
+			}
+		}
+		lines := file.lines
+		for _, edit := range file.edits {
+			lines = slices.Replace(lines, edit.start-1, edit.end, edit.lines...)
+		}
+		opts.Files = append(opts.Files, &ChangeRepoFile{
+			Operation:     "update",
+			TreePath:      treePath,
+			ContentReader: strings.NewReader(strings.Join(lines, "\n")),
+			SHA:           file.entryID,
+		})
+	}
+
+	if opts.Message == "" {
+		opts.Message = "Apply suggestion from code review"
+		if len(comments) > 1 {
+			opts.Message = "Apply suggestions from code review"
+		}
+	}
+	for _, c := range comments {
+		if c.PosterID != doer.ID && c.Poster.ID > 0 {
+			opts.Message = pull_service.AddCommitMessageTailer(opts.Message, "Co-authored-by", c.Poster.NewGitSig().String())
+		}
+	}
+
+	filesResponse, err := ChangeRepoFiles(ctx, pr.HeadRepo, doer, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range comments {
+		if err := issues_model.MarkConversation(ctx, c, doer, true); err != nil {
+			return nil, err
+		}
+	}
+	return filesResponse, nil
+}
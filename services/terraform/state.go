@@ -0,0 +1,268 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+
+	"code.gitea.io/gitea/models/db"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/secret"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrInvalidStateName = util.NewInvalidArgumentErrorf("terraform state name is invalid")
+	ErrStateTooLarge    = util.NewInvalidArgumentErrorf("terraform state is too large")
+	ErrInvalidLockInfo  = util.NewInvalidArgumentErrorf("terraform lock information is invalid")
+)
+
+var stateNamePattern = regexp.MustCompile(`\A[A-Za-z0-9][A-Za-z0-9._-]{0,254}\z`)
+
+// IsValidStateName checks if the name can be used for a new state
+func IsValidStateName(name string) bool {
+	return stateNamePattern.MatchString(name)
+}
+
+// ErrStateLocked is returned if the state is locked by another client
+type ErrStateLocked struct {
+	LockInfo string
+}
+
+func (err ErrStateLocked) Error() string {
+	return "terraform state is locked"
+}
+
+// lockInfo is the part of the lock information sent by Terraform which is needed by the backend
+type lockInfo struct {
+	ID string `json:"ID"`
+}
+
+// The locks of the states are held across requests and are therefore persisted in the database.
+// The global lock only serializes the modifications of a state.
+func getLockKey(repoID int64, name string) string {
+	return fmt.Sprintf("terraform_state_%d_%s", repoID, name)
+}
+
+func getEncryptionKey() []byte {
+	key := sha256.Sum256([]byte(setting.SecretKey))
+	return key[:]
+}
+
+// ReadStateVersion reads and decrypts the content of a state version
+func ReadStateVersion(v *terraform_model.TerraformStateVersion) ([]byte, error) {
+	f, err := storage.TerraformStates.Open(v.StoragePath())
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	encrypted, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := secret.AesDecrypt(getEncryptionKey(), encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt terraform state version %d, the SECRET_KEY might be incorrect: %w", v.ID, err)
+	}
+
+	hash := sha256.Sum256(content)
+	if hex.EncodeToString(hash[:]) != v.HashSHA256 {
+		return nil, fmt.Errorf("checksum mismatch of terraform state version %d", v.ID)
+	}
+	return content, nil
+}
+
+// ReadLatestState reads the content of the latest version of a state
+func ReadLatestState(ctx context.Context, repoID int64, name string) ([]byte, error) {
+	s, err := terraform_model.GetStateByName(ctx, repoID, name)
+	if err != nil {
+		return nil, err
+	}
+	if s.LatestVersion == 0 {
+		return nil, terraform_model.ErrStateVersionNotExist
+	}
+
+	v, err := terraform_model.GetStateVersion(ctx, s.ID, s.LatestVersion)
+	if err != nil {
+		return nil, err
+	}
+	return ReadStateVersion(v)
+}
+
+// UpdateState stores the content as new version of a state. If the state is locked, the ID of the lock must be provided.
+// Versions exceeding the configured number of kept versions are deleted.
+func UpdateState(ctx context.Context, repoID int64, doer *user_model.User, name, lockID string, content []byte) error {
+	if int64(len(content)) > setting.TerraformState.MaxSize*1024*1024 {
+		return ErrStateTooLarge
+	}
+
+	var storagePath string
+	var expired []*terraform_model.TerraformStateVersion
+
+	err := globallock.LockAndDo(ctx, getLockKey(repoID, name), func(ctx context.Context) error {
+		return db.WithTx(ctx, func(ctx context.Context) error {
+			s, err := terraform_model.GetOrInsertState(ctx, repoID, name)
+			if err != nil {
+				return err
+			}
+			if s.IsLocked() && s.LockID != lockID {
+				return ErrStateLocked{LockInfo: s.LockInfo}
+			}
+
+			encrypted, err := secret.AesEncrypt(getEncryptionKey(), content)
+			if err != nil {
+				return err
+			}
+
+			hash := sha256.Sum256(content)
+			v := &terraform_model.TerraformStateVersion{
+				RepoID:     repoID,
+				StateID:    s.ID,
+				Version:    s.LatestVersion + 1,
+				Size:       int64(len(content)),
+				HashSHA256: hex.EncodeToString(hash[:]),
+				CreatorID:  doer.ID,
+			}
+			if _, err := storage.TerraformStates.Save(v.StoragePath(), bytes.NewReader(encrypted), int64(len(encrypted))); err != nil {
+				return err
+			}
+			storagePath = v.StoragePath()
+
+			if err := terraform_model.InsertStateVersion(ctx, v); err != nil {
+				return err
+			}
+
+			s.LatestVersion = v.Version
+			if err := terraform_model.UpdateStateCols(ctx, s, "latest_version"); err != nil {
+				return err
+			}
+
+			if setting.TerraformState.MaxVersions <= 0 {
+				return nil
+			}
+			keepFrom := v.Version - setting.TerraformState.MaxVersions + 1
+			expired, err = terraform_model.GetStateVersionsBefore(ctx, s.ID, keepFrom)
+			if err != nil {
+				return err
+			}
+			return terraform_model.DeleteStateVersionsBefore(ctx, s.ID, keepFrom)
+		})
+	})
+	if err != nil {
+		// the content was stored but the version was not committed
+		if storagePath != "" {
+			if err := storage.TerraformStates.Delete(storagePath); err != nil {
+				log.Error("Error deleting terraform state content %s from storage: %v", storagePath, err)
+			}
+		}
+		return err
+	}
+
+	RemoveStateVersionFiles(expired)
+	return nil
+}
+
+// DeleteState deletes a state with all its versions. If the state is locked, the ID of the lock must be provided.
+func DeleteState(ctx context.Context, repoID int64, name, lockID string) error {
+	var versions []*terraform_model.TerraformStateVersion
+
+	err := globallock.LockAndDo(ctx, getLockKey(repoID, name), func(ctx context.Context) error {
+		s, err := terraform_model.GetStateByName(ctx, repoID, name)
+		if err != nil {
+			return err
+		}
+		if s.IsLocked() && s.LockID != lockID {
+			return ErrStateLocked{LockInfo: s.LockInfo}
+		}
+
+		versions, err = terraform_model.GetStateVersions(ctx, s.ID)
+		if err != nil {
+			return err
+		}
+
+		return terraform_model.DeleteStateByID(ctx, s.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	RemoveStateVersionFiles(versions)
+	return nil
+}
+
+// RemoveStateVersionFiles removes the stored content of the versions
+func RemoveStateVersionFiles(versions []*terraform_model.TerraformStateVersion) {
+	for _, v := range versions {
+		if err := storage.TerraformStates.Delete(v.StoragePath()); err != nil {
+			log.Error("Error deleting terraform state version %d from storage: %v", v.ID, err)
+		}
+	}
+}
+
+// LockState acquires the lock of a state. The state is created if it does not exist yet.
+func LockState(ctx context.Context, repoID int64, name string, info []byte) error {
+	var li lockInfo
+	if err := json.Unmarshal(info, &li); err != nil || li.ID == "" {
+		return ErrInvalidLockInfo
+	}
+
+	return globallock.LockAndDo(ctx, getLockKey(repoID, name), func(ctx context.Context) error {
+		s, err := terraform_model.GetOrInsertState(ctx, repoID, name)
+		if err != nil {
+			return err
+		}
+		if s.IsLocked() {
+			return ErrStateLocked{LockInfo: s.LockInfo}
+		}
+
+		s.LockID = li.ID
+		s.LockInfo = string(info)
+		s.LockedUnix = timeutil.TimeStampNow()
+		return terraform_model.UpdateStateCols(ctx, s, "lock_id", "lock_info", "locked_unix")
+	})
+}
+
+// UnlockState releases the lock of a state. Without lock information the lock is released unconditionally,
+// which is what Terraform does on a forced unlock.
+func UnlockState(ctx context.Context, repoID int64, name string, info []byte) error {
+	var li lockInfo
+	if len(bytes.TrimSpace(info)) != 0 {
+		if err := json.Unmarshal(info, &li); err != nil || li.ID == "" {
+			return ErrInvalidLockInfo
+		}
+	}
+
+	return globallock.LockAndDo(ctx, getLockKey(repoID, name), func(ctx context.Context) error {
+		s, err := terraform_model.GetStateByName(ctx, repoID, name)
+		if err != nil {
+			return err
+		}
+		if !s.IsLocked() {
+			return nil
+		}
+		if li.ID != "" && li.ID != s.LockID {
+			return ErrStateLocked{LockInfo: s.LockInfo}
+		}
+
+		s.LockID = ""
+		s.LockInfo = ""
+		s.LockedUnix = 0
+		return terraform_model.UpdateStateCols(ctx, s, "lock_id", "lock_info", "locked_unix")
+	})
+}
@@ -12,6 +12,7 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/json"
@@ -62,6 +63,10 @@ jobs:
 						Time:    timestamppb.New(now.Add(5 * time.Second)),
 						Content: "job1",
 					},
+					{
+						Time:    timestamppb.New(now.Add(5 * time.Second)),
+						Content: "::warning file=README.md,line=1::job1 warning",
+					},
 					{
 						Time:    timestamppb.New(now.Add(6 * time.Second)),
 						Content: "\U0001F3C1  Job succeeded",
@@ -130,6 +135,9 @@ jobs:
 			runner.execTask(t, task, outcome)
 			runIndex = task.Context.GetFields()["run_number"].GetStringValue()
 			assert.Equal(t, "1", runIndex)
+
+			actionTask := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: task.Id})
+			assert.NoError(t, actions_model.SetTaskStepSummary(db.DefaultContext, actionTask, 0, "## "+jobName))
 		}
 
 		for i := 0; i < len(testCase.outcomes); i++ {
@@ -152,11 +160,18 @@ jobs:
 		})
 		session.MakeRequest(t, req, http.StatusOK)
 
+		unittest.AssertCount(t, &actions_model.ActionTaskSummary{RepoID: apiRepo.ID}, 3)
+		unittest.AssertCount(t, &actions_model.ActionTaskAnnotation{RepoID: apiRepo.ID}, 1)
+
 		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/%s/actions/runs/%s/delete", user2.Name, apiRepo.Name, runIndex), map[string]string{
 			"_csrf": GetUserCSRFToken(t, session),
 		})
 		session.MakeRequest(t, req, http.StatusOK)
 
+		// the summaries and annotations of the tasks are deleted with the run
+		unittest.AssertNotExistsBean(t, &actions_model.ActionTaskSummary{RepoID: apiRepo.ID})
+		unittest.AssertNotExistsBean(t, &actions_model.ActionTaskAnnotation{RepoID: apiRepo.ID})
+
 		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/%s/actions/runs/%s/delete", user2.Name, apiRepo.Name, runIndex), map[string]string{
 			"_csrf": GetUserCSRFToken(t, session),
 		})
@@ -0,0 +1,227 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/tests"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAPIActionsRunControl(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeWriteRepository)
+	readToken := getUserToken(t, owner.Name, auth_model.AccessTokenScopeReadRepository)
+
+	var runIndex int64 = 1000
+	createRun := func(t *testing.T, status actions_model.Status, needApproval bool, jobs ...*actions_model.ActionRunJob) *actions_model.ActionRun {
+		runIndex++
+		run := &actions_model.ActionRun{
+			Title:         "control test",
+			RepoID:        repo.ID,
+			OwnerID:       repo.OwnerID,
+			WorkflowID:    "test.yml",
+			Index:         runIndex,
+			TriggerUserID: owner.ID,
+			Ref:           "refs/heads/master",
+			CommitSHA:     "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+			Event:         "push",
+			TriggerEvent:  "push",
+			EventPayload:  "{}",
+			Status:        status,
+			NeedApproval:  needApproval,
+		}
+		require.NoError(t, db.Insert(db.DefaultContext, run))
+		for _, job := range jobs {
+			job.RunID = run.ID
+			job.RepoID = repo.ID
+			job.OwnerID = repo.OwnerID
+			job.CommitSHA = run.CommitSHA
+			job.Name = job.JobID
+			require.NoError(t, db.Insert(db.DefaultContext, job))
+		}
+		return run
+	}
+	runURL := func(run *actions_model.ActionRun, action string) string {
+		return fmt.Sprintf("/api/v1/repos/%s/actions/runs/%d/%s", repo.FullName(), run.ID, action)
+	}
+	jobURL := func(job *actions_model.ActionRunJob, action string) string {
+		return fmt.Sprintf("/api/v1/repos/%s/actions/jobs/%d/%s", repo.FullName(), job.ID, action)
+	}
+	jobStatus := func(t *testing.T, job *actions_model.ActionRunJob) actions_model.Status {
+		return unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID}).Status
+	}
+
+	t.Run("Rerun", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		build := &actions_model.ActionRunJob{JobID: "build", Status: actions_model.StatusSuccess}
+		test := &actions_model.ActionRunJob{JobID: "test", Status: actions_model.StatusSuccess, Needs: []string{"build"}}
+		run := createRun(t, actions_model.StatusSuccess, false, build, test)
+
+		req := NewRequest(t, "POST", runURL(run, "rerun")).AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "POST", runURL(run, "rerun")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		assert.Equal(t, actions_model.StatusWaiting, jobStatus(t, build))
+		assert.Equal(t, actions_model.StatusBlocked, jobStatus(t, test))
+
+		// the run is not done anymore
+		req = NewRequest(t, "POST", runURL(run, "rerun")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("RerunFailedJobs", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		build := &actions_model.ActionRunJob{JobID: "build", Status: actions_model.StatusFailure}
+		lint := &actions_model.ActionRunJob{JobID: "lint", Status: actions_model.StatusSuccess}
+		test := &actions_model.ActionRunJob{JobID: "test", Status: actions_model.StatusSkipped, Needs: []string{"build"}}
+		run := createRun(t, actions_model.StatusFailure, false, build, lint, test)
+
+		req := NewRequest(t, "POST", runURL(run, "rerun-failed-jobs")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		assert.Equal(t, actions_model.StatusWaiting, jobStatus(t, build))
+		assert.Equal(t, actions_model.StatusSuccess, jobStatus(t, lint))
+		assert.Equal(t, actions_model.StatusBlocked, jobStatus(t, test))
+	})
+
+	t.Run("RerunJob", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		build := &actions_model.ActionRunJob{JobID: "build", Status: actions_model.StatusSuccess}
+		test := &actions_model.ActionRunJob{JobID: "test", Status: actions_model.StatusFailure, Needs: []string{"build"}}
+		createRun(t, actions_model.StatusFailure, false, build, test)
+
+		req := NewRequest(t, "POST", jobURL(test, "rerun")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		assert.Equal(t, actions_model.StatusSuccess, jobStatus(t, build))
+		assert.Equal(t, actions_model.StatusWaiting, jobStatus(t, test))
+
+		req = NewRequest(t, "POST", fmt.Sprintf("/api/v1/repos/%s/actions/jobs/%d/rerun", repo.FullName(), 999999)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		build := &actions_model.ActionRunJob{JobID: "build", Status: actions_model.StatusWaiting}
+		test := &actions_model.ActionRunJob{JobID: "test", Status: actions_model.StatusBlocked, Needs: []string{"build"}}
+		run := createRun(t, actions_model.StatusWaiting, false, build, test)
+
+		req := NewRequest(t, "POST", runURL(run, "cancel")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		assert.Equal(t, actions_model.StatusCancelled, jobStatus(t, build))
+		assert.Equal(t, actions_model.StatusCancelled, jobStatus(t, test))
+
+		req = NewRequest(t, "POST", runURL(run, "cancel")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("CancelJob", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		build := &actions_model.ActionRunJob{JobID: "build", Status: actions_model.StatusWaiting}
+		lint := &actions_model.ActionRunJob{JobID: "lint", Status: actions_model.StatusWaiting}
+		createRun(t, actions_model.StatusWaiting, false, build, lint)
+
+		req := NewRequest(t, "POST", jobURL(build, "cancel")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		assert.Equal(t, actions_model.StatusCancelled, jobStatus(t, build))
+		assert.Equal(t, actions_model.StatusWaiting, jobStatus(t, lint))
+
+		req = NewRequest(t, "POST", jobURL(build, "cancel")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		build := &actions_model.ActionRunJob{JobID: "build", Status: actions_model.StatusBlocked}
+		run := createRun(t, actions_model.StatusBlocked, true, build)
+
+		req := NewRequest(t, "POST", runURL(run, "approve")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		run = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{ID: run.ID})
+		assert.False(t, run.NeedApproval)
+		assert.Equal(t, owner.ID, run.ApprovedBy)
+		assert.Equal(t, actions_model.StatusWaiting, jobStatus(t, build))
+
+		req = NewRequest(t, "POST", runURL(run, "approve")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("FollowLogs", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		build := &actions_model.ActionRunJob{JobID: "build", Status: actions_model.StatusSuccess}
+		createRun(t, actions_model.StatusSuccess, false, build)
+
+		task := &actions_model.ActionTask{
+			JobID:       build.ID,
+			RepoID:      repo.ID,
+			OwnerID:     repo.OwnerID,
+			CommitSHA:   build.CommitSHA,
+			Status:      actions_model.StatusSuccess,
+			Stopped:     timeutil.TimeStampNow().AddDuration(-time.Hour),
+			LogFilename: fmt.Sprintf("%s/follow-test.log", repo.FullName()),
+			TokenHash:   "follow-test",
+		}
+		now := timestamppb.Now()
+		ns, err := actions_module.WriteLogs(t.Context(), task.LogFilename, 0, []*runnerv1.LogRow{
+			{Time: now, Content: "first line"},
+			{Time: now, Content: "second line"},
+		})
+		require.NoError(t, err)
+		var offset int64
+		for _, n := range ns {
+			task.LogIndexes = append(task.LogIndexes, offset)
+			offset += int64(n)
+		}
+		task.LogLength = int64(len(ns))
+		require.NoError(t, db.Insert(db.DefaultContext, task))
+		build.TaskID = task.ID
+		_, err = db.GetEngine(db.DefaultContext).ID(build.ID).Cols("task_id").Update(build)
+		require.NoError(t, err)
+
+		req := NewRequest(t, "GET", jobURL(build, "logs/follow")).AddTokenAuth(readToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "first line")
+		assert.Contains(t, resp.Body.String(), "second line")
+
+		req = NewRequest(t, "GET", jobURL(build, "logs/follow")+"?offset=1").AddTokenAuth(readToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), "first line")
+		assert.Contains(t, resp.Body.String(), "second line")
+
+		req = NewRequest(t, "GET", jobURL(build, "logs/follow")+"?offset=-1").AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIActionsRunnerGroup(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin, auth_model.AccessTokenScopeWriteRepository)
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	repo2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/admin/actions/runner-groups", &api.CreateActionRunnerGroupOption{
+		Name:    "production",
+		RepoIDs: []int64{repo1.ID},
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var group api.ActionRunnerGroup
+	DecodeJSON(t, resp, &group)
+	assert.Equal(t, []int64{repo1.ID}, group.RepoIDs)
+
+	groupURL := fmt.Sprintf("/api/v1/admin/actions/runner-groups/%d", group.ID)
+
+	repoIDs := []int64{repo1.ID, repo2.ID}
+	req = NewRequestWithJSON(t, "PATCH", groupURL, &api.EditActionRunnerGroupOption{RepoIDs: &repoIDs}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusOK)
+
+	req = NewRequest(t, "GET", groupURL).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &group)
+	assert.Equal(t, repoIDs, group.RepoIDs)
+
+	// deleting a repository removes it from the runner groups
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/repos/%s", repo2.FullName())).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+
+	req = NewRequest(t, "GET", groupURL).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &group)
+	assert.Equal(t, []int64{repo1.ID}, group.RepoIDs)
+}
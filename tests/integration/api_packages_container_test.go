@@ -25,6 +25,7 @@ import (
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/test"
 	package_service "code.gitea.io/gitea/services/packages"
+	packages_promote_service "code.gitea.io/gitea/services/packages/promote"
 	"code.gitea.io/gitea/tests"
 
 	oci "github.com/opencontainers/image-spec/specs-go/v1"
@@ -715,6 +716,184 @@ func TestPackageContainer(t *testing.T) {
 				assert.Len(t, apiPackages, 4) // "latest", "main", "multi", "sha256:..."
 			})
 
+			t.Run("Referrers", func(t *testing.T) {
+				defer tests.PrintCurrentTest(t)()
+
+				emptyConfigContent := `{}`
+				emptyConfigDigest := "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+
+				req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", url, emptyConfigDigest), strings.NewReader(emptyConfigContent)).
+					AddTokenAuth(userToken)
+				MakeRequest(t, req, http.StatusCreated)
+
+				subject := `{"mediaType":"` + manifestContentType + `","digest":"` + manifestDigest + `","size":` + strconv.Itoa(len(manifestContent)) + `}`
+				emptyConfig := `{"mediaType":"` + oci.MediaTypeEmptyJSON + `","digest":"` + emptyConfigDigest + `","size":2}`
+				layer := `{"mediaType":"application/octet-stream","digest":"` + blobDigest + `","size":32}`
+
+				sbomContent := `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","artifactType":"application/spdx+json","config":` + emptyConfig + `,"layers":[` + layer + `],"subject":` + subject + `}`
+				signatureContent := `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","config":{"mediaType":"application/vnd.example.signature","digest":"` + emptyConfigDigest + `","size":2},"layers":[` + layer + `],"subject":` + subject + `,"annotations":{"org.example.signer":"test"}}`
+
+				digestOf := func(content string) string {
+					h := sha256.Sum256([]byte(content))
+					return "sha256:" + hex.EncodeToString(h[:])
+				}
+				sbomDigest := digestOf(sbomContent)
+				signatureDigest := digestOf(signatureContent)
+
+				for _, content := range []string{sbomContent, signatureContent} {
+					req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", url, digestOf(content)), strings.NewReader(content)).
+						AddTokenAuth(userToken).
+						SetHeader("Content-Type", oci.MediaTypeImageManifest)
+					resp := MakeRequest(t, req, http.StatusCreated)
+					assert.Equal(t, manifestDigest, resp.Header().Get("OCI-Subject"))
+				}
+
+				pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeContainer, image, sbomDigest)
+				assert.NoError(t, err)
+				pd, err := packages_model.GetPackageDescriptor(db.DefaultContext, pv)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{manifestDigest}, getAllByName(pd.VersionProperties, container_module.PropertyManifestSubject))
+				metadata := pd.Metadata.(*container_module.Metadata)
+				assert.Equal(t, "application/spdx+json", metadata.ArtifactType)
+				assert.Equal(t, manifestDigest, metadata.Subject)
+				assert.Equal(t, "unknown/unknown", metadata.Platform)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/invalid", url)).
+					AddTokenAuth(userToken)
+				MakeRequest(t, req, http.StatusBadRequest)
+
+				getReferrers := func(t *testing.T, subject, query string) (*oci.Index, http.Header) {
+					req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s%s", url, subject, query)).
+						AddTokenAuth(userToken)
+					resp := MakeRequest(t, req, http.StatusOK)
+					assert.Equal(t, oci.MediaTypeImageIndex, resp.Header().Get("Content-Type"))
+
+					var index oci.Index
+					DecodeJSON(t, resp, &index)
+					assert.Equal(t, 2, index.SchemaVersion)
+					assert.Equal(t, oci.MediaTypeImageIndex, index.MediaType)
+					return &index, resp.Header()
+				}
+
+				index, _ := getReferrers(t, unknownDigest, "")
+				assert.Empty(t, index.Manifests)
+
+				index, header := getReferrers(t, manifestDigest, "")
+				assert.Empty(t, header.Get("OCI-Filters-Applied"))
+				assert.Len(t, index.Manifests, 2)
+				for _, d := range index.Manifests {
+					assert.Equal(t, oci.MediaTypeImageManifest, d.MediaType)
+					switch string(d.Digest) {
+					case sbomDigest:
+						assert.Equal(t, "application/spdx+json", d.ArtifactType)
+						assert.EqualValues(t, len(sbomContent), d.Size)
+						assert.Empty(t, d.Annotations)
+					case signatureDigest:
+						assert.Equal(t, "application/vnd.example.signature", d.ArtifactType)
+						assert.EqualValues(t, len(signatureContent), d.Size)
+						assert.Equal(t, map[string]string{"org.example.signer": "test"}, d.Annotations)
+					default:
+						assert.FailNow(t, "unknown referrer", "unknown referrer: %s", d.Digest)
+					}
+				}
+
+				index, header = getReferrers(t, manifestDigest, "?artifactType=application/spdx%2Bjson")
+				assert.Equal(t, "artifactType", header.Get("OCI-Filters-Applied"))
+				assert.Len(t, index.Manifests, 1)
+				assert.Equal(t, sbomDigest, string(index.Manifests[0].Digest))
+
+				pv, err = packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeContainer, image, "latest")
+				assert.NoError(t, err)
+				pd, err = packages_model.GetPackageDescriptor(db.DefaultContext, pv)
+				assert.NoError(t, err)
+				req = NewRequest(t, "GET", pd.VersionWebLink())
+				resp := session.MakeRequest(t, req, http.StatusOK)
+				assert.Contains(t, resp.Body.String(), "application/spdx&#43;json")
+
+				for _, d := range []string{sbomDigest, signatureDigest} {
+					req = NewRequest(t, "DELETE", fmt.Sprintf("%s/manifests/%s", url, d)).
+						AddTokenAuth(userToken)
+					MakeRequest(t, req, http.StatusAccepted)
+				}
+
+				index, _ = getReferrers(t, manifestDigest, "")
+				assert.Empty(t, index.Manifests)
+			})
+
+			t.Run("ImmutableVersions", func(t *testing.T) {
+				defer tests.PrintCurrentTest(t)()
+
+				assert.NoError(t, package_service.SetImmutableVersionsEnabled(db.DefaultContext, user.ID, true))
+				defer func() {
+					assert.NoError(t, package_service.SetImmutableVersionsEnabled(db.DefaultContext, user.ID, false))
+				}()
+
+				req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", url, tags[0]), strings.NewReader(untaggedManifestContent)).
+					AddTokenAuth(userToken).
+					SetHeader("Content-Type", oci.MediaTypeImageManifest)
+				MakeRequest(t, req, http.StatusForbidden)
+
+				req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", url, tags[0]), strings.NewReader(manifestContent)).
+					AddTokenAuth(userToken).
+					SetHeader("Content-Type", manifestContentType)
+				MakeRequest(t, req, http.StatusCreated)
+
+				req = NewRequest(t, "DELETE", fmt.Sprintf("%s/manifests/%s", url, untaggedManifestDigest)).
+					AddTokenAuth(userToken)
+				MakeRequest(t, req, http.StatusForbidden)
+			})
+
+			t.Run("Promote", func(t *testing.T) {
+				defer tests.PrintCurrentTest(t)()
+
+				org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+
+				pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeContainer, image, multiTag)
+				assert.NoError(t, err)
+				pd, err := packages_model.GetPackageDescriptor(db.DefaultContext, pv)
+				assert.NoError(t, err)
+
+				_, err = packages_promote_service.PromotePackageVersion(db.DefaultContext, user, pd, org)
+				assert.NoError(t, err)
+
+				pv, err = packages_model.GetVersionByNameAndVersion(db.DefaultContext, org.ID, packages_model.TypeContainer, image, multiTag)
+				assert.NoError(t, err)
+				pd, err = packages_model.GetPackageDescriptor(db.DefaultContext, pv)
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{strings.ToLower(org.LowerName + "/" + image)}, getAllByName(pd.PackageProperties, container_module.PropertyRepository))
+
+				// the manifests referenced by the index are copied with their layers
+				orgURL := fmt.Sprintf("%sv2/%s/%s", setting.AppURL, org.Name, image)
+				for _, digest := range []string{indexManifestDigest, manifestDigest, untaggedManifestDigest} {
+					req := NewRequest(t, "GET", fmt.Sprintf("%s/manifests/%s", orgURL, digest)).
+						AddTokenAuth(userToken)
+					resp := MakeRequest(t, req, http.StatusOK)
+					assert.Equal(t, digest, resp.Header().Get("Docker-Content-Digest"))
+				}
+				req := NewRequest(t, "GET", fmt.Sprintf("%s/manifests/%s", orgURL, multiTag)).
+					AddTokenAuth(userToken)
+				MakeRequest(t, req, http.StatusOK)
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/blobs/%s", orgURL, blobDigest)).
+					AddTokenAuth(userToken)
+				resp := MakeRequest(t, req, http.StatusOK)
+				assert.Equal(t, blobContent, resp.Body.Bytes())
+
+				// the referenced manifests are untagged
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/tags/list", orgURL)).
+					AddTokenAuth(userToken)
+				resp = MakeRequest(t, req, http.StatusOK)
+				type TagList struct {
+					Tags []string `json:"tags"`
+				}
+				tagList := &TagList{}
+				DecodeJSON(t, resp, &tagList)
+				assert.Equal(t, []string{multiTag}, tagList.Tags)
+
+				// the catalog checked later must only contain the images of the user
+				_, err = package_service.RemoveAllPackages(db.DefaultContext, org.ID)
+				assert.NoError(t, err)
+			})
+
 			t.Run("Delete", func(t *testing.T) {
 				t.Run("Blob", func(t *testing.T) {
 					defer tests.PrintCurrentTest(t)()
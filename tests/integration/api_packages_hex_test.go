@@ -0,0 +1,330 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestPackageHex(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	// the Hex clients send the API key without a scheme
+	apiKey := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "test_package"
+	packageVersion := "1.0.1"
+	packageDescription := "Test Description"
+
+	filename := fmt.Sprintf("%s-%s.tar", packageName, packageVersion)
+
+	createTar := func(files [][2]string) []byte {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, file := range files {
+			tw.WriteHeader(&tar.Header{
+				Name: file[0],
+				Mode: 0o600,
+				Size: int64(len(file[1])),
+			})
+			tw.Write([]byte(file[1]))
+		}
+		tw.Close()
+		return buf.Bytes()
+	}
+
+	metadataContent := `{<<"name">>,<<"` + packageName + `">>}.
+{<<"version">>,<<"` + packageVersion + `">>}.
+{<<"app">>,<<"` + packageName + `">>}.
+{<<"description">>,<<"` + packageDescription + `">>}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"requirements">>,[[{<<"name">>,<<"jason">>},{<<"app">>,<<"jason">>},{<<"optional">>,true},{<<"requirement">>,<<"~> 1.4">>},{<<"repository">>,<<"hexpm">>}]]}.
+`
+
+	var contents bytes.Buffer
+	zw := gzip.NewWriter(&contents)
+	zw.Write(createTar([][2]string{{"lib/test_package.ex", "defmodule TestPackage do\nend\n"}}))
+	zw.Close()
+
+	innerChecksum := sha256.Sum256([]byte("3" + metadataContent + contents.String()))
+
+	content := createTar([][2]string{
+		{"VERSION", "3"},
+		{"CHECKSUM", strings.ToUpper(hex.EncodeToString(innerChecksum[:]))},
+		{"metadata.config", metadataContent},
+		{"contents.tar.gz", contents.String()},
+	})
+	outerChecksum := sha256.Sum256(content)
+
+	root := fmt.Sprintf("/api/packages/%s/hex", user.Name)
+
+	// parseMessage parses the length-delimited fields of a protobuf message
+	parseMessage := func(t *testing.T, data []byte) map[protowire.Number][][]byte {
+		fields := make(map[protowire.Number][][]byte)
+		for len(data) > 0 {
+			num, typ, n := protowire.ConsumeTag(data)
+			require.GreaterOrEqual(t, n, 0)
+			data = data[n:]
+			if typ == protowire.BytesType {
+				v, n := protowire.ConsumeBytes(data)
+				require.GreaterOrEqual(t, n, 0)
+				fields[num] = append(fields[num], v)
+				data = data[n:]
+			} else {
+				n = protowire.ConsumeFieldValue(num, typ, data)
+				require.GreaterOrEqual(t, n, 0)
+				data = data[n:]
+			}
+		}
+		return fields
+	}
+
+	var publicKey *rsa.PublicKey
+
+	// getResource fetches a registry resource, verifies the signature and returns the payload fields
+	getResource := func(t *testing.T, url string) map[protowire.Number][][]byte {
+		req := NewRequest(t, "GET", url)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		zr, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		data, err := io.ReadAll(zr)
+		require.NoError(t, err)
+
+		signed := parseMessage(t, data)
+		require.Len(t, signed[1], 1)
+		require.Len(t, signed[2], 1)
+
+		hash := sha512.Sum512(signed[1][0])
+		assert.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA512, hash[:], signed[2][0]))
+
+		return parseMessage(t, signed[1][0])
+	}
+
+	t.Run("PublicKey", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/public_key")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		block, _ := pem.Decode(resp.Body.Bytes())
+		require.NotNil(t, block)
+		assert.Equal(t, "PUBLIC KEY", block.Type)
+
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		require.NoError(t, err)
+		assert.IsType(t, &rsa.PublicKey{}, pub)
+		publicKey = pub.(*rsa.PublicKey)
+	})
+
+	t.Run("Publish", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := root + "/api/publish"
+
+		req := NewRequestWithBody(t, "POST", url, bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		// the scope of the API key is checked
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content)).
+			SetHeader("Authorization", getUserToken(t, user.Name, auth_model.AccessTokenScopeReadPackage))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader([]byte("invalid"))).
+			SetHeader("Authorization", apiKey)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content)).
+			SetHeader("Authorization", apiKey).
+			SetHeader("Accept", "application/vnd.hex+erlang")
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		assert.Equal(t, "application/vnd.hex+erlang", resp.Header().Get("Content-Type"))
+		assert.Equal(t, byte(131), resp.Body.Bytes()[0])
+		assert.Contains(t, resp.Body.String(), "html_url")
+
+		pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeHex)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+		assert.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &hex_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+		assert.Equal(t, packageDescription, pd.Metadata.(*hex_module.Metadata).Description)
+		assert.Equal(t, hex.EncodeToString(innerChecksum[:]), pd.VersionProperties.GetByName(hex_module.PropertyInnerChecksum))
+
+		pfs, err := packages.GetFilesByVersionID(db.DefaultContext, pvs[0].ID)
+		assert.NoError(t, err)
+		assert.Len(t, pfs, 1)
+		assert.Equal(t, filename, pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content)).
+			SetHeader("Authorization", apiKey)
+		resp = MakeRequest(t, req, http.StatusConflict)
+		assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+
+		req = NewRequestWithBody(t, "POST", url+"?replace=true", bytes.NewReader(content)).
+			SetHeader("Authorization", apiKey)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pvs, err = packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeHex)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		t.Run("ReplaceQuotaExceeded", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			// the quota is not checked for admins
+			user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+			apiKey := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+			url := fmt.Sprintf("/api/packages/%s/hex/api/publish", user.Name)
+
+			req := NewRequestWithBody(t, "POST", url, bytes.NewReader(content)).
+				SetHeader("Authorization", apiKey)
+			MakeRequest(t, req, http.StatusCreated)
+
+			defer test.MockVariableValue(&setting.Packages.LimitSizeHex, int64(len(content)-1))()
+
+			req = NewRequestWithBody(t, "POST", url+"?replace=true", bytes.NewReader(content)).
+				SetHeader("Authorization", apiKey)
+			MakeRequest(t, req, http.StatusForbidden)
+
+			// the existing release is kept
+			pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeHex)
+			assert.NoError(t, err)
+			assert.Len(t, pvs, 1)
+
+			pfs, err := packages.GetFilesByVersionID(db.DefaultContext, pvs[0].ID)
+			assert.NoError(t, err)
+			assert.Len(t, pfs, 1)
+		})
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s", root, filename))
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-0.0.1.tar", root, packageName))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/hex/%s/%s", user.Name, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), packageDescription)
+		assert.Contains(t, resp.Body.String(), "mix hex.repo add "+user.Name)
+	})
+
+	t.Run("Names", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		names := getResource(t, root+"/names")
+
+		assert.Equal(t, [][]byte{[]byte(user.Name)}, names[2])
+		require.Len(t, names[1], 1)
+		assert.Equal(t, [][]byte{[]byte(packageName)}, parseMessage(t, names[1][0])[1])
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		versions := getResource(t, root+"/versions")
+
+		assert.Equal(t, [][]byte{[]byte(user.Name)}, versions[2])
+		require.Len(t, versions[1], 1)
+
+		p := parseMessage(t, versions[1][0])
+		assert.Equal(t, [][]byte{[]byte(packageName)}, p[1])
+		assert.Equal(t, [][]byte{[]byte(packageVersion)}, p[2])
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/packages/unknown")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		p := getResource(t, root+"/packages/"+packageName)
+
+		assert.Equal(t, [][]byte{[]byte(packageName)}, p[2])
+		assert.Equal(t, [][]byte{[]byte(user.Name)}, p[3])
+		require.Len(t, p[1], 1)
+
+		release := parseMessage(t, p[1][0])
+		assert.Equal(t, [][]byte{[]byte(packageVersion)}, release[1])
+		assert.Equal(t, [][]byte{innerChecksum[:]}, release[2])
+		assert.Equal(t, [][]byte{outerChecksum[:]}, release[5])
+		require.Len(t, release[3], 1)
+
+		dependency := parseMessage(t, release[3][0])
+		assert.Equal(t, [][]byte{[]byte("jason")}, dependency[1])
+		assert.Equal(t, [][]byte{[]byte("~> 1.4")}, dependency[2])
+		assert.Equal(t, [][]byte{[]byte("jason")}, dependency[4])
+		assert.Equal(t, [][]byte{[]byte("hexpm")}, dependency[5])
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := fmt.Sprintf("%s/api/packages/%s/releases/%s", root, packageName, packageVersion)
+
+		req := NewRequest(t, "DELETE", url)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "DELETE", url).
+			SetHeader("Authorization", apiKey)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", url).
+			SetHeader("Authorization", apiKey)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeHex)
+		assert.NoError(t, err)
+		assert.Empty(t, pvs)
+
+		req = NewRequest(t, "GET", root+"/packages/"+packageName)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
@@ -4,11 +4,15 @@
 package integration
 
 import (
+	"archive/zip"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"testing"
@@ -18,8 +22,11 @@ import (
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/packages/pypi"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/tests"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -236,4 +243,199 @@ func TestPackagePyPI(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("PackageMetadataJSON", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/simple/%s", root, packageName)).
+			SetHeader("Accept", "application/vnd.pypi.simple.v1+json, text/html;q=0.01").
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "application/vnd.pypi.simple.v1+json", resp.Header().Get("Content-Type"))
+
+		var result struct {
+			Meta struct {
+				APIVersion string `json:"api-version"`
+			} `json:"meta"`
+			Name  string `json:"name"`
+			Files []struct {
+				Filename       string            `json:"filename"`
+				URL            string            `json:"url"`
+				Hashes         map[string]string `json:"hashes"`
+				RequiresPython string            `json:"requires-python"`
+			} `json:"files"`
+		}
+		DecodeJSON(t, resp, &result)
+
+		assert.Equal(t, "1.0", result.Meta.APIVersion)
+		assert.Equal(t, packageName, result.Name)
+		assert.Len(t, result.Files, 2)
+		for _, f := range result.Files {
+			assert.Equal(t, fmt.Sprintf("%s%s/files/%s/%s/%s", strings.TrimSuffix(setting.AppURL, "/"), root, packageName, packageVersion, f.Filename), f.URL)
+			assert.Equal(t, hashSHA256, f.Hashes["sha256"])
+			assert.Equal(t, "3.6", f.RequiresPython)
+		}
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/simple/%s", root, packageName)).
+			SetHeader("Accept", "application/json").
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNotAcceptable)
+	})
+
+	t.Run("WheelMetadata", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pkgName := "wheel-package"
+		filename := "wheel_package-1.0-py3-none-any.whl"
+		coreMetadata := "Metadata-Version: 2.1\nName: wheel-package\nVersion: 1.0\nRequires-Python: >=3.8\n"
+
+		var buf bytes.Buffer
+		archive := zip.NewWriter(&buf)
+		w, _ := archive.Create("wheel_package-1.0.dist-info/METADATA")
+		_, _ = w.Write([]byte(coreMetadata))
+		_ = archive.Close()
+
+		wheelHash := sha256.Sum256(buf.Bytes())
+		metadataHash := sha256.Sum256([]byte(coreMetadata))
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("content", filename)
+		_, _ = part.Write(buf.Bytes())
+		writer.WriteField("name", pkgName)
+		writer.WriteField("version", "1.0")
+		writer.WriteField("sha256_digest", hex.EncodeToString(wheelHash[:]))
+		_ = writer.Close()
+
+		uploadHelper(t, body, writer.FormDataContentType(), http.StatusCreated)
+
+		pvs, err := packages.GetVersionsByPackageName(db.DefaultContext, user.ID, packages.TypePyPI, pkgName)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+		assert.NoError(t, err)
+		assert.Equal(t, ">=3.8", pd.Metadata.(*pypi.Metadata).RequiresPython)
+		assert.Len(t, pd.Files, 2)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/files/%s/1.0/%s.metadata", root, pkgName, filename)).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, coreMetadata, resp.Body.String())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/simple/%s", root, pkgName)).
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		a := htmlDoc.doc.Find("a")
+		assert.Equal(t, 1, a.Length())
+		assert.Equal(t, "sha256="+hex.EncodeToString(metadataHash[:]), a.AttrOr("data-core-metadata", ""))
+		assert.Equal(t, "sha256="+hex.EncodeToString(metadataHash[:]), a.AttrOr("data-dist-info-metadata", ""))
+		assert.Equal(t, ">=3.8", a.AttrOr("data-requires-python", ""))
+		_, yanked := a.Attr("data-yanked")
+		assert.False(t, yanked)
+	})
+
+	t.Run("WheelMetadataQuotaExceeded", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pkgName := "wheel-package-quota"
+		filename := "wheel_package_quota-1.0-py3-none-any.whl"
+		coreMetadata := "Metadata-Version: 2.1\nName: wheel-package-quota\nVersion: 1.0\n\n" + strings.Repeat("description ", 1000)
+
+		var buf bytes.Buffer
+		archive := zip.NewWriter(&buf)
+		w, _ := archive.Create("wheel_package_quota-1.0.dist-info/METADATA")
+		_, _ = w.Write([]byte(coreMetadata))
+		_ = archive.Close()
+
+		// the compressed wheel fits into the quota but its core metadata does not
+		defer test.MockVariableValue(&setting.Packages.LimitSizePyPI, int64(buf.Len()))()
+
+		wheelHash := sha256.Sum256(buf.Bytes())
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("content", filename)
+		_, _ = part.Write(buf.Bytes())
+		writer.WriteField("name", pkgName)
+		writer.WriteField("version", "1.0")
+		writer.WriteField("sha256_digest", hex.EncodeToString(wheelHash[:]))
+		_ = writer.Close()
+
+		uploadHelper(t, body, writer.FormDataContentType(), http.StatusCreated)
+
+		pvs, err := packages.GetVersionsByPackageName(db.DefaultContext, user.ID, packages.TypePyPI, pkgName)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+		assert.NoError(t, err)
+		assert.Len(t, pd.Files, 1)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/simple/%s", root, pkgName)).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		a := htmlDoc.doc.Find("a")
+		assert.Equal(t, 1, a.Length())
+		_, hasMetadata := a.Attr("data-core-metadata")
+		assert.False(t, hasMetadata)
+	})
+
+	t.Run("Yank", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+
+		req := NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/-/packages/pypi/%s/%s/settings", user.Name, packageName, url.PathEscape(packageVersion)), map[string]string{
+			"_csrf":  GetUserCSRFToken(t, session),
+			"action": "yank",
+			"reason": "broken build",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/simple/%s", root, packageName)).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		htmlDoc.doc.Find("a").Each(func(_ int, a *goquery.Selection) {
+			assert.Equal(t, "broken build", a.AttrOr("data-yanked", ""))
+		})
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/simple/%s", root, packageName)).
+			SetHeader("Accept", "application/vnd.pypi.simple.v1+json").
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var result struct {
+			Files []struct {
+				Yanked any `json:"yanked"`
+			} `json:"files"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Len(t, result.Files, 2)
+		for _, f := range result.Files {
+			assert.Equal(t, "broken build", f.Yanked)
+		}
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/-/packages/pypi/%s/%s/settings", user.Name, packageName, url.PathEscape(packageVersion)), map[string]string{
+			"_csrf":  GetUserCSRFToken(t, session),
+			"action": "unyank",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/simple/%s", root, packageName)).
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		htmlDoc = NewHTMLParser(t, resp.Body)
+		htmlDoc.doc.Find("a").Each(func(_ int, a *goquery.Selection) {
+			_, yanked := a.Attr("data-yanked")
+			assert.False(t, yanked)
+		})
+	})
 }
@@ -0,0 +1,229 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/setting"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageRemote(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	content := "remote package content"
+	sha256Sum := sha256.Sum256([]byte(content))
+	sha512Sum := sha512.Sum512([]byte(content))
+
+	var fileRequests atomic.Int32
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		// npm
+		case "/npm/remote-package":
+			_, _ = fmt.Fprintf(w, `{"name":"remote-package","dist-tags":{"latest":"1.0.1"},"versions":{
+				"1.0.0":{"name":"remote-package","version":"1.0.0","description":"Remote","dist":{"tarball":"%[1]s/npm/remote-package/-/remote-package-1.0.0.tgz","integrity":"sha512-%[2]s"}},
+				"1.0.1":{"name":"remote-package","version":"1.0.1","dist":{"tarball":"%[1]s/npm/remote-package/-/remote-package-1.0.1.tgz","integrity":"sha512-%[3]s"}}
+			}}`, srv.URL, base64.StdEncoding.EncodeToString(sha512Sum[:]), base64.StdEncoding.EncodeToString(make([]byte, 64)))
+		case "/npm/remote-package/-/remote-package-1.0.0.tgz", "/npm/remote-package/-/remote-package-1.0.1.tgz":
+			fileRequests.Add(1)
+			_, _ = io.WriteString(w, content)
+		// PyPI
+		case "/pypi/simple/remote-package/":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = fmt.Fprintf(w, `<html><body><a href="../../files/remote_package-1.0.0.tar.gz#sha256=%s" data-requires-python="&gt;=3.8">remote_package-1.0.0.tar.gz</a></body></html>`, hex.EncodeToString(sha256Sum[:]))
+		case "/pypi/files/remote_package-1.0.0.tar.gz":
+			fileRequests.Add(1)
+			_, _ = io.WriteString(w, content)
+		// Go
+		case "/go/example.com/remote/@v/list":
+			_, _ = io.WriteString(w, "v1.0.0\n")
+		case "/go/example.com/remote/@v/v1.0.0.mod":
+			_, _ = io.WriteString(w, "module example.com/remote\n")
+		case "/go/example.com/remote/@v/v1.0.0.zip":
+			fileRequests.Add(1)
+			_, _ = io.WriteString(w, content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	addRemote := func(t *testing.T, packageType packages_model.Type, path string) {
+		_, err := packages_model.InsertRemote(db.DefaultContext, &packages_model.PackageRemote{
+			Enabled: true,
+			OwnerID: user.ID,
+			Type:    packageType,
+			URL:     srv.URL + path,
+		})
+		require.NoError(t, err)
+	}
+
+	assertCached := func(t *testing.T, packageType packages_model.Type, name string, count int) {
+		pvs, err := packages_model.GetVersionsByPackageName(db.DefaultContext, user.ID, packageType, name)
+		require.NoError(t, err)
+		require.Len(t, pvs, count)
+
+		isCached, err := remote_service.IsCachedPackage(db.DefaultContext, pvs[0].PackageID)
+		require.NoError(t, err)
+		assert.True(t, isCached)
+	}
+
+	t.Run("NoRemote", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/api/packages/%s/npm/remote-package", user.Name)), http.StatusNotFound)
+	})
+
+	t.Run("Npm", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		addRemote(t, packages_model.TypeNpm, "/npm")
+		fileRequests.Store(0)
+
+		root := fmt.Sprintf("/api/packages/%s/npm", user.Name)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/remote-package"), http.StatusOK)
+
+		var metadata npm_module.PackageMetadata
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &metadata))
+		require.Contains(t, metadata.Versions, "1.0.0")
+		assert.Equal(t, setting.AppURL+root[1:]+"/remote-package/-/1.0.0/remote-package-1.0.0.tgz", metadata.Versions["1.0.0"].Dist.Tarball)
+
+		for range 2 {
+			resp = MakeRequest(t, NewRequest(t, "GET", root+"/remote-package/-/1.0.0/remote-package-1.0.0.tgz"), http.StatusOK)
+			assert.Equal(t, content, resp.Body.String())
+		}
+		assert.EqualValues(t, 1, fileRequests.Load())
+
+		assertCached(t, packages_model.TypeNpm, "remote-package", 1)
+
+		// the integrity of the remote file does not match
+		MakeRequest(t, NewRequest(t, "GET", root+"/remote-package/-/1.0.1/remote-package-1.0.1.tgz"), http.StatusBadGateway)
+		MakeRequest(t, NewRequest(t, "GET", root+"/remote-package/-/1.0.2/remote-package-1.0.2.tgz"), http.StatusNotFound)
+	})
+
+	t.Run("NpmVirtual", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		member := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		_, err := packages_model.InsertVirtual(db.DefaultContext, &packages_model.PackageVirtual{
+			Enabled:   true,
+			OwnerID:   user.ID,
+			Type:      packages_model.TypeNpm,
+			MemberIDs: []int64{member.ID},
+		})
+		require.NoError(t, err)
+
+		buf, err := packages_module.CreateHashedBufferFromReader(strings.NewReader("member package content"))
+		require.NoError(t, err)
+		defer buf.Close()
+		_, _, err = packages_service.CreatePackageAndAddFile(db.DefaultContext,
+			&packages_service.PackageCreationInfo{
+				PackageInfo: packages_service.PackageInfo{
+					Owner:       member,
+					PackageType: packages_model.TypeNpm,
+					Name:        "remote-package",
+					Version:     "2.0.0",
+				},
+				SemverCompatible: true,
+				Creator:          member,
+				Metadata:         &npm_module.Metadata{},
+			},
+			&packages_service.PackageFileCreationInfo{
+				PackageFileInfo: packages_service.PackageFileInfo{Filename: "remote-package-2.0.0.tgz"},
+				Creator:         member,
+				Data:            buf,
+				IsLead:          true,
+			},
+		)
+		require.NoError(t, err)
+
+		root := fmt.Sprintf("/api/packages/%s/npm", user.Name)
+
+		// the local versions of the members are merged into the versions of the remote
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/remote-package"), http.StatusOK)
+		var metadata npm_module.PackageMetadata
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &metadata))
+		assert.Len(t, metadata.Versions, 3)
+		assert.Contains(t, metadata.Versions, "1.0.1")
+		if assert.Contains(t, metadata.Versions, "2.0.0") {
+			assert.Equal(t, setting.AppURL+root[1:]+"/remote-package/-/2.0.0/remote-package-2.0.0.tgz", metadata.Versions["2.0.0"].Dist.Tarball)
+		}
+		assert.Equal(t, "1.0.1", metadata.DistTags["latest"])
+
+		resp = MakeRequest(t, NewRequest(t, "GET", root+"/remote-package/-/2.0.0/remote-package-2.0.0.tgz"), http.StatusOK)
+		assert.Equal(t, "member package content", resp.Body.String())
+	})
+
+	t.Run("PyPI", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		addRemote(t, packages_model.TypePyPI, "/pypi/simple")
+		fileRequests.Store(0)
+
+		root := fmt.Sprintf("/api/packages/%s/pypi", user.Name)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/simple/remote-package"), http.StatusOK)
+		fileURL := fmt.Sprintf("%s/files/remote-package/1.0.0/remote_package-1.0.0.tar.gz", root)
+		assert.Contains(t, resp.Body.String(), fileURL+"#sha256="+hex.EncodeToString(sha256Sum[:]))
+		assert.Contains(t, resp.Body.String(), `data-requires-python="&gt;=3.8"`)
+
+		for range 2 {
+			resp = MakeRequest(t, NewRequest(t, "GET", fileURL), http.StatusOK)
+			assert.Equal(t, content, resp.Body.String())
+		}
+		assert.EqualValues(t, 1, fileRequests.Load())
+
+		assertCached(t, packages_model.TypePyPI, "remote-package", 1)
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		addRemote(t, packages_model.TypeGo, "/go")
+		fileRequests.Store(0)
+
+		root := fmt.Sprintf("/api/packages/%s/go/example.com/remote", user.Name)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/@v/list"), http.StatusOK)
+		assert.Equal(t, "v1.0.0\n", resp.Body.String())
+
+		for range 2 {
+			resp = MakeRequest(t, NewRequest(t, "GET", root+"/@v/v1.0.0.zip"), http.StatusOK)
+			assert.Equal(t, content, resp.Body.String())
+		}
+		assert.EqualValues(t, 1, fileRequests.Load())
+
+		resp = MakeRequest(t, NewRequest(t, "GET", root+"/@v/v1.0.0.mod"), http.StatusOK)
+		assert.True(t, strings.HasPrefix(resp.Body.String(), "module example.com/remote"))
+
+		assertCached(t, packages_model.TypeGo, "example.com/remote", 1)
+	})
+}
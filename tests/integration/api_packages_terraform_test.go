@@ -0,0 +1,274 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageTerraform(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	t.Run("ServiceDiscovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/.well-known/terraform.json"), http.StatusOK)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, setting.AppURL+"api/packages/-/terraform/modules/v1/", result["modules.v1"])
+		assert.Equal(t, setting.AppURL+"api/packages/-/terraform/providers/v1/", result["providers.v1"])
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		moduleName := "vpc"
+		moduleSystem := "aws"
+		moduleVersion := "1.2.0"
+		readme := "# VPC module"
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		archive := tar.NewWriter(zw)
+		for name, content := range map[string]string{"main.tf": `resource "null_resource" "test" {}`, "README.md": readme} {
+			archive.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			archive.Write([]byte(content))
+		}
+		archive.Close()
+		zw.Close()
+		content := buf.Bytes()
+
+		uploadURL := fmt.Sprintf("/api/packages/%s/terraform/modules/%s/%s/%s", user.Name, moduleName, moduleSystem, moduleVersion)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			MakeRequest(t, NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)), http.StatusUnauthorized)
+
+			req := NewRequestWithBody(t, "PUT", uploadURL, strings.NewReader("invalid")).
+				AddBasicAuth(user.Name)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddBasicAuth(user.Name)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			require.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+			require.NoError(t, err)
+			assert.Equal(t, "vpc/aws", pd.Package.Name)
+			assert.Equal(t, moduleVersion, pd.Version.Version)
+			metadata := pd.Metadata.(*terraform_module.Metadata)
+			assert.Equal(t, terraform_module.KindModule, metadata.Kind)
+			assert.Equal(t, readme, metadata.Readme)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddBasicAuth(user.Name)
+			MakeRequest(t, req, http.StatusConflict)
+		})
+
+		t.Run("Versions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("/api/packages/-/terraform/modules/v1/%s/%s/%s/versions", user.Name, moduleName, moduleSystem))
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Modules []struct {
+					Versions []struct {
+						Version string `json:"version"`
+					} `json:"versions"`
+				} `json:"modules"`
+			}
+			DecodeJSON(t, resp, &result)
+			require.Len(t, result.Modules, 1)
+			require.Len(t, result.Modules[0].Versions, 1)
+			assert.Equal(t, moduleVersion, result.Modules[0].Versions[0].Version)
+
+			req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/-/terraform/modules/v1/%s/%s/azure/versions", user.Name, moduleName))
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("/api/packages/-/terraform/modules/v1/%s/%s/%s/%s/download", user.Name, moduleName, moduleSystem, moduleVersion))
+			resp := MakeRequest(t, req, http.StatusNoContent)
+
+			location := resp.Header().Get("X-Terraform-Get")
+			assert.Equal(t, fmt.Sprintf("%sapi/packages/%s/terraform/modules/%s/%s/%s/vpc-aws-%s.tar.gz", setting.AppURL, user.Name, moduleName, moduleSystem, moduleVersion, moduleVersion), location)
+
+			resp = MakeRequest(t, NewRequest(t, "GET", strings.TrimPrefix(location, setting.AppURL[:len(setting.AppURL)-1])), http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+		})
+
+		t.Run("View", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/terraform/%s/%s", user.Name, url.PathEscape("vpc/aws"), moduleVersion))
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "VPC module")
+		})
+	})
+
+	t.Run("Provider", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		providerType := "example"
+		providerVersion := "0.1.0"
+		prefix := terraform_module.ProviderFilePrefix(providerType, providerVersion)
+
+		entity, err := openpgp.NewEntity("User Two", "", user.Email, nil)
+		require.NoError(t, err)
+
+		var armored bytes.Buffer
+		w, err := armor.Encode(&armored, openpgp.PublicKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, entity.Serialize(w))
+		require.NoError(t, w.Close())
+
+		_, err = asymkey_model.AddGPGKey(db.DefaultContext, user.ID, armored.String(), "", "")
+		require.NoError(t, err)
+
+		zipContent := []byte("provider binary")
+		zipHash := sha256.Sum256(zipContent)
+		checksums := []byte(fmt.Sprintf("%s  %slinux_amd64.zip\n", hex.EncodeToString(zipHash[:]), prefix))
+
+		var signature bytes.Buffer
+		require.NoError(t, openpgp.DetachSign(&signature, entity, bytes.NewReader(checksums), nil))
+
+		root := fmt.Sprintf("/api/packages/%s/terraform/providers/%s/%s", user.Name, providerType, providerVersion)
+
+		upload := func(t *testing.T, filename string, content []byte, expectedStatus int) {
+			req := NewRequestWithBody(t, "PUT", root+"/"+filename, bytes.NewReader(content)).
+				AddBasicAuth(user.Name)
+			MakeRequest(t, req, expectedStatus)
+		}
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			upload(t, "invalid.zip", zipContent, http.StatusBadRequest)
+			upload(t, prefix+"SHA256SUMS.sig", signature.Bytes(), http.StatusBadRequest)
+
+			upload(t, prefix+"linux_amd64.zip", zipContent, http.StatusCreated)
+			upload(t, prefix+"linux_amd64.zip", zipContent, http.StatusConflict)
+			upload(t, prefix+"SHA256SUMS", []byte("invalid"), http.StatusBadRequest)
+			upload(t, prefix+"SHA256SUMS", checksums, http.StatusCreated)
+			upload(t, prefix+"SHA256SUMS.sig", []byte("invalid"), http.StatusBadRequest)
+			upload(t, prefix+"SHA256SUMS.sig", signature.Bytes(), http.StatusCreated)
+			upload(t, prefix+"manifest.json", []byte(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`), http.StatusCreated)
+		})
+
+		t.Run("Versions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("/api/packages/-/terraform/providers/v1/%s/%s/versions", user.Name, providerType))
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Versions []struct {
+					Version   string   `json:"version"`
+					Protocols []string `json:"protocols"`
+					Platforms []struct {
+						OS   string `json:"os"`
+						Arch string `json:"arch"`
+					} `json:"platforms"`
+				} `json:"versions"`
+			}
+			DecodeJSON(t, resp, &result)
+			require.Len(t, result.Versions, 1)
+			assert.Equal(t, providerVersion, result.Versions[0].Version)
+			assert.Equal(t, []string{"6.0"}, result.Versions[0].Protocols)
+			require.Len(t, result.Versions[0].Platforms, 1)
+			assert.Equal(t, "linux", result.Versions[0].Platforms[0].OS)
+			assert.Equal(t, "amd64", result.Versions[0].Platforms[0].Arch)
+
+			pv, err := packages.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages.TypeTerraform, providerType, providerVersion)
+			require.NoError(t, err)
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pv)
+			require.NoError(t, err)
+			assert.Equal(t, terraform_module.KindProvider, pd.Metadata.(*terraform_module.Metadata).Kind)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			downloadURL := fmt.Sprintf("/api/packages/-/terraform/providers/v1/%s/%s/%s/download", user.Name, providerType, providerVersion)
+
+			MakeRequest(t, NewRequest(t, "GET", downloadURL+"/darwin/arm64"), http.StatusNotFound)
+
+			resp := MakeRequest(t, NewRequest(t, "GET", downloadURL+"/linux/amd64"), http.StatusOK)
+
+			var result struct {
+				Protocols           []string `json:"protocols"`
+				OS                  string   `json:"os"`
+				Arch                string   `json:"arch"`
+				Filename            string   `json:"filename"`
+				DownloadURL         string   `json:"download_url"`
+				ShasumsURL          string   `json:"shasums_url"`
+				ShasumsSignatureURL string   `json:"shasums_signature_url"`
+				Shasum              string   `json:"shasum"`
+				SigningKeys         struct {
+					GPGPublicKeys []struct {
+						KeyID      string `json:"key_id"`
+						ASCIIArmor string `json:"ascii_armor"`
+					} `json:"gpg_public_keys"`
+				} `json:"signing_keys"`
+			}
+			DecodeJSON(t, resp, &result)
+			assert.Equal(t, []string{"6.0"}, result.Protocols)
+			assert.Equal(t, "linux", result.OS)
+			assert.Equal(t, "amd64", result.Arch)
+			assert.Equal(t, prefix+"linux_amd64.zip", result.Filename)
+			assert.Equal(t, hex.EncodeToString(zipHash[:]), result.Shasum)
+			require.Len(t, result.SigningKeys.GPGPublicKeys, 1)
+			assert.Equal(t, entity.PrimaryKey.KeyIdString(), result.SigningKeys.GPGPublicKeys[0].KeyID)
+			assert.Equal(t, armored.String(), result.SigningKeys.GPGPublicKeys[0].ASCIIArmor)
+
+			appURL := setting.AppURL[:len(setting.AppURL)-1]
+			for u, expected := range map[string][]byte{
+				result.DownloadURL:         zipContent,
+				result.ShasumsURL:          checksums,
+				result.ShasumsSignatureURL: signature.Bytes(),
+			} {
+				resp := MakeRequest(t, NewRequest(t, "GET", strings.TrimPrefix(u, appURL)), http.StatusOK)
+				assert.Equal(t, expected, resp.Body.Bytes())
+			}
+		})
+	})
+}
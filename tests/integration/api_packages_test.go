@@ -4,7 +4,9 @@
 package integration
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"crypto/sha256"
 	"fmt"
 	"net/http"
@@ -17,15 +19,19 @@ import (
 	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	packages_module "code.gitea.io/gitea/modules/packages"
 	container_module "code.gitea.io/gitea/modules/packages/container"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/modules/util"
 	packages_service "code.gitea.io/gitea/services/packages"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
 	repo_service "code.gitea.io/gitea/services/repository"
 	"code.gitea.io/gitea/tests"
 
+	"github.com/blakesmith/ar"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -203,6 +209,46 @@ func TestPackageAPI(t *testing.T) {
 		assert.Equal(t, "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e", files[0].HashSHA512)
 	})
 
+	t.Run("DownloadStatistics", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		statsURL := fmt.Sprintf("/api/v1/packages/%s/generic/%s/%s/downloads", user.Name, packageName, packageVersion)
+
+		req := NewRequest(t, "GET", statsURL).
+			AddTokenAuth(tokenReadPackage)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var stats *api.PackageDownloadStatistics
+		DecodeJSON(t, resp, &stats)
+
+		assert.Equal(t, int64(0), stats.TotalCount)
+		assert.Nil(t, stats.LastDownloadedAt)
+		assert.Len(t, stats.Days, 30)
+
+		for range 2 {
+			req = NewRequest(t, "GET", url).
+				AddBasicAuth(user.Name)
+			MakeRequest(t, req, http.StatusOK)
+		}
+
+		req = NewRequest(t, "GET", statsURL+"?days=7").
+			AddTokenAuth(tokenReadPackage)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		DecodeJSON(t, resp, &stats)
+
+		assert.Equal(t, int64(2), stats.TotalCount)
+		assert.NotNil(t, stats.LastDownloadedAt)
+		assert.Len(t, stats.Days, 7)
+		assert.Equal(t, time.Now().UTC().Format(time.DateOnly), stats.Days[6].Date)
+		assert.Equal(t, int64(2), stats.Days[6].Count)
+		assert.Equal(t, int64(0), stats.Days[0].Count)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/generic/%s/%s", user.Name, packageName, packageVersion))
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `id="package-download-stats"`)
+	})
+
 	t.Run("DeletePackage", func(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
@@ -559,6 +605,7 @@ func TestPackageCleanup(t *testing.T) {
 			Version     string
 			ShouldExist bool
 			Created     int64
+			Downloaded  bool
 		}
 
 		cases := []struct {
@@ -610,6 +657,18 @@ func TestPackageCleanup(t *testing.T) {
 					RemoveDays: 60,
 				},
 			},
+			{
+				Name: "RemoveNotDownloadedDays",
+				Versions: []version{
+					{Version: "keep", ShouldExist: true, Created: 1, Downloaded: true},
+					{Version: "test", ShouldExist: true},
+					{Version: "v1.0", ShouldExist: false, Created: 1},
+				},
+				Rule: &packages_model.PackageCleanupRule{
+					Enabled:                 true,
+					RemoveNotDownloadedDays: 30,
+				},
+			},
 			{
 				Name: "RemovePattern",
 				Versions: []version{
@@ -672,6 +731,12 @@ func TestPackageCleanup(t *testing.T) {
 						_, err = db.GetEngine(db.DefaultContext).Exec("UPDATE package_version SET created_unix = ? WHERE id = ?", v.Created, pv.ID)
 						assert.NoError(t, err)
 					}
+
+					if v.Downloaded {
+						req = NewRequest(t, "GET", url).
+							AddBasicAuth(user.Name)
+						MakeRequest(t, req, http.StatusOK)
+					}
 				}
 
 				c.Rule.OwnerID = user.ID
@@ -699,3 +764,297 @@ func TestPackageCleanup(t *testing.T) {
 		}
 	})
 }
+
+func TestPackagePromotion(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	other := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+
+	token := getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+	otherToken := getTokenForLoggedInUser(t, loginUser(t, other.Name), auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "promote-package"
+	packageVersion := "1.0.0"
+	content := []byte("promoted content")
+
+	req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", user.Name, packageName, packageVersion), bytes.NewReader(content)).
+		AddBasicAuth(user.Name)
+	MakeRequest(t, req, http.StatusCreated)
+
+	promoteURL := fmt.Sprintf("/api/v1/packages/%s/generic/%s/%s/promote", user.Name, packageName, packageVersion)
+
+	t.Run("API", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "POST", promoteURL, &api.PromotePackageVersionOption{Owner: "not-existing"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithJSON(t, "POST", promoteURL, &api.PromotePackageVersionOption{Owner: user.Name}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithJSON(t, "POST", promoteURL, &api.PromotePackageVersionOption{Owner: org.Name}).
+			AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithJSON(t, "POST", promoteURL, &api.PromotePackageVersionOption{Owner: org.Name}).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var p *api.Package
+		DecodeJSON(t, resp, &p)
+		assert.Equal(t, org.Name, p.Owner.UserName)
+		assert.Equal(t, user.Name, p.Creator.UserName)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+
+		source, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeGeneric, packageName, packageVersion)
+		assert.NoError(t, err)
+		promoted, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, org.ID, packages_model.TypeGeneric, packageName, packageVersion)
+		assert.NoError(t, err)
+
+		sourceFiles, err := packages_model.GetFilesByVersionID(db.DefaultContext, source.ID)
+		assert.NoError(t, err)
+		promotedFiles, err := packages_model.GetFilesByVersionID(db.DefaultContext, promoted.ID)
+		assert.NoError(t, err)
+		assert.Len(t, promotedFiles, 1)
+		assert.Equal(t, sourceFiles[0].Name, promotedFiles[0].Name)
+		assert.Equal(t, sourceFiles[0].BlobID, promotedFiles[0].BlobID)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", org.Name, packageName, packageVersion)).
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequestWithJSON(t, "POST", promoteURL, &api.PromotePackageVersionOption{Owner: org.Name}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+	})
+
+	t.Run("RepositoryIndex", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		control := fmt.Sprintf("Package: %s\nVersion: %s\nArchitecture: amd64\nDescription: promoted\n", packageName, packageVersion)
+		var cbuf bytes.Buffer
+		zw := gzip.NewWriter(&cbuf)
+		tw := tar.NewWriter(zw)
+		_ = tw.WriteHeader(&tar.Header{Name: "control", Mode: 0o600, Size: int64(len(control))})
+		_, _ = tw.Write([]byte(control))
+		_ = tw.Close()
+		_ = zw.Close()
+
+		var buf bytes.Buffer
+		aw := ar.NewWriter(&buf)
+		_ = aw.WriteGlobalHeader()
+		_ = aw.WriteHeader(&ar.Header{Name: "control.tar.gz", Mode: 0o600, Size: int64(cbuf.Len())})
+		_, _ = aw.Write(cbuf.Bytes())
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/debian/pool/stable/main/upload", user.Name), &buf).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/packages/%s/debian/%s/%s/promote", user.Name, packageName, packageVersion), &api.PromotePackageVersionOption{Owner: org.Name}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// the index of the target owner lists the promoted package
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/%s/debian/dists/stable/main/binary-amd64/Packages", org.Name))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "Package: "+packageName+"\n")
+		assert.Contains(t, resp.Body.String(), fmt.Sprintf("Filename: pool/stable/main/%s_%s_amd64.deb\n", packageName, packageVersion))
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/%s/debian/pool/stable/main/%s_%s_amd64.deb", org.Name, packageName, packageVersion))
+		MakeRequest(t, req, http.StatusOK)
+	})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(content))
+		assert.NoError(t, err)
+		defer buf.Close()
+
+		_, _, err = packages_service.CreatePackageAndAddFile(db.DefaultContext,
+			&packages_service.PackageCreationInfo{
+				PackageInfo: packages_service.PackageInfo{
+					Owner:       user,
+					PackageType: packages_model.TypeGeneric,
+					Name:        "cached-package",
+					Version:     packageVersion,
+				},
+				Creator:           user,
+				PackageProperties: map[string]string{remote_service.PropertyRemote: "https://example.com"},
+			},
+			&packages_service.PackageFileCreationInfo{
+				PackageFileInfo: packages_service.PackageFileInfo{Filename: "file.bin"},
+				Creator:         user,
+				Data:            buf,
+				IsLead:          true,
+			},
+		)
+		assert.NoError(t, err)
+
+		// the packages cached from a remote registry don't offer the promotion
+		session := loginUser(t, user.Name)
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/generic/cached-package/%s/settings", user.Name, packageVersion))
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), `value="promote"`)
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/generic/%s/%s/settings", user.Name, packageName, packageVersion))
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `value="promote"`)
+
+		req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/packages/%s/generic/cached-package/%s/promote", user.Name, packageVersion), &api.PromotePackageVersionOption{Owner: org.Name}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		_, err = packages_model.GetVersionByNameAndVersion(db.DefaultContext, org.ID, packages_model.TypeGeneric, "cached-package", packageVersion)
+		assert.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+	})
+
+	t.Run("Web", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+
+		promote := func(t *testing.T, version, owner string) string {
+			req := NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/-/packages/generic/%s/%s/settings", user.Name, packageName, version), map[string]string{
+				"_csrf":  GetUserCSRFToken(t, session),
+				"action": "promote",
+				"owner":  owner,
+			})
+			resp := session.MakeRequest(t, req, http.StatusSeeOther)
+			return test.RedirectURL(resp)
+		}
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/%s/2.0.0/file.bin", user.Name, packageName), bytes.NewReader(content)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		promote(t, "2.0.0", other.Name)
+		_, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, other.ID, packages_model.TypeGeneric, packageName, "2.0.0")
+		assert.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+
+		assert.Equal(t, fmt.Sprintf("/%s/-/packages/generic/%s/2.0.0", org.Name, packageName), promote(t, "2.0.0", org.Name))
+		_, err = packages_model.GetVersionByNameAndVersion(db.DefaultContext, org.ID, packages_model.TypeGeneric, packageName, "2.0.0")
+		assert.NoError(t, err)
+	})
+}
+
+func TestPackageImmutableVersions(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	session := loginUser(t, user.Name)
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "immutable-package"
+
+	uploadPackage := func(t *testing.T, version string) {
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", user.Name, packageName, version), bytes.NewReader([]byte{1})).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	setImmutable := func(t *testing.T, enabled bool) {
+		values := map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		}
+		if enabled {
+			values["immutable"] = "on"
+		}
+		req := NewRequestWithValues(t, "POST", "/user/settings/packages/immutable", values)
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		immutable, err := packages_service.IsImmutableVersionsEnabled(db.DefaultContext, user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, enabled, immutable)
+	}
+
+	uploadPackage(t, "1.0")
+	uploadPackage(t, "1.1")
+
+	setImmutable(t, true)
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("/api/packages/%s/generic/%s/1.0/file.bin", user.Name, packageName)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/packages/%s/generic/%s/1.0", user.Name, packageName)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/packages/%s/generic/%s/1.0", user.Name, packageName)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		_, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeGeneric, packageName, "1.0")
+		assert.NoError(t, err)
+
+		// admins are allowed to delete immutable versions
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/packages/%s/generic/%s/1.0", user.Name, packageName)).
+			AddBasicAuth(admin.Name)
+		MakeRequest(t, req, http.StatusNoContent)
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		overwrite := func(data []byte) error {
+			buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(data))
+			assert.NoError(t, err)
+			defer buf.Close()
+
+			_, err = packages_service.AddFileToExistingPackage(db.DefaultContext,
+				&packages_service.PackageInfo{
+					Owner:       user,
+					PackageType: packages_model.TypeGeneric,
+					Name:        packageName,
+					Version:     "1.1",
+				},
+				&packages_service.PackageFileCreationInfo{
+					PackageFileInfo:   packages_service.PackageFileInfo{Filename: "file.bin"},
+					Creator:           user,
+					Data:              buf,
+					OverwriteExisting: true,
+				},
+			)
+			return err
+		}
+
+		assert.NoError(t, overwrite([]byte{1}))
+		assert.ErrorIs(t, overwrite([]byte{2}), packages_service.ErrImmutablePackageVersion)
+	})
+
+	t.Run("CleanupRules", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pcr, err := packages_model.InsertCleanupRule(db.DefaultContext, &packages_model.PackageCleanupRule{
+			Enabled:       true,
+			OwnerID:       user.ID,
+			Type:          packages_model.TypeGeneric,
+			RemovePattern: `.*`,
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, packages_cleanup_service.CleanupTask(db.DefaultContext, 0))
+
+		_, err = packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeGeneric, packageName, "1.1")
+		assert.NoError(t, err)
+
+		assert.NoError(t, packages_model.DeleteCleanupRuleByID(db.DefaultContext, pcr.ID))
+	})
+
+	setImmutable(t, false)
+
+	req := NewRequest(t, "DELETE", fmt.Sprintf("/api/packages/%s/generic/%s/1.1", user.Name, packageName)).
+		AddBasicAuth(user.Name)
+	MakeRequest(t, req, http.StatusNoContent)
+}
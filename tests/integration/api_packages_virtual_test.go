@@ -0,0 +1,121 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageVirtual(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	publicMember := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	privateMember := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 31})
+
+	putFile := func(t *testing.T, owner *user_model.User, version, content string) {
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/maven/com/gitea/test-project/%s/test-project-%s.jar", owner.Name, version, version), strings.NewReader(content)).
+			AddBasicAuth(owner.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	putFile(t, user, "1.0.0", "owner 1.0.0")
+	putFile(t, publicMember, "1.0.0", "member 1.0.0")
+	putFile(t, publicMember, "1.1.0", "member 1.1.0")
+	putFile(t, privateMember, "2.0.0", "private 2.0.0")
+
+	root := fmt.Sprintf("/api/packages/%s/maven/com/gitea/test-project", user.Name)
+
+	t.Run("Settings", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+
+		req := NewRequestWithValues(t, "POST", "/user/settings/packages/virtuals/add", map[string]string{
+			"_csrf":   GetUserCSRFToken(t, session),
+			"enabled": "on",
+			"type":    "maven",
+			"members": "not-existing-user",
+			"action":  "save",
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+
+		pvs, err := packages_model.GetVirtualsByOwner(db.DefaultContext, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, pvs)
+
+		req = NewRequestWithValues(t, "POST", "/user/settings/packages/virtuals/add", map[string]string{
+			"_csrf":   GetUserCSRFToken(t, session),
+			"enabled": "on",
+			"type":    "maven",
+			"members": fmt.Sprintf("%s\n%s\n%s", publicMember.Name, privateMember.Name, user.Name),
+			"action":  "save",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		pvs, err = packages_model.GetVirtualsByOwner(db.DefaultContext, user.ID)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+		assert.Equal(t, packages_model.TypeMaven, pvs[0].Type)
+		assert.Equal(t, []int64{publicMember.ID, privateMember.ID}, pvs[0].MemberIDs)
+	})
+
+	t.Run("Metadata", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", root+"/maven-metadata.xml").AddBasicAuth(user.Name), http.StatusOK)
+		body := resp.Body.String()
+		assert.Contains(t, body, "<version>1.0.0</version>")
+		assert.Contains(t, body, "<version>1.1.0</version>")
+		assert.NotContains(t, body, "<version>2.0.0</version>")
+		assert.Equal(t, 1, strings.Count(body, "<version>1.0.0</version>"))
+
+		// the private member is visible to its owner
+		resp = MakeRequest(t, NewRequest(t, "GET", root+"/maven-metadata.xml").AddBasicAuth(privateMember.Name), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "<version>2.0.0</version>")
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		cases := []struct {
+			Version  string
+			Expected string
+			Status   int
+		}{
+			{"1.0.0", "owner 1.0.0", http.StatusOK},
+			{"1.1.0", "member 1.1.0", http.StatusOK},
+			{"2.0.0", "", http.StatusNotFound},
+		}
+		for _, c := range cases {
+			resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/%s/test-project-%s.jar", root, c.Version, c.Version)), c.Status)
+			if c.Status == http.StatusOK {
+				assert.Equal(t, c.Expected, resp.Body.String())
+			}
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pv, err := packages_model.GetEnabledVirtualByOwnerAndType(db.DefaultContext, user.ID, packages_model.TypeMaven)
+		require.NoError(t, err)
+		pv.Enabled = false
+		require.NoError(t, packages_model.UpdateVirtual(db.DefaultContext, pv))
+
+		MakeRequest(t, NewRequest(t, "GET", root+"/1.1.0/test-project-1.1.0.jar"), http.StatusNotFound)
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	packages_vulnerability_service "code.gitea.io/gitea/services/packages/vulnerability"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageVulnerability(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "vulnerable-package"
+	packageVersion := "1.0.0"
+
+	buildEntry := func(id, fixed string) string {
+		return `{
+			"id": "` + id + `",
+			"modified": "2024-01-02T00:00:00Z",
+			"published": "2024-01-01T00:00:00Z",
+			"summary": "Prototype pollution in left-pad",
+			"database_specific": {"severity": "HIGH"},
+			"affected": [{
+				"package": {"ecosystem": "npm", "name": "left-pad"},
+				"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "` + fixed + `"}]}]
+			}]
+		}`
+	}
+
+	importDatabase := func(t *testing.T, entries map[string]string) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, content := range entries {
+			w, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = w.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+
+		_, err := packages_vulnerability_service.ImportDatabase(db.DefaultContext, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+	}
+
+	upload := `{
+		"_id": "` + packageName + `",
+		"name": "` + packageName + `",
+		"dist-tags": {"latest": "` + packageVersion + `"},
+		"versions": {
+			"` + packageVersion + `": {
+				"name": "` + packageName + `",
+				"version": "` + packageVersion + `",
+				"dist": {
+					"integrity": "sha512-yA4FJsVhetynGfOC1jFf79BuS+jrHbm0fhh+aHzCQkOaOBXKf9oBnC4a6DnLLnEsHQDRLYd00cwj8sCXpC+wIg==",
+					"shasum": "aaa7eaf852a948b0aa05afeda35b1badca155d90"
+				},
+				"dependencies": {
+					"left-pad": "^1.1.0",
+					"right-pad": "^1.0.0"
+				}
+			}
+		},
+		"_attachments": {
+			"` + packageName + `-` + packageVersion + `.tgz": {
+				"data": "H4sIAAAAAAAA/ytITM5OTE/VL4DQelnF+XkMVAYGBgZmJiYK2MRBwNDcSIHB2NTMwNDQzMwAqA7IMDUxA9LUdgg2UFpcklgEdAql5kD8ogCnhwio5lJQUMpLzE1VslJQcihOzi9I1S9JLS7RhSYIJR2QgrLUouLM/DyQGkM9Az1D3YIiqExKanFyUWZBCVQ2BKhVwQVJDKwosbQkI78IJO/tZ+LsbRykxFXLNdA+HwWjYBSMgpENACgAbtAACAAA"
+			}
+		}
+	}`
+
+	url := fmt.Sprintf("/api/v1/packages/%s/npm/%s/%s/vulnerabilities", user.Name, packageName, packageVersion)
+
+	listVulnerabilities := func(t *testing.T) []*api.PackageVulnerability {
+		req := NewRequest(t, "GET", url).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var vulnerabilities []*api.PackageVulnerability
+		DecodeJSON(t, resp, &vulnerabilities)
+		return vulnerabilities
+	}
+
+	t.Run("ScanOnUpload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		importDatabase(t, map[string]string{
+			"GHSA-0000-0000-0001.json": buildEntry("GHSA-0000-0000-0001", "1.3.0"),
+			"README.md":                "not an entry",
+		})
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/npm/%s", user.Name, packageName), strings.NewReader(upload)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		vulnerabilities := listVulnerabilities(t)
+		assert.Len(t, vulnerabilities, 1)
+		assert.Equal(t, "GHSA-0000-0000-0001", vulnerabilities[0].ID)
+		assert.Equal(t, "HIGH", vulnerabilities[0].Severity)
+		assert.Equal(t, "left-pad", vulnerabilities[0].DependencyName)
+		assert.Equal(t, "^1.1.0", vulnerabilities[0].DependencyVersion)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/npm/%s/%s", user.Name, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "GHSA-0000-0000-0001")
+	})
+
+	t.Run("RescanAfterImport", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		importDatabase(t, map[string]string{
+			"GHSA-0000-0000-0001.json": buildEntry("GHSA-0000-0000-0001", "1.0.5"),
+			"GHSA-0000-0000-0002.json": buildEntry("GHSA-0000-0000-0002", "2.0.0"),
+		})
+
+		vulnerabilities := listVulnerabilities(t)
+		assert.Len(t, vulnerabilities, 1)
+		assert.Equal(t, "GHSA-0000-0000-0001", vulnerabilities[0].ID)
+
+		require.NoError(t, packages_vulnerability_service.ScanTask(db.DefaultContext))
+
+		vulnerabilities = listVulnerabilities(t)
+		assert.Len(t, vulnerabilities, 1)
+		assert.Equal(t, "GHSA-0000-0000-0002", vulnerabilities[0].ID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pvs, err := packages_model.GetVersionsByPackageType(db.DefaultContext, user.ID, packages_model.TypeNpm)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/packages/%s/npm/%s/%s", user.Name, packageName, packageVersion)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		findings, err := packages_model.GetVulnerabilityFindingsByVersionID(db.DefaultContext, pvs[0].ID)
+		assert.NoError(t, err)
+		assert.Empty(t, findings)
+
+		importDatabase(t, map[string]string{})
+
+		count, err := packages_model.CountVulnerabilities(db.DefaultContext)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+	})
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRepoTerraformState(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWriteRepository)
+	readToken := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadRepository)
+
+	stateURL := fmt.Sprintf("/api/v1/repos/%s/terraform/states/production", repo.FullName())
+
+	// the Terraform http backend authenticates with basic auth
+	newRequest := func(t *testing.T, method, url, body, token string) *RequestWrapper {
+		req := NewRequestWithBody(t, method, url, strings.NewReader(body))
+		req.Request.SetBasicAuth("terraform", token)
+		return req
+	}
+
+	lockInfo := `{"ID":"lock-1","Operation":"OperationTypeApply","Info":"","Who":"user@host","Version":"1.9.0","Created":"2025-01-01T00:00:00Z","Path":""}`
+	stateV1 := `{"version":4,"serial":1,"resources":[],"outputs":{"password":{"value":"secret-value","type":"string"}}}`
+	stateV2 := `{"version":4,"serial":2,"resources":[]}`
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", stateURL), http.StatusUnauthorized)
+		MakeRequest(t, newRequest(t, "GET", stateURL, "", getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)), http.StatusForbidden)
+		MakeRequest(t, newRequest(t, "LOCK", stateURL, lockInfo, readToken), http.StatusForbidden)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, newRequest(t, "GET", stateURL, "", token), http.StatusNotFound)
+
+		MakeRequest(t, newRequest(t, "LOCK", stateURL, "invalid", token), http.StatusBadRequest)
+		MakeRequest(t, newRequest(t, "LOCK", stateURL, lockInfo, token), http.StatusOK)
+
+		resp := MakeRequest(t, newRequest(t, "LOCK", stateURL, strings.Replace(lockInfo, "lock-1", "lock-2", 1), token), http.StatusLocked)
+		assert.JSONEq(t, lockInfo, resp.Body.String())
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, newRequest(t, "POST", stateURL, stateV1, token), http.StatusLocked)
+		MakeRequest(t, newRequest(t, "POST", stateURL+"?ID=lock-2", stateV1, token), http.StatusLocked)
+
+		req := newRequest(t, "POST", stateURL+"?ID=lock-1", stateV1, token)
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString([]byte("invalid")))
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		hash := md5.Sum([]byte(stateV1))
+		req = newRequest(t, "POST", stateURL+"?ID=lock-1", stateV1, token)
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(hash[:]))
+		MakeRequest(t, req, http.StatusOK)
+
+		MakeRequest(t, newRequest(t, "POST", stateURL+"?ID=lock-1", stateV2, token), http.StatusOK)
+
+		resp := MakeRequest(t, newRequest(t, "GET", stateURL, "", readToken), http.StatusOK)
+		assert.Equal(t, stateV2, resp.Body.String())
+	})
+
+	t.Run("EncryptedAtRest", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		s, err := terraform_model.GetStateByName(db.DefaultContext, repo.ID, "production")
+		require.NoError(t, err)
+		v, err := terraform_model.GetStateVersion(db.DefaultContext, s.ID, 1)
+		require.NoError(t, err)
+
+		f, err := storage.TerraformStates.Open(v.StoragePath())
+		require.NoError(t, err)
+		defer f.Close()
+		stored, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored), "secret-value")
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, newRequest(t, "GET", stateURL+"/versions", "", token), http.StatusOK)
+		var versions []*api.TerraformStateVersion
+		DecodeJSON(t, resp, &versions)
+		require.Len(t, versions, 2)
+		assert.EqualValues(t, 2, versions[0].Version)
+		assert.EqualValues(t, len(stateV1), versions[1].Size)
+
+		resp = MakeRequest(t, newRequest(t, "GET", stateURL+"/versions/1", "", token), http.StatusOK)
+		assert.Equal(t, stateV1, resp.Body.String())
+
+		MakeRequest(t, newRequest(t, "GET", stateURL+"/versions/3", "", token), http.StatusNotFound)
+	})
+
+	t.Run("Unlock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, newRequest(t, "UNLOCK", stateURL, strings.Replace(lockInfo, "lock-1", "lock-2", 1), token), http.StatusLocked)
+		MakeRequest(t, newRequest(t, "UNLOCK", stateURL, lockInfo, token), http.StatusOK)
+
+		resp := MakeRequest(t, newRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/terraform/states", repo.FullName()), "", token), http.StatusOK)
+		var states []*api.TerraformState
+		DecodeJSON(t, resp, &states)
+		require.Len(t, states, 1)
+		assert.Equal(t, "production", states[0].Name)
+		assert.EqualValues(t, 2, states[0].Version)
+		assert.False(t, states[0].Locked)
+
+		// a forced unlock has no lock information
+		MakeRequest(t, newRequest(t, "LOCK", stateURL, lockInfo, token), http.StatusOK)
+		MakeRequest(t, newRequest(t, "UNLOCK", stateURL, "", token), http.StatusOK)
+		MakeRequest(t, newRequest(t, "POST", stateURL, stateV2, token), http.StatusOK)
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.TerraformState.MaxVersions, 2)()
+
+		s, err := terraform_model.GetStateByName(db.DefaultContext, repo.ID, "production")
+		require.NoError(t, err)
+		expired, err := terraform_model.GetStateVersionsBefore(db.DefaultContext, s.ID, 3)
+		require.NoError(t, err)
+		require.Len(t, expired, 2)
+
+		MakeRequest(t, newRequest(t, "POST", stateURL, stateV1, token), http.StatusOK)
+
+		resp := MakeRequest(t, newRequest(t, "GET", stateURL+"/versions", "", token), http.StatusOK)
+		var versions []*api.TerraformStateVersion
+		DecodeJSON(t, resp, &versions)
+		require.Len(t, versions, 2)
+		assert.EqualValues(t, 4, versions[0].Version)
+		assert.EqualValues(t, 3, versions[1].Version)
+
+		MakeRequest(t, newRequest(t, "GET", stateURL+"/versions/2", "", token), http.StatusNotFound)
+		for _, v := range expired {
+			_, err := storage.TerraformStates.Stat(v.StoragePath())
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		}
+	})
+
+	t.Run("ActionsToken", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// the running task 47 belongs to repository 4
+		actionsToken := "8061e833a55f6fc0157c98b883e91fcfeeb1a71a"
+		repo4 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+
+		MakeRequest(t, newRequest(t, "POST", stateURL, stateV1, actionsToken), http.StatusNotFound)
+
+		url := fmt.Sprintf("/api/v1/repos/%s/terraform/states/ci", repo4.FullName())
+		MakeRequest(t, newRequest(t, "POST", url, stateV1, actionsToken), http.StatusOK)
+		resp := MakeRequest(t, newRequest(t, "GET", url, "", actionsToken), http.StatusOK)
+		assert.Equal(t, stateV1, resp.Body.String())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, newRequest(t, "LOCK", stateURL, lockInfo, token), http.StatusOK)
+		MakeRequest(t, newRequest(t, "DELETE", stateURL, "", token), http.StatusLocked)
+		MakeRequest(t, newRequest(t, "DELETE", stateURL+"?ID=lock-1", "", token), http.StatusOK)
+
+		MakeRequest(t, newRequest(t, "GET", stateURL, "", token), http.StatusNotFound)
+		unittest.AssertNotExistsBean(t, &terraform_model.TerraformStateVersion{RepoID: repo.ID})
+	})
+}
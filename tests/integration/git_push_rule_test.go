@@ -0,0 +1,102 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+	repo_service "code.gitea.io/gitea/services/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitPushRules(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		token := getUserToken(t, user2.Name, auth_model.AccessTokenScopeWriteRepository)
+
+		_, err := repo_service.CreateRepository(db.DefaultContext, user2, user2, repo_service.CreateRepoOptions{
+			Name:          "push-rules",
+			AutoInit:      true,
+			Readme:        "Default",
+			DefaultBranch: "master",
+		})
+		require.NoError(t, err)
+
+		req := NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/push-rules/push_rule", &api.UpdatePushRuleOption{
+			MaxFileSize:                1024,
+			ForbiddenPathPatterns:      []string{"*.key"},
+			CommitMessagePattern:       `^[A-Z]+-[0-9]+ `,
+			RequireVerifiedAuthorEmail: true,
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		dstPath := t.TempDir()
+		u.Path = "user2/push-rules.git"
+		u.User = url.UserPassword(user2.Name, userPassword)
+		doGitClone(dstPath, u)(t)
+
+		commit := func(t *testing.T, fileName string, size int, email, message string) {
+			require.NoError(t, os.WriteFile(filepath.Join(dstPath, fileName), bytes.Repeat([]byte("a"), size), 0o644))
+			require.NoError(t, git.AddChanges(dstPath, true))
+			signature := &git.Signature{Email: email, Name: "User Two", When: time.Now()}
+			require.NoError(t, git.CommitChanges(dstPath, git.CommitChangesOptions{
+				Committer: signature,
+				Author:    signature,
+				Message:   message,
+			}))
+		}
+		// push returns the error message sent back by the hook, or an empty string if the push is accepted
+		push := func(t *testing.T) string {
+			_, stderr, err := git.NewCommand("push", "origin", "master").RunStdString(t.Context(), &git.RunOpts{Dir: dstPath})
+			if err == nil {
+				return ""
+			}
+			_, _, err = git.NewCommand("reset", "--hard", "origin/master").RunStdString(t.Context(), &git.RunOpts{Dir: dstPath})
+			require.NoError(t, err)
+			return stderr
+		}
+
+		commit(t, "allowed.txt", 10, "user2@example.com", "ABC-1 allowed")
+		commit(t, "allowed-case.txt", 10, "User2@Example.com", "ABC-2 the emails are case insensitive")
+		assert.Empty(t, push(t))
+
+		// the pushed commits may be authored by other users, e.g. after cherry-picking their commits
+		commit(t, "other-user.txt", 10, "user4@example.com", "ABC-3 the verified email of another user")
+		assert.Empty(t, push(t))
+
+		commit(t, "unknown.txt", 10, "unknown@example.com", "ABC-4 an email which does not belong to any user")
+		assert.Contains(t, push(t), "push rule: the author email unknown@example.com of commit")
+
+		commit(t, "inactive.txt", 10, "user2-2@example.com", "ABC-4 an email which is not activated")
+		assert.Contains(t, push(t), "is not a verified email address of any user")
+
+		commit(t, "message.txt", 10, "user2@example.com", "no ticket")
+		assert.Contains(t, push(t), "does not match the pattern ^[A-Z]+-[0-9]+ ")
+
+		commit(t, "private.key", 10, "user2@example.com", "ABC-5 a forbidden file")
+		assert.Contains(t, push(t), "changes private.key, which matches the forbidden path pattern *.key")
+
+		commit(t, "large.txt", 2048, "user2@example.com", "ABC-6 a large file")
+		assert.Contains(t, push(t), "file large.txt (2.0 KiB) exceeds the maximum file size 1.0 KiB")
+
+		// the commits are checked even if only the last one is pushed
+		commit(t, "forbidden-first.key", 10, "user2@example.com", "ABC-7 a forbidden file")
+		commit(t, "allowed-last.txt", 10, "user2@example.com", "ABC-8 allowed")
+		assert.Contains(t, push(t), "changes forbidden-first.key")
+	})
+}
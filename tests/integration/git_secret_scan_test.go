@@ -0,0 +1,87 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	repo_service "code.gitea.io/gitea/services/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitPushSecretScanningBatches(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		defer test.MockVariableValue(&setting.SecretScanning.Enabled, true)()
+		defer test.MockVariableValue(&setting.SecretScanning.Mode, setting.SecretScanningModeWarn)()
+
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+		repo, err := repo_service.CreateRepository(db.DefaultContext, user2, user2, repo_service.CreateRepoOptions{
+			Name:          "secret-scan-batches",
+			AutoInit:      true,
+			Readme:        "Default",
+			DefaultBranch: "master",
+		})
+		require.NoError(t, err)
+
+		// nobody is allowed to create the tags
+		require.NoError(t, git_model.InsertProtectedTag(db.DefaultContext, &git_model.ProtectedTag{RepoID: repo.ID, NamePattern: "v*"}))
+
+		dstPath := t.TempDir()
+		u.Path = "user2/secret-scan-batches.git"
+		u.User = url.UserPassword(user2.Name, userPassword)
+		doGitClone(dstPath, u)(t)
+
+		commit := func(t *testing.T, fileName, content, message string) {
+			require.NoError(t, os.WriteFile(filepath.Join(dstPath, fileName), []byte(content), 0o644))
+			require.NoError(t, git.AddChanges(dstPath, true))
+			signature := &git.Signature{Email: "user2@example.com", Name: "User Two", When: time.Now()}
+			require.NoError(t, git.CommitChanges(dstPath, git.CommitChangesOptions{
+				Committer: signature,
+				Author:    signature,
+				Message:   message,
+			}))
+		}
+
+		commit(t, "config.txt", "aws_access_key_id = AKIA"+"IOSFODNN7EXAMPLE\n", "add config")
+
+		// the first batch holds master and 499 new branches, the second batch holds the last branch and the protected tag
+		var refs strings.Builder
+		for i := range 500 {
+			fmt.Fprintf(&refs, "create refs/heads/b-%03d HEAD\n", i)
+		}
+		refs.WriteString("create refs/tags/v1 HEAD\n")
+		_, _, err = git.NewCommand("update-ref", "--stdin").RunStdString(t.Context(), &git.RunOpts{Dir: dstPath, Stdin: strings.NewReader(refs.String())})
+		require.NoError(t, err)
+
+		_, stderr, err := git.NewCommand("push", "origin", "refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*").RunStdString(t.Context(), &git.RunOpts{Dir: dstPath})
+		require.Error(t, err)
+		assert.Contains(t, stderr, "Tag v1 is protected")
+
+		exists, err := git_model.IsBranchExist(db.DefaultContext, repo.ID, "b-000")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		// the alerts of the accepted first batch are kept although the push has been rejected
+		alert := unittest.AssertExistsAndLoadBean(t, &git_model.SecretScanAlert{RepoID: repo.ID})
+		assert.Equal(t, "aws_access_key_id", alert.PatternID)
+		assert.Equal(t, "refs/heads/master", alert.RefName)
+		assert.Equal(t, "config.txt", alert.Path)
+	})
+}
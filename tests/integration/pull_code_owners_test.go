@@ -0,0 +1,174 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	api "code.gitea.io/gitea/modules/structs"
+	pull_service "code.gitea.io/gitea/services/pull"
+	repo_service "code.gitea.io/gitea/services/repository"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequireCodeOwnerReviews(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+		user15 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 15}) // the member of org3/test_team
+		token := getUserToken(t, user2.Name, auth_model.AccessTokenScopeWriteRepository)
+
+		repo, err := repo_service.CreateRepositoryDirectly(db.DefaultContext, user2, user2, repo_service.CreateRepoOptions{
+			Name:             "test_require_codeowner",
+			Readme:           "Default",
+			AutoInit:         true,
+			ObjectFormatName: git.Sha1ObjectFormat.Name(),
+			DefaultBranch:    "master",
+		}, true)
+		require.NoError(t, err)
+		for _, u := range []*user_model.User{user4, user5, user15} {
+			require.NoError(t, repo_service.AddOrUpdateCollaborator(db.DefaultContext, repo, u, perm.AccessModeWrite))
+		}
+
+		_, err = files_service.ChangeRepoFiles(db.DefaultContext, repo, user2, &files_service.ChangeRepoFilesOptions{
+			OldBranch: repo.DefaultBranch,
+			Files: []*files_service.ChangeRepoFile{
+				{
+					Operation:     "create",
+					TreePath:      "CODEOWNERS",
+					ContentReader: strings.NewReader("user4.md @user4\nuser5.md @user5\nteam.md @org3/test_team\nuser2.md @user2\n"),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/test_require_codeowner/branch_protections", &api.CreateBranchProtectionOption{
+			RuleName:                "master",
+			EnablePush:              true,
+			RequireCodeOwnerReviews: true,
+			IgnoreStaleApprovals:    true,
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+
+		createPull := func(t *testing.T, branch string, files ...string) *issues_model.PullRequest {
+			changes := make([]*files_service.ChangeRepoFile, 0, len(files))
+			for _, f := range files {
+				changes = append(changes, &files_service.ChangeRepoFile{
+					Operation:     "create",
+					TreePath:      f,
+					ContentReader: strings.NewReader(f),
+				})
+			}
+			_, err := files_service.ChangeRepoFiles(db.DefaultContext, repo, user2, &files_service.ChangeRepoFilesOptions{
+				OldBranch: repo.DefaultBranch,
+				NewBranch: branch,
+				Files:     changes,
+			})
+			require.NoError(t, err)
+
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/test_require_codeowner/pulls", &api.CreatePullRequestOption{
+				Head:  branch,
+				Base:  repo.DefaultBranch,
+				Title: branch,
+			}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusCreated)
+			apiPull := &api.PullRequest{}
+			DecodeJSON(t, resp, apiPull)
+			pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: apiPull.ID})
+			require.NoError(t, pr.LoadIssue(db.DefaultContext))
+			require.NoError(t, pr.Issue.LoadRepo(db.DefaultContext))
+			return pr
+		}
+		approve := func(t *testing.T, pr *issues_model.PullRequest, doer *user_model.User) {
+			commitID, err := gitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+			require.NoError(t, err)
+			_, _, err = pull_service.SubmitReview(db.DefaultContext, doer, gitRepo, pr.Issue, issues_model.ReviewTypeApprove, "", commitID, nil)
+			require.NoError(t, err)
+		}
+		assertOutstanding := func(t *testing.T, pr *issues_model.PullRequest, userIDs, teamIDs []int64) {
+			pb, err := git_model.GetFirstMatchProtectedBranchRule(db.DefaultContext, repo.ID, repo.DefaultBranch)
+			require.NoError(t, err)
+			users, teams, err := pull_service.GetOutstandingCodeOwners(db.DefaultContext, pb, pr)
+			require.NoError(t, err)
+			actualUserIDs := make([]int64, 0, len(users))
+			for _, u := range users {
+				actualUserIDs = append(actualUserIDs, u.ID)
+			}
+			actualTeamIDs := make([]int64, 0, len(teams))
+			for _, team := range teams {
+				actualTeamIDs = append(actualTeamIDs, team.ID)
+			}
+			assert.ElementsMatch(t, userIDs, actualUserIDs)
+			assert.ElementsMatch(t, teamIDs, actualTeamIDs)
+
+			err = pull_service.CheckPullBranchProtections(db.DefaultContext, pr, true)
+			if len(userIDs) > 0 || len(teamIDs) > 0 {
+				assert.ErrorIs(t, err, pull_service.ErrNotReadyToMerge)
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+		testTeam := unittest.AssertExistsAndLoadBean(t, &organization.Team{OrgID: 3, LowerName: "test_team"})
+
+		t.Run("UsersAndTeams", func(t *testing.T) {
+			pr := createPull(t, "codeowner-all", "user4.md", "user5.md", "team.md", "user2.md", "unowned.md")
+			assertOutstanding(t, pr, []int64{2, 4, 5}, []int64{testTeam.ID})
+
+			// the approval of another code owner is required instead of the one of the poster
+			approve(t, pr, user4)
+			assertOutstanding(t, pr, []int64{5}, []int64{testTeam.ID})
+
+			// a team is satisfied by the approval of any of its members
+			approve(t, pr, user15)
+			assertOutstanding(t, pr, []int64{5}, nil)
+
+			// the stale approvals don't count
+			require.NoError(t, issues_model.MarkReviewsAsStale(db.DefaultContext, pr.IssueID))
+			assertOutstanding(t, pr, []int64{2, 4, 5}, []int64{testTeam.ID})
+
+			approve(t, pr, user4)
+			approve(t, pr, user15)
+			approve(t, pr, user5)
+			assertOutstanding(t, pr, nil, nil)
+		})
+
+		t.Run("PosterOnlyOwner", func(t *testing.T) {
+			pr := createPull(t, "codeowner-poster", "user2.md")
+			assertOutstanding(t, pr, []int64{2}, nil)
+
+			// the approval of a reviewer who doesn't own the changed files doesn't count
+			approve(t, pr, user4)
+			assertOutstanding(t, pr, []int64{2}, nil)
+		})
+
+		t.Run("PosterWithTeam", func(t *testing.T) {
+			pr := createPull(t, "codeowner-poster-team", "user2.md", "team.md")
+			assertOutstanding(t, pr, []int64{2}, []int64{testTeam.ID})
+
+			approve(t, pr, user15)
+			assertOutstanding(t, pr, nil, nil)
+		})
+	})
+}
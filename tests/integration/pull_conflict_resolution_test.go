@@ -0,0 +1,130 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	api "code.gitea.io/gitea/modules/structs"
+	pull_service "code.gitea.io/gitea/services/pull"
+	repo_service "code.gitea.io/gitea/services/repository"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullResolveConflicts(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		token := getUserToken(t, user2.Name, auth_model.AccessTokenScopeWriteRepository)
+
+		repo, err := repo_service.CreateRepository(db.DefaultContext, user2, user2, repo_service.CreateRepoOptions{
+			Name:          "resolve-conflicts",
+			AutoInit:      true,
+			Readme:        "Default",
+			DefaultBranch: "master",
+		})
+		require.NoError(t, err)
+
+		changeFile := func(t *testing.T, operation, oldBranch, newBranch, content string) {
+			_, err := files_service.ChangeRepoFiles(db.DefaultContext, repo, user2, &files_service.ChangeRepoFilesOptions{
+				Files: []*files_service.ChangeRepoFile{
+					{
+						Operation:     operation,
+						TreePath:      "file.txt",
+						ContentReader: strings.NewReader(content),
+					},
+				},
+				Message:   "change file on " + newBranch,
+				OldBranch: oldBranch,
+				NewBranch: newBranch,
+			})
+			require.NoError(t, err)
+		}
+
+		changeFile(t, "create", "master", "master", "a\nb\nc\n")
+		changeFile(t, "update", "master", "conflict", "a\nhead\nc\n")
+		changeFile(t, "update", "master", "master", "a\nbase\nc\n")
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/resolve-conflicts/pulls", &api.CreatePullRequestOption{
+			Head:  "conflict",
+			Base:  "master",
+			Title: "conflict",
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		apiPull := &api.PullRequest{}
+		DecodeJSON(t, resp, apiPull)
+		pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: apiPull.ID})
+		require.NoError(t, pr.LoadIssue(db.DefaultContext))
+		require.NoError(t, pr.Issue.LoadRepo(db.DefaultContext))
+
+		gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		headCommitID, err := gitRepo.GetBranchCommitID("conflict")
+		require.NoError(t, err)
+		baseCommitID, err := gitRepo.GetBranchCommitID("master")
+		require.NoError(t, err)
+
+		conflicts, err := pull_service.GetConflicts(db.DefaultContext, pr, user2)
+		require.NoError(t, err)
+		assert.Equal(t, headCommitID, conflicts.HeadCommitID)
+		assert.Equal(t, baseCommitID, conflicts.BaseCommitID)
+		require.Len(t, conflicts.Files, 1)
+		file := conflicts.Files[0]
+		assert.Equal(t, "file.txt", file.Path)
+		assert.True(t, file.IsText)
+		assert.Equal(t, 1, file.NumConflicts())
+
+		// the cached conflicts are rendered
+		session := loginUser(t, user2.Name)
+		req = NewRequest(t, "GET", pr.Issue.Link()+"/conflicts")
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), headCommitID)
+
+		resolve := func(headCommitID, baseCommitID string) error {
+			return pull_service.ResolveConflicts(db.DefaultContext, pr, user2, &pull_service.ResolveConflictsOptions{
+				HeadCommitID: headCommitID,
+				BaseCommitID: baseCommitID,
+				Choices:      map[string][]pull_service.ConflictChoice{"file.txt": {pull_service.ConflictChoiceBoth}},
+				Message:      "Resolve conflicts",
+			})
+		}
+
+		// the resolution is rejected if a branch moved since the conflicts were loaded
+		assert.ErrorIs(t, resolve(baseCommitID, baseCommitID), pull_service.ErrConflictsOutdated)
+		assert.ErrorIs(t, resolve(headCommitID, headCommitID), pull_service.ErrConflictsOutdated)
+
+		require.NoError(t, resolve(headCommitID, baseCommitID))
+
+		mergeCommit, err := gitRepo.GetBranchCommit("conflict")
+		require.NoError(t, err)
+		assert.Equal(t, "Resolve conflicts", strings.TrimSpace(mergeCommit.CommitMessage))
+		if assert.Equal(t, 2, mergeCommit.ParentCount()) {
+			parentID, err := mergeCommit.ParentID(0)
+			require.NoError(t, err)
+			assert.Equal(t, headCommitID, parentID.String())
+			parentID, err = mergeCommit.ParentID(1)
+			require.NoError(t, err)
+			assert.Equal(t, baseCommitID, parentID.String())
+		}
+		content, err := mergeCommit.GetFileContent("file.txt", 1024)
+		require.NoError(t, err)
+		assert.Equal(t, "a\nhead\nbase\nc\n", content)
+
+		conflicts, err = pull_service.GetConflicts(db.DefaultContext, pr, user2)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts.Files)
+	})
+}
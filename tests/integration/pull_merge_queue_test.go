@@ -0,0 +1,240 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/commitstatus"
+	"code.gitea.io/gitea/modules/gitrepo"
+	api "code.gitea.io/gitea/modules/structs"
+	repo_service "code.gitea.io/gitea/services/repository"
+	commitstatus_service "code.gitea.io/gitea/services/repository/commitstatus"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullMergeQueue(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		session := loginUser(t, user2.Name)
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		apiCtx := NewAPITestContext(t, user2.Name, "merge-queue", auth_model.AccessTokenScopeWriteRepository)
+
+		repo, err := repo_service.CreateRepository(db.DefaultContext, user2, user2, repo_service.CreateRepoOptions{
+			Name:          "merge-queue",
+			AutoInit:      true,
+			Readme:        "Default",
+			DefaultBranch: "master",
+		})
+		require.NoError(t, err)
+
+		// the merge queue can't be enabled without required status checks
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/merge-queue/branch_protections", &api.CreateBranchProtectionOption{
+			RuleName:         "master",
+			EnablePush:       true,
+			EnableMergeQueue: true,
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/merge-queue/branch_protections", &api.CreateBranchProtectionOption{
+			RuleName:            "master",
+			EnablePush:          true,
+			EnableStatusCheck:   true,
+			StatusCheckContexts: []string{"ci"},
+			EnableMergeQueue:    true,
+			MergeQueueBatchSize: 2,
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+
+		setStatus := func(t *testing.T, sha string, state commitstatus.CommitStatusState) {
+			require.NoError(t, commitstatus_service.CreateCommitStatus(db.DefaultContext, repo, user2, sha, &git_model.CommitStatus{
+				State:     state,
+				TargetURL: "https://gitea.com",
+				Context:   "ci",
+			}))
+		}
+		commitFile := func(t *testing.T, oldBranch, newBranch, treePath string) {
+			_, err := files_service.ChangeRepoFiles(db.DefaultContext, repo, user2, &files_service.ChangeRepoFilesOptions{
+				Files: []*files_service.ChangeRepoFile{
+					{
+						Operation:     "create",
+						TreePath:      treePath,
+						ContentReader: strings.NewReader(treePath),
+					},
+				},
+				Message:   "add " + treePath,
+				OldBranch: oldBranch,
+				NewBranch: newBranch,
+			})
+			require.NoError(t, err)
+		}
+		createPull := func(t *testing.T, branch string) *issues_model.PullRequest {
+			commitFile(t, "master", branch, branch+".txt")
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/merge-queue/pulls", &api.CreatePullRequestOption{
+				Head:  branch,
+				Base:  "master",
+				Title: branch,
+			}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusCreated)
+			apiPull := &api.PullRequest{}
+			DecodeJSON(t, resp, apiPull)
+			// the checks of the pull request itself have to succeed before it can be queued
+			setStatus(t, apiPull.Head.Sha, commitstatus.CommitStatusSuccess)
+			return unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: apiPull.ID})
+		}
+		enqueue := func(t *testing.T, pr *issues_model.PullRequest) {
+			apiCtx.ExpectedCode = http.StatusCreated
+			doAPIMergePullRequest(apiCtx, "user2", "merge-queue", pr.Index)(t)
+		}
+		waitTesting := func(t *testing.T, prs ...*issues_model.PullRequest) []*pull_model.MergeQueueEntry {
+			entries := make([]*pull_model.MergeQueueEntry, len(prs))
+			require.Eventually(t, func() bool {
+				for i, pr := range prs {
+					exists, entry, err := pull_model.GetMergeQueueEntryByPullID(db.DefaultContext, pr.ID)
+					if err != nil || !exists || !entry.IsTesting() {
+						return false
+					}
+					entries[i] = entry
+				}
+				return true
+			}, 10*time.Second, 100*time.Millisecond)
+			return entries
+		}
+		waitRetested := func(t *testing.T, pr *issues_model.PullRequest, baseCommitID string) {
+			require.Eventually(t, func() bool {
+				exists, entry, err := pull_model.GetMergeQueueEntryByPullID(db.DefaultContext, pr.ID)
+				return err == nil && exists && entry.IsTesting() && entry.GroupBaseCommitID == baseCommitID
+			}, 10*time.Second, 100*time.Millisecond)
+		}
+		waitMerged := func(t *testing.T, pr *issues_model.PullRequest) *issues_model.PullRequest {
+			require.Eventually(t, func() bool {
+				pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+				return pr.HasMerged
+			}, 10*time.Second, 100*time.Millisecond)
+			return pr
+		}
+		waitRemoved := func(t *testing.T, pr *issues_model.PullRequest, reason string) {
+			require.Eventually(t, func() bool {
+				exists, _, err := pull_model.GetMergeQueueEntryByPullID(db.DefaultContext, pr.ID)
+				return err == nil && !exists
+			}, 10*time.Second, 100*time.Millisecond)
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{
+				IssueID: pr.IssueID,
+				Type:    issues_model.CommentTypePRRemovedFromMergeQueue,
+				Content: reason,
+			})
+		}
+		masterCommitID := func(t *testing.T) string {
+			commitID, err := gitRepo.GetBranchCommitID("master")
+			require.NoError(t, err)
+			return commitID
+		}
+
+		t.Run("Land", func(t *testing.T) {
+			prA := createPull(t, "land-a")
+			prB := createPull(t, "land-b")
+			base := masterCommitID(t)
+			enqueue(t, prA)
+			enqueue(t, prB)
+
+			// depending on the timing both are tested in one group or the second one is tested on top of the first one
+			entries := waitTesting(t, prA, prB)
+			assert.Equal(t, base, entries[0].GroupBaseCommitID)
+			if entries[0].GroupHeadCommitID == entries[1].GroupHeadCommitID {
+				assert.Equal(t, base, entries[1].GroupBaseCommitID)
+			} else {
+				assert.Equal(t, entries[0].GroupHeadCommitID, entries[1].GroupBaseCommitID)
+			}
+			setStatus(t, entries[0].GroupHeadCommitID, commitstatus.CommitStatusSuccess)
+			setStatus(t, entries[1].GroupHeadCommitID, commitstatus.CommitStatusSuccess)
+
+			// the tested commits land on the branch
+			prA = waitMerged(t, prA)
+			prB = waitMerged(t, prB)
+			assert.Equal(t, entries[0].GroupCommitID, prA.MergedCommitID)
+			assert.Equal(t, entries[1].GroupCommitID, prB.MergedCommitID)
+			assert.Equal(t, entries[1].GroupHeadCommitID, masterCommitID(t))
+			unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: prA.ID})
+			unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: prB.ID})
+		})
+
+		t.Run("ChecksFailed", func(t *testing.T) {
+			pr := createPull(t, "checks-failed")
+			enqueue(t, pr)
+			entries := waitTesting(t, pr)
+
+			setStatus(t, entries[0].GroupHeadCommitID, commitstatus.CommitStatusFailure)
+			waitRemoved(t, pr, "checks_failed")
+			pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+			assert.False(t, pr.HasMerged)
+		})
+
+		t.Run("BaseMoved", func(t *testing.T) {
+			pr := createPull(t, "base-moved")
+			enqueue(t, pr)
+			entries := waitTesting(t, pr)
+
+			// the branch is updated outside of the queue, so the tested commit is out of date
+			commitFile(t, "master", "master", "base-moved-outside.txt")
+			base := masterCommitID(t)
+			setStatus(t, entries[0].GroupHeadCommitID, commitstatus.CommitStatusSuccess)
+
+			// the pull request is tested again on top of the new commit
+			waitRetested(t, pr, base)
+			entries = waitTesting(t, pr)
+			pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+			assert.False(t, pr.HasMerged)
+
+			setStatus(t, entries[0].GroupHeadCommitID, commitstatus.CommitStatusSuccess)
+			pr = waitMerged(t, pr)
+			assert.Equal(t, entries[0].GroupHeadCommitID, masterCommitID(t))
+		})
+
+		t.Run("RemoveTesting", func(t *testing.T) {
+			prA := createPull(t, "remove-a")
+			prB := createPull(t, "remove-b")
+			base := masterCommitID(t)
+			enqueue(t, prA)
+			enqueue(t, prB)
+			waitTesting(t, prA, prB)
+
+			// removing the first pull request resets the tested commits containing it
+			req := NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/repos/user2/merge-queue/pulls/%d/merge-queue", prA.Index)).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNoContent)
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{
+				IssueID: prA.IssueID,
+				Type:    issues_model.CommentTypePRRemovedFromMergeQueue,
+				Content: "canceled",
+			})
+
+			waitRetested(t, prB, base)
+			entries := waitTesting(t, prB)
+
+			setStatus(t, entries[0].GroupHeadCommitID, commitstatus.CommitStatusSuccess)
+			waitMerged(t, prB)
+			prA = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prA.ID})
+			assert.False(t, prA.HasMerged)
+			assert.Equal(t, entries[0].GroupHeadCommitID, masterCommitID(t))
+		})
+	})
+}
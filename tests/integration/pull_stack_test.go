@@ -0,0 +1,180 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/queue"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/forms"
+	repo_service "code.gitea.io/gitea/services/repository"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullStack(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		token := getUserToken(t, user2.Name, auth_model.AccessTokenScopeWriteRepository)
+
+		repo, err := repo_service.CreateRepository(db.DefaultContext, user2, user2, repo_service.CreateRepoOptions{
+			Name:          "pull-stack",
+			AutoInit:      true,
+			Readme:        "Default",
+			DefaultBranch: "master",
+		})
+		require.NoError(t, err)
+
+		commitFile := func(t *testing.T, oldBranch, newBranch string) {
+			_, err := files_service.ChangeRepoFiles(db.DefaultContext, repo, user2, &files_service.ChangeRepoFilesOptions{
+				Files: []*files_service.ChangeRepoFile{
+					{
+						Operation:     "create",
+						TreePath:      newBranch + ".txt",
+						ContentReader: strings.NewReader(newBranch),
+					},
+				},
+				Message:   "add " + newBranch,
+				OldBranch: oldBranch,
+				NewBranch: newBranch,
+			})
+			require.NoError(t, err)
+		}
+		createPull := func(t *testing.T, head, base string, stackParent int64, expectedStatus int) *issues_model.PullRequest {
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/pull-stack/pulls", &api.CreatePullRequestOption{
+				Head:        head,
+				Base:        base,
+				Title:       head,
+				StackParent: stackParent,
+			}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, expectedStatus)
+			if expectedStatus != http.StatusCreated {
+				return nil
+			}
+			apiPull := &api.PullRequest{}
+			DecodeJSON(t, resp, apiPull)
+			return unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: apiPull.ID})
+		}
+
+		commitFile(t, "master", "stack-a")
+		commitFile(t, "stack-a", "stack-b")
+		commitFile(t, "stack-a", "stack-c")
+		commitFile(t, "stack-a", "stack-d")
+
+		prA := createPull(t, "stack-a", "master", 0, http.StatusCreated)
+		// the parent must be the pull request of the base branch
+		createPull(t, "stack-b", "stack-a", 999, http.StatusUnprocessableEntity)
+		createPull(t, "stack-b", "master", prA.Index, http.StatusUnprocessableEntity)
+		prB := createPull(t, "stack-b", "stack-a", prA.Index, http.StatusCreated)
+		assert.Equal(t, prA.ID, prB.StackParentID)
+		// the pull requests targeting the branch without opting in are not stacked
+		prC := createPull(t, "stack-c", "stack-a", 0, http.StatusCreated)
+		assert.Zero(t, prC.StackParentID)
+		prD := createPull(t, "stack-d", "stack-a", 0, http.StatusCreated)
+
+		t.Run("EditStackParent", func(t *testing.T) {
+			editStackParent := func(t *testing.T, pr *issues_model.PullRequest, stackParent int64, expectedStatus int) {
+				req := NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/repos/user2/pull-stack/pulls/%d", pr.Index), &api.EditPullRequestOption{
+					StackParent: &stackParent,
+				}).AddTokenAuth(token)
+				MakeRequest(t, req, expectedStatus)
+			}
+
+			// a pull request can't be stacked on a pull request stacked on it
+			editStackParent(t, prA, prB.Index, http.StatusUnprocessableEntity)
+			editStackParent(t, prD, prA.Index, http.StatusCreated)
+			prD = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prD.ID})
+			assert.Equal(t, prA.ID, prD.StackParentID)
+
+			editStackParent(t, prC, prA.Index, http.StatusCreated)
+			editStackParent(t, prC, 0, http.StatusCreated)
+			prC = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prC.ID})
+			assert.Zero(t, prC.StackParentID)
+
+			req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/user2/pull-stack/pulls/%d/stack", prA.Index)).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			var stack []*api.PullRequest
+			DecodeJSON(t, resp, &stack)
+			if assert.Len(t, stack, 2) {
+				assert.Equal(t, prA.Index, stack[0].Index)
+				assert.Equal(t, prB.Index, stack[1].Index)
+			}
+		})
+
+		t.Run("MergeParent", func(t *testing.T) {
+			// the merger isn't allowed to force push to the head branch of D, so it can't be rebased
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/pull-stack/branch_protections", &api.CreateBranchProtectionOption{
+				RuleName:   "stack-d",
+				EnablePush: true,
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			// wait for the mergeable check of the pull request
+			queue.GetManager().FlushAll(t.Context(), 5*time.Second)
+			req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/user2/pull-stack/pulls/%d/merge", prA.Index), &forms.MergePullRequestForm{
+				Do: string(repo_model.MergeStyleSquash),
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusOK)
+
+			gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+			require.NoError(t, err)
+			defer gitRepo.Close()
+			masterCommit, err := gitRepo.GetBranchCommit("master")
+			require.NoError(t, err)
+
+			// the stacked pull request is retargeted and rebased onto the squashed commit without the commit of A
+			prB = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prB.ID})
+			assert.Equal(t, "master", prB.BaseBranch)
+			assert.Equal(t, prA.ID, prB.StackParentID)
+			headCommit, err := gitRepo.GetBranchCommit("stack-b")
+			require.NoError(t, err)
+			assert.Equal(t, "add stack-b", strings.TrimSpace(headCommit.CommitMessage))
+			if assert.Equal(t, 1, headCommit.ParentCount()) {
+				parentID, err := headCommit.ParentID(0)
+				require.NoError(t, err)
+				assert.Equal(t, masterCommit.ID.String(), parentID.String())
+			}
+
+			// the pull request which didn't opt in is left untouched
+			prC = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prC.ID})
+			assert.Equal(t, "stack-a", prC.BaseBranch)
+
+			// the author of the pull request which couldn't be rebased is told to update it
+			prD = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prD.ID})
+			assert.Equal(t, "master", prD.BaseBranch)
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{
+				IssueID: prD.IssueID,
+				Type:    issues_model.CommentTypePullStackRebaseFailed,
+				Content: strconv.FormatInt(prA.Index, 10),
+			})
+			headCommit, err = gitRepo.GetBranchCommit("stack-d")
+			require.NoError(t, err)
+			parentID, err := headCommit.ParentID(0)
+			require.NoError(t, err)
+			assert.NotEqual(t, masterCommit.ID.String(), parentID.String())
+
+			// the comment is rendered on the pull request page
+			session := loginUser(t, user2.Name)
+			req = NewRequest(t, "GET", fmt.Sprintf("/user2/pull-stack/pulls/%d", prD.Index))
+			resp := session.MakeRequest(t, req, http.StatusOK)
+			assert.Contains(t, resp.Body.String(), fmt.Sprintf("/user2/pull-stack/pulls/%d\">#%d</a>", prA.Index, prA.Index))
+		})
+	})
+}
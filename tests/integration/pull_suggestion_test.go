@@ -0,0 +1,144 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	repo_service "code.gitea.io/gitea/services/repository"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullApplySuggestions(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+		token := getUserToken(t, user2.Name, auth_model.AccessTokenScopeWriteRepository)
+
+		repo, err := repo_service.CreateRepository(db.DefaultContext, user2, user2, repo_service.CreateRepoOptions{
+			Name:          "apply-suggestions",
+			AutoInit:      true,
+			Readme:        "Default",
+			DefaultBranch: "master",
+		})
+		require.NoError(t, err)
+
+		_, err = files_service.ChangeRepoFiles(db.DefaultContext, repo, user2, &files_service.ChangeRepoFilesOptions{
+			Files: []*files_service.ChangeRepoFile{
+				{
+					Operation:     "create",
+					TreePath:      "file.txt",
+					ContentReader: strings.NewReader("line 1\nline 2\nline 3\nline 4\n"),
+				},
+			},
+			Message:   "add file",
+			OldBranch: "master",
+			NewBranch: "suggestions",
+		})
+		require.NoError(t, err)
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/apply-suggestions/pulls", &api.CreatePullRequestOption{
+			Head:  "suggestions",
+			Base:  "master",
+			Title: "suggestions",
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		apiPull := &api.PullRequest{}
+		DecodeJSON(t, resp, apiPull)
+		pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: apiPull.ID})
+		require.NoError(t, pr.LoadIssue(db.DefaultContext))
+		require.NoError(t, pr.Issue.LoadRepo(db.DefaultContext))
+
+		gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		reviewedCommit, err := gitRepo.GetBranchCommit("suggestions")
+		require.NoError(t, err)
+
+		createSuggestion := func(t *testing.T, doer *user_model.User, startLine, line int64, suggestion string) *issues_model.Comment {
+			c, err := issues_model.CreateComment(db.DefaultContext, &issues_model.CreateCommentOptions{
+				Type:         issues_model.CommentTypeCode,
+				Doer:         doer,
+				Repo:         repo,
+				Issue:        pr.Issue,
+				TreePath:     "file.txt",
+				StartLineNum: startLine,
+				LineNum:      line,
+				CommitSHA:    reviewedCommit.ID.String(),
+				Content:      "```suggestion\n" + suggestion + "\n```",
+			})
+			require.NoError(t, err)
+			return c
+		}
+
+		c1 := createSuggestion(t, user4, 0, 2, "second line")
+		c2 := createSuggestion(t, user5, 3, 4, "third and fourth line")
+		c3 := createSuggestion(t, user2, 0, 1, "first line")
+
+		t.Run("NotAllowed", func(t *testing.T) {
+			_, err := files_service.ApplySuggestions(db.DefaultContext, user4, pr, []int64{c1.ID}, "")
+			assert.ErrorIs(t, err, util.ErrPermissionDenied)
+		})
+
+		t.Run("Apply", func(t *testing.T) {
+			session := loginUser(t, user2.Name)
+			req := NewRequest(t, "GET", pr.Issue.Link()+"/files")
+			resp := session.MakeRequest(t, req, http.StatusOK)
+			assert.Contains(t, resp.Body.String(), `action="`+pr.Issue.Link()+`/suggestions"`)
+
+			_, err := files_service.ApplySuggestions(db.DefaultContext, user2, pr, []int64{c1.ID, c2.ID, c3.ID}, "")
+			require.NoError(t, err)
+
+			headCommit, err := gitRepo.GetBranchCommit("suggestions")
+			require.NoError(t, err)
+			parentID, err := headCommit.ParentID(0)
+			require.NoError(t, err)
+			assert.Equal(t, reviewedCommit.ID.String(), parentID.String())
+
+			content, err := headCommit.GetFileContent("file.txt", 1024)
+			require.NoError(t, err)
+			assert.Equal(t, "first line\nsecond line\nthird and fourth line\n", content)
+
+			// the authors of the suggestions are credited, except for the doer who is the author of the commit
+			assert.True(t, strings.HasPrefix(headCommit.CommitMessage, "Apply suggestions from code review\n"))
+			assert.Contains(t, headCommit.CommitMessage, "Co-authored-by: "+user4.NewGitSig().String())
+			assert.Contains(t, headCommit.CommitMessage, "Co-authored-by: "+user5.NewGitSig().String())
+			assert.NotContains(t, headCommit.CommitMessage, "Co-authored-by: "+user2.NewGitSig().String())
+
+			// the conversations of the applied suggestions are resolved
+			for _, c := range []*issues_model.Comment{c1, c2, c3} {
+				c = unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: c.ID})
+				assert.Equal(t, user2.ID, c.ResolveDoerID)
+			}
+		})
+
+		t.Run("Outdated", func(t *testing.T) {
+			// the commented line has been changed since the reviewed commit
+			c := createSuggestion(t, user4, 0, 2, "2nd line")
+			_, err := files_service.ApplySuggestions(db.DefaultContext, user2, pr, []int64{c.ID}, "")
+			assert.True(t, files_service.IsErrSuggestionOutdated(err))
+
+			headCommit, err := gitRepo.GetBranchCommit("suggestions")
+			require.NoError(t, err)
+			content, err := headCommit.GetFileContent("file.txt", 1024)
+			require.NoError(t, err)
+			assert.Equal(t, "first line\nsecond line\nthird and fourth line\n", content)
+		})
+	})
+}
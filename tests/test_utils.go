@@ -225,6 +225,8 @@ func PrepareCleanPackageData(t testing.TB) {
 		&packages_model.PackageProperty{},
 		&packages_model.PackageBlobUpload{},
 		&packages_model.PackageCleanupRule{},
+		&packages_model.PackageRemote{},
+		&packages_model.PackageVirtual{},
 	))
 	assert.NoError(t, storage.Clean(storage.Packages))
 }
@@ -0,0 +1,21 @@
+import {createApp} from 'vue';
+
+export async function initPackageDownloadStats() {
+  const el = document.querySelector('#package-download-stats');
+  if (!el) return;
+
+  const {default: PackageDownloadStats} = await import(/* webpackChunkName: "package-download-stats" */'../components/PackageDownloadStats.vue');
+  try {
+    const {days} = JSON.parse(el.getAttribute('data-statistics'));
+    const View = createApp(PackageDownloadStats, {
+      days,
+      locale: {
+        downloads: el.getAttribute('data-locale-downloads'),
+      },
+    });
+    View.mount(el);
+  } catch (err) {
+    console.error('PackageDownloadStats failed to load', err);
+    el.textContent = el.getAttribute('data-locale-component-failed-to-load');
+  }
+}
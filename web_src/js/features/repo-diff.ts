@@ -1,7 +1,7 @@
 import {initRepoIssueContentHistory} from './repo-issue-content.ts';
 import {initDiffFileTree} from './repo-diff-filetree.ts';
 import {initDiffCommitSelect} from './repo-diff-commitselect.ts';
-import {validateTextareaNonEmpty} from './comp/ComboMarkdownEditor.ts';
+import {initComboMarkdownEditor, validateTextareaNonEmpty} from './comp/ComboMarkdownEditor.ts';
 import {initViewedCheckboxListenerFor, countAndUpdateViewedFiles, initExpandAndCollapseFilesButton} from './pull-view-file.ts';
 import {initImageDiff} from './imagediff.ts';
 import {showErrorToast} from '../modules/toast.ts';
@@ -116,6 +116,22 @@ function initRepoDiffConversationForm() {
   });
 }
 
+function initRepoDiffFileComment() {
+  // the "comment on file" item of the file header menu loads a comment form for the whole file
+  addDelegatedEventListener(document, 'click', '.add-file-comment', async (el, e) => {
+    e.preventDefault();
+    const elFileComments = document.querySelector(el.getAttribute('data-target'));
+    if (elFileComments.querySelector('.comment-code-cloud')) return;
+
+    const response = await GET(el.getAttribute('data-new-comment-url'));
+    elFileComments.innerHTML = await response.text();
+    elFileComments.querySelector<HTMLInputElement>("input[name='side']").value = 'proposed';
+    elFileComments.querySelector<HTMLInputElement>("input[name='path']").value = elFileComments.getAttribute('data-path');
+    const editor = await initComboMarkdownEditor(elFileComments.querySelector<HTMLElement>('.combo-markdown-editor'));
+    editor.focus();
+  });
+}
+
 function initRepoDiffConversationNav() {
   // Previous/Next code review conversation
   addDelegatedEventListener(document, 'click', '.previous-conversation, .next-conversation', (el, e) => {
@@ -255,6 +271,7 @@ export function initRepoDiffView() {
 
   if (!document.querySelector('#diff-file-boxes')) return;
   initRepoDiffConversationNav(); // "previous" and "next" buttons only appear on "diff" page
+  initRepoDiffFileComment();
   initDiffFileTree();
   initDiffCommitSelect();
   initRepoDiffShowMore();